@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 	"todo/common"
-	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage"
 	proto "todo/proto/gen/go/api"
 
 	"github.com/adhocore/gronx"
@@ -31,6 +32,21 @@ func (t *TodoServer) AddTask(ctx context.Context, req *proto.AddTaskReq) (*proto
 	if err := validateRecurringRule(req.RecurringRule); err != nil {
 		return nil, fmt.Errorf("invalid recurring rule: %v", err)
 	}
+	if err := validateStatus(req.Status); err != nil {
+		return nil, err
+	}
+	if _, ok := proto.Priority_name[int32(req.Priority)]; !ok {
+		return nil, fmt.Errorf("unknown priority: %v", req.Priority)
+	}
+	for _, text := range req.Checklist {
+		if text == "" {
+			return nil, errors.New("checklist item text cannot be blank")
+		}
+	}
+	// new checklist items are never done
+	if req.Status == proto.Status_COMPLETE && req.RequireChecklistComplete && len(req.Checklist) > 0 {
+		return nil, errors.New("task cannot be complete until every checklist item is done")
+	}
 
 	// get userid from ctx
 	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
@@ -38,31 +54,63 @@ func (t *TodoServer) AddTask(ctx context.Context, req *proto.AddTaskReq) (*proto
 		return nil, fmt.Errorf("user id is not provided in metadata")
 	}
 
+	// tasks of a project are kept with its owner
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	if err := access.checkAcceptsTasks(); err != nil {
+		return nil, err
+	}
+	if err := t.validateAssignee(ctx, access, req.AssigneeId); err != nil {
+		return nil, err
+	}
+
 	// generate task id
 	taskID := uuid.New().String()
 
+	// create checklist items
+	var checklist []storage.ChecklistItem
+	for _, text := range req.Checklist {
+		checklist = append(checklist, storage.ChecklistItem{
+			ID:   uuid.New().String(),
+			Text: text,
+		})
+	}
+
 	// use db client to add task
-	ddbRecurringRule := &dynamodb.RecurringRule{}
+	ddbRecurringRule := &storage.RecurringRule{}
 	if req.RecurringRule != nil {
 		ddbRecurringRule.CronExpression = req.RecurringRule.CronExpression
 		ddbRecurringRule.StartDate = req.RecurringRule.StartDate
 		ddbRecurringRule.EndDate = req.RecurringRule.EndDate
 	}
-	_, err := t.ddb.AddTask(ctx, &dynamodb.AddTaskReq{
-		Task: dynamodb.Task{
-			UserID:        userIDs[0],
-			TaskID:        taskID,
-			Title:         req.Title,
-			Description:   req.Description,
-			Status:        req.Status.String(),
-			Tags:          req.Tags,
-			Parents:       req.Parents,
-			RecurringRule: ddbRecurringRule,
-		},
+	task := storage.Task{
+		UserID:                   access.ownerID,
+		TaskID:                   taskID,
+		Title:                    req.Title,
+		Description:              req.Description,
+		Status:                   req.Status.String(),
+		Tags:                     storage.NormalizeTags(req.Tags),
+		Parents:                  req.Parents,
+		DueDate:                  req.DueDate,
+		RecurringRule:            ddbRecurringRule,
+		Priority:                 req.Priority.String(),
+		EffortMinutes:            req.EffortMinutes,
+		Checklist:                checklist,
+		ProjectID:                req.ProjectId,
+		AssigneeID:               req.AssigneeId,
+		RequireChecklistComplete: req.RequireChecklistComplete,
+	}
+	_, err = t.tasks.AddTask(ctx, &storage.AddTaskReq{
+		Task: task,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to add task to database: %v", err)
 	}
+	t.index.Put(taskDocument(&task, time.Now().Unix()))
+	t.recordAudit(ctx, req.ProjectId, userIDs[0], auditTaskAdded, taskID, req.Title)
+	t.notifyAssignment(ctx, userIDs[0], "", &task)
 
 	return &proto.AddTaskResp{
 		Id: taskID,
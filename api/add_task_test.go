@@ -3,14 +3,16 @@ package api
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
+	"todo/search"
 
 	"google.golang.org/grpc/metadata"
 )
@@ -18,7 +20,7 @@ import (
 func Test_TodoServer_AddTask(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		tasks                   storage.TaskStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -35,8 +37,8 @@ func Test_TodoServer_AddTask(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
@@ -53,8 +55,8 @@ func Test_TodoServer_AddTask(t *testing.T) {
 		{
 			name: "no user id in context",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -71,8 +73,8 @@ func Test_TodoServer_AddTask(t *testing.T) {
 		{
 			name: "no title",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
@@ -85,10 +87,43 @@ func Test_TodoServer_AddTask(t *testing.T) {
 			wantResp: false,
 			wantErr:  true,
 		},
+		{
+			name: "happy path - priority and effort",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddTaskReq{
+					Title:         "do something",
+					Priority:      proto.Priority_P1,
+					EffortMinutes: 45,
+				},
+			},
+			wantResp: true,
+			wantErr:  false,
+		},
+		{
+			name: "unknown priority",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddTaskReq{
+					Title:    "do something",
+					Priority: proto.Priority(100),
+				},
+			},
+			wantResp: false,
+			wantErr:  true,
+		},
 		{
 			name: "AddTask returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
+				tasks: &storageMock.MockTaskStore{
 					AddTaskErr: errors.New("test error"),
 				},
 				jwt: &tmMock.MockTokenManager{},
@@ -110,8 +145,9 @@ func Test_TodoServer_AddTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				tasks:                   tt.fields.tasks,
 				jwt:                     tt.fields.jwt,
+				index:                   search.NewIndex(),
 			}
 			got, err := tr.AddTask(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
@@ -126,6 +162,19 @@ func Test_TodoServer_AddTask(t *testing.T) {
 	}
 }
 
+func Test_TodoServer_AddTask_NormalizesTags(t *testing.T) {
+	tasks := &storageMock.MockTaskStore{}
+	tr := &TodoServer{tasks: tasks, index: search.NewIndex()}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	_, err := tr.AddTask(ctx, &proto.AddTaskReq{Title: "do something", Tags: []string{"Work", "work ", " Home  Office"}})
+	if err != nil {
+		t.Fatalf("TodoServer.AddTask() error = %v", err)
+	}
+	if got, want := tasks.TasksTable[common.TEST_USER_1_ID][0].Tags, []string{"work", "home office"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TodoServer.AddTask() stored tags %q, want %q", got, want)
+	}
+}
+
 func Test_validateRecurringRule(t *testing.T) {
 	type args struct {
 		rule *proto.RecurringRule
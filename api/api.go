@@ -3,38 +3,115 @@ package api
 import (
 	"context"
 	"fmt"
-	"os"
-	"todo/common"
+	"sync/atomic"
+	"todo/config"
 	"todo/interfaces/dynamodb"
+	"todo/interfaces/memory"
+	"todo/interfaces/notifier"
+	"todo/interfaces/sqlite"
+	"todo/interfaces/storage"
 	"todo/interfaces/token_manager"
 	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"github.com/alexedwards/argon2id"
 )
 
 type TodoServer struct {
 	proto.UnimplementedTodoServer
-	ddb dynamodb.DynamoDBInterface
-	jwt token_manager.TokenManagerInterface
+	users    storage.UserStore
+	tasks    storage.TaskStore
+	events   storage.EventStore
+	views    storage.ViewStore
+	projects storage.ProjectStore
+	members  storage.MemberStore
+	audit    storage.AuditStore
+	pinger   storage.Pinger
+	// newUnitOfWork starts a unit of work spanning the stores
+	newUnitOfWork func() storage.UnitOfWork
+	jwt           token_manager.TokenManagerInterface
+	// notifier tells users about changes other users make, such as tasks assigned to them
+	notifier notifier.NotifierInterface
+	// argon2 are the params used to hash passwords
+	argon2 *argon2id.Params
+	// feedBaseURL is the public url calendar feeds are reached at, if known
+	feedBaseURL string
+	// index is the search index of the tasks written through the server, once indexReady
+	// reports that the stored tasks have been indexed too
+	index      *search.Index
+	indexReady atomic.Bool
+	// closeStorage closes the storage backend
+	closeStorage func() error
+}
+
+// newStorage returns the configured storage backend.
+func newStorage(ctx context.Context, cfg config.StorageConfig) (storage.Backend, error) {
+	switch cfg.Backend {
+	case "dynamodb":
+		tables := dynamodb.TableNames{
+			Users:    cfg.DynamoDB.UsersTableName(),
+			Tasks:    cfg.DynamoDB.TasksTableName(),
+			Events:   cfg.DynamoDB.EventsTableName(),
+			Views:    cfg.DynamoDB.ViewsTableName(),
+			Projects: cfg.DynamoDB.ProjectsTableName(),
+			Members:  cfg.DynamoDB.MembersTableName(),
+			Audit:    cfg.DynamoDB.AuditTableName(),
+		}
+		return dynamodb.NewDynamoDBClient(ctx, tables, cfg.DynamoDB.Endpoint)
+	case "sqlite":
+		return sqlite.NewSQLiteClient(ctx, cfg.SQLitePath)
+	case "memory":
+		return memory.NewMemoryClient(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q: expected dynamodb, sqlite or memory", cfg.Backend)
+	}
 }
 
-func NewTodoServer(ctx context.Context) (*TodoServer, error) {
-	// get database client
-	databaseClient, err := dynamodb.NewDynamoDBClient(ctx)
+// NewTodoServer returns a server using the storage backend, token lifetime and password hashing params
+// of the given config, which must be valid.
+func NewTodoServer(ctx context.Context, cfg *config.Config) (*TodoServer, error) {
+	// get storage backend
+	backend, err := newStorage(ctx, cfg.Storage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get database client: %v", err)
+		return nil, fmt.Errorf("failed to get storage backend: %v", err)
 	}
 
 	// get token manager
-	jwtSecret, ok := os.LookupEnv(common.JWT_SECRET_ENV_VAR)
-	if !ok {
-		return nil, fmt.Errorf("%s must be provided as an environment variable", common.JWT_SECRET_ENV_VAR)
-	}
-	tokenManager, err := token_manager.NewTokenManager(jwtSecret)
+	tokenManager, err := token_manager.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenLifetime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %v", err)
 	}
 
+	argon2 := cfg.Auth.Argon2
 	return &TodoServer{
-		ddb: databaseClient,
-		jwt: tokenManager,
+		users:         backend,
+		tasks:         backend,
+		events:        backend,
+		views:         backend,
+		projects:      backend,
+		members:       backend,
+		audit:         backend,
+		pinger:        backend,
+		newUnitOfWork: backend.NewUnitOfWork,
+		closeStorage:  backend.Close,
+		jwt:           tokenManager,
+		notifier:      notifier.NewLogNotifier(),
+		argon2: &argon2id.Params{
+			Memory:      argon2.Memory,
+			Iterations:  argon2.Iterations,
+			Parallelism: argon2.Parallelism,
+			SaltLength:  argon2.SaltLength,
+			KeyLength:   argon2.KeyLength,
+		},
+		feedBaseURL: cfg.Feed.URL,
+		index:       search.NewIndex(),
 	}, nil
 }
+
+// Close closes the storage backend. It must be called once the server no longer serves requests.
+func (t *TodoServer) Close() error {
+	if t.closeStorage == nil {
+		return nil
+	}
+	return t.closeStorage()
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"todo/common"
+	"todo/config"
+	"todo/interfaces/storage"
+)
+
+func Test_newStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		wantErr bool
+	}{
+		{name: "sqlite", backend: "sqlite", wantErr: false},
+		{name: "memory", backend: "memory", wantErr: false},
+		{name: "unknown backend", backend: "postgres", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default().Storage
+			cfg.Backend = tt.backend
+			cfg.SQLitePath = filepath.Join(t.TempDir(), "todo.db")
+			got, err := newStorage(context.Background(), cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newStorage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				got.Close()
+			}
+		})
+	}
+}
+
+func Test_NewTodoServer_UnitOfWork(t *testing.T) {
+	cfg := config.Default()
+	cfg.Storage.Backend = "memory"
+	cfg.Auth.JWTSecret = "secret"
+	ctx := context.Background()
+	tr, err := NewTodoServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewTodoServer() error = %v", err)
+	}
+
+	// a unit of work of the server spans its user and task stores
+	uow := tr.newUnitOfWork()
+	uow.AddUser(storage.User{ID: common.TEST_USER_1_ID, Email: "user@example.com"})
+	uow.AddTask(storage.Task{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Title: "title"})
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("UnitOfWork.Commit() error = %v", err)
+	}
+	getUserResp, err := tr.users.GetUser(ctx, &storage.GetUserReq{ID: common.TEST_USER_1_ID})
+	if err != nil || getUserResp.User == nil {
+		t.Errorf("UserStore.GetUser() = %v, %v, want the user added by the unit of work", getUserResp, err)
+	}
+	getTaskResp, err := tr.tasks.GetTask(ctx, &storage.GetTaskReq{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID})
+	if err != nil || getTaskResp.Task == nil {
+		t.Errorf("TaskStore.GetTask() = %v, %v, want the task added by the unit of work", getTaskResp, err)
+	}
+}
+
+func Test_TodoServer_Close(t *testing.T) {
+	cfg := config.Default()
+	cfg.Storage.Backend = "sqlite"
+	cfg.Storage.SQLitePath = filepath.Join(t.TempDir(), "todo.db")
+	cfg.Auth.JWTSecret = "secret"
+	ctx := context.Background()
+	tr, err := NewTodoServer(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewTodoServer() error = %v", err)
+	}
+	if err := tr.pinger.Ping(ctx); err != nil {
+		t.Fatalf("Pinger.Ping() before closing error = %v", err)
+	}
+
+	// the storage backend is closed along with the server
+	if err := tr.Close(); err != nil {
+		t.Fatalf("TodoServer.Close() error = %v", err)
+	}
+	if err := tr.pinger.Ping(ctx); err == nil {
+		t.Errorf("Pinger.Ping() after closing error = nil, want an error")
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"todo/common"
+	"todo/interfaces/notifier"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// validateAssignee returns an error unless the tasks the access reaches can be assigned to the user. Tasks of a
+// project can be assigned to its owner and to the editors and owners among its members, and other tasks only to
+// the user they are kept with.
+func (t *TodoServer) validateAssignee(ctx context.Context, access *projectAccess, assigneeID string) error {
+	if assigneeID == "" || assigneeID == access.ownerID {
+		return nil
+	}
+	if access.project == nil {
+		return fmt.Errorf("tasks outside projects can only be assigned to their owner")
+	}
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    assigneeID,
+		ProjectID: access.project.ProjectID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get member: %v", err)
+	}
+	member := getMemberResp.Member
+	if member == nil || !member.Accepted || proto.Role(proto.Role_value[member.Role]) < proto.Role_EDITOR {
+		return fmt.Errorf("user %s is not an editor of project %s and cannot be assigned its tasks", assigneeID, access.project.ProjectID)
+	}
+	return nil
+}
+
+// notifyAssignment audits a change of the assignee of a task the user made and notifies the users the task was
+// assigned to or unassigned from, unless it was the user. The change has already been made, so failures to notify
+// are logged rather than returned.
+func (t *TodoServer) notifyAssignment(ctx context.Context, userID, previousAssigneeID string, task *storage.Task) {
+	if task.AssigneeID == previousAssigneeID {
+		return
+	}
+	details := "assigned to " + task.AssigneeID
+	if task.AssigneeID == "" {
+		details = "unassigned from " + previousAssigneeID
+	} else if previousAssigneeID != "" {
+		details = fmt.Sprintf("reassigned from %s to %s", previousAssigneeID, task.AssigneeID)
+	}
+	t.recordAudit(ctx, task.ProjectID, userID, auditTaskAssigned, task.TaskID, details)
+
+	for _, notification := range []notifier.Notification{
+		{UserID: task.AssigneeID, Kind: notifier.KindTaskAssigned},
+		{UserID: previousAssigneeID, Kind: notifier.KindTaskUnassigned},
+	} {
+		if notification.UserID == "" || notification.UserID == userID {
+			continue
+		}
+		notification.ActorID = userID
+		notification.ProjectID = task.ProjectID
+		notification.TaskID = task.TaskID
+		notification.TaskTitle = task.Title
+		if err := t.notifier.Notify(ctx, notification); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to notify user", "notify_user_id", notification.UserID, "kind", notification.Kind, "error", err)
+		}
+	}
+}
+
+// ListAssignedToMe returns the tasks assigned to the user that the user still has access to, ordered by due date.
+func (t *TodoServer) ListAssignedToMe(ctx context.Context, req *proto.ListAssignedToMeReq) (*proto.ListAssignedToMeResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	getAssignedTasksResp, err := t.tasks.GetAssignedTasks(ctx, &storage.GetAssignedTasksReq{
+		AssigneeID: userIDs[0],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %v", err)
+	}
+
+	// tasks stay assigned to members who leave their project, or whose tasks move out of it
+	accessible := map[string]bool{}
+	var assigned []storage.Task
+	for _, task := range getAssignedTasksResp.Tasks {
+		if task.UserID != userIDs[0] {
+			if task.ProjectID == "" {
+				continue
+			}
+			ok, checked := accessible[task.ProjectID]
+			if !checked {
+				getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+					UserID:    userIDs[0],
+					ProjectID: task.ProjectID,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get member: %v", err)
+				}
+				member := getMemberResp.Member
+				ok = member != nil && member.Accepted && member.OwnerID == task.UserID
+				accessible[task.ProjectID] = ok
+			}
+			if !ok {
+				continue
+			}
+		}
+		assigned = append(assigned, task)
+	}
+
+	// sort by due date, followed by tasks without one
+	dueDate := func(task *storage.Task) int64 {
+		if task.DueDate == 0 {
+			return math.MaxInt64
+		}
+		return task.DueDate
+	}
+	slices.SortFunc(assigned, func(a, b storage.Task) int {
+		return cmp.Or(
+			cmp.Compare(dueDate(&a), dueDate(&b)),
+			cmp.Compare(a.UserID, b.UserID),
+			cmp.Compare(a.TaskID, b.TaskID),
+		)
+	})
+	tasks := []*proto.Task{}
+	for i := range assigned {
+		task, err := toProtoTask(&assigned[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return &proto.ListAssignedToMeResp{
+		Tasks: tasks,
+	}, nil
+}
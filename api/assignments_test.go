@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"todo/common"
+	"todo/interfaces/notifier"
+	notifierMock "todo/interfaces/notifier/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+)
+
+func Test_TodoServer_validateAssignee(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       string
+		projectID    string
+		assigneeID   string
+		getMemberErr error
+		wantErr      bool
+	}{
+		{name: "no assignee", userID: common.TEST_USER_2_ID},
+		{name: "own task to themselves", userID: common.TEST_USER_2_ID, assigneeID: common.TEST_USER_2_ID},
+		{name: "own task to another user", userID: common.TEST_USER_2_ID, assigneeID: common.TEST_USER_1_ID, wantErr: true},
+		{name: "project task to its owner", userID: common.TEST_USER_2_ID, projectID: "work", assigneeID: common.TEST_USER_1_ID},
+		{name: "project task to an editor", userID: common.TEST_USER_1_ID, projectID: "work", assigneeID: common.TEST_USER_2_ID},
+		{name: "project task to a viewer", userID: common.TEST_USER_1_ID, projectID: "work", assigneeID: testViewerID, wantErr: true},
+		{name: "project task to an invited user", userID: common.TEST_USER_1_ID, projectID: "work", assigneeID: testInviteeID, wantErr: true},
+		{name: "project task to another user", userID: common.TEST_USER_1_ID, projectID: "work", assigneeID: testOutsiderID, wantErr: true},
+		{
+			name:         "GetMember throws error",
+			userID:       common.TEST_USER_1_ID,
+			projectID:    "work",
+			assigneeID:   common.TEST_USER_2_ID,
+			getMemberErr: errors.New("test error"),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			access, err := s.authorizeProject(context.Background(), tt.userID, tt.projectID, proto.Role_EDITOR)
+			if err != nil {
+				t.Fatalf("TodoServer.authorizeProject() error = %v", err)
+			}
+			s.members.(*storageMock.MockMemberStore).GetMemberErr = tt.getMemberErr
+			if err := s.validateAssignee(context.Background(), access, tt.assigneeID); (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.validateAssignee() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_AddTask_Assignee(t *testing.T) {
+	tests := []struct {
+		name              string
+		userID            string
+		req               *proto.AddTaskReq
+		wantNotifications []notifier.Notification
+		wantErr           bool
+	}{
+		{
+			name:   "editor assigns task to owner",
+			userID: common.TEST_USER_2_ID,
+			req:    &proto.AddTaskReq{Title: "handed off", ProjectId: "work", AssigneeId: common.TEST_USER_1_ID},
+			wantNotifications: []notifier.Notification{{
+				UserID:    common.TEST_USER_1_ID,
+				Kind:      notifier.KindTaskAssigned,
+				ActorID:   common.TEST_USER_2_ID,
+				ProjectID: "work",
+				TaskTitle: "handed off",
+			}},
+		},
+		{
+			name:   "users are not notified of their own assignments",
+			userID: common.TEST_USER_2_ID,
+			req:    &proto.AddTaskReq{Title: "mine", ProjectId: "work", AssigneeId: common.TEST_USER_2_ID},
+		},
+		{
+			name:    "viewer cannot be assigned",
+			userID:  common.TEST_USER_1_ID,
+			req:     &proto.AddTaskReq{Title: "x", ProjectId: "work", AssigneeId: testViewerID},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			resp, err := s.AddTask(userContext(tt.userID), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.AddTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			getTaskResp, _ := s.tasks.GetTask(context.Background(), &storage.GetTaskReq{UserID: common.TEST_USER_1_ID, TaskID: resp.Id})
+			if getTaskResp.Task.AssigneeID != tt.req.AssigneeId {
+				t.Errorf("stored assignee = %q, want %q", getTaskResp.Task.AssigneeID, tt.req.AssigneeId)
+			}
+			for i := range tt.wantNotifications {
+				tt.wantNotifications[i].TaskID = resp.Id
+			}
+			if got := s.notifier.(*notifierMock.MockNotifier).Notifications; !reflect.DeepEqual(got, tt.wantNotifications) {
+				t.Errorf("notifications = %+v, want %+v", got, tt.wantNotifications)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_UpdateTask_Assignee(t *testing.T) {
+	tests := []struct {
+		name              string
+		userID            string
+		current           string
+		assigneeID        string
+		projectID         string
+		notifyErr         error
+		wantNotifications []string
+		wantDetails       string
+		wantErr           bool
+	}{
+		{
+			name:              "assigns task",
+			userID:            common.TEST_USER_1_ID,
+			assigneeID:        common.TEST_USER_2_ID,
+			projectID:         "work",
+			wantNotifications: []string{notifier.KindTaskAssigned + " " + common.TEST_USER_2_ID},
+			wantDetails:       "assigned to " + common.TEST_USER_2_ID,
+		},
+		{
+			name:       "viewer cannot reassign task",
+			userID:     testViewerID,
+			current:    common.TEST_USER_2_ID,
+			assigneeID: common.TEST_USER_1_ID,
+			projectID:  "work",
+			wantErr:    true,
+		},
+		{
+			name:       "editor reassigns task",
+			userID:     common.TEST_USER_2_ID,
+			current:    common.TEST_USER_2_ID,
+			assigneeID: common.TEST_USER_1_ID,
+			projectID:  "work",
+			// the editor is not notified of their own unassignment
+			wantNotifications: []string{notifier.KindTaskAssigned + " " + common.TEST_USER_1_ID},
+			wantDetails:       "reassigned from " + common.TEST_USER_2_ID + " to " + common.TEST_USER_1_ID,
+		},
+		{
+			name:              "unassigns task",
+			userID:            common.TEST_USER_1_ID,
+			current:           common.TEST_USER_2_ID,
+			projectID:         "work",
+			wantNotifications: []string{notifier.KindTaskUnassigned + " " + common.TEST_USER_2_ID},
+			wantDetails:       "unassigned from " + common.TEST_USER_2_ID,
+		},
+		{
+			name:       "keeps assignee who left the project",
+			userID:     common.TEST_USER_1_ID,
+			current:    testOutsiderID,
+			assigneeID: testOutsiderID,
+			projectID:  "work",
+		},
+		{
+			name:       "moving task out of the project checks its assignee",
+			userID:     common.TEST_USER_1_ID,
+			current:    common.TEST_USER_2_ID,
+			assigneeID: common.TEST_USER_2_ID,
+			wantErr:    true,
+		},
+		{
+			name:        "failed notifications are logged",
+			userID:      common.TEST_USER_1_ID,
+			assigneeID:  common.TEST_USER_2_ID,
+			projectID:   "work",
+			notifyErr:   errors.New("test error"),
+			wantDetails: "assigned to " + common.TEST_USER_2_ID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			s.tasks.(*storageMock.MockTaskStore).TasksTable[common.TEST_USER_1_ID][0].AssigneeID = tt.current
+			notifications := &notifierMock.MockNotifier{NotifyErr: tt.notifyErr}
+			s.notifier = notifications
+			_, err := s.UpdateTask(userContext(tt.userID), &proto.UpdateTaskReq{Task: &proto.Task{
+				Id:         common.TASK_1A_ID,
+				Title:      "shared",
+				ProjectId:  tt.projectID,
+				AssigneeId: tt.assigneeID,
+			}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.UpdateTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []string
+			for _, notification := range notifications.Notifications {
+				if notification.ActorID != tt.userID || notification.TaskID != common.TASK_1A_ID || notification.TaskTitle != "shared" {
+					t.Errorf("notification = %+v, want one of %s updating %s", notification, tt.userID, common.TASK_1A_ID)
+				}
+				got = append(got, notification.Kind+" "+notification.UserID)
+			}
+			if !reflect.DeepEqual(got, tt.wantNotifications) {
+				t.Errorf("notifications = %v, want %v", got, tt.wantNotifications)
+			}
+			var details string
+			for _, entry := range s.audit.(*storageMock.MockAuditStore).AuditTable {
+				if entry.Action == auditTaskAssigned {
+					details = entry.Details
+				}
+			}
+			if details != tt.wantDetails {
+				t.Errorf("audited assignment = %q, want %q", details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListAssignedToMe(t *testing.T) {
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		getAssignedErr   error
+		getMemberErr     error
+		wantIDs          []string
+		wantErr          bool
+		removeMembership bool
+	}{
+		{
+			name:    "lists accessible tasks by due date",
+			ctx:     userContext(common.TEST_USER_2_ID),
+			wantIDs: []string{common.TASK_1D_ID, common.TASK_1A_ID, common.TASK_2A_ID},
+		},
+		{
+			name:             "leaves out tasks of projects the user left",
+			ctx:              userContext(common.TEST_USER_2_ID),
+			removeMembership: true,
+			wantIDs:          []string{common.TASK_2A_ID},
+		},
+		{
+			name:    "no assigned tasks",
+			ctx:     userContext(testViewerID),
+			wantIDs: []string{},
+		},
+		{
+			name:           "GetAssignedTasks throws error",
+			ctx:            userContext(common.TEST_USER_2_ID),
+			getAssignedErr: errors.New("test error"),
+			wantErr:        true,
+		},
+		{
+			name:         "GetMember throws error",
+			ctx:          userContext(common.TEST_USER_2_ID),
+			getMemberErr: errors.New("test error"),
+			wantErr:      true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			tasks := s.tasks.(*storageMock.MockTaskStore)
+			tasks.TasksTable = map[string][]storage.Task{
+				common.TEST_USER_1_ID: {
+					{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Status: "INCOMPLETE", ProjectID: "work", DueDate: 200, AssigneeID: common.TEST_USER_2_ID},
+					// tasks moved out of the shared project are no longer accessible
+					{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, Status: "INCOMPLETE", AssigneeID: common.TEST_USER_2_ID},
+					{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID, Status: "INCOMPLETE", ProjectID: "old", AssigneeID: common.TEST_USER_2_ID},
+					{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1D_ID, Status: "INCOMPLETE", ProjectID: "work", DueDate: 100, AssigneeID: common.TEST_USER_2_ID},
+				},
+				common.TEST_USER_2_ID: {
+					{UserID: common.TEST_USER_2_ID, TaskID: common.TASK_2A_ID, Status: "INCOMPLETE", AssigneeID: common.TEST_USER_2_ID},
+					{UserID: common.TEST_USER_2_ID, TaskID: common.TASK_2B_ID, Status: "INCOMPLETE"},
+				},
+			}
+			tasks.GetAssignedTasksErr = tt.getAssignedErr
+			members := s.members.(*storageMock.MockMemberStore)
+			members.GetMemberErr = tt.getMemberErr
+			if tt.removeMembership {
+				members.MembersTable = members.MembersTable[1:]
+			}
+			got, err := s.ListAssignedToMe(tt.ctx, &proto.ListAssignedToMeReq{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListAssignedToMe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			ids := []string{}
+			for _, task := range got.Tasks {
+				ids = append(ids, task.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("TodoServer.ListAssignedToMe() ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"todo/common"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// errChecklistChanged is returned when a checklist changes between reading and updating it.
+var errChecklistChanged = errors.New("checklist was modified by another request, try again")
+
+// checklistComplete reports whether every checklist item is done.
+func checklistComplete(checklist []storage.ChecklistItem) bool {
+	for _, item := range checklist {
+		if !item.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// toProtoChecklistItem converts a database checklist item to its proto representation.
+func toProtoChecklistItem(item storage.ChecklistItem) *proto.ChecklistItem {
+	return &proto.ChecklistItem{
+		Id:   item.ID,
+		Text: item.Text,
+		Done: item.Done,
+	}
+}
+
+// toProtoChecklist converts a database checklist to its proto representation.
+func toProtoChecklist(checklist []storage.ChecklistItem) []*proto.ChecklistItem {
+	var items []*proto.ChecklistItem
+	for _, item := range checklist {
+		items = append(items, toProtoChecklistItem(item))
+	}
+	return items
+}
+
+// getChecklistItem gets a task the access reaches and the index of one of its checklist items.
+func (t *TodoServer) getChecklistItem(ctx context.Context, access *projectAccess, taskID, itemID string) (*storage.Task, int, error) {
+	task, err := t.getAccessibleTask(ctx, access, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+	index := slices.IndexFunc(task.Checklist, func(item storage.ChecklistItem) bool {
+		return item.ID == itemID
+	})
+	if index < 0 {
+		return nil, 0, fmt.Errorf("checklist item %s does not exist", itemID)
+	}
+	return task, index, nil
+}
+
+// checklistUpdateError converts errors from checklist updates into errors for the caller.
+func checklistUpdateError(taskID string, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("task %s does not exist", taskID)
+	case errors.Is(err, storage.ErrConditionFailed):
+		return errChecklistChanged
+	default:
+		return fmt.Errorf("failed to update checklist: %v", err)
+	}
+}
+
+// AddChecklistItem appends a new item to the end of a task's checklist.
+func (t *TodoServer) AddChecklistItem(ctx context.Context, req *proto.AddChecklistItemReq) (*proto.AddChecklistItemResp, error) {
+	// validate request
+	if req.TaskId == "" {
+		return nil, errors.New("task id cannot be blank")
+	}
+	if req.Text == "" {
+		return nil, errors.New("text cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// check the task may be edited
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	task, err := t.getAccessibleTask(ctx, access, req.TaskId)
+	if err != nil {
+		return nil, err
+	}
+
+	// add item
+	item := storage.ChecklistItem{
+		ID:   uuid.New().String(),
+		Text: req.Text,
+	}
+	_, err = t.tasks.AddChecklistItem(ctx, &storage.AddChecklistItemReq{
+		UserID: access.ownerID,
+		TaskID: req.TaskId,
+		Item:   item,
+	})
+	if err != nil {
+		return nil, checklistUpdateError(req.TaskId, err)
+	}
+	t.recordAudit(ctx, task.ProjectID, userIDs[0], auditChecklistUpdated, req.TaskId, "added item "+item.Text)
+
+	return &proto.AddChecklistItemResp{
+		Item: toProtoChecklistItem(item),
+	}, nil
+}
+
+// ToggleChecklistItem marks a checklist item as done if it is not done, and as not done if it is.
+func (t *TodoServer) ToggleChecklistItem(ctx context.Context, req *proto.ToggleChecklistItemReq) (*proto.ToggleChecklistItemResp, error) {
+	// validate request
+	if req.TaskId == "" {
+		return nil, errors.New("task id cannot be blank")
+	}
+	if req.ItemId == "" {
+		return nil, errors.New("item id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// find item
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	task, index, err := t.getChecklistItem(ctx, access, req.TaskId, req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	// toggle item, failing if it moved or was toggled since it was read
+	item := task.Checklist[index]
+	item.Done = !item.Done
+	_, err = t.tasks.SetChecklistItemDone(ctx, &storage.SetChecklistItemDoneReq{
+		UserID: access.ownerID,
+		TaskID: req.TaskId,
+		Index:  index,
+		ItemID: req.ItemId,
+		Done:   item.Done,
+	})
+	if err != nil {
+		return nil, checklistUpdateError(req.TaskId, err)
+	}
+	details := "checked item " + item.Text
+	if !item.Done {
+		details = "unchecked item " + item.Text
+	}
+	t.recordAudit(ctx, task.ProjectID, userIDs[0], auditChecklistUpdated, req.TaskId, details)
+
+	return &proto.ToggleChecklistItemResp{
+		Item: toProtoChecklistItem(item),
+	}, nil
+}
+
+// RemoveChecklistItem removes an item from a task's checklist.
+func (t *TodoServer) RemoveChecklistItem(ctx context.Context, req *proto.RemoveChecklistItemReq) (*proto.RemoveChecklistItemResp, error) {
+	// validate request
+	if req.TaskId == "" {
+		return nil, errors.New("task id cannot be blank")
+	}
+	if req.ItemId == "" {
+		return nil, errors.New("item id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// find item
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	task, index, err := t.getChecklistItem(ctx, access, req.TaskId, req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove item, failing if it moved since it was read
+	_, err = t.tasks.RemoveChecklistItem(ctx, &storage.RemoveChecklistItemReq{
+		UserID: access.ownerID,
+		TaskID: req.TaskId,
+		Index:  index,
+		ItemID: req.ItemId,
+	})
+	if err != nil {
+		return nil, checklistUpdateError(req.TaskId, err)
+	}
+	t.recordAudit(ctx, task.ProjectID, userIDs[0], auditChecklistUpdated, req.TaskId, "removed item "+task.Checklist[index].Text)
+
+	return &proto.RemoveChecklistItemResp{}, nil
+}
+
+// MoveChecklistItem moves a checklist item to a new position, shifting the items between.
+func (t *TodoServer) MoveChecklistItem(ctx context.Context, req *proto.MoveChecklistItemReq) (*proto.MoveChecklistItemResp, error) {
+	// validate request
+	if req.TaskId == "" {
+		return nil, errors.New("task id cannot be blank")
+	}
+	if req.ItemId == "" {
+		return nil, errors.New("item id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// find item
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	task, index, err := t.getChecklistItem(ctx, access, req.TaskId, req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Position < 0 || int(req.Position) >= len(task.Checklist) {
+		return nil, fmt.Errorf("position must be between 0 and %d", len(task.Checklist)-1)
+	}
+
+	// reorder checklist, failing if it changed since it was read
+	item := task.Checklist[index]
+	checklist := slices.Delete(slices.Clone(task.Checklist), index, index+1)
+	checklist = slices.Insert(checklist, int(req.Position), item)
+	_, err = t.tasks.ReplaceChecklist(ctx, &storage.ReplaceChecklistReq{
+		UserID:            access.ownerID,
+		TaskID:            req.TaskId,
+		Checklist:         checklist,
+		ExpectedChecklist: task.Checklist,
+	})
+	if err != nil {
+		return nil, checklistUpdateError(req.TaskId, err)
+	}
+	t.recordAudit(ctx, task.ProjectID, userIDs[0], auditChecklistUpdated, req.TaskId, "moved item "+item.Text)
+
+	return &proto.MoveChecklistItemResp{
+		Checklist: toProtoChecklist(checklist),
+	}, nil
+}
@@ -0,0 +1,401 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	"todo/interfaces/token_manager"
+	tmMock "todo/interfaces/token_manager/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// newChecklistTestTable returns a fresh tasks table for each test case since the checklist RPCs modify it.
+func newChecklistTestTable() map[string][]storage.Task {
+	return map[string][]storage.Task{
+		common.TEST_USER_1_ID: {
+			{
+				TaskID: common.TASK_1A_ID,
+				Title:  "title",
+				Status: proto.Status_INCOMPLETE.String(),
+				Checklist: []storage.ChecklistItem{
+					{ID: "item-a", Text: "a"},
+					{ID: "item-b", Text: "b", Done: true},
+					{ID: "item-c", Text: "c"},
+				},
+			},
+		},
+	}
+}
+
+func Test_TodoServer_AddChecklistItem(t *testing.T) {
+	type fields struct {
+		tasks storage.TaskStore
+		jwt   token_manager.TokenManagerInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *proto.AddChecklistItemReq
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy path",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID, Text: "d"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no text",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "task does not exist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddChecklistItemReq{TaskId: common.TASK_1B_ID, Text: "d"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no user id in context",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: context.Background(),
+				req: &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID, Text: "d"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "AddChecklistItem returns error",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:          newChecklistTestTable(),
+					AddChecklistItemErr: errors.New("test error"),
+				},
+				jwt: &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID, Text: "d"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{
+				tasks: tt.fields.tasks,
+				jwt:   tt.fields.jwt,
+			}
+			got, err := tr.AddChecklistItem(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.AddChecklistItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Item.Id == "" || got.Item.Text != tt.args.req.Text || got.Item.Done {
+				t.Errorf("TodoServer.AddChecklistItem() item = %v", got.Item)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ToggleChecklistItem(t *testing.T) {
+	type fields struct {
+		tasks storage.TaskStore
+		jwt   token_manager.TokenManagerInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *proto.ToggleChecklistItemReq
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantDone bool
+		wantErr  bool
+	}{
+		{
+			name: "happy path - mark done",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.ToggleChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-a"},
+			},
+			wantDone: true,
+			wantErr:  false,
+		},
+		{
+			name: "happy path - mark not done",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.ToggleChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-b"},
+			},
+			wantDone: false,
+			wantErr:  false,
+		},
+		{
+			name: "item does not exist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.ToggleChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-z"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no item id",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.ToggleChecklistItemReq{TaskId: common.TASK_1A_ID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "checklist modified concurrently",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:              newChecklistTestTable(),
+					SetChecklistItemDoneErr: storage.ErrConditionFailed,
+				},
+				jwt: &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.ToggleChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-a"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{
+				tasks: tt.fields.tasks,
+				jwt:   tt.fields.jwt,
+			}
+			got, err := tr.ToggleChecklistItem(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.ToggleChecklistItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Item.Done != tt.wantDone {
+				t.Errorf("TodoServer.ToggleChecklistItem() done = %v, want %v", got.Item.Done, tt.wantDone)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_RemoveChecklistItem(t *testing.T) {
+	type fields struct {
+		tasks storage.TaskStore
+		jwt   token_manager.TokenManagerInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *proto.RemoveChecklistItemReq
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy path",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.RemoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-b"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "item does not exist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.RemoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-z"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "RemoveChecklistItem returns error",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:             newChecklistTestTable(),
+					RemoveChecklistItemErr: errors.New("test error"),
+				},
+				jwt: &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.RemoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-b"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{
+				tasks: tt.fields.tasks,
+				jwt:   tt.fields.jwt,
+			}
+			_, err := tr.RemoveChecklistItem(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.RemoveChecklistItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_MoveChecklistItem(t *testing.T) {
+	type fields struct {
+		tasks storage.TaskStore
+		jwt   token_manager.TokenManagerInterface
+	}
+	type args struct {
+		ctx context.Context
+		req *proto.MoveChecklistItemReq
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name: "happy path - move down",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.MoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-a", Position: 2},
+			},
+			wantIDs: []string{"item-b", "item-c", "item-a"},
+			wantErr: false,
+		},
+		{
+			name: "happy path - move up",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.MoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-c", Position: 0},
+			},
+			wantIDs: []string{"item-c", "item-a", "item-b"},
+			wantErr: false,
+		},
+		{
+			name: "position out of range",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newChecklistTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.MoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-a", Position: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name: "checklist modified concurrently",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:          newChecklistTestTable(),
+					ReplaceChecklistErr: storage.ErrConditionFailed,
+				},
+				jwt: &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.MoveChecklistItemReq{TaskId: common.TASK_1A_ID, ItemId: "item-a", Position: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{
+				tasks: tt.fields.tasks,
+				jwt:   tt.fields.jwt,
+			}
+			got, err := tr.MoveChecklistItem(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.MoveChecklistItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var gotIDs []string
+			for _, item := range got.Checklist {
+				gotIDs = append(gotIDs, item.Id)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("TodoServer.MoveChecklistItem() checklist = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+)
+
+// toProtoRecurringRule converts a database recurring rule to its proto representation.
+func toProtoRecurringRule(rule *storage.RecurringRule) *proto.RecurringRule {
+	if rule == nil {
+		return nil
+	}
+	return &proto.RecurringRule{
+		CronExpression: rule.CronExpression,
+		StartDate:      rule.StartDate,
+		EndDate:        rule.EndDate,
+	}
+}
+
+// toProtoTask converts a database task to its proto representation.
+// It returns an error if the task or its status history holds an unknown status.
+func toProtoTask(task *storage.Task) (*proto.Task, error) {
+	status, err := parseStatus(task.Status)
+	if err != nil {
+		return nil, fmt.Errorf("task %s: %v", task.TaskID, err)
+	}
+	var statusHistory []*proto.StatusChange
+	for _, change := range task.StatusHistory {
+		historicStatus, err := parseStatus(change.Status)
+		if err != nil {
+			return nil, fmt.Errorf("task %s status history: %v", task.TaskID, err)
+		}
+		statusHistory = append(statusHistory, &proto.StatusChange{
+			Status:    historicStatus,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+	return &proto.Task{
+		Id:                       task.TaskID,
+		Title:                    task.Title,
+		Description:              task.Description,
+		Status:                   status,
+		Tags:                     task.Tags,
+		Parents:                  task.Parents,
+		DueDate:                  task.DueDate,
+		RecurringRule:            toProtoRecurringRule(task.RecurringRule),
+		CreatedAt:                task.CreatedAt,
+		UpdatedAt:                task.UpdatedAt,
+		CompletedAt:              task.CompletedAt,
+		Priority:                 proto.Priority(proto.Priority_value[task.Priority]),
+		EffortMinutes:            task.EffortMinutes,
+		StatusHistory:            statusHistory,
+		Checklist:                toProtoChecklist(task.Checklist),
+		ProjectId:                task.ProjectID,
+		AssigneeId:               task.AssigneeID,
+		RequireChecklistComplete: task.RequireChecklistComplete,
+	}, nil
+}
+
+// toProtoEvent converts a database event to its proto representation.
+func toProtoEvent(event *storage.Event) *proto.Event {
+	return &proto.Event{
+		Id:            event.EventID,
+		Title:         event.Title,
+		Description:   event.Description,
+		Location:      event.Location,
+		StartTime:     event.StartTime,
+		EndTime:       event.EndTime,
+		AllDay:        event.AllDay,
+		RecurringRule: toProtoRecurringRule(event.RecurringRule),
+		CreatedAt:     event.CreatedAt,
+		UpdatedAt:     event.UpdatedAt,
+	}
+}
+
+// toProtoProject converts a database project to its proto representation for a user with the given role in it.
+func toProtoProject(project *storage.Project, role proto.Role) *proto.Project {
+	return &proto.Project{
+		Id:          project.ProjectID,
+		Name:        project.Name,
+		Description: project.Description,
+		Color:       project.Color,
+		Archived:    project.Archived,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+		OwnerId:     project.UserID,
+		Role:        role,
+	}
+}
+
+// toProtoMember converts a database member to its proto representation.
+func toProtoMember(member *storage.Member) *proto.Member {
+	return &proto.Member{
+		UserId:    member.UserID,
+		Role:      proto.Role(proto.Role_value[member.Role]),
+		Accepted:  member.Accepted,
+		InvitedBy: member.InvitedBy,
+	}
+}
+
+// toProtoAuditEntry converts a database audit entry to its proto representation.
+func toProtoAuditEntry(entry *storage.AuditEntry) *proto.AuditEntry {
+	return &proto.AuditEntry{
+		Id:        entry.EntryID,
+		UserId:    entry.UserID,
+		Action:    entry.Action,
+		TaskId:    entry.TaskID,
+		Details:   entry.Details,
+		CreatedAt: entry.CreatedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+)
+
+func Test_toProtoTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		task    *storage.Task
+		want    *proto.Task
+		wantErr bool
+	}{
+		{
+			name: "complete task with timestamps",
+			task: &storage.Task{
+				UserID:        "user",
+				TaskID:        "task",
+				Title:         "title",
+				Status:        "COMPLETE",
+				Tags:          []string{"tag1"},
+				DueDate:       40,
+				CreatedAt:     10,
+				UpdatedAt:     30,
+				CompletedAt:   20,
+				Priority:      "P1",
+				EffortMinutes: 90,
+				RecurringRule: &storage.RecurringRule{
+					CronExpression: "0 9 * * 1",
+					StartDate:      1,
+					EndDate:        2,
+				},
+			},
+			want: &proto.Task{
+				Id:            "task",
+				Title:         "title",
+				Status:        proto.Status_COMPLETE,
+				Tags:          []string{"tag1"},
+				DueDate:       40,
+				CreatedAt:     10,
+				UpdatedAt:     30,
+				CompletedAt:   20,
+				Priority:      proto.Priority_P1,
+				EffortMinutes: 90,
+				RecurringRule: &proto.RecurringRule{
+					CronExpression: "0 9 * * 1",
+					StartDate:      1,
+					EndDate:        2,
+				},
+			},
+		},
+		{
+			name: "incomplete task without recurring rule",
+			task: &storage.Task{
+				TaskID:    "task",
+				Status:    "INCOMPLETE",
+				CreatedAt: 10,
+				UpdatedAt: 10,
+			},
+			want: &proto.Task{
+				Id:        "task",
+				Status:    proto.Status_INCOMPLETE,
+				CreatedAt: 10,
+				UpdatedAt: 10,
+			},
+		},
+		{
+			name: "unknown status",
+			task: &storage.Task{
+				TaskID: "task",
+				Status: "DONE",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown status in history",
+			task: &storage.Task{
+				TaskID: "task",
+				Status: "INCOMPLETE",
+				StatusHistory: []storage.StatusChange{
+					{Status: "DONE", ChangedAt: 10},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toProtoTask(tt.task)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toProtoTask() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toProtoTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
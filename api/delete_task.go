@@ -6,7 +6,8 @@ import (
 	"fmt"
 
 	"todo/common"
-	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage"
+	"todo/logging"
 	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc/metadata"
@@ -24,13 +25,36 @@ func (t *TodoServer) DeleteTask(ctx context.Context, req *proto.DeleteTaskReq) (
 		return nil, fmt.Errorf("user id is not provided in metadata")
 	}
 
-	_, err := t.ddb.DeleteTask(ctx, &dynamodb.DeleteTaskReq{
-		UserID: userIDs[0],
+	// get the task to check it may be deleted and to audit its deletion
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+	getTaskResp, err := t.tasks.GetTask(ctx, &storage.GetTaskReq{
+		UserID: access.ownerID,
+		TaskID: req.TaskId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %v", err)
+	}
+	task := getTaskResp.Task
+	// deleting a missing task of the user changes nothing, but tasks of a project must belong to it
+	if access.project != nil && (task == nil || task.ProjectID != access.project.ProjectID) {
+		return nil, fmt.Errorf("task %s does not exist", req.TaskId)
+	}
+
+	_, err = t.tasks.DeleteTask(ctx, &storage.DeleteTaskReq{
+		UserID: access.ownerID,
 		TaskID: req.TaskId,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete task: %v", err)
 	}
+	t.index.Delete(access.ownerID, req.TaskId)
+	logging.FromContext(ctx).InfoContext(ctx, "deleted task", "task_id", req.TaskId)
+	if task != nil {
+		t.recordAudit(ctx, task.ProjectID, userIDs[0], auditTaskDeleted, req.TaskId, task.Title)
+	}
 
 	return &proto.DeleteTaskResp{}, nil
 }
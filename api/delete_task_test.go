@@ -6,11 +6,12 @@ import (
 	"reflect"
 	"testing"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
+	"todo/search"
 
 	"google.golang.org/grpc/metadata"
 )
@@ -18,7 +19,7 @@ import (
 func Test_TodoServer_DeleteTask(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		tasks                   storage.TaskStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -35,7 +36,9 @@ func Test_TodoServer_DeleteTask(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
+				tasks: &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{
+					common.TEST_USER_1_ID: {{UserID: common.TEST_USER_1_ID, TaskID: "task_id"}},
+				}},
 				jwt: &tmMock.MockTokenManager{},
 			},
 			args: args{
@@ -50,8 +53,8 @@ func Test_TodoServer_DeleteTask(t *testing.T) {
 		{
 			name: "no task id provided",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
@@ -63,8 +66,8 @@ func Test_TodoServer_DeleteTask(t *testing.T) {
 		{
 			name: "no user id provided in context",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -75,10 +78,43 @@ func Test_TodoServer_DeleteTask(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "missing task",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {}}},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.DeleteTaskReq{
+					TaskId: "task_id",
+				},
+			},
+			want:    &proto.DeleteTaskResp{},
+			wantErr: false,
+		},
+		{
+			name: "DDB GetTask throws error",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{
+					GetTaskErr: errors.New("test error"),
+				},
+				jwt: &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.DeleteTaskReq{
+					TaskId: "task_id",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "DDB DeleteTask throws error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:    map[string][]storage.Task{common.TEST_USER_1_ID: {}},
 					DeleteTaskErr: errors.New("test error"),
 				},
 				jwt: &tmMock.MockTokenManager{},
@@ -97,8 +133,9 @@ func Test_TodoServer_DeleteTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				tasks:                   tt.fields.tasks,
 				jwt:                     tt.fields.jwt,
+				index:                   search.NewIndex(),
 			}
 			got, err := tr.DeleteTask(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"todo/common"
+	"todo/ical"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// calendarChunkSize is the most bytes of a calendar sent in each response of an export.
+const calendarChunkSize = 64 * 1024
+
+// getAllEvents returns every event of the user.
+func (t *TodoServer) getAllEvents(ctx context.Context, userID string) ([]storage.Event, error) {
+	var events []storage.Event
+	pageToken := ""
+	for {
+		getAllEventsResp, err := t.events.GetAllEvents(ctx, &storage.GetAllEventsReq{
+			UserID:    userID,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, getAllEventsResp.Events...)
+		if getAllEventsResp.NextPageToken == "" {
+			return events, nil
+		}
+		pageToken = getAllEventsResp.NextPageToken
+	}
+}
+
+// getCalendar returns every task and event of the user as a calendar.
+func (t *TodoServer) getCalendar(ctx context.Context, userID string) (*ical.Calendar, error) {
+	tasks, err := t.getAllTasks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %v", err)
+	}
+	events, err := t.getAllEvents(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %v", err)
+	}
+	cal := &ical.Calendar{}
+	for _, storageTask := range tasks {
+		task, err := toProtoTask(&storageTask)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert task: %v", err)
+		}
+		cal.Tasks = append(cal.Tasks, task)
+	}
+	for _, storageEvent := range events {
+		cal.Events = append(cal.Events, toProtoEvent(&storageEvent))
+	}
+	return cal, nil
+}
+
+// ExportCalendar streams every task and event of the user as an iCalendar file, in chunks.
+// The calendar is encoded before anything is sent, so that a task or event whose recurring rule
+// cannot be expressed as an RRULE fails the export with an error naming it.
+func (t *TodoServer) ExportCalendar(req *proto.ExportCalendarReq, stream proto.Todo_ExportCalendarServer) error {
+	ctx := stream.Context()
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// encode calendar
+	cal, err := t.getCalendar(ctx, userIDs[0])
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, cal); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "unable to export calendar: %v", err)
+	}
+
+	// send calendar in chunks
+	for data := buf.Bytes(); len(data) > 0; {
+		n := min(len(data), calendarChunkSize)
+		if err := stream.Send(&proto.ExportCalendarResp{Data: data[:n]}); err != nil {
+			return fmt.Errorf("failed to send calendar: %v", err)
+		}
+		data = data[n:]
+	}
+	return nil
+}
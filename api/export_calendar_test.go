@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockExportCalendarStream records the chunks sent by ExportCalendar.
+type mockExportCalendarStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*proto.ExportCalendarResp
+}
+
+func (s *mockExportCalendarStream) Context() context.Context { return s.ctx }
+func (s *mockExportCalendarStream) Send(resp *proto.ExportCalendarResp) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func Test_TodoServer_ExportCalendar(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	task := storage.Task{
+		TaskID:        common.TASK_1A_ID,
+		Title:         "weekly review",
+		Status:        proto.Status_INCOMPLETE.String(),
+		CreatedAt:     1704067200,
+		UpdatedAt:     1704067200,
+		RecurringRule: &storage.RecurringRule{CronExpression: "0 9 * * 1"},
+	}
+	event := storage.Event{EventID: "event", Title: "standup", StartTime: 1704704400, EndTime: 1704705300, UpdatedAt: 1704067200}
+	tests := []struct {
+		name     string
+		tasks    map[string][]storage.Task
+		events   map[string][]storage.Event
+		ctx      context.Context
+		want     []string
+		wantCode codes.Code
+		wantErr  bool
+	}{
+		{
+			name:   "tasks and events",
+			tasks:  map[string][]storage.Task{common.TEST_USER_1_ID: {task}},
+			events: map[string][]storage.Event{common.TEST_USER_1_ID: {event}},
+			ctx:    userCtx,
+			want: []string{
+				"BEGIN:VTODO", "UID:" + common.TASK_1A_ID, "RRULE:FREQ=WEEKLY;BYDAY=MO;BYHOUR=9;BYMINUTE=0",
+				"BEGIN:VEVENT", "UID:event", "DTSTART:20240108T090000Z",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty calendar",
+			tasks:   map[string][]storage.Task{},
+			events:  map[string][]storage.Event{},
+			ctx:     userCtx,
+			want:    []string{"BEGIN:VCALENDAR", "END:VCALENDAR"},
+			wantErr: false,
+		},
+		{
+			name: "inexpressible rule",
+			tasks: map[string][]storage.Task{common.TEST_USER_1_ID: {{
+				TaskID:        common.TASK_1A_ID,
+				Title:         "pay rent",
+				Status:        proto.Status_INCOMPLETE.String(),
+				RecurringRule: &storage.RecurringRule{CronExpression: "0 0 L * *"},
+			}}},
+			ctx:      userCtx,
+			wantCode: codes.FailedPrecondition,
+			wantErr:  true,
+		},
+		{
+			name:    "no user id in context",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				tasks:  &storageMock.MockTaskStore{TasksTable: tt.tasks},
+				events: &storageMock.MockEventStore{EventsTable: tt.events},
+			}
+			stream := &mockExportCalendarStream{ctx: tt.ctx}
+			err := s.ExportCalendar(&proto.ExportCalendarReq{}, stream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ExportCalendar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("TodoServer.ExportCalendar() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			var data bytes.Buffer
+			for _, resp := range stream.sent {
+				data.Write(resp.Data)
+			}
+			if tt.wantErr && data.Len() > 0 {
+				t.Errorf("TodoServer.ExportCalendar() sent %q despite error", data.String())
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(data.String(), want+"\r\n") {
+					t.Errorf("TodoServer.ExportCalendar() = %q, want it to contain %q", data.String(), want)
+				}
+			}
+		})
+	}
+}
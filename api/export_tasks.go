@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"todo/common"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// exportPageSize is the most tasks sent in each response of an export.
+const exportPageSize = 100
+
+// ExportTasks streams every task of the user, a page at a time, in task id order.
+func (t *TodoServer) ExportTasks(req *proto.ExportTasksReq, stream proto.Todo_ExportTasksServer) error {
+	ctx := stream.Context()
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// send each page of tasks
+	pageToken := ""
+	for {
+		getAllTasksResp, err := t.tasks.GetAllTasks(ctx, &storage.GetAllTasksReq{
+			UserID:    userIDs[0],
+			Limit:     exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %v", err)
+		}
+		resp := &proto.ExportTasksResp{}
+		for _, storageTask := range getAllTasksResp.Tasks {
+			task, err := toProtoTask(&storageTask)
+			if err != nil {
+				return fmt.Errorf("failed to convert task: %v", err)
+			}
+			resp.Tasks = append(resp.Tasks, task)
+		}
+		if len(resp.Tasks) > 0 {
+			if err := stream.Send(resp); err != nil {
+				return fmt.Errorf("failed to send tasks: %v", err)
+			}
+		}
+		if getAllTasksResp.NextPageToken == "" {
+			return nil
+		}
+		pageToken = getAllTasksResp.NextPageToken
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockExportStream records the responses sent by ExportTasks.
+type mockExportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*proto.ExportTasksResp
+}
+
+func (s *mockExportStream) Context() context.Context { return s.ctx }
+func (s *mockExportStream) Send(resp *proto.ExportTasksResp) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func Test_TodoServer_ExportTasks(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	manyTasks := make([]storage.Task, exportPageSize+1)
+	for i := range manyTasks {
+		manyTasks[i] = storage.Task{TaskID: fmt.Sprintf("task_%03d", i), Status: proto.Status_INCOMPLETE.String()}
+	}
+	tests := []struct {
+		name      string
+		tasks     map[string][]storage.Task
+		ctx       context.Context
+		wantPages []int
+		wantErr   bool
+	}{
+		{
+			name:      "pages",
+			tasks:     map[string][]storage.Task{common.TEST_USER_1_ID: manyTasks},
+			ctx:       userCtx,
+			wantPages: []int{exportPageSize, 1},
+			wantErr:   false,
+		},
+		{
+			name:      "no tasks",
+			tasks:     map[string][]storage.Task{},
+			ctx:       userCtx,
+			wantPages: nil,
+			wantErr:   false,
+		},
+		{
+			name:    "no user id in context",
+			tasks:   map[string][]storage.Task{},
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{tasks: &storageMock.MockTaskStore{TasksTable: tt.tasks}}
+			stream := &mockExportStream{ctx: tt.ctx}
+			err := s.ExportTasks(&proto.ExportTasksReq{}, stream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ExportTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var pages []int
+			for _, resp := range stream.sent {
+				pages = append(pages, len(resp.Tasks))
+			}
+			if fmt.Sprint(pages) != fmt.Sprint(tt.wantPages) {
+				t.Errorf("TodoServer.ExportTasks() pages = %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"todo/ical"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+)
+
+// feedRefreshInterval is how often calendar apps are asked to fetch a feed again.
+const feedRefreshInterval = time.Hour
+
+// feedPath returns the path of the feed with the given token.
+func feedPath(token string) string {
+	return "/feed/" + token + ".ics"
+}
+
+// authenticateFeed returns the id of the user whose feed the token authenticates,
+// or an empty string if it authenticates none.
+func (t *TodoServer) authenticateFeed(ctx context.Context, token string) (string, error) {
+	userID, secret, ok := parseFeedToken(token)
+	if !ok {
+		return "", nil
+	}
+	getUserResp, err := t.users.GetUser(ctx, &storage.GetUserReq{ID: userID})
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %v", err)
+	}
+	user := getUserResp.User
+	if user == nil || user.FeedTokenHash == "" {
+		return "", nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashFeedSecret(secret)), []byte(user.FeedTokenHash)) != 1 {
+		return "", nil
+	}
+	return userID, nil
+}
+
+// getFeed returns the calendar of the user's feed: their events, and the due dates of their tasks that are not
+// complete or cancelled, as all-day events. Events whose recurring rules cannot be expressed as RRULEs are left out.
+func (t *TodoServer) getFeed(ctx context.Context, userID string) (*ical.Calendar, error) {
+	cal, err := t.getCalendar(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	feed := &ical.Calendar{Name: "todo", RefreshInterval: feedRefreshInterval}
+	for _, task := range cal.Tasks {
+		if task.DueDate == 0 || task.Status == proto.Status_COMPLETE || task.Status == proto.Status_CANCELLED {
+			continue
+		}
+		feed.Events = append(feed.Events, &proto.Event{
+			Id:          task.Id,
+			Title:       "Due: " + task.Title,
+			Description: task.Description,
+			StartTime:   task.DueDate,
+			EndTime:     task.DueDate + int64(24*time.Hour/time.Second),
+			AllDay:      true,
+			CreatedAt:   task.CreatedAt,
+			UpdatedAt:   task.UpdatedAt,
+		})
+	}
+	for _, event := range cal.Events {
+		if rule := event.RecurringRule; rule != nil && rule.CronExpression != "" {
+			if _, err := ical.ToRRule(rule.CronExpression, event.AllDay); err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "leaving event out of feed", "event_id", event.Id, "error", err)
+				continue
+			}
+		}
+		feed.Events = append(feed.Events, event)
+	}
+	return feed, nil
+}
+
+// ServeFeed serves the iCalendar feed of the user whose feed token is in the path, as /feed/<token>.ics.
+// Tokens that authenticate no feed are not found, so that they cannot be told apart from revoked ones.
+func (t *TodoServer) ServeFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	// authenticate token
+	userID, err := t.authenticateFeed(ctx, token)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to authenticate feed", "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if userID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	// encode feed
+	feed, err := t.getFeed(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to get feed", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, feed); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to encode feed", "user_id", userID, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Write(buf.Bytes())
+}
+
+// NewFeedServer returns an HTTP server serving users' calendar feeds on the given address.
+func (t *TodoServer) NewFeedServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /feed/{file}", t.ServeFeed)
+	return &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
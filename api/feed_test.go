@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+)
+
+func Test_TodoServer_ServeFeed(t *testing.T) {
+	token, hash, err := newFeedToken(common.TEST_USER_1_ID)
+	if err != nil {
+		t.Fatalf("newFeedToken() error = %v", err)
+	}
+	otherSecret := "other-secret"
+	tasks := []storage.Task{
+		{TaskID: "open", Title: "file taxes", Status: proto.Status_INCOMPLETE.String(), DueDate: 1712966400},
+		{TaskID: "done", Title: "done task", Status: proto.Status_COMPLETE.String(), DueDate: 1712966400},
+		{TaskID: "undated", Title: "undated task", Status: proto.Status_INCOMPLETE.String()},
+	}
+	events := []storage.Event{
+		{EventID: "standup", Title: "standup", StartTime: 1704704400, EndTime: 1704705300},
+		{
+			EventID:       "rent",
+			Title:         "pay rent",
+			StartTime:     1704067200,
+			EndTime:       1704067200,
+			RecurringRule: &storage.RecurringRule{CronExpression: "0 0 L * *"},
+		},
+	}
+	tests := []struct {
+		name       string
+		feedHash   string
+		path       string
+		wantStatus int
+		want       []string
+		wantNot    []string
+	}{
+		{
+			name:       "feed",
+			feedHash:   hash,
+			path:       feedPath(token),
+			wantStatus: http.StatusOK,
+			want:       []string{"X-WR-CALNAME:todo", "UID:open", "SUMMARY:Due: file taxes", "DTSTART;VALUE=DATE:20240413", "DTEND;VALUE=DATE:20240414", "UID:standup"},
+			wantNot:    []string{"UID:done", "UID:undated", "UID:rent", "VTODO"},
+		},
+		{
+			name:       "revoked",
+			feedHash:   "",
+			path:       feedPath(token),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "wrong secret",
+			feedHash:   hashFeedSecret(otherSecret),
+			path:       feedPath(token),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown user",
+			feedHash:   hash,
+			path:       feedPath("missing." + otherSecret),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "malformed token",
+			feedHash:   hash,
+			path:       "/feed/token.ics",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not an ics file",
+			feedHash:   hash,
+			path:       "/feed/" + token,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				users: &storageMock.MockUserStore{UsersTable: map[string]storage.User{
+					common.TEST_USER_1_ID: {ID: common.TEST_USER_1_ID, FeedTokenHash: tt.feedHash},
+				}},
+				tasks:  &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: tasks}},
+				events: &storageMock.MockEventStore{EventsTable: map[string][]storage.Event{common.TEST_USER_1_ID: events}},
+			}
+			rec := httptest.NewRecorder()
+			s.NewFeedServer("").Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("TodoServer.ServeFeed() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/calendar") {
+				t.Errorf("TodoServer.ServeFeed() content type = %q, want text/calendar", got)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want+"\r\n") {
+					t.Errorf("TodoServer.ServeFeed() = %q, want it to contain %q", body, want)
+				}
+			}
+			for _, wantNot := range tt.wantNot {
+				if strings.Contains(body, wantNot) {
+					t.Errorf("TodoServer.ServeFeed() = %q, want it not to contain %q", body, wantNot)
+				}
+			}
+		})
+	}
+}
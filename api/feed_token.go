@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"todo/common"
+	"todo/interfaces/storage"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// feedTokenSecretBytes is the number of random bytes in the secret of a feed token.
+const feedTokenSecretBytes = 32
+
+// newFeedToken returns a new feed token of the user and the hash of its secret to store.
+// A token is the user's id and the secret, so that the feed can look up the user without an index.
+func newFeedToken(userID string) (token, hash string, err error) {
+	secret := make([]byte, feedTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate feed token: %v", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	return userID + "." + encoded, hashFeedSecret(encoded), nil
+}
+
+// parseFeedToken splits a feed token into the user's id and its secret.
+func parseFeedToken(token string) (userID, secret string, ok bool) {
+	userID, secret, ok = strings.Cut(token, ".")
+	return userID, secret, ok && userID != "" && secret != ""
+}
+
+// hashFeedSecret returns the hash of a feed token's secret, which, being random, needs no salt
+// or slow hashing.
+func hashFeedSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// feedURL returns the url of the feed with the given token, or an empty string if the server's public url is unknown.
+func (t *TodoServer) feedURL(token string) string {
+	if t.feedBaseURL == "" || token == "" {
+		return ""
+	}
+	return strings.TrimSuffix(t.feedBaseURL, "/") + feedPath(token)
+}
+
+// RegenerateFeedToken issues the user a new calendar feed token, or none if revoking it,
+// replacing the hash of the previous token so that it no longer authenticates the feed.
+func (t *TodoServer) RegenerateFeedToken(ctx context.Context, req *proto.RegenerateFeedTokenReq) (*proto.RegenerateFeedTokenResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// generate token
+	var token, hash string
+	if !req.Revoke {
+		var err error
+		if token, hash, err = newFeedToken(userIDs[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	// replace previous token
+	_, err := t.users.UpdateUser(ctx, &storage.UpdateUserReq{
+		ID:            userIDs[0],
+		FeedTokenHash: &hash,
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user does not exist")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %v", err)
+	}
+
+	return &proto.RegenerateFeedTokenResp{
+		Token: token,
+		Url:   t.feedURL(token),
+	}, nil
+}
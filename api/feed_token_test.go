@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_TodoServer_RegenerateFeedToken(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.RegenerateFeedTokenReq
+		feedBaseURL string
+		wantURL     bool
+		wantCode    codes.Code
+		wantErr     bool
+	}{
+		{
+			name:    "new token",
+			ctx:     userCtx,
+			req:     &proto.RegenerateFeedTokenReq{},
+			wantErr: false,
+		},
+		{
+			name:        "new token with url",
+			ctx:         userCtx,
+			req:         &proto.RegenerateFeedTokenReq{},
+			feedBaseURL: "https://todo.example.com/",
+			wantURL:     true,
+			wantErr:     false,
+		},
+		{
+			name:    "revoke",
+			ctx:     userCtx,
+			req:     &proto.RegenerateFeedTokenReq{Revoke: true},
+			wantErr: false,
+		},
+		{
+			name:     "missing user",
+			ctx:      metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, "missing")),
+			req:      &proto.RegenerateFeedTokenReq{},
+			wantCode: codes.NotFound,
+			wantErr:  true,
+		},
+		{
+			name:    "no user id in context",
+			ctx:     context.Background(),
+			req:     &proto.RegenerateFeedTokenReq{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &storageMock.MockUserStore{UsersTable: map[string]storage.User{
+				common.TEST_USER_1_ID: {ID: common.TEST_USER_1_ID, FeedTokenHash: "previous"},
+			}}
+			s := &TodoServer{users: users, feedBaseURL: tt.feedBaseURL}
+			got, err := s.RegenerateFeedToken(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.RegenerateFeedToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("TodoServer.RegenerateFeedToken() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			stored := users.UsersTable[common.TEST_USER_1_ID].FeedTokenHash
+			if tt.req.Revoke {
+				if got.Token != "" || stored != "" {
+					t.Errorf("TodoServer.RegenerateFeedToken() token = %q, stored hash = %q, want both empty", got.Token, stored)
+				}
+				return
+			}
+			userID, secret, ok := parseFeedToken(got.Token)
+			if !ok || userID != common.TEST_USER_1_ID || hashFeedSecret(secret) != stored {
+				t.Errorf("TodoServer.RegenerateFeedToken() token = %q does not match stored hash %q", got.Token, stored)
+			}
+			wantURL := ""
+			if tt.wantURL {
+				wantURL = "https://todo.example.com/feed/" + got.Token + ".ics"
+			}
+			if got.Url != wantURL {
+				t.Errorf("TodoServer.RegenerateFeedToken() url = %q, want %q", got.Url, wantURL)
+			}
+		})
+	}
+}
+
+func Test_newFeedToken(t *testing.T) {
+	first, firstHash, err := newFeedToken(common.TEST_USER_1_ID)
+	if err != nil {
+		t.Fatalf("newFeedToken() error = %v", err)
+	}
+	second, secondHash, err := newFeedToken(common.TEST_USER_1_ID)
+	if err != nil {
+		t.Fatalf("newFeedToken() error = %v", err)
+	}
+	if first == second || firstHash == secondHash {
+		t.Errorf("newFeedToken() returned the same token twice: %q", first)
+	}
+	if strings.ContainsAny(first, "/?#") {
+		t.Errorf("newFeedToken() = %q, which is not safe in a path", first)
+	}
+}
@@ -2,52 +2,118 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 	"todo/common"
-	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage"
 	proto "todo/proto/gen/go/api"
+	"todo/query"
 
 	"google.golang.org/grpc/metadata"
 )
 
+// sortKeys maps each proto sort option to the task attribute it sorts by.
+var sortKeys = map[proto.SortBy]string{
+	proto.SortBy_SORT_BY_UNSPECIFIED: "",
+	proto.SortBy_SORT_BY_DUE_DATE:    storage.DueDateKey,
+	proto.SortBy_SORT_BY_CREATED_AT:  storage.CreatedAtKey,
+	proto.SortBy_SORT_BY_UPDATED_AT:  storage.UpdatedAtKey,
+	proto.SortBy_SORT_BY_TITLE:       storage.TitleKey,
+	proto.SortBy_SORT_BY_PRIORITY:    storage.PriorityKey,
+}
+
+// toStorageGetAllTasksReq validates a request and converts it into the storage request listing its tasks,
+// leaving the user id to be set.
+func toStorageGetAllTasksReq(req *proto.GetAllTasksReq) (*storage.GetAllTasksReq, error) {
+	sortKey, ok := sortKeys[req.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort option: %v", req.SortBy)
+	}
+	if req.PageSize < 0 {
+		return nil, errors.New("page size cannot be negative")
+	}
+	var priorities []string
+	for _, priority := range req.Priorities {
+		if _, ok := proto.Priority_name[int32(priority)]; !ok {
+			return nil, fmt.Errorf("unknown priority: %v", priority)
+		}
+		priorities = append(priorities, priority.String())
+	}
+	var filter storage.Filter
+	if req.Query != "" {
+		var err error
+		filter, err = query.Parse(req.Query, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %v", err)
+		}
+	}
+	if req.ProjectId != "" {
+		project := storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: req.ProjectId}
+		if filter == nil {
+			filter = project
+		} else {
+			filter = storage.And{project, filter}
+		}
+	}
+	return &storage.GetAllTasksReq{
+		SortKey:          sortKey,
+		Descending:       req.SortDirection == proto.SortDirection_DESCENDING,
+		Limit:            req.PageSize,
+		PageToken:        req.PageToken,
+		Priorities:       priorities,
+		MaxEffortMinutes: req.MaxEffortMinutes,
+		Filter:           filter,
+	}, nil
+}
+
+// getTasksPage gets the page of tasks of a storage request, returning them along with the next page token.
+func (t *TodoServer) getTasksPage(ctx context.Context, req *storage.GetAllTasksReq) ([]*proto.Task, string, error) {
+	getAllTasksResp, err := t.tasks.GetAllTasks(ctx, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get all tasks from ddb: %v", err)
+	}
+	tasks := []*proto.Task{}
+
+	// convert all ddb tasks to proto tasks
+	for _, ddbTask := range getAllTasksResp.Tasks {
+		task, err := toProtoTask(&ddbTask)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to convert task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, getAllTasksResp.NextPageToken, nil
+}
+
 func (t *TodoServer) GetAllTasks(ctx context.Context, req *proto.GetAllTasksReq) (*proto.GetAllTasksResp, error) {
+	// validate req
+	getAllTasksReq, err := toStorageGetAllTasksReq(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// get userid from ctx
 	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
 	if len(userIDs) == 0 {
 		return nil, fmt.Errorf("user id is not provided in metadata")
 	}
 
-	// get all tasks
-	getAllTasksResp, err := t.ddb.GetAllTasks(ctx, &dynamodb.GetAllTasksReq{
-		UserID: userIDs[0],
-	})
+	// the tasks of a project shared with the user are kept with its owner
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_VIEWER)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all tasks from ddb: %v", err)
+		return nil, err
 	}
-	tasks := []*proto.Task{}
+	getAllTasksReq.UserID = access.ownerID
 
-	// convert all ddb tasks to proto tasks
-	for _, task := range getAllTasksResp.Tasks {
-		var recurringRule *proto.RecurringRule
-		if task.RecurringRule != nil {
-			recurringRule = &proto.RecurringRule{}
-			recurringRule.CronExpression = task.RecurringRule.CronExpression
-			recurringRule.StartDate = task.RecurringRule.StartDate
-			recurringRule.EndDate = task.RecurringRule.EndDate
-		}
-		tasks = append(tasks, &proto.Task{
-			Id:            task.TaskID,
-			Title:         task.Title,
-			Description:   task.Description,
-			Status:        proto.Status(proto.Status_value[task.Status]),
-			Tags:          task.Tags,
-			Parents:       task.Parents,
-			DueDate:       task.DueDate,
-			RecurringRule: recurringRule,
-		})
+	// get all tasks
+	tasks, nextPageToken, err := t.getTasksPage(ctx, getAllTasksReq)
+	if err != nil {
+		return nil, err
 	}
 
 	return &proto.GetAllTasksResp{
-		Tasks: tasks,
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
 	}, nil
 }
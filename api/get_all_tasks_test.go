@@ -3,10 +3,11 @@ package api
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
@@ -17,7 +18,7 @@ import (
 func Test_TodoServer_GetAllTasks(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		tasks                   storage.TaskStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -34,14 +35,14 @@ func Test_TodoServer_GetAllTasks(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_1_ID: {
-							{TaskID: common.TASK_1A_ID},
+							{TaskID: common.TASK_1A_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 						common.TEST_USER_2_ID: {
-							{TaskID: common.TASK_2B_ID},
-							{TaskID: common.TASK_2C_ID},
+							{TaskID: common.TASK_2B_ID, Status: proto.Status_INCOMPLETE.String()},
+							{TaskID: common.TASK_2C_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 					},
 				},
@@ -60,14 +61,14 @@ func Test_TodoServer_GetAllTasks(t *testing.T) {
 		{
 			name: "no user id in context",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_1_ID: {
-							{TaskID: common.TASK_1A_ID},
+							{TaskID: common.TASK_1A_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 						common.TEST_USER_2_ID: {
-							{TaskID: common.TASK_2B_ID},
-							{TaskID: common.TASK_2C_ID},
+							{TaskID: common.TASK_2B_ID, Status: proto.Status_INCOMPLETE.String()},
+							{TaskID: common.TASK_2C_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 					},
 				},
@@ -83,14 +84,14 @@ func Test_TodoServer_GetAllTasks(t *testing.T) {
 		{
 			name: "GetAllTasks returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_1_ID: {
-							{TaskID: common.TASK_1A_ID},
+							{TaskID: common.TASK_1A_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 						common.TEST_USER_2_ID: {
-							{TaskID: common.TASK_2B_ID},
-							{TaskID: common.TASK_2C_ID},
+							{TaskID: common.TASK_2B_ID, Status: proto.Status_INCOMPLETE.String()},
+							{TaskID: common.TASK_2C_ID, Status: proto.Status_INCOMPLETE.String()},
 						},
 					},
 					GetAllTasksErr: errors.New("test error"),
@@ -109,7 +110,7 @@ func Test_TodoServer_GetAllTasks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				tasks:                   tt.fields.tasks,
 				jwt:                     tt.fields.jwt,
 			}
 			got, err := tr.GetAllTasks(tt.args.ctx, tt.args.req)
@@ -137,3 +138,150 @@ func Test_TodoServer_GetAllTasks(t *testing.T) {
 		})
 	}
 }
+
+func Test_TodoServer_GetAllTasks_Sorting(t *testing.T) {
+	store := &storageMock.MockTaskStore{
+		TasksTable: map[string][]storage.Task{
+			common.TEST_USER_1_ID: {
+				{TaskID: common.TASK_1A_ID, Status: proto.Status_INCOMPLETE.String(), Title: "b", DueDate: 30, CreatedAt: 1, Priority: "P2", EffortMinutes: 60, ProjectID: "work"},
+				{TaskID: common.TASK_1B_ID, Status: proto.Status_INCOMPLETE.String(), Title: "c", DueDate: 10, CreatedAt: 2, Priority: "PRIORITY_UNSPECIFIED", ProjectID: "work"},
+				{TaskID: common.TASK_1C_ID, Status: proto.Status_INCOMPLETE.String(), Title: "a", DueDate: 20, CreatedAt: 3, Priority: "P0", EffortMinutes: 15},
+			},
+		},
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name        string
+		req         *proto.GetAllTasksReq
+		wantTaskIDs []string
+		wantNext    bool
+		wantErr     bool
+	}{
+		{
+			name:        "sort by due date",
+			req:         &proto.GetAllTasksReq{SortBy: proto.SortBy_SORT_BY_DUE_DATE},
+			wantTaskIDs: []string{common.TASK_1B_ID, common.TASK_1C_ID, common.TASK_1A_ID},
+		},
+		{
+			name: "sort by title descending",
+			req: &proto.GetAllTasksReq{
+				SortBy:        proto.SortBy_SORT_BY_TITLE,
+				SortDirection: proto.SortDirection_DESCENDING,
+			},
+			wantTaskIDs: []string{common.TASK_1B_ID, common.TASK_1A_ID, common.TASK_1C_ID},
+		},
+		{
+			name: "first page sorted by created at",
+			req: &proto.GetAllTasksReq{
+				SortBy:   proto.SortBy_SORT_BY_CREATED_AT,
+				PageSize: 2,
+			},
+			wantTaskIDs: []string{common.TASK_1A_ID, common.TASK_1B_ID},
+			wantNext:    true,
+		},
+		{
+			name: "last page sorted by created at",
+			req: &proto.GetAllTasksReq{
+				SortBy:    proto.SortBy_SORT_BY_CREATED_AT,
+				PageSize:  2,
+				PageToken: "2",
+			},
+			wantTaskIDs: []string{common.TASK_1C_ID},
+			wantNext:    false,
+		},
+		{
+			name:        "sort by priority",
+			req:         &proto.GetAllTasksReq{SortBy: proto.SortBy_SORT_BY_PRIORITY},
+			wantTaskIDs: []string{common.TASK_1C_ID, common.TASK_1A_ID, common.TASK_1B_ID},
+		},
+		{
+			name: "filter by priority",
+			req: &proto.GetAllTasksReq{
+				SortBy:     proto.SortBy_SORT_BY_TITLE,
+				Priorities: []proto.Priority{proto.Priority_P2, proto.Priority_PRIORITY_UNSPECIFIED},
+			},
+			wantTaskIDs: []string{common.TASK_1A_ID, common.TASK_1B_ID},
+		},
+		{
+			name: "filter by max effort",
+			req: &proto.GetAllTasksReq{
+				SortBy:           proto.SortBy_SORT_BY_TITLE,
+				MaxEffortMinutes: 30,
+			},
+			wantTaskIDs: []string{common.TASK_1C_ID},
+		},
+		{
+			name: "filter by query",
+			req: &proto.GetAllTasksReq{
+				SortBy: proto.SortBy_SORT_BY_TITLE,
+				Query:  "priority<=p2 -title:a OR effort<30m",
+			},
+			wantTaskIDs: []string{common.TASK_1C_ID, common.TASK_1A_ID},
+		},
+		{
+			name: "filter by project",
+			req: &proto.GetAllTasksReq{
+				SortBy:    proto.SortBy_SORT_BY_TITLE,
+				ProjectId: "work",
+			},
+			wantTaskIDs: []string{common.TASK_1A_ID, common.TASK_1B_ID},
+		},
+		{
+			name: "filter by project and query",
+			req: &proto.GetAllTasksReq{
+				ProjectId: "work",
+				Query:     "has:effort",
+			},
+			wantTaskIDs: []string{common.TASK_1A_ID},
+		},
+		{
+			name:    "invalid query",
+			req:     &proto.GetAllTasksReq{Query: "color:red"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown priority filter",
+			req:     &proto.GetAllTasksReq{Priorities: []proto.Priority{proto.Priority(100)}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort option",
+			req:     &proto.GetAllTasksReq{SortBy: proto.SortBy(100)},
+			wantErr: true,
+		},
+		{
+			name:    "negative page size",
+			req:     &proto.GetAllTasksReq{PageSize: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{
+				tasks: store,
+				projects: &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{
+					common.TEST_USER_1_ID: {{UserID: common.TEST_USER_1_ID, ProjectID: "work"}},
+				}},
+				jwt: &tmMock.MockTokenManager{},
+			}
+			got, err := tr.GetAllTasks(ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TodoServer.GetAllTasks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotTaskIDs := []string{}
+			for _, task := range got.Tasks {
+				gotTaskIDs = append(gotTaskIDs, task.Id)
+			}
+			if !reflect.DeepEqual(gotTaskIDs, tt.wantTaskIDs) {
+				t.Errorf("TodoServer.GetAllTasks() task ids = %v, want %v", gotTaskIDs, tt.wantTaskIDs)
+			}
+			if (got.NextPageToken != "") != tt.wantNext {
+				t.Errorf("TodoServer.GetAllTasks() next page token = %q, wantNext %v", got.NextPageToken, tt.wantNext)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"todo/common"
-	"todo/interfaces/dynamodb"
 	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc/metadata"
@@ -24,38 +23,21 @@ func (t *TodoServer) GetTask(ctx context.Context, req *proto.GetTaskReq) (*proto
 	}
 
 	// get task
-	getTaskResp, err := t.ddb.GetTask(ctx, &dynamodb.GetTaskReq{
-		UserID: userIDs[0],
-		TaskID: req.Id,
-	})
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_VIEWER)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get task: %v", err)
+		return nil, err
 	}
-	if getTaskResp.Task == nil {
-		return nil, fmt.Errorf("task %s does not exist", req.Id)
+	storedTask, err := t.getAccessibleTask(ctx, access, req.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	// simplify
-	ddbTask := getTaskResp.Task
-
 	// form and send response
-	var recurringRule *proto.RecurringRule
-	if ddbTask.RecurringRule != nil {
-		recurringRule = &proto.RecurringRule{}
-		recurringRule.CronExpression = ddbTask.RecurringRule.CronExpression
-		recurringRule.StartDate = ddbTask.RecurringRule.StartDate
-		recurringRule.EndDate = ddbTask.RecurringRule.EndDate
+	task, err := toProtoTask(storedTask)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert task: %v", err)
 	}
 	return &proto.GetTaskResp{
-		Task: &proto.Task{
-			Id:            ddbTask.TaskID,
-			Title:         ddbTask.Title,
-			Description:   ddbTask.Description,
-			Status:        proto.Status(proto.Status_value[ddbTask.Status]),
-			Tags:          ddbTask.Tags,
-			Parents:       ddbTask.Parents,
-			DueDate:       ddbTask.DueDate,
-			RecurringRule: recurringRule,
-		},
+		Task: task,
 	}, nil
 }
@@ -5,8 +5,8 @@ import (
 	"reflect"
 	"testing"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
@@ -17,7 +17,7 @@ import (
 func Test_TodoServer_GetTask(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		tasks                   storage.TaskStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -34,8 +34,8 @@ func Test_TodoServer_GetTask(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_1_ID: {
 							{
 								TaskID: "task_id",
@@ -65,8 +65,8 @@ func Test_TodoServer_GetTask(t *testing.T) {
 		{
 			name: "no user id in context",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_1_ID: {
 							{
 								TaskID: "task_id",
@@ -90,8 +90,8 @@ func Test_TodoServer_GetTask(t *testing.T) {
 		{
 			name: "mismatched user id",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					TasksTable: map[string][]dynamodb.Task{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{
 						common.TEST_USER_2_ID: {
 							{
 								TaskID: "task_id",
@@ -117,7 +117,7 @@ func Test_TodoServer_GetTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				tasks:                   tt.fields.tasks,
 				jwt:                     tt.fields.jwt,
 			}
 			got, err := tr.GetTask(tt.args.ctx, tt.args.req)
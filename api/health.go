@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices are the services whose health is reported: the server as a whole and the todo service.
+var healthServices = []string{"", proto.Todo_ServiceDesc.ServiceName}
+
+// NewHealthServer returns a health server reporting every service as NOT_SERVING
+// until ReportHealth verifies that the storage backend is ready.
+func NewHealthServer() *health.Server {
+	healthServer := health.NewServer()
+	for _, service := range healthServices {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return healthServer
+}
+
+// Ready probes the storage backend, returning an error unless every table it uses can be reached.
+func (t *TodoServer) Ready(ctx context.Context) error {
+	return t.pinger.Ping(ctx)
+}
+
+// ReportHealth probes the storage backend immediately and then every interval until ctx is done,
+// reporting every service as SERVING while the backend is ready and NOT_SERVING otherwise.
+func (t *TodoServer) ReportHealth(ctx context.Context, healthServer *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, interval)
+		err := t.Ready(probeCtx)
+		cancel()
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			slog.Warn("storage is not ready", "error", err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		for _, service := range healthServices {
+			healthServer.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
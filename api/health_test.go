@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// mockPinger returns err from every ping.
+type mockPinger struct {
+	err error
+}
+
+func (m *mockPinger) Ping(ctx context.Context) error { return m.err }
+
+func Test_TodoServer_ReportHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		pingErr error
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "ready", pingErr: nil, want: healthpb.HealthCheckResponse_SERVING},
+		{name: "not ready", pingErr: errors.New("test error"), want: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthServer := NewHealthServer()
+			ts := &TodoServer{pinger: &mockPinger{err: tt.pingErr}}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			// a done context stops reporting after the first probe
+			ts.ReportHealth(ctx, healthServer, time.Minute)
+			for _, service := range healthServices {
+				resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", service, err)
+				}
+				if resp.Status != tt.want {
+					t.Errorf("Check(%q) status = %v, want %v", service, resp.Status, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_NewHealthServer(t *testing.T) {
+	resp, err := NewHealthServer().Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Check() status = %v, want NOT_SERVING before storage is verified", resp.Status)
+	}
+}
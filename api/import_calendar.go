@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"todo/common"
+	"todo/ical"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportCalendarBytes is the largest calendar a single import may hold.
+const maxImportCalendarBytes = 16 << 20
+
+// validateImportedEvent returns why the imported event cannot be created.
+func validateImportedEvent(event *proto.Event) error {
+	if event.Title == "" {
+		return errors.New("title cannot be blank")
+	}
+	if event.EndTime < event.StartTime {
+		return errors.New("event cannot end before it starts")
+	}
+	if rule := event.RecurringRule; rule != nil && rule.CronExpression != "" {
+		if err := validateRecurringRule(rule); err != nil {
+			return fmt.Errorf("invalid recurring rule: %v", err)
+		}
+	}
+	return nil
+}
+
+// planEventImport gives each imported event of the user a new id, and reports the imported events that are invalid,
+// have duplicate ids or duplicate an existing event with the same title and start time.
+func planEventImport(userID string, imported []*proto.Event, existing []storage.Event) ([]storage.Event, []*proto.CalendarConflict) {
+	var events []storage.Event
+	var conflicts []*proto.CalendarConflict
+	conflict := func(event *proto.Event, reason string, args ...any) {
+		conflicts = append(conflicts, &proto.CalendarConflict{
+			Component: ical.ComponentEvent,
+			Uid:       event.Id,
+			Summary:   event.Title,
+			Reason:    fmt.Sprintf(reason, args...),
+		})
+	}
+
+	// index existing events
+	type titleAndStartTime struct {
+		title     string
+		startTime int64
+	}
+	existingEvents := map[titleAndStartTime]string{}
+	for _, event := range existing {
+		existingEvents[titleAndStartTime{event.Title, event.StartTime}] = event.EventID
+	}
+
+	ids := map[string]bool{}
+	for _, event := range imported {
+		if event.Id != "" {
+			if ids[event.Id] {
+				conflict(event, "duplicate event id %s", event.Id)
+				continue
+			}
+			ids[event.Id] = true
+		}
+		if err := validateImportedEvent(event); err != nil {
+			conflict(event, "invalid event: %v", err)
+			continue
+		}
+		if existingID, ok := existingEvents[titleAndStartTime{event.Title, event.StartTime}]; ok {
+			conflict(event, "duplicates existing event %s", existingID)
+			continue
+		}
+
+		storageEvent := storage.Event{
+			UserID:        userID,
+			EventID:       uuid.New().String(),
+			Title:         event.Title,
+			Description:   event.Description,
+			Location:      event.Location,
+			StartTime:     event.StartTime,
+			EndTime:       event.EndTime,
+			AllDay:        event.AllDay,
+			RecurringRule: &storage.RecurringRule{},
+		}
+		if event.RecurringRule != nil {
+			storageEvent.RecurringRule.CronExpression = event.RecurringRule.CronExpression
+			storageEvent.RecurringRule.StartDate = event.RecurringRule.StartDate
+			storageEvent.RecurringRule.EndDate = event.RecurringRule.EndDate
+		}
+		events = append(events, storageEvent)
+	}
+	return events, conflicts
+}
+
+// ImportCalendar receives an iCalendar file in chunks and creates its VTODOs as new tasks and its VEVENTs
+// as new events of the user, unless any of its components conflict, in which case nothing is imported
+// and the conflicts are returned. Like ImportTasks, the tasks and events are committed in batches.
+func (t *TodoServer) ImportCalendar(stream proto.Todo_ImportCalendarServer) error {
+	ctx := stream.Context()
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// receive calendar
+	var data bytes.Buffer
+	dryRun := false
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive calendar: %v", err)
+		}
+		if first {
+			dryRun = req.DryRun
+		}
+		data.Write(req.Data)
+		if data.Len() > maxImportCalendarBytes {
+			return status.Errorf(codes.InvalidArgument, "unable to import a calendar of more than %d bytes", maxImportCalendarBytes)
+		}
+	}
+
+	// decode calendar
+	cal, err := ical.Decode(&data)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid calendar: %v", err)
+	}
+	if len(cal.Tasks)+len(cal.Events) > maxImportTasks {
+		return status.Errorf(codes.InvalidArgument, "unable to import more than %d tasks and events at once", maxImportTasks)
+	}
+	var conflicts []*proto.CalendarConflict
+	for _, invalid := range cal.Invalid {
+		conflicts = append(conflicts, &proto.CalendarConflict{
+			Component: invalid.Component,
+			Uid:       invalid.UID,
+			Summary:   invalid.Summary,
+			Reason:    fmt.Sprintf("invalid component: %v", invalid.Err),
+		})
+	}
+
+	// plan import against the existing tasks and events
+	existingTasks, err := t.getAllTasks(ctx, userIDs[0])
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %v", err)
+	}
+	plan := planImport(userIDs[0], cal.Tasks, existingTasks, nil)
+	for _, conflict := range plan.conflicts {
+		conflicts = append(conflicts, &proto.CalendarConflict{
+			Component: ical.ComponentTodo,
+			Uid:       conflict.TaskId,
+			Summary:   conflict.Title,
+			Reason:    conflict.Reason,
+		})
+	}
+	existingEvents, err := t.getAllEvents(ctx, userIDs[0])
+	if err != nil {
+		return fmt.Errorf("failed to get events: %v", err)
+	}
+	events, eventConflicts := planEventImport(userIDs[0], cal.Events, existingEvents)
+	conflicts = append(conflicts, eventConflicts...)
+	if len(conflicts) > 0 {
+		return stream.SendAndClose(&proto.ImportCalendarResp{Conflicts: conflicts})
+	}
+	resp := &proto.ImportCalendarResp{
+		ImportedTasks:  int32(len(plan.tasks)),
+		ImportedEvents: int32(len(events)),
+	}
+	if dryRun {
+		return stream.SendAndClose(resp)
+	}
+
+	// commit tasks, then events, in batches
+	total := len(plan.tasks) + len(events)
+	for start := 0; start < total; start += importBatchSize {
+		unitOfWork := t.newUnitOfWork()
+		for i := start; i < min(start+importBatchSize, total); i++ {
+			if i < len(plan.tasks) {
+				unitOfWork.AddTask(plan.tasks[i])
+			} else {
+				unitOfWork.AddEvent(events[i-len(plan.tasks)])
+			}
+		}
+		if err := unitOfWork.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to import calendar after importing %d tasks and events: %v", start, err)
+		}
+		now := time.Now().Unix()
+		for i := start; i < min(start+importBatchSize, len(plan.tasks)); i++ {
+			t.index.Put(taskDocument(&plan.tasks[i], now))
+		}
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "imported calendar", "tasks", len(plan.tasks), "events", len(events))
+
+	return stream.SendAndClose(resp)
+}
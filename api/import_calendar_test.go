@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockImportCalendarStream receives the given requests and records the response of ImportCalendar.
+type mockImportCalendarStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*proto.ImportCalendarReq
+	resp *proto.ImportCalendarResp
+}
+
+func (s *mockImportCalendarStream) Context() context.Context { return s.ctx }
+func (s *mockImportCalendarStream) Recv() (*proto.ImportCalendarReq, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+func (s *mockImportCalendarStream) SendAndClose(resp *proto.ImportCalendarResp) error {
+	s.resp = resp
+	return nil
+}
+
+// calendarReqs splits a calendar into requests of chunks of at most size bytes.
+func calendarReqs(dryRun bool, size int, lines ...string) []*proto.ImportCalendarReq {
+	data := []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VCALENDAR\r\n")
+	var reqs []*proto.ImportCalendarReq
+	for len(data) > 0 {
+		n := min(len(data), size)
+		reqs = append(reqs, &proto.ImportCalendarReq{DryRun: dryRun, Data: data[:n]})
+		data = data[n:]
+	}
+	return reqs
+}
+
+func Test_TodoServer_ImportCalendar(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	existingTask := storage.Task{TaskID: common.TASK_1A_ID, Title: "existing", Status: proto.Status_INCOMPLETE.String()}
+	existingEvent := storage.Event{EventID: "existing-event", Title: "existing", StartTime: 1704704400, EndTime: 1704704400}
+	child := []string{"BEGIN:VTODO", "UID:child", "SUMMARY:child", "RELATED-TO;RELTYPE=PARENT:parent", "END:VTODO"}
+	parent := []string{"BEGIN:VTODO", "UID:parent", "SUMMARY:parent", "END:VTODO"}
+	standup := []string{
+		"BEGIN:VEVENT", "UID:standup", "SUMMARY:standup", "DTSTART:20240108T090000Z", "DURATION:PT15M",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", "END:VEVENT",
+	}
+	join := func(components ...[]string) []string {
+		var lines []string
+		for _, component := range components {
+			lines = append(lines, component...)
+		}
+		return lines
+	}
+	tests := []struct {
+		name          string
+		reqs          []*proto.ImportCalendarReq
+		commitErr     error
+		wantTasks     int32
+		wantEvents    int32
+		wantConflicts []string
+		wantStored    [2]int
+		wantErr       bool
+	}{
+		{
+			name:       "tasks and events in chunks",
+			reqs:       calendarReqs(false, 16, join(child, parent, standup)...),
+			wantTasks:  2,
+			wantEvents: 1,
+			wantStored: [2]int{3, 2},
+			wantErr:    false,
+		},
+		{
+			name:       "dry run",
+			reqs:       calendarReqs(true, 1024, join(child, parent, standup)...),
+			wantTasks:  2,
+			wantEvents: 1,
+			wantStored: [2]int{1, 1},
+			wantErr:    false,
+		},
+		{
+			name: "conflicts",
+			reqs: calendarReqs(false, 1024, join(
+				parent,
+				[]string{"BEGIN:VTODO", "UID:existing", "SUMMARY:existing", "END:VTODO"},
+				[]string{"BEGIN:VEVENT", "UID:first-friday", "SUMMARY:first friday", "DTSTART:20240105T090000Z", "RRULE:FREQ=MONTHLY;BYDAY=1FR", "END:VEVENT"},
+				[]string{"BEGIN:VEVENT", "UID:existing-event", "SUMMARY:existing", "DTSTART:20240108T090000Z", "END:VEVENT"},
+				standup,
+				standup,
+				[]string{"BEGIN:VEVENT", "UID:untitled", "DTSTART:20240108T090000Z", "END:VEVENT"},
+			)...),
+			wantConflicts: []string{
+				"VEVENT first-friday: invalid component: the RRULE BYDAY \"1FR\" is not a plain day of the week, " +
+					"such as one with an ordinal, which a cron expression cannot express",
+				"VTODO existing: duplicates existing task " + common.TASK_1A_ID,
+				"VEVENT existing-event: duplicates existing event existing-event",
+				"VEVENT standup: duplicate event id standup",
+				"VEVENT untitled: invalid event: title cannot be blank",
+			},
+			wantStored: [2]int{1, 1},
+			wantErr:    false,
+		},
+		{
+			name:       "not a calendar",
+			reqs:       []*proto.ImportCalendarReq{{Data: []byte("BEGIN:VCARD\r\nEND:VCARD\r\n")}},
+			wantStored: [2]int{1, 1},
+			wantErr:    true,
+		},
+		{
+			name:       "commit fails",
+			reqs:       calendarReqs(false, 1024, standup...),
+			commitErr:  errors.New("test error"),
+			wantStored: [2]int{1, 1},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {existingTask}}}
+			events := &storageMock.MockEventStore{EventsTable: map[string][]storage.Event{common.TEST_USER_1_ID: {existingEvent}}}
+			s := &TodoServer{
+				tasks:  tasks,
+				events: events,
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: tasks, Events: events, CommitErr: tt.commitErr}
+				},
+				index: search.NewIndex(),
+			}
+			stream := &mockImportCalendarStream{ctx: userCtx, reqs: tt.reqs}
+			err := s.ImportCalendar(stream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ImportCalendar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			stored := [2]int{len(tasks.TasksTable[common.TEST_USER_1_ID]), len(events.EventsTable[common.TEST_USER_1_ID])}
+			if stored != tt.wantStored {
+				t.Errorf("stored tasks and events = %v, want %v", stored, tt.wantStored)
+			}
+			if tt.wantErr {
+				return
+			}
+			if stream.resp.ImportedTasks != tt.wantTasks || stream.resp.ImportedEvents != tt.wantEvents {
+				t.Errorf("TodoServer.ImportCalendar() imported = %d tasks and %d events, want %d and %d",
+					stream.resp.ImportedTasks, stream.resp.ImportedEvents, tt.wantTasks, tt.wantEvents)
+			}
+			var conflicts []string
+			for _, conflict := range stream.resp.Conflicts {
+				conflicts = append(conflicts, conflict.Component+" "+conflict.Uid+": "+conflict.Reason)
+			}
+			if len(conflicts) != len(tt.wantConflicts) {
+				t.Fatalf("TodoServer.ImportCalendar() conflicts = %q, want %q", conflicts, tt.wantConflicts)
+			}
+			for i := range conflicts {
+				if conflicts[i] != tt.wantConflicts[i] {
+					t.Errorf("TodoServer.ImportCalendar() conflict %d = %q, want %q", i, conflicts[i], tt.wantConflicts[i])
+				}
+			}
+		})
+	}
+}
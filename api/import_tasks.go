@@ -0,0 +1,343 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxImportTasks is the most tasks a single import may hold.
+	maxImportTasks = 10000
+	// importBatchSize is the most tasks committed in each unit of work of an import,
+	// since a DynamoDB transaction holds at most 100 writes.
+	importBatchSize = 100
+)
+
+// importPlan is the tasks an import creates, or why it cannot create them.
+type importPlan struct {
+	tasks []storage.Task
+	// taskIDs maps the ids of imported tasks to the ids of the tasks created for them
+	taskIDs   map[string]string
+	conflicts []*proto.ImportConflict
+}
+
+// validateImportedTask returns why the imported task cannot be created, as AddTask would refuse it.
+func validateImportedTask(task *proto.Task) error {
+	if task.Title == "" {
+		return errors.New("title cannot be blank")
+	}
+	// exports hold an empty rule for tasks that do not recur
+	if rule := task.RecurringRule; rule != nil && rule.CronExpression != "" {
+		if err := validateRecurringRule(rule); err != nil {
+			return fmt.Errorf("invalid recurring rule: %v", err)
+		}
+	}
+	if err := validateStatus(task.Status); err != nil {
+		return err
+	}
+	if _, ok := proto.Priority_name[int32(task.Priority)]; !ok {
+		return fmt.Errorf("unknown priority: %v", task.Priority)
+	}
+	for _, item := range task.Checklist {
+		if item.Text == "" {
+			return errors.New("checklist item text cannot be blank")
+		}
+	}
+	if task.Status == proto.Status_COMPLETE && task.RequireChecklistComplete {
+		for _, item := range task.Checklist {
+			if !item.Done {
+				return errors.New("task cannot be complete until every checklist item is done")
+			}
+		}
+	}
+	return nil
+}
+
+// findParentCycles returns the cycles the parents of the imported tasks form among themselves, each as the ids
+// of its tasks from child to parent, in the order of the imported tasks. Tasks that are their own parent and
+// tasks with duplicate ids are left out, since they are reported on their own.
+func findParentCycles(imported []*proto.Task) [][]string {
+	byID := map[string]*proto.Task{}
+	for _, task := range imported {
+		if _, ok := byID[task.Id]; task.Id != "" && !ok {
+			byID[task.Id] = task
+		}
+	}
+
+	// walk the parents of each task depth first, finding a cycle whenever a parent is still being walked
+	const (
+		walking = 1
+		walked  = 2
+	)
+	states := map[string]int{}
+	var path []string
+	var cycles [][]string
+	var walk func(id string)
+	walk = func(id string) {
+		states[id] = walking
+		path = append(path, id)
+		for _, parent := range byID[id].Parents {
+			if _, ok := byID[parent]; !ok || parent == id {
+				continue
+			}
+			switch states[parent] {
+			case walking:
+				start := slices.Index(path, parent)
+				cycles = append(cycles, slices.Clone(path[start:]))
+			case 0:
+				walk(parent)
+			}
+		}
+		path = path[:len(path)-1]
+		states[id] = walked
+	}
+	for _, task := range imported {
+		if byID[task.Id] == task && states[task.Id] == 0 {
+			walk(task.Id)
+		}
+	}
+	return cycles
+}
+
+// planImport gives each imported task of the user a new id, remapping the parents that refer to other imported tasks,
+// and reports the imported tasks that are invalid, have duplicate ids, refer to missing parents, have parents forming
+// a cycle, or share their title and due date with an existing task or another imported task. Imported tasks keep their
+// project when it is one of the projectIDs, and otherwise belong to no project, since projects are not imported.
+func planImport(userID string, imported []*proto.Task, existing []storage.Task, projectIDs map[string]bool) *importPlan {
+	plan := &importPlan{taskIDs: map[string]string{}}
+	conflict := func(task *proto.Task, reason string, args ...any) {
+		plan.conflicts = append(plan.conflicts, &proto.ImportConflict{
+			TaskId: task.Id,
+			Title:  task.Title,
+			Reason: fmt.Sprintf(reason, args...),
+		})
+	}
+
+	// index existing tasks
+	existingIDs := map[string]bool{}
+	type titleAndDueDate struct {
+		title   string
+		dueDate int64
+	}
+	existingTasks := map[titleAndDueDate]string{}
+	for _, task := range existing {
+		existingIDs[task.TaskID] = true
+		existingTasks[titleAndDueDate{task.Title, task.DueDate}] = task.TaskID
+	}
+	importedTasks := map[titleAndDueDate]bool{}
+
+	// generate the id of each task first, so that parents may come after their children
+	newIDs := make([]string, len(imported))
+	for i, task := range imported {
+		newIDs[i] = uuid.New().String()
+		if task.Id == "" {
+			continue
+		}
+		if _, ok := plan.taskIDs[task.Id]; ok {
+			conflict(task, "duplicate task id %s", task.Id)
+			continue
+		}
+		plan.taskIDs[task.Id] = newIDs[i]
+	}
+
+	for i, task := range imported {
+		if err := validateImportedTask(task); err != nil {
+			conflict(task, "invalid task: %v", err)
+			continue
+		}
+		key := titleAndDueDate{task.Title, task.DueDate}
+		if existingID, ok := existingTasks[key]; ok {
+			conflict(task, "duplicates existing task %s", existingID)
+			continue
+		}
+		if importedTasks[key] {
+			conflict(task, "duplicates an earlier imported task with the same title and due date")
+			continue
+		}
+		importedTasks[key] = true
+
+		// remap parents
+		var parents []string
+		for _, parent := range task.Parents {
+			switch newID, ok := plan.taskIDs[parent]; {
+			case parent == task.Id:
+				conflict(task, "task cannot be its own parent")
+			case ok:
+				parents = append(parents, newID)
+			case existingIDs[parent]:
+				parents = append(parents, parent)
+			default:
+				conflict(task, "parent %s does not exist", parent)
+			}
+		}
+
+		// copy checklist with new item ids
+		var checklist []storage.ChecklistItem
+		for _, item := range task.Checklist {
+			checklist = append(checklist, storage.ChecklistItem{
+				ID:   uuid.New().String(),
+				Text: item.Text,
+				Done: item.Done,
+			})
+		}
+
+		storageTask := storage.Task{
+			UserID:                   userID,
+			TaskID:                   newIDs[i],
+			Title:                    task.Title,
+			Description:              task.Description,
+			Status:                   task.Status.String(),
+			Tags:                     storage.NormalizeTags(task.Tags),
+			Parents:                  parents,
+			DueDate:                  task.DueDate,
+			RecurringRule:            &storage.RecurringRule{},
+			Priority:                 task.Priority.String(),
+			EffortMinutes:            task.EffortMinutes,
+			Checklist:                checklist,
+			RequireChecklistComplete: task.RequireChecklistComplete,
+		}
+		if projectIDs[task.ProjectId] {
+			storageTask.ProjectID = task.ProjectId
+		}
+		if task.RecurringRule != nil {
+			storageTask.RecurringRule.CronExpression = task.RecurringRule.CronExpression
+			storageTask.RecurringRule.StartDate = task.RecurringRule.StartDate
+			storageTask.RecurringRule.EndDate = task.RecurringRule.EndDate
+		}
+		plan.tasks = append(plan.tasks, storageTask)
+	}
+
+	// reject parents forming a cycle, reporting each cycle against its first task
+	for _, cycle := range findParentCycles(imported) {
+		task := imported[slices.IndexFunc(imported, func(task *proto.Task) bool { return task.Id == cycle[0] })]
+		conflict(task, "parents form a cycle: %s", strings.Join(append(cycle, cycle[0]), " -> "))
+	}
+	return plan
+}
+
+// getImportProjectIDs returns the ids of the user's projects that accept tasks, if any of the imported tasks
+// belongs to a project.
+func (t *TodoServer) getImportProjectIDs(ctx context.Context, userID string, imported []*proto.Task) (map[string]bool, error) {
+	if !slices.ContainsFunc(imported, func(task *proto.Task) bool { return task.ProjectId != "" }) {
+		return nil, nil
+	}
+	getAllProjectsResp, err := t.projects.GetAllProjects(ctx, &storage.GetAllProjectsReq{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := map[string]bool{}
+	for _, project := range getAllProjectsResp.Projects {
+		if !project.Archived {
+			projectIDs[project.ProjectID] = true
+		}
+	}
+	return projectIDs, nil
+}
+
+// getAllTasks returns every task of the user.
+func (t *TodoServer) getAllTasks(ctx context.Context, userID string) ([]storage.Task, error) {
+	var tasks []storage.Task
+	pageToken := ""
+	for {
+		getAllTasksResp, err := t.tasks.GetAllTasks(ctx, &storage.GetAllTasksReq{
+			UserID:    userID,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, getAllTasksResp.Tasks...)
+		if getAllTasksResp.NextPageToken == "" {
+			return tasks, nil
+		}
+		pageToken = getAllTasksResp.NextPageToken
+	}
+}
+
+// ImportTasks receives batches of tasks and creates them as new tasks of the user, unless any of them conflict,
+// in which case nothing is imported and the conflicts are returned. The tasks are committed in batches,
+// so an import that fails while writing may leave some of its tasks imported.
+func (t *TodoServer) ImportTasks(stream proto.Todo_ImportTasksServer) error {
+	ctx := stream.Context()
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// receive every task
+	var imported []*proto.Task
+	dryRun := false
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive tasks: %v", err)
+		}
+		if first {
+			dryRun = req.DryRun
+		}
+		imported = append(imported, req.Tasks...)
+		if len(imported) > maxImportTasks {
+			return status.Errorf(codes.InvalidArgument, "unable to import more than %d tasks at once", maxImportTasks)
+		}
+	}
+
+	// plan import against the existing tasks
+	existing, err := t.getAllTasks(ctx, userIDs[0])
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %v", err)
+	}
+	projectIDs, err := t.getImportProjectIDs(ctx, userIDs[0], imported)
+	if err != nil {
+		return fmt.Errorf("failed to get projects: %v", err)
+	}
+	plan := planImport(userIDs[0], imported, existing, projectIDs)
+	if len(plan.conflicts) > 0 {
+		return stream.SendAndClose(&proto.ImportTasksResp{Conflicts: plan.conflicts})
+	}
+	if dryRun {
+		return stream.SendAndClose(&proto.ImportTasksResp{Imported: int32(len(plan.tasks))})
+	}
+
+	// commit tasks in batches
+	for start := 0; start < len(plan.tasks); start += importBatchSize {
+		batch := plan.tasks[start:min(start+importBatchSize, len(plan.tasks))]
+		unitOfWork := t.newUnitOfWork()
+		for _, task := range batch {
+			unitOfWork.AddTask(task)
+		}
+		if err := unitOfWork.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to import tasks after importing %d: %v", start, err)
+		}
+		now := time.Now().Unix()
+		for i := range batch {
+			t.index.Put(taskDocument(&batch[i], now))
+		}
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "imported tasks", "count", len(plan.tasks))
+
+	return stream.SendAndClose(&proto.ImportTasksResp{
+		Imported: int32(len(plan.tasks)),
+		TaskIds:  plan.taskIDs,
+	})
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockImportStream receives the given requests and records the response of ImportTasks.
+type mockImportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*proto.ImportTasksReq
+	resp *proto.ImportTasksResp
+}
+
+func (s *mockImportStream) Context() context.Context { return s.ctx }
+func (s *mockImportStream) Recv() (*proto.ImportTasksReq, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+func (s *mockImportStream) SendAndClose(resp *proto.ImportTasksResp) error {
+	s.resp = resp
+	return nil
+}
+
+func Test_TodoServer_ImportTasks(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	existing := storage.Task{TaskID: common.TASK_1A_ID, Title: "existing", Status: proto.Status_INCOMPLETE.String()}
+	child := &proto.Task{Id: "child", Title: "child", Parents: []string{"parent", common.TASK_1A_ID}}
+	parent := &proto.Task{Id: "parent", Title: "parent", RecurringRule: &proto.RecurringRule{CronExpression: "0 9 * * 1"}}
+	tests := []struct {
+		name          string
+		reqs          []*proto.ImportTasksReq
+		commitErr     error
+		wantImported  int32
+		wantConflicts []string
+		wantStored    int
+		wantErr       bool
+	}{
+		{
+			name:         "parents remapped",
+			reqs:         []*proto.ImportTasksReq{{Tasks: []*proto.Task{child}}, {Tasks: []*proto.Task{parent}}},
+			wantImported: 2,
+			wantStored:   3,
+			wantErr:      false,
+		},
+		{
+			name:         "empty recurring rule of exported task",
+			reqs:         []*proto.ImportTasksReq{{Tasks: []*proto.Task{{Title: "once", RecurringRule: &proto.RecurringRule{}}}}},
+			wantImported: 1,
+			wantStored:   2,
+			wantErr:      false,
+		},
+		{
+			name:         "dry run",
+			reqs:         []*proto.ImportTasksReq{{DryRun: true, Tasks: []*proto.Task{child, parent}}},
+			wantImported: 2,
+			wantStored:   1,
+			wantErr:      false,
+		},
+		{
+			name: "conflicts",
+			reqs: []*proto.ImportTasksReq{{Tasks: []*proto.Task{
+				parent,
+				{Id: "parent", Title: "duplicate id"},
+				{Title: "missing parent", Parents: []string{"missing"}},
+				{Title: ""},
+				{Title: "existing"},
+				{Title: "bad rule", RecurringRule: &proto.RecurringRule{CronExpression: "never"}},
+			}}},
+			wantImported: 0,
+			wantConflicts: []string{
+				"duplicate task id parent",
+				"parent missing does not exist",
+				"invalid task: title cannot be blank",
+				"duplicates existing task " + common.TASK_1A_ID,
+				"invalid task: invalid recurring rule: invalid cron expression",
+			},
+			wantStored: 1,
+			wantErr:    false,
+		},
+		{
+			name: "cycles and duplicates among imported tasks",
+			reqs: []*proto.ImportTasksReq{{Tasks: []*proto.Task{
+				{Id: "a", Title: "a", Parents: []string{"b"}},
+				{Id: "b", Title: "b", Parents: []string{"c"}},
+				{Id: "c", Title: "c", Parents: []string{"a", common.TASK_1A_ID}},
+				{Title: "same", DueDate: 1},
+				{Title: "same", DueDate: 2},
+				{Title: "same", DueDate: 1},
+			}}},
+			wantImported: 0,
+			wantConflicts: []string{
+				"duplicates an earlier imported task with the same title and due date",
+				"parents form a cycle: a -> b -> c -> a",
+			},
+			wantStored: 1,
+			wantErr:    false,
+		},
+		{
+			name:       "commit fails",
+			reqs:       []*proto.ImportTasksReq{{Tasks: []*proto.Task{parent}}},
+			commitErr:  errors.New("test error"),
+			wantStored: 1,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {existing}}}
+			s := &TodoServer{
+				tasks: tasks,
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: tasks, CommitErr: tt.commitErr}
+				},
+				index: search.NewIndex(),
+			}
+			stream := &mockImportStream{ctx: userCtx, reqs: tt.reqs}
+			err := s.ImportTasks(stream)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ImportTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(tasks.TasksTable[common.TEST_USER_1_ID]); got != tt.wantStored {
+				t.Errorf("stored tasks = %d, want %d", got, tt.wantStored)
+			}
+			if tt.wantErr {
+				return
+			}
+			if stream.resp.Imported != tt.wantImported {
+				t.Errorf("TodoServer.ImportTasks() imported = %d, want %d", stream.resp.Imported, tt.wantImported)
+			}
+			var conflicts []string
+			for _, conflict := range stream.resp.Conflicts {
+				conflicts = append(conflicts, conflict.Reason)
+			}
+			if len(conflicts) != len(tt.wantConflicts) {
+				t.Fatalf("TodoServer.ImportTasks() conflicts = %q, want %q", conflicts, tt.wantConflicts)
+			}
+			for i := range conflicts {
+				if conflicts[i] != tt.wantConflicts[i] {
+					t.Errorf("TodoServer.ImportTasks() conflict %d = %q, want %q", i, conflicts[i], tt.wantConflicts[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_planImport_parents(t *testing.T) {
+	existing := []storage.Task{{TaskID: common.TASK_1A_ID, Title: "existing"}}
+	imported := []*proto.Task{
+		{Id: "child", Title: "child", Parents: []string{"parent", common.TASK_1A_ID}},
+		{Id: "parent", Title: "parent"},
+	}
+	plan := planImport(common.TEST_USER_1_ID, imported, existing, nil)
+	if len(plan.conflicts) > 0 {
+		t.Fatalf("planImport() conflicts = %v", plan.conflicts)
+	}
+	childTask, parentTask := plan.tasks[0], plan.tasks[1]
+	if childTask.TaskID != plan.taskIDs["child"] || parentTask.TaskID != plan.taskIDs["parent"] || childTask.TaskID == "child" {
+		t.Errorf("planImport() ids = %s, %s, want new ids %v", childTask.TaskID, parentTask.TaskID, plan.taskIDs)
+	}
+	if len(childTask.Parents) != 2 || childTask.Parents[0] != parentTask.TaskID || childTask.Parents[1] != common.TASK_1A_ID {
+		t.Errorf("planImport() parents = %v, want [%s %s]", childTask.Parents, parentTask.TaskID, common.TASK_1A_ID)
+	}
+}
+
+func Test_findParentCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		imported []*proto.Task
+		want     [][]string
+	}{
+		{
+			name: "no cycles",
+			imported: []*proto.Task{
+				{Id: "a", Parents: []string{"b", "c"}},
+				{Id: "b", Parents: []string{"c"}},
+				{Id: "c", Parents: []string{common.TASK_1A_ID}},
+			},
+			want: nil,
+		},
+		{
+			name: "two tasks",
+			imported: []*proto.Task{
+				{Id: "a", Parents: []string{"b"}},
+				{Id: "b", Parents: []string{"a"}},
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "two cycles",
+			imported: []*proto.Task{
+				{Id: "a", Parents: []string{"b"}},
+				{Id: "b", Parents: []string{"c"}},
+				{Id: "c", Parents: []string{"b"}},
+				{Id: "d", Parents: []string{"e"}},
+				{Id: "e", Parents: []string{"d"}},
+			},
+			want: [][]string{{"b", "c"}, {"d", "e"}},
+		},
+		{
+			name: "own parent",
+			imported: []*proto.Task{
+				{Id: "a", Parents: []string{"a"}},
+			},
+			want: nil,
+		},
+		{
+			name: "tasks without ids",
+			imported: []*proto.Task{
+				{Parents: []string{""}},
+				{Parents: []string{""}},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findParentCycles(tt.imported); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findParentCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"todo/api"
 	"todo/common"
+	"todo/config"
 	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc/metadata"
@@ -14,7 +15,6 @@ import (
 
 func Test_Integration_TodoServer(t *testing.T) {
 	var todo *api.TodoServer
-	var err error
 	var userA string
 	var userB string
 	var taskA1 string
@@ -23,7 +23,11 @@ func Test_Integration_TodoServer(t *testing.T) {
 	var taskB2 string
 
 	t.Run("Get Todo Server", func(t *testing.T) {
-		todo, err = api.NewTodoServer(context.Background())
+		cfg, err := config.Load(nil)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		todo, err = api.NewTodoServer(context.Background(), cfg)
 		if err != nil {
 			t.Errorf("failed to get todo server: %v", err)
 		}
@@ -107,6 +111,33 @@ func Test_Integration_TodoServer(t *testing.T) {
 		taskB2 = resp.Id
 	})
 
+	t.Run("UserB gets tasks sorted by title", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, userB))
+		resp, err := todo.GetAllTasks(ctx, &proto.GetAllTasksReq{
+			SortBy:        proto.SortBy_SORT_BY_TITLE,
+			SortDirection: proto.SortDirection_DESCENDING,
+			PageSize:      1,
+		})
+		if err != nil {
+			t.Errorf("failed to GetAllTasks: %v", err)
+		}
+		if len(resp.Tasks) != 1 || resp.Tasks[0].Id != taskB2 || resp.NextPageToken == "" {
+			t.Errorf("unexpected first page: %v", resp)
+		}
+		resp, err = todo.GetAllTasks(ctx, &proto.GetAllTasksReq{
+			SortBy:        proto.SortBy_SORT_BY_TITLE,
+			SortDirection: proto.SortDirection_DESCENDING,
+			PageSize:      1,
+			PageToken:     resp.NextPageToken,
+		})
+		if err != nil {
+			t.Errorf("failed to GetAllTasks: %v", err)
+		}
+		if len(resp.Tasks) != 1 || resp.Tasks[0].Id != taskB1 {
+			t.Errorf("unexpected second page: %v", resp)
+		}
+	})
+
 	t.Run("UserA updates a task", func(t *testing.T) {
 		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, userA))
 		getTaskResp, err := todo.GetTask(ctx, &proto.GetTaskReq{Id: taskA1})
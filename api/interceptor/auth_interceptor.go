@@ -3,24 +3,40 @@ package interceptor
 import (
 	"context"
 	"todo/common"
+	"todo/logging"
+	"todo/metrics"
+	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-// UnaryAuthMiddleware authenticates JWTs.
-// An "authorization" key set to the user's jwt must be provided in the metadata of the incoming context.
-// Users attempting to sign up or sign in do not need a jwt in the metadata, but
-// the "authorization" field should still exist in the metadata.
-// A new jwt is issued and set in the header upon a successful call of the handler.
-func (i *Interceptor) UnaryAuthMiddleware(
-	ctx context.Context,
-	req any,
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (any, error) {
+// unauthenticatedMethods are the full names of the methods that are called without a jwt:
+// users signing up or in receive their jwt in the response, and health checks come from the orchestrator.
+var unauthenticatedMethods = map[string]bool{
+	proto.Todo_Signup_FullMethodName:     true,
+	proto.Todo_Signin_FullMethodName:     true,
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// withoutUserID returns the context without any user id the client sent in the incoming metadata, so that
+// methods called without a jwt cannot be told who the caller is by the caller.
+func withoutUserID(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md.Delete(common.USERID_METADATA_KEY)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// authenticate verifies the jwt in the "authorization" key of the incoming metadata, returning a context
+// with the user's ID set in the incoming metadata in place of any the client sent.
+func (i *Interceptor) authenticate(ctx context.Context) (context.Context, error) {
 	// get jwt from metadata
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -31,41 +47,88 @@ func (i *Interceptor) UnaryAuthMiddleware(
 		return nil, status.Error(codes.Unauthenticated, "authorization token is not provided in metadata")
 	}
 
-	switch info.FullMethod {
-	case "Signup", "Signin":
-		// TODO: Figure out these method names and implement alternative interceptor actions for each.
-		// I might not implement incoming interceptor actions for these methods at all.
-	default:
-		// verify token and append user's ID to metadata
-		userID, err := i.jwt.VerifyToken(tokens[0])
-		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
-		}
-		md.Append(common.USERID_METADATA_KEY, userID)
-		ctx = metadata.NewIncomingContext(ctx, md)
-	}
-
-	// call handler and return if error
-	resp, err := handler(ctx, req)
+	// verify token and set user's ID in metadata
+	userID, err := i.jwt.VerifyToken(tokens[0])
 	if err != nil {
-		return nil, err
+		metrics.TokenVerificationFailures.Inc()
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
+	md.Set(common.USERID_METADATA_KEY, userID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	logging.With(ctx, "user_id", userID)
+	return ctx, nil
+}
 
+// issueToken issues a new jwt for the user in the incoming metadata and sets it in the header.
+func (i *Interceptor) issueToken(ctx context.Context) error {
 	// get user id from metadata
-	userIDs := md.Get(common.USERID_METADATA_KEY)
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
 	if len(userIDs) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "user id is not provided in metadata")
+		return status.Error(codes.Unauthenticated, "user id is not provided in metadata")
 	}
 
 	// issue jwt and set it in the header
 	jwt, err := i.jwt.IssueToken(userIDs[0])
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "failed to issue jwt: %v", err)
+		return status.Errorf(codes.Unauthenticated, "failed to issue jwt: %v", err)
 	}
 	err = grpc.SetHeader(ctx, metadata.Pairs(common.JWT_METADATA_KEY, jwt))
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "failed to set jwt into header: %v", err)
+		return status.Errorf(codes.Unauthenticated, "failed to set jwt into header: %v", err)
 	}
+	return nil
+}
 
+// UnaryAuthMiddleware authenticates JWTs.
+// An "authorization" key set to the user's jwt must be provided in the metadata of the incoming context.
+// Methods called without a jwt, such as signing up or in, are passed straight to the handler, without any user id
+// the client sent.
+// A new jwt is issued and set in the header upon a successful call of the handler.
+func (i *Interceptor) UnaryAuthMiddleware(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if unauthenticatedMethods[info.FullMethod] {
+		return handler(withoutUserID(ctx), req)
+	}
+
+	ctx, err := i.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// call handler and return if error
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.issueToken(ctx); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
+
+// StreamAuthMiddleware authenticates the JWTs of streams as UnaryAuthMiddleware does requests.
+// Since the header is sent before any message of the stream, the new jwt is issued before calling the handler.
+func (i *Interceptor) StreamAuthMiddleware(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if unauthenticatedMethods[info.FullMethod] {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: withoutUserID(ss.Context())})
+	}
+
+	ctx, err := i.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if err := i.issueToken(ctx); err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
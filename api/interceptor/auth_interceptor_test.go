@@ -8,8 +8,10 @@ import (
 	"todo/common"
 	"todo/interfaces/token_manager"
 	"todo/interfaces/token_manager/mock"
+	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -59,6 +61,48 @@ func TestInterceptor_UnaryAuthMiddleware(t *testing.T) {
 			want:    "response",
 			wantErr: false,
 		},
+		{
+			name: "unauthenticated method without metadata",
+			fields: fields{jwt: &mock.MockTokenManager{
+				VerifyTokenErr: errors.New("test error"),
+			}},
+			args: args{
+				ctx:     ctx,
+				req:     nil,
+				info:    &grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName},
+				handler: func(ctx context.Context, req any) (any, error) { return "response", nil },
+			},
+			want:    "response",
+			wantErr: false,
+		},
+		{
+			name: "sign in skips token verification",
+			fields: fields{jwt: &mock.MockTokenManager{
+				VerifyTokenErr: errors.New("test error"),
+			}},
+			args: args{
+				ctx:     validCtx,
+				req:     nil,
+				info:    &grpc.UnaryServerInfo{FullMethod: proto.Todo_Signin_FullMethodName},
+				handler: func(ctx context.Context, req any) (any, error) { return "response", nil },
+			},
+			want:    "response",
+			wantErr: false,
+		},
+		{
+			name: "missing metadata",
+			fields: fields{jwt: &mock.MockTokenManager{
+				TokenMap: map[string]string{"token": "user1234"},
+			}},
+			args: args{
+				ctx:     ctx,
+				req:     nil,
+				info:    &grpc.UnaryServerInfo{FullMethod: proto.Todo_AddTask_FullMethodName},
+				handler: func(ctx context.Context, req any) (any, error) { return "response", nil },
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "VerifyToken returns error",
 			fields: fields{jwt: &mock.MockTokenManager{
@@ -104,3 +148,112 @@ func TestInterceptor_UnaryAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+// mockServerStream is a server stream with the given context.
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context { return m.ctx }
+
+func TestInterceptor_StreamAuthMiddleware(t *testing.T) {
+	ctx := context.Background()
+	ctx = grpc.NewContextWithServerTransportStream(ctx, &mockServerTransportStream{})
+	validCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(common.AUTHORIZATION_METADATA_KEY, "token"))
+	forgedCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(common.USERID_METADATA_KEY, "forged", common.AUTHORIZATION_METADATA_KEY, "token"))
+
+	// handler fails unless the stream's context holds the user's ID
+	handler := func(srv any, ss grpc.ServerStream) error {
+		userIDs := metadata.ValueFromIncomingContext(ss.Context(), common.USERID_METADATA_KEY)
+		if len(userIDs) == 0 || userIDs[0] != "user1234" {
+			return errors.New("user id is not in stream context")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		jwt     token_manager.TokenManagerInterface
+		ctx     context.Context
+		method  string
+		handler grpc.StreamHandler
+		wantErr bool
+	}{
+		{
+			name:    "happy path",
+			jwt:     &mock.MockTokenManager{TokenMap: map[string]string{"token": "user1234"}},
+			ctx:     validCtx,
+			method:  proto.Todo_ExportTasks_FullMethodName,
+			handler: handler,
+			wantErr: false,
+		},
+		{
+			name:    "missing metadata",
+			jwt:     &mock.MockTokenManager{TokenMap: map[string]string{"token": "user1234"}},
+			ctx:     ctx,
+			method:  proto.Todo_ExportTasks_FullMethodName,
+			handler: handler,
+			wantErr: true,
+		},
+		{
+			name:    "VerifyToken returns error",
+			jwt:     &mock.MockTokenManager{VerifyTokenErr: errors.New("test error")},
+			ctx:     validCtx,
+			method:  proto.Todo_ImportTasks_FullMethodName,
+			handler: handler,
+			wantErr: true,
+		},
+		{
+			name: "IssueToken returns error",
+			jwt: &mock.MockTokenManager{
+				TokenMap:      map[string]string{"token": "user1234"},
+				IssueTokenErr: errors.New("test error"),
+			},
+			ctx:     validCtx,
+			method:  proto.Todo_ImportTasks_FullMethodName,
+			handler: handler,
+			wantErr: true,
+		},
+		{
+			name:    "unauthenticated method",
+			jwt:     &mock.MockTokenManager{VerifyTokenErr: errors.New("test error")},
+			ctx:     ctx,
+			method:  healthpb.Health_Watch_FullMethodName,
+			handler: func(srv any, ss grpc.ServerStream) error { return nil },
+			wantErr: false,
+		},
+		{
+			name:    "user id sent by the client is replaced",
+			jwt:     &mock.MockTokenManager{TokenMap: map[string]string{"token": "user1234"}},
+			ctx:     forgedCtx,
+			method:  proto.Todo_ExportTasks_FullMethodName,
+			handler: handler,
+			wantErr: false,
+		},
+		{
+			name:   "user id sent by the client is removed from unauthenticated methods",
+			jwt:    &mock.MockTokenManager{VerifyTokenErr: errors.New("test error")},
+			ctx:    forgedCtx,
+			method: healthpb.Health_Watch_FullMethodName,
+			handler: func(srv any, ss grpc.ServerStream) error {
+				if userIDs := metadata.ValueFromIncomingContext(ss.Context(), common.USERID_METADATA_KEY); len(userIDs) > 0 {
+					return errors.New("user id is in stream context")
+				}
+				return nil
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Interceptor{
+				jwt: tt.jwt,
+			}
+			err := i.StreamAuthMiddleware(nil, &mockServerStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, tt.handler)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Interceptor.StreamAuthMiddleware() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,31 +1,44 @@
 package interceptor
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"log/slog"
 
-	"todo/common"
+	"todo/config"
+	"todo/interfaces/rate_limiter"
 	"todo/interfaces/token_manager"
+
+	"google.golang.org/grpc"
 )
 
 // Interceptor holds all the interceptor logic for the server
 type Interceptor struct {
-	jwt token_manager.TokenManagerInterface
+	jwt     token_manager.TokenManagerInterface
+	logger  *slog.Logger
+	limiter *rate_limiter.RateLimiter
 }
 
-// NewInterceptor returns a new instance of Interceptor
-func NewInterceptor() (*Interceptor, error) {
+// NewInterceptor returns a new instance of Interceptor using the token and rate limit settings of the given config
+// and logging requests with the given logger
+func NewInterceptor(cfg *config.Config, logger *slog.Logger) (*Interceptor, error) {
 	// get token manager
-	jwtSecret, ok := os.LookupEnv(common.JWT_SECRET_ENV_VAR)
-	if !ok {
-		return nil, fmt.Errorf("%s must be provided as an environment variable", common.JWT_SECRET_ENV_VAR)
-	}
-	tokenManager, err := token_manager.NewTokenManager(jwtSecret)
+	tokenManager, err := token_manager.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenLifetime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token manager: %v", err)
 	}
 
 	return &Interceptor{
-		jwt: tokenManager,
+		jwt:     tokenManager,
+		logger:  logger,
+		limiter: rate_limiter.NewRateLimiter(cfg.RateLimit),
 	}, nil
 }
+
+// serverStream is a server stream with a context set by an interceptor.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
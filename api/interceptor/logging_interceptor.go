@@ -0,0 +1,105 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"todo/common"
+	"todo/logging"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxRequestIDLength is the longest request id accepted from clients.
+const maxRequestIDLength = 128
+
+// requestID returns the request id in the incoming metadata if it is valid, or a new one otherwise.
+func requestID(ctx context.Context) string {
+	ids := metadata.ValueFromIncomingContext(ctx, common.REQUEST_ID_METADATA_KEY)
+	if len(ids) > 0 && validRequestID(ids[0]) {
+		return ids[0]
+	}
+	return uuid.New().String()
+}
+
+// validRequestID reports whether a client's request id is short and printable, so that it is safe to log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// logRequest calls handle with a context carrying the request's logger, which has the request's id and method,
+// and logs the request's user id, duration and status code once it is handled.
+// The request id is taken from the "x-request-id" key of the incoming metadata, or generated if it is
+// missing, and is set in the header so that clients can quote it.
+func (i *Interceptor) logRequest(ctx context.Context, method string, handle func(ctx context.Context) error) error {
+	// get request id and echo it in the header
+	id := requestID(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(common.REQUEST_ID_METADATA_KEY, id)); err != nil {
+		i.logger.WarnContext(ctx, "failed to set request id into header", "request_id", id, "error", err)
+	}
+
+	// call handler with the request's logger, which includes the request's trace if it is sampled
+	logger := i.logger.With("request_id", id, "method", method)
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsSampled() {
+		logger = logger.With("trace_id", spanContext.TraceID().String())
+	}
+	ctx = logging.NewContext(ctx, logger)
+	start := time.Now()
+	err := handle(ctx)
+
+	// log the outcome, at error level for server faults
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		level = slog.LevelError
+	}
+	attrs := []any{"duration", time.Since(start), "code", code.String()}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	logging.FromContext(ctx).Log(ctx, level, "handled request", attrs...)
+	return err
+}
+
+// UnaryLoggingMiddleware gives each request a logger carrying its request id and method, and logs
+// the request's user id, duration and status code once it is handled, as described by logRequest.
+func (i *Interceptor) UnaryLoggingMiddleware(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	var resp any
+	err := i.logRequest(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// StreamLoggingMiddleware logs streams as UnaryLoggingMiddleware does requests.
+func (i *Interceptor) StreamLoggingMiddleware(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return i.logRequest(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	})
+}
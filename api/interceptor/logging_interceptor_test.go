@@ -0,0 +1,145 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"todo/common"
+	"todo/logging"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headerRecordingStream records the header set by the interceptor under test.
+type headerRecordingStream struct {
+	mockServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerRecordingStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestInterceptor_UnaryLoggingMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		incoming      metadata.MD
+		handler       grpc.UnaryHandler
+		wantRequestID string
+		wantLevel     string
+		wantCode      string
+		wantUserID    any
+		traceID       trace.TraceID
+		wantTraceID   any
+	}{
+		{
+			name:          "request id accepted from client",
+			incoming:      metadata.Pairs(common.REQUEST_ID_METADATA_KEY, "client-request-1"),
+			handler:       func(ctx context.Context, req any) (any, error) { return "response", nil },
+			wantRequestID: "client-request-1",
+			wantLevel:     "INFO",
+			wantCode:      "OK",
+		},
+		{
+			name:      "request id generated",
+			incoming:  metadata.MD{},
+			handler:   func(ctx context.Context, req any) (any, error) { return "response", nil },
+			wantLevel: "INFO",
+			wantCode:  "OK",
+		},
+		{
+			name:      "invalid request id replaced",
+			incoming:  metadata.Pairs(common.REQUEST_ID_METADATA_KEY, "bad id\n"+strings.Repeat("x", 200)),
+			handler:   func(ctx context.Context, req any) (any, error) { return "response", nil },
+			wantLevel: "INFO",
+			wantCode:  "OK",
+		},
+		{
+			name:     "user id added by handler",
+			incoming: metadata.MD{},
+			handler: func(ctx context.Context, req any) (any, error) {
+				logging.With(ctx, "user_id", "user1234")
+				return "response", nil
+			},
+			wantLevel:  "INFO",
+			wantCode:   "OK",
+			wantUserID: "user1234",
+		},
+		{
+			name:        "sampled trace",
+			incoming:    metadata.MD{},
+			handler:     func(ctx context.Context, req any) (any, error) { return "response", nil },
+			wantLevel:   "INFO",
+			wantCode:    "OK",
+			traceID:     trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			wantTraceID: "0102030405060708090a0b0c0d0e0f10",
+		},
+		{
+			name:      "client error",
+			incoming:  metadata.MD{},
+			handler:   func(ctx context.Context, req any) (any, error) { return nil, status.Error(codes.NotFound, "missing") },
+			wantLevel: "INFO",
+			wantCode:  "NotFound",
+		},
+		{
+			name:      "server error",
+			incoming:  metadata.MD{},
+			handler:   func(ctx context.Context, req any) (any, error) { return nil, status.Error(codes.Internal, "broken") },
+			wantLevel: "ERROR",
+			wantCode:  "Internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			i := &Interceptor{logger: logging.New(&buf, "json", slog.LevelInfo)}
+			stream := &headerRecordingStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			ctx = metadata.NewIncomingContext(ctx, tt.incoming)
+			if tt.traceID.IsValid() {
+				ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID:    tt.traceID,
+					SpanID:     trace.SpanID{1},
+					TraceFlags: trace.FlagsSampled,
+				}))
+			}
+
+			i.UnaryLoggingMiddleware(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "SomeRPC"}, tt.handler)
+
+			// the request id is echoed in the header
+			ids := stream.header.Get(common.REQUEST_ID_METADATA_KEY)
+			if len(ids) != 1 || !validRequestID(ids[0]) {
+				t.Fatalf("header request ids = %v, want one valid request id", ids)
+			}
+			if tt.wantRequestID != "" && ids[0] != tt.wantRequestID {
+				t.Errorf("header request id = %s, want %s", ids[0], tt.wantRequestID)
+			}
+
+			// the request is logged with its request id
+			record := map[string]any{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("failed to unmarshal log record: %v", err)
+			}
+			if record["request_id"] != ids[0] || record["method"] != "SomeRPC" {
+				t.Errorf("logged request_id, method = %v, %v, want %s, SomeRPC", record["request_id"], record["method"], ids[0])
+			}
+			if record["level"] != tt.wantLevel || record["code"] != tt.wantCode {
+				t.Errorf("logged level, code = %v, %v, want %s, %s", record["level"], record["code"], tt.wantLevel, tt.wantCode)
+			}
+			if record["user_id"] != tt.wantUserID {
+				t.Errorf("logged user_id = %v, want %v", record["user_id"], tt.wantUserID)
+			}
+			if record["trace_id"] != tt.wantTraceID {
+				t.Errorf("logged trace_id = %v, want %v", record["trace_id"], tt.wantTraceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+	"todo/metrics"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// observeRequest counts a handled request by method and status code, and observes how long it took since start.
+func observeRequest(method string, start time.Time, err error) {
+	metrics.RPCDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	metrics.RPCRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// UnaryMetricsMiddleware counts every request by method and status code, and observes how long it takes to handle.
+func (i *Interceptor) UnaryMetricsMiddleware(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamMetricsMiddleware counts and times streams as UnaryMetricsMiddleware does requests.
+func (i *Interceptor) StreamMetricsMiddleware(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeRequest(info.FullMethod, start, err)
+	return err
+}
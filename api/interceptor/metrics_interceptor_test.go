@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"todo/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptor_UnaryMetricsMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		handler  grpc.UnaryHandler
+		wantCode string
+	}{
+		{
+			name:     "success",
+			method:   "/metrics.test/Success",
+			handler:  func(ctx context.Context, req any) (any, error) { return "response", nil },
+			wantCode: "OK",
+		},
+		{
+			name:     "error",
+			method:   "/metrics.test/Error",
+			handler:  func(ctx context.Context, req any) (any, error) { return nil, status.Error(codes.NotFound, "missing") },
+			wantCode: "NotFound",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Interceptor{}
+			i.UnaryMetricsMiddleware(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, tt.handler)
+			if got := testutil.ToFloat64(metrics.RPCRequests.WithLabelValues(tt.method, tt.wantCode)); got != 1 {
+				t.Errorf("requests with code %s = %v, want 1", tt.wantCode, got)
+			}
+			var duration dto.Metric
+			if err := metrics.RPCDuration.WithLabelValues(tt.method).(prometheus.Metric).Write(&duration); err != nil {
+				t.Fatalf("failed to write duration metric: %v", err)
+			}
+			if got := duration.GetHistogram().GetSampleCount(); got != 1 {
+				t.Errorf("duration observations = %v, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+	"todo/common"
+	"todo/metrics"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// unlimitedMethods are the full names of the methods that are never rate limited,
+// since health checks come from the orchestrator.
+var unlimitedMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+}
+
+// rateLimitCaller returns who a request is rate limited as: the user id added to the metadata by
+// UnaryAuthMiddleware, or the peer's IP address for methods called without a jwt.
+func rateLimitCaller(ctx context.Context) string {
+	if userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY); len(userIDs) > 0 {
+		return "user:" + userIDs[0]
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "peer:unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return "peer:" + p.Addr.String()
+	}
+	return "peer:" + host
+}
+
+// checkRateLimit takes the method's cost from the caller's bucket, returning codes.ResourceExhausted if it cannot.
+// How many seconds to wait before retrying is set in the "retry-after" key of the header, and as the error's RetryInfo.
+func (i *Interceptor) checkRateLimit(ctx context.Context, method string) error {
+	if i.limiter == nil || unlimitedMethods[method] {
+		return nil
+	}
+
+	// take the method's cost from the caller's bucket
+	allow, wait := i.limiter.Allow(rateLimitCaller(ctx), method, time.Now())
+	if allow {
+		return nil
+	}
+	metrics.RateLimitedRequests.WithLabelValues(method).Inc()
+
+	// tell the caller when to retry
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if err := grpc.SetHeader(ctx, metadata.Pairs(common.RETRY_AFTER_METADATA_KEY, strconv.FormatInt(seconds, 10))); err != nil {
+		return status.Errorf(codes.Internal, "failed to set retry after into header: %v", err)
+	}
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(time.Duration(seconds) * time.Second),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to add retry info to status: %v", err)
+	}
+	return st.Err()
+}
+
+// UnaryRateLimitMiddleware rejects requests of callers that have exceeded their rate limit, as described by checkRateLimit.
+// It must run after UnaryAuthMiddleware so that users are limited by their id rather than their address.
+func (i *Interceptor) UnaryRateLimitMiddleware(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := i.checkRateLimit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamRateLimitMiddleware rejects streams of callers that have exceeded their rate limit when they are opened,
+// as UnaryRateLimitMiddleware does requests. It must run after StreamAuthMiddleware.
+func (i *Interceptor) StreamRateLimitMiddleware(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := i.checkRateLimit(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,127 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"todo/common"
+	"todo/config"
+	"todo/interfaces/rate_limiter"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptor_UnaryRateLimitMiddleware(t *testing.T) {
+	userCtx := func(userID string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, userID))
+	}
+	peerCtx := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 50000}})
+	}
+	type call struct {
+		ctx            context.Context
+		method         string
+		wantCode       codes.Code
+		wantRetryAfter int64
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "user exceeds limit",
+			calls: []call{
+				{ctx: userCtx("user1"), method: "/api.Todo/GetAllTasks", wantCode: codes.OK},
+				{ctx: userCtx("user1"), method: "/api.Todo/GetAllTasks", wantCode: codes.ResourceExhausted, wantRetryAfter: 2},
+				{ctx: userCtx("user2"), method: "/api.Todo/GetAllTasks", wantCode: codes.OK},
+			},
+		},
+		{
+			name: "peer exceeds limit",
+			calls: []call{
+				{ctx: peerCtx("10.0.0.1"), method: "/api.Todo/Signin", wantCode: codes.OK},
+				{ctx: peerCtx("10.0.0.1"), method: "/api.Todo/Signin", wantCode: codes.ResourceExhausted, wantRetryAfter: 10},
+				{ctx: peerCtx("10.0.0.2"), method: "/api.Todo/Signin", wantCode: codes.OK},
+			},
+		},
+		{
+			name: "health checks are not limited",
+			calls: []call{
+				{ctx: peerCtx("10.0.0.1"), method: healthpb.Health_Check_FullMethodName, wantCode: codes.OK},
+				{ctx: peerCtx("10.0.0.1"), method: healthpb.Health_Check_FullMethodName, wantCode: codes.OK},
+				{ctx: peerCtx("10.0.0.1"), method: healthpb.Health_Check_FullMethodName, wantCode: codes.OK},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Interceptor{limiter: rate_limiter.NewRateLimiter(config.RateLimitConfig{
+				RequestsPerSecond: 1,
+				Burst:             2,
+				Methods: map[string]config.MethodRateLimitConfig{
+					"/api.Todo/GetAllTasks": {Cost: 2},
+					"/api.Todo/Signin":      {RequestsPerSecond: 0.1, Burst: 1},
+				},
+			})}
+			handler := func(ctx context.Context, req any) (any, error) { return "response", nil }
+			for n, c := range tt.calls {
+				stream := &headerRecordingStream{}
+				ctx := grpc.NewContextWithServerTransportStream(c.ctx, stream)
+				_, err := i.UnaryRateLimitMiddleware(ctx, nil, &grpc.UnaryServerInfo{FullMethod: c.method}, handler)
+				if got := status.Code(err); got != c.wantCode {
+					t.Fatalf("call %d: code = %v, want %v", n, got, c.wantCode)
+				}
+				if c.wantRetryAfter == 0 {
+					continue
+				}
+				if got := stream.header.Get(common.RETRY_AFTER_METADATA_KEY); len(got) != 1 || got[0] != strconv.FormatInt(c.wantRetryAfter, 10) {
+					t.Errorf("call %d: header retry after = %v, want %d", n, got, c.wantRetryAfter)
+				}
+				details := status.Convert(err).Details()
+				if len(details) != 1 {
+					t.Fatalf("call %d: details = %v, want retry info", n, details)
+				}
+				if info, ok := details[0].(*errdetails.RetryInfo); !ok || info.RetryDelay.GetSeconds() != c.wantRetryAfter {
+					t.Errorf("call %d: details = %v, want retry delay of %ds", n, details, c.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestInterceptor_rateLimitForgedUserID(t *testing.T) {
+	i := &Interceptor{limiter: rate_limiter.NewRateLimiter(config.RateLimitConfig{
+		RequestsPerSecond: 1,
+		Burst:             2,
+		Methods: map[string]config.MethodRateLimitConfig{
+			proto.Todo_Signin_FullMethodName: {RequestsPerSecond: 0.1, Burst: 1},
+		},
+	})}
+	// the rate limiter runs after the auth middleware, as the server chains them
+	info := &grpc.UnaryServerInfo{FullMethod: proto.Todo_Signin_FullMethodName}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return i.UnaryRateLimitMiddleware(ctx, req, info, func(ctx context.Context, req any) (any, error) { return "response", nil })
+	}
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}
+	for n, userID := range []string{"forged1", "forged2"} {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(common.USERID_METADATA_KEY, userID))
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &headerRecordingStream{})
+		_, err := i.UnaryAuthMiddleware(ctx, nil, info, handler)
+		want := codes.OK
+		if n > 0 {
+			want = codes.ResourceExhausted
+		}
+		if got := status.Code(err); got != want {
+			t.Fatalf("call %d with user id %s: code = %v, want %v", n, userID, got, want)
+		}
+	}
+}
@@ -0,0 +1,533 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// maxMembers is the number of members, invited or accepted, each project may have besides its owner
+	maxMembers = 50
+	// defaultAuditEntries is the number of audit entries listed when the request sets no limit
+	defaultAuditEntries = 50
+	// maxAuditEntries is the most audit entries a single request may list
+	maxAuditEntries = 500
+)
+
+// actions of audit entries
+const (
+	auditTaskAdded         = "task.added"
+	auditTaskUpdated       = "task.updated"
+	auditTaskDeleted       = "task.deleted"
+	auditTaskAssigned      = "task.assigned"
+	auditChecklistUpdated  = "checklist.updated"
+	auditTasksMoved        = "tasks.moved"
+	auditProjectUpdated    = "project.updated"
+	auditMemberInvited     = "member.invited"
+	auditMemberJoined      = "member.joined"
+	auditMemberRoleChanged = "member.role_changed"
+	auditMemberRemoved     = "member.removed"
+)
+
+// projectAccess is what a user may do with the tasks of a project, or with their own tasks.
+type projectAccess struct {
+	// ownerID is the id of the user the project and its tasks are kept with
+	ownerID string
+	// project is nil when the access is to the user's own tasks rather than to those of a project
+	project *storage.Project
+	role    proto.Role
+}
+
+// checkAcceptsTasks returns an error if the project of the access is archived.
+func (a *projectAccess) checkAcceptsTasks() error {
+	if a.project != nil && a.project.Archived {
+		return fmt.Errorf("project %s is archived", a.project.ProjectID)
+	}
+	return nil
+}
+
+// validateRole returns an error unless the role can be given to a member.
+func validateRole(role proto.Role) error {
+	switch role {
+	case proto.Role_VIEWER, proto.Role_EDITOR, proto.Role_OWNER:
+		return nil
+	default:
+		return fmt.Errorf("unknown role: %v", role)
+	}
+}
+
+// authorizeProject returns the user's access to a project, failing unless the user added the project or is
+// a member who accepted an invitation to it with at least the given role. An empty project id gives the user
+// access to their own tasks. Projects the user has no access to are reported as missing, so that their ids
+// cannot be probed.
+func (t *TodoServer) authorizeProject(ctx context.Context, userID, projectID string, role proto.Role) (*projectAccess, error) {
+	if projectID == "" {
+		return &projectAccess{ownerID: userID, role: proto.Role_OWNER}, nil
+	}
+
+	// the user owns the projects kept with them
+	getProjectResp, err := t.projects.GetProject(ctx, &storage.GetProjectReq{
+		UserID:    userID,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %v", err)
+	}
+	if getProjectResp.Project != nil {
+		return &projectAccess{ownerID: userID, project: getProjectResp.Project, role: proto.Role_OWNER}, nil
+	}
+
+	// otherwise the project must be shared with the user
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    userID,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	member := getMemberResp.Member
+	if member == nil || !member.Accepted {
+		return nil, fmt.Errorf("project %s does not exist", projectID)
+	}
+	memberRole := proto.Role(proto.Role_value[member.Role])
+	if memberRole < role {
+		return nil, fmt.Errorf("permission denied: %s of project %s, which requires %s", memberRole, projectID, role)
+	}
+	getProjectResp, err = t.projects.GetProject(ctx, &storage.GetProjectReq{
+		UserID:    member.OwnerID,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %v", err)
+	}
+	if getProjectResp.Project == nil {
+		return nil, fmt.Errorf("project %s does not exist", projectID)
+	}
+	return &projectAccess{ownerID: member.OwnerID, project: getProjectResp.Project, role: memberRole}, nil
+}
+
+// getAccessibleTask gets a task the access reaches, which must belong to the project of the access if it has one.
+func (t *TodoServer) getAccessibleTask(ctx context.Context, access *projectAccess, taskID string) (*storage.Task, error) {
+	getTaskResp, err := t.tasks.GetTask(ctx, &storage.GetTaskReq{
+		UserID: access.ownerID,
+		TaskID: taskID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %v", err)
+	}
+	task := getTaskResp.Task
+	// the tasks of the owner outside the project are not shared
+	if task == nil || (access.project != nil && task.ProjectID != access.project.ProjectID) {
+		return nil, fmt.Errorf("task %s does not exist", taskID)
+	}
+	return task, nil
+}
+
+// recordAudit adds an entry to the audit of a project for a change the user made. The change has already been
+// made, so a failure to record it is logged rather than returned. Changes outside projects are not audited.
+func (t *TodoServer) recordAudit(ctx context.Context, projectID, userID, action, taskID, details string) {
+	if projectID == "" {
+		return
+	}
+	// version 7 uuids sort in the order they are generated
+	entryID, err := uuid.NewV7()
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to generate audit entry id", "error", err)
+		return
+	}
+	_, err = t.audit.AddAuditEntry(ctx, &storage.AddAuditEntryReq{
+		Entry: storage.AuditEntry{
+			ProjectID: projectID,
+			EntryID:   entryID.String(),
+			UserID:    userID,
+			Action:    action,
+			TaskID:    taskID,
+			Details:   details,
+		},
+	})
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to record audit entry", "project_id", projectID, "action", action, "error", err)
+	}
+}
+
+// InviteMember invites a user to a project, giving them the role once they accept the invitation.
+func (t *TodoServer) InviteMember(ctx context.Context, req *proto.InviteMemberReq) (*proto.InviteMemberResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+	if req.UserId == "" {
+		return nil, errors.New("user id cannot be blank")
+	}
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_OWNER)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == access.ownerID {
+		return nil, fmt.Errorf("user %s already owns project %s", req.UserId, req.ProjectId)
+	}
+
+	// check the invited user exists and is not a member yet
+	getUserResp, err := t.users.GetUser(ctx, &storage.GetUserReq{
+		ID: req.UserId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+	if getUserResp.User == nil {
+		return nil, fmt.Errorf("user %s does not exist", req.UserId)
+	}
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    req.UserId,
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	if getMemberResp.Member != nil {
+		return nil, fmt.Errorf("user %s is already a member of project %s", req.UserId, req.ProjectId)
+	}
+	getProjectMembersResp, err := t.members.GetProjectMembers(ctx, &storage.GetProjectMembersReq{
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %v", err)
+	}
+	if len(getProjectMembersResp.Members) >= maxMembers {
+		return nil, fmt.Errorf("unable to have more than %d members in a project", maxMembers)
+	}
+
+	_, err = t.members.AddMember(ctx, &storage.AddMemberReq{
+		Member: storage.Member{
+			UserID:    req.UserId,
+			ProjectID: req.ProjectId,
+			OwnerID:   access.ownerID,
+			Role:      req.Role.String(),
+			InvitedBy: userIDs[0],
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add member: %v", err)
+	}
+	t.recordAudit(ctx, req.ProjectId, userIDs[0], auditMemberInvited, "", fmt.Sprintf("invited %s as %s", req.UserId, req.Role))
+
+	return &proto.InviteMemberResp{}, nil
+}
+
+// ListInvitations returns the invitations of the user to projects that the user has not accepted yet.
+func (t *TodoServer) ListInvitations(ctx context.Context, req *proto.ListInvitationsReq) (*proto.ListInvitationsResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	getMembershipsResp, err := t.members.GetMemberships(ctx, &storage.GetMembershipsReq{
+		UserID: userIDs[0],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memberships: %v", err)
+	}
+	invitations := []*proto.Invitation{}
+	for _, member := range getMembershipsResp.Members {
+		if member.Accepted {
+			continue
+		}
+		getProjectResp, err := t.projects.GetProject(ctx, &storage.GetProjectReq{
+			UserID:    member.OwnerID,
+			ProjectID: member.ProjectID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %v", err)
+		}
+		// the project was deleted after the user was invited
+		if getProjectResp.Project == nil {
+			continue
+		}
+		invitations = append(invitations, &proto.Invitation{
+			ProjectId:   member.ProjectID,
+			ProjectName: getProjectResp.Project.Name,
+			Role:        proto.Role(proto.Role_value[member.Role]),
+			InvitedBy:   member.InvitedBy,
+		})
+	}
+
+	return &proto.ListInvitationsResp{
+		Invitations: invitations,
+	}, nil
+}
+
+// AcceptInvitation accepts an invitation of the user to a project, giving the user access to it.
+func (t *TodoServer) AcceptInvitation(ctx context.Context, req *proto.AcceptInvitationReq) (*proto.AcceptInvitationResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    userIDs[0],
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	member := getMemberResp.Member
+	if member == nil {
+		return nil, fmt.Errorf("no invitation to project %s", req.ProjectId)
+	}
+	getProjectResp, err := t.projects.GetProject(ctx, &storage.GetProjectReq{
+		UserID:    member.OwnerID,
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %v", err)
+	}
+	if getProjectResp.Project == nil {
+		return nil, fmt.Errorf("project %s does not exist", req.ProjectId)
+	}
+
+	// accepting an accepted invitation changes nothing. The invitation must not have changed since it was read,
+	// so that accepting it cannot undo a change of role made meanwhile.
+	if !member.Accepted {
+		update := *member
+		update.Accepted = true
+		updateMemberResp, err := t.members.UpdateMember(ctx, &storage.UpdateMemberReq{
+			Member:           update,
+			ExpectedRole:     member.Role,
+			ExpectedAccepted: member.Accepted,
+		})
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("no invitation to project %s", req.ProjectId)
+		}
+		if errors.Is(err, storage.ErrConditionFailed) {
+			return nil, fmt.Errorf("invitation to project %s was modified by another request, try again", req.ProjectId)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update member: %v", err)
+		}
+		member = &updateMemberResp.Member
+		t.recordAudit(ctx, req.ProjectId, userIDs[0], auditMemberJoined, "", fmt.Sprintf("joined as %s", member.Role))
+	}
+
+	return &proto.AcceptInvitationResp{
+		Project: toProtoProject(getProjectResp.Project, proto.Role(proto.Role_value[member.Role])),
+	}, nil
+}
+
+// ListMembers returns the owner of a project the user has access to followed by its other members.
+func (t *TodoServer) ListMembers(ctx context.Context, req *proto.ListMembersReq) (*proto.ListMembersResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_VIEWER)
+	if err != nil {
+		return nil, err
+	}
+	getProjectMembersResp, err := t.members.GetProjectMembers(ctx, &storage.GetProjectMembersReq{
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %v", err)
+	}
+	members := []*proto.Member{{
+		UserId:   access.ownerID,
+		Role:     proto.Role_OWNER,
+		Accepted: true,
+	}}
+	for i := range getProjectMembersResp.Members {
+		members = append(members, toProtoMember(&getProjectMembersResp.Members[i]))
+	}
+
+	return &proto.ListMembersResp{
+		Members: members,
+	}, nil
+}
+
+// UpdateMemberRole changes the role of a member of a project.
+func (t *TodoServer) UpdateMemberRole(ctx context.Context, req *proto.UpdateMemberRoleReq) (*proto.UpdateMemberRoleResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+	if req.UserId == "" {
+		return nil, errors.New("user id cannot be blank")
+	}
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_OWNER)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == access.ownerID {
+		return nil, errors.New("the role of the owner who added the project cannot be changed")
+	}
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    req.UserId,
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	if getMemberResp.Member == nil {
+		return nil, fmt.Errorf("user %s is not a member of project %s", req.UserId, req.ProjectId)
+	}
+	// update the member only if it has not changed since it was read, so that a change of role cannot undo
+	// an invitation accepted meanwhile
+	stored := getMemberResp.Member
+	member := *stored
+	member.Role = req.Role.String()
+	updateMemberResp, err := t.members.UpdateMember(ctx, &storage.UpdateMemberReq{
+		Member:           member,
+		ExpectedRole:     stored.Role,
+		ExpectedAccepted: stored.Accepted,
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("user %s is not a member of project %s", req.UserId, req.ProjectId)
+	}
+	if errors.Is(err, storage.ErrConditionFailed) {
+		return nil, fmt.Errorf("member %s was modified by another request, try again", req.UserId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update member: %v", err)
+	}
+	t.recordAudit(ctx, req.ProjectId, userIDs[0], auditMemberRoleChanged, "",
+		fmt.Sprintf("changed the role of %s from %s to %s", req.UserId, getMemberResp.Member.Role, req.Role))
+
+	return &proto.UpdateMemberRoleResp{
+		Member: toProtoMember(&updateMemberResp.Member),
+	}, nil
+}
+
+// RemoveMember removes a member from a project, or lets the user decline an invitation or leave a project.
+func (t *TodoServer) RemoveMember(ctx context.Context, req *proto.RemoveMemberReq) (*proto.RemoveMemberResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+	if req.UserId == "" {
+		return nil, errors.New("user id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// members may always remove themselves, but only owners may remove others
+	details := "left the project"
+	if req.UserId != userIDs[0] {
+		access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_OWNER)
+		if err != nil {
+			return nil, err
+		}
+		if req.UserId == access.ownerID {
+			return nil, errors.New("the owner who added the project cannot be removed")
+		}
+		details = "removed " + req.UserId
+	}
+	getMemberResp, err := t.members.GetMember(ctx, &storage.GetMemberReq{
+		UserID:    req.UserId,
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	if getMemberResp.Member == nil {
+		return nil, fmt.Errorf("user %s is not a member of project %s", req.UserId, req.ProjectId)
+	}
+	if !getMemberResp.Member.Accepted && req.UserId == userIDs[0] {
+		details = "declined the invitation"
+	}
+
+	_, err = t.members.DeleteMember(ctx, &storage.DeleteMemberReq{
+		UserID:    req.UserId,
+		ProjectID: req.ProjectId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete member: %v", err)
+	}
+	t.recordAudit(ctx, req.ProjectId, userIDs[0], auditMemberRemoved, "", details)
+
+	return &proto.RemoveMemberResp{}, nil
+}
+
+// ListAuditEntries returns the newest audit entries of a project the user has access to.
+func (t *TodoServer) ListAuditEntries(ctx context.Context, req *proto.ListAuditEntriesReq) (*proto.ListAuditEntriesResp, error) {
+	// validate req
+	if req.ProjectId == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+	if req.Limit < 0 || req.Limit > maxAuditEntries {
+		return nil, fmt.Errorf("limit must be between 0 and %d", maxAuditEntries)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultAuditEntries
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	if _, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_VIEWER); err != nil {
+		return nil, err
+	}
+	getAuditEntriesResp, err := t.audit.GetAuditEntries(ctx, &storage.GetAuditEntriesReq{
+		ProjectID: req.ProjectId,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %v", err)
+	}
+	entries := []*proto.AuditEntry{}
+	for i := range getAuditEntriesResp.Entries {
+		entries = append(entries, toProtoAuditEntry(&getAuditEntriesResp.Entries[i]))
+	}
+
+	return &proto.ListAuditEntriesResp{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,876 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"todo/common"
+	notifierMock "todo/interfaces/notifier/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	testViewerID   = "test_viewer"
+	testInviteeID  = "test_invitee"
+	testOutsiderID = "test_outsider"
+)
+
+// newMembersTestServer returns a server whose first test user owns the projects of testProjects, sharing "work" with
+// the second test user as an editor and with a viewer, and inviting another user who has not accepted yet. The first
+// test user's TASK_1A_ID belongs to "work" while TASK_1B_ID belongs to no project.
+func newMembersTestServer() *TodoServer {
+	users := map[string]storage.User{}
+	for _, id := range []string{common.TEST_USER_1_ID, common.TEST_USER_2_ID, testViewerID, testInviteeID, testOutsiderID} {
+		users[id] = storage.User{ID: id}
+	}
+	tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{
+		common.TEST_USER_1_ID: {
+			{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Title: "shared", Status: proto.Status_INCOMPLETE.String(), ProjectID: "work"},
+			{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, Title: "private", Status: proto.Status_INCOMPLETE.String()},
+		},
+		common.TEST_USER_2_ID: {},
+	}}
+	return &TodoServer{
+		users:    &storageMock.MockUserStore{UsersTable: users},
+		tasks:    tasks,
+		projects: &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()}},
+		members: &storageMock.MockMemberStore{MembersTable: []storage.Member{
+			{UserID: common.TEST_USER_2_ID, ProjectID: "work", OwnerID: common.TEST_USER_1_ID, Role: proto.Role_EDITOR.String(), InvitedBy: common.TEST_USER_1_ID, Accepted: true},
+			{UserID: testViewerID, ProjectID: "work", OwnerID: common.TEST_USER_1_ID, Role: proto.Role_VIEWER.String(), InvitedBy: common.TEST_USER_1_ID, Accepted: true},
+			{UserID: testInviteeID, ProjectID: "work", OwnerID: common.TEST_USER_1_ID, Role: proto.Role_EDITOR.String(), InvitedBy: common.TEST_USER_1_ID},
+		}},
+		audit:    &storageMock.MockAuditStore{},
+		notifier: &notifierMock.MockNotifier{},
+		index:    search.NewIndex(),
+		newUnitOfWork: func() storage.UnitOfWork {
+			return &storageMock.MockUnitOfWork{Tasks: tasks}
+		},
+	}
+}
+
+// userContext returns a context carrying the user id as the auth interceptor sets it.
+func userContext(userID string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, userID))
+}
+
+func Test_TodoServer_authorizeProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		projectID string
+		role      proto.Role
+		wantOwner string
+		wantRole  proto.Role
+		wantErr   bool
+	}{
+		{name: "own tasks", userID: common.TEST_USER_2_ID, role: proto.Role_OWNER, wantOwner: common.TEST_USER_2_ID, wantRole: proto.Role_OWNER},
+		{name: "own project", userID: common.TEST_USER_1_ID, projectID: "work", role: proto.Role_OWNER, wantOwner: common.TEST_USER_1_ID, wantRole: proto.Role_OWNER},
+		{name: "editor", userID: common.TEST_USER_2_ID, projectID: "work", role: proto.Role_EDITOR, wantOwner: common.TEST_USER_1_ID, wantRole: proto.Role_EDITOR},
+		{name: "editor may view", userID: common.TEST_USER_2_ID, projectID: "work", role: proto.Role_VIEWER, wantOwner: common.TEST_USER_1_ID, wantRole: proto.Role_EDITOR},
+		{name: "viewer", userID: testViewerID, projectID: "work", role: proto.Role_VIEWER, wantOwner: common.TEST_USER_1_ID, wantRole: proto.Role_VIEWER},
+		{name: "viewer may not edit", userID: testViewerID, projectID: "work", role: proto.Role_EDITOR, wantErr: true},
+		{name: "editor may not own", userID: common.TEST_USER_2_ID, projectID: "work", role: proto.Role_OWNER, wantErr: true},
+		{name: "unaccepted invitation", userID: testInviteeID, projectID: "work", role: proto.Role_VIEWER, wantErr: true},
+		{name: "not a member", userID: testOutsiderID, projectID: "work", role: proto.Role_VIEWER, wantErr: true},
+		{name: "missing project", userID: common.TEST_USER_1_ID, projectID: "missing", role: proto.Role_VIEWER, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			got, err := s.authorizeProject(context.Background(), tt.userID, tt.projectID, tt.role)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.authorizeProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.ownerID != tt.wantOwner || got.role != tt.wantRole {
+				t.Errorf("TodoServer.authorizeProject() = owner %s role %v, want owner %s role %v", got.ownerID, got.role, tt.wantOwner, tt.wantRole)
+			}
+			if (got.project != nil) != (tt.projectID != "") {
+				t.Errorf("TodoServer.authorizeProject() project = %v, want project %q", got.project, tt.projectID)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_authorizeProject_deletedProject(t *testing.T) {
+	s := newMembersTestServer()
+	s.projects = &storageMock.MockProjectStore{}
+	if _, err := s.authorizeProject(context.Background(), common.TEST_USER_2_ID, "work", proto.Role_VIEWER); err == nil {
+		t.Error("TodoServer.authorizeProject() error = nil for a deleted project")
+	}
+}
+
+func Test_TodoServer_InviteMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       *proto.InviteMemberReq
+		getMember error
+		wantErr   bool
+	}{
+		{
+			name: "invites user",
+			ctx:  userContext(common.TEST_USER_1_ID),
+			req:  &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER},
+		},
+		{
+			name:    "editor may not invite",
+			ctx:     userContext(common.TEST_USER_2_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "already a member",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: testInviteeID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "owner",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: common.TEST_USER_1_ID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "missing user",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: "missing", Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "unspecified role",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID},
+			wantErr: true,
+		},
+		{
+			name:    "blank project id",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{UserId: testOutsiderID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "blank user id",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.InviteMemberReq{ProjectId: "work", Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:      "GetMember throws error",
+			ctx:       userContext(common.TEST_USER_1_ID),
+			req:       &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER},
+			getMember: errors.New("test error"),
+			wantErr:   true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			members := s.members.(*storageMock.MockMemberStore)
+			members.GetMemberErr = tt.getMember
+			_, err := s.InviteMember(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.InviteMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			members.GetMemberErr = nil
+			getMemberResp, _ := members.GetMember(context.Background(), &storage.GetMemberReq{UserID: tt.req.UserId, ProjectID: tt.req.ProjectId})
+			member := getMemberResp.Member
+			if member == nil || member.Accepted || member.OwnerID != common.TEST_USER_1_ID || member.Role != tt.req.Role.String() {
+				t.Errorf("stored member = %+v, want an unaccepted %v of the owner's project", member, tt.req.Role)
+			}
+			if entries := s.audit.(*storageMock.MockAuditStore).AuditTable; len(entries) != 1 || entries[0].Action != auditMemberInvited {
+				t.Errorf("audit = %+v, want a %s entry", entries, auditMemberInvited)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_InviteMember_tooManyMembers(t *testing.T) {
+	s := newMembersTestServer()
+	members := s.members.(*storageMock.MockMemberStore)
+	for len(members.MembersTable) < maxMembers {
+		members.MembersTable = append(members.MembersTable, storage.Member{UserID: string(rune('a' + len(members.MembersTable))), ProjectID: "work"})
+	}
+	_, err := s.InviteMember(userContext(common.TEST_USER_1_ID), &proto.InviteMemberReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER})
+	if err == nil {
+		t.Error("TodoServer.InviteMember() error = nil with the most members a project may have")
+	}
+}
+
+func Test_TodoServer_ListInvitations(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    []*proto.Invitation
+		wantErr bool
+	}{
+		{
+			name: "lists unaccepted invitations",
+			ctx:  userContext(testInviteeID),
+			want: []*proto.Invitation{{ProjectId: "work", ProjectName: "Work", Role: proto.Role_EDITOR, InvitedBy: common.TEST_USER_1_ID}},
+		},
+		{
+			name: "accepted invitations are not listed",
+			ctx:  userContext(common.TEST_USER_2_ID),
+			want: []*proto.Invitation{},
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			got, err := s.ListInvitations(tt.ctx, &proto.ListInvitationsReq{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListInvitations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Invitations, tt.want) {
+				t.Errorf("TodoServer.ListInvitations() = %v, want %v", got.Invitations, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_AcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       *proto.AcceptInvitationReq
+		want      *proto.Project
+		wantAudit int
+		wantErr   bool
+	}{
+		{
+			name:      "accepts invitation",
+			ctx:       userContext(testInviteeID),
+			req:       &proto.AcceptInvitationReq{ProjectId: "work"},
+			want:      &proto.Project{Id: "work", Name: "Work", Color: "#1e90ff", OwnerId: common.TEST_USER_1_ID, Role: proto.Role_EDITOR},
+			wantAudit: 1,
+		},
+		{
+			name: "accepted invitation",
+			ctx:  userContext(common.TEST_USER_2_ID),
+			req:  &proto.AcceptInvitationReq{ProjectId: "work"},
+			want: &proto.Project{Id: "work", Name: "Work", Color: "#1e90ff", OwnerId: common.TEST_USER_1_ID, Role: proto.Role_EDITOR},
+		},
+		{
+			name:    "no invitation",
+			ctx:     userContext(testOutsiderID),
+			req:     &proto.AcceptInvitationReq{ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "blank project id",
+			ctx:     userContext(testInviteeID),
+			req:     &proto.AcceptInvitationReq{},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.AcceptInvitationReq{ProjectId: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			got, err := s.AcceptInvitation(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.AcceptInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Project, tt.want) {
+				t.Errorf("TodoServer.AcceptInvitation() = %v, want %v", got.Project, tt.want)
+			}
+			userIDs := metadata.ValueFromIncomingContext(tt.ctx, common.USERID_METADATA_KEY)
+			if _, err := s.authorizeProject(context.Background(), userIDs[0], "work", proto.Role_VIEWER); err != nil {
+				t.Errorf("TodoServer.authorizeProject() after accepting error = %v", err)
+			}
+			if entries := s.audit.(*storageMock.MockAuditStore).AuditTable; len(entries) != tt.wantAudit {
+				t.Errorf("audit = %+v, want %d entries", entries, tt.wantAudit)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListMembers(t *testing.T) {
+	// proto messages are built per case since comparing them may touch their internal state
+	owner := func() *proto.Member {
+		return &proto.Member{UserId: common.TEST_USER_1_ID, Role: proto.Role_OWNER, Accepted: true}
+	}
+	all := func() []*proto.Member {
+		return []*proto.Member{
+			owner(),
+			{UserId: testInviteeID, Role: proto.Role_EDITOR, InvitedBy: common.TEST_USER_1_ID},
+			{UserId: common.TEST_USER_2_ID, Role: proto.Role_EDITOR, Accepted: true, InvitedBy: common.TEST_USER_1_ID},
+			{UserId: testViewerID, Role: proto.Role_VIEWER, Accepted: true, InvitedBy: common.TEST_USER_1_ID},
+		}
+	}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *proto.ListMembersReq
+		want    []*proto.Member
+		wantErr bool
+	}{
+		{name: "owner", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListMembersReq{ProjectId: "work"}, want: all()},
+		{name: "viewer", ctx: userContext(testViewerID), req: &proto.ListMembersReq{ProjectId: "work"}, want: all()},
+		{name: "project without members", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListMembersReq{ProjectId: "old"}, want: []*proto.Member{owner()}},
+		{name: "unaccepted invitation", ctx: userContext(testInviteeID), req: &proto.ListMembersReq{ProjectId: "work"}, wantErr: true},
+		{name: "blank project id", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListMembersReq{}, wantErr: true},
+		{name: "missing user id", ctx: context.Background(), req: &proto.ListMembersReq{ProjectId: "work"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			got, err := s.ListMembers(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListMembers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Members, tt.want) {
+				t.Errorf("TodoServer.ListMembers() = %v, want %v", got.Members, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_UpdateMemberRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *proto.UpdateMemberRoleReq
+		wantErr bool
+	}{
+		{
+			name: "changes role",
+			ctx:  userContext(common.TEST_USER_1_ID),
+			req:  &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testViewerID, Role: proto.Role_OWNER},
+		},
+		{
+			name:    "editor may not change roles",
+			ctx:     userContext(common.TEST_USER_2_ID),
+			req:     &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testViewerID, Role: proto.Role_EDITOR},
+			wantErr: true,
+		},
+		{
+			name:    "owner who added the project",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: common.TEST_USER_1_ID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "not a member",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testOutsiderID, Role: proto.Role_VIEWER},
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testViewerID, Role: proto.Role(42)},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testViewerID, Role: proto.Role_EDITOR},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			got, err := s.UpdateMemberRole(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.UpdateMemberRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Member.Role != tt.req.Role || got.Member.UserId != tt.req.UserId {
+				t.Errorf("TodoServer.UpdateMemberRole() = %v, want role %v", got.Member, tt.req.Role)
+			}
+			access, err := s.authorizeProject(context.Background(), tt.req.UserId, tt.req.ProjectId, tt.req.Role)
+			if err != nil || access.role != tt.req.Role {
+				t.Errorf("TodoServer.authorizeProject() after the change = %v, %v, want role %v", access, err, tt.req.Role)
+			}
+		})
+	}
+}
+
+// racingMemberStore runs race once before the first member update, as if another request updated the member
+// between the read and the write of the update.
+type racingMemberStore struct {
+	storage.MemberStore
+	race func()
+}
+
+func (r *racingMemberStore) UpdateMember(ctx context.Context, req *storage.UpdateMemberReq) (*storage.UpdateMemberResp, error) {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.MemberStore.UpdateMember(ctx, req)
+}
+
+func Test_TodoServer_members_concurrentUpdates(t *testing.T) {
+	setInvitee := func(members storage.MemberStore, role proto.Role, accepted bool) func() {
+		return func() {
+			member := storage.Member{UserID: testInviteeID, ProjectID: "work", OwnerID: common.TEST_USER_1_ID, Role: role.String(), InvitedBy: common.TEST_USER_1_ID, Accepted: accepted}
+			if _, err := members.UpdateMember(context.Background(), &storage.UpdateMemberReq{Member: member}); err != nil {
+				t.Fatalf("MemberStore.UpdateMember() error = %v", err)
+			}
+		}
+	}
+	tests := []struct {
+		name         string
+		race         func(members storage.MemberStore) func()
+		call         func(s *TodoServer) error
+		wantRole     proto.Role
+		wantAccepted bool
+	}{
+		{
+			name: "accept after a demotion",
+			race: func(members storage.MemberStore) func() { return setInvitee(members, proto.Role_VIEWER, false) },
+			call: func(s *TodoServer) error {
+				_, err := s.AcceptInvitation(userContext(testInviteeID), &proto.AcceptInvitationReq{ProjectId: "work"})
+				return err
+			},
+			wantRole: proto.Role_VIEWER,
+		},
+		{
+			name: "change of role after an acceptance",
+			race: func(members storage.MemberStore) func() { return setInvitee(members, proto.Role_EDITOR, true) },
+			call: func(s *TodoServer) error {
+				_, err := s.UpdateMemberRole(userContext(common.TEST_USER_1_ID), &proto.UpdateMemberRoleReq{ProjectId: "work", UserId: testInviteeID, Role: proto.Role_VIEWER})
+				return err
+			},
+			wantRole:     proto.Role_EDITOR,
+			wantAccepted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			members := s.members
+			s.members = &racingMemberStore{MemberStore: members, race: tt.race(members)}
+			if err := tt.call(s); err == nil {
+				t.Fatalf("update after a concurrent update error = nil, want an error")
+			}
+			got, err := members.GetMember(context.Background(), &storage.GetMemberReq{UserID: testInviteeID, ProjectID: "work"})
+			if err != nil {
+				t.Fatalf("MemberStore.GetMember() error = %v", err)
+			}
+			if got.Member.Role != tt.wantRole.String() || got.Member.Accepted != tt.wantAccepted {
+				t.Errorf("member = %+v, want role %v and accepted %v", got.Member, tt.wantRole, tt.wantAccepted)
+			}
+			if entries := s.audit.(*storageMock.MockAuditStore).AuditTable; len(entries) != 0 {
+				t.Errorf("audit = %+v, want no entries", entries)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.RemoveMemberReq
+		wantDetails string
+		wantErr     bool
+	}{
+		{
+			name:        "owner removes member",
+			ctx:         userContext(common.TEST_USER_1_ID),
+			req:         &proto.RemoveMemberReq{ProjectId: "work", UserId: testViewerID},
+			wantDetails: "removed " + testViewerID,
+		},
+		{
+			name:        "member leaves",
+			ctx:         userContext(testViewerID),
+			req:         &proto.RemoveMemberReq{ProjectId: "work", UserId: testViewerID},
+			wantDetails: "left the project",
+		},
+		{
+			name:        "invited user declines",
+			ctx:         userContext(testInviteeID),
+			req:         &proto.RemoveMemberReq{ProjectId: "work", UserId: testInviteeID},
+			wantDetails: "declined the invitation",
+		},
+		{
+			name:    "editor may not remove others",
+			ctx:     userContext(common.TEST_USER_2_ID),
+			req:     &proto.RemoveMemberReq{ProjectId: "work", UserId: testViewerID},
+			wantErr: true,
+		},
+		{
+			name:    "owner who added the project",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.RemoveMemberReq{ProjectId: "work", UserId: common.TEST_USER_1_ID},
+			wantErr: true,
+		},
+		{
+			name:    "not a member",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.RemoveMemberReq{ProjectId: "work", UserId: testOutsiderID},
+			wantErr: true,
+		},
+		{
+			name:    "blank user id",
+			ctx:     userContext(common.TEST_USER_1_ID),
+			req:     &proto.RemoveMemberReq{ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.RemoveMemberReq{ProjectId: "work", UserId: testViewerID},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			_, err := s.RemoveMember(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.RemoveMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			getMemberResp, _ := s.members.GetMember(context.Background(), &storage.GetMemberReq{UserID: tt.req.UserId, ProjectID: tt.req.ProjectId})
+			if getMemberResp.Member != nil {
+				t.Errorf("stored member = %+v, want none", getMemberResp.Member)
+			}
+			entries := s.audit.(*storageMock.MockAuditStore).AuditTable
+			if len(entries) != 1 || entries[0].Details != tt.wantDetails {
+				t.Errorf("audit = %+v, want an entry with details %q", entries, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListAuditEntries(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		req           *proto.ListAuditEntriesReq
+		getEntriesErr error
+		wantIDs       []string
+		wantErr       bool
+	}{
+		{name: "lists newest entries", ctx: userContext(testViewerID), req: &proto.ListAuditEntriesReq{ProjectId: "work"}, wantIDs: []string{"3", "2", "1"}},
+		{name: "limit", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListAuditEntriesReq{ProjectId: "work", Limit: 2}, wantIDs: []string{"3", "2"}},
+		{name: "no entries", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListAuditEntriesReq{ProjectId: "old"}, wantIDs: []string{}},
+		{name: "not a member", ctx: userContext(testOutsiderID), req: &proto.ListAuditEntriesReq{ProjectId: "work"}, wantErr: true},
+		{name: "negative limit", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListAuditEntriesReq{ProjectId: "work", Limit: -1}, wantErr: true},
+		{name: "limit too large", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListAuditEntriesReq{ProjectId: "work", Limit: maxAuditEntries + 1}, wantErr: true},
+		{name: "blank project id", ctx: userContext(common.TEST_USER_1_ID), req: &proto.ListAuditEntriesReq{}, wantErr: true},
+		{
+			name:          "GetAuditEntries throws error",
+			ctx:           userContext(common.TEST_USER_1_ID),
+			req:           &proto.ListAuditEntriesReq{ProjectId: "work"},
+			getEntriesErr: errors.New("test error"),
+			wantErr:       true,
+		},
+		{name: "missing user id", ctx: context.Background(), req: &proto.ListAuditEntriesReq{ProjectId: "work"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			s.audit = &storageMock.MockAuditStore{
+				AuditTable: []storage.AuditEntry{
+					{ProjectID: "work", EntryID: "1", UserID: common.TEST_USER_1_ID, Action: auditMemberInvited},
+					{ProjectID: "work", EntryID: "2", UserID: common.TEST_USER_2_ID, Action: auditTaskAdded, TaskID: common.TASK_1A_ID},
+					{ProjectID: "work", EntryID: "3", UserID: common.TEST_USER_2_ID, Action: auditTaskUpdated, TaskID: common.TASK_1A_ID},
+				},
+				GetAuditEntriesErr: tt.getEntriesErr,
+			}
+			got, err := s.ListAuditEntries(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListAuditEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			ids := []string{}
+			for _, entry := range got.Entries {
+				ids = append(ids, entry.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("TodoServer.ListAuditEntries() ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_recordAudit(t *testing.T) {
+	s := newMembersTestServer()
+	s.recordAudit(context.Background(), "", common.TEST_USER_1_ID, auditTaskAdded, common.TASK_1B_ID, "")
+	s.recordAudit(context.Background(), "work", common.TEST_USER_2_ID, auditTaskAdded, common.TASK_1A_ID, "")
+	entries := s.audit.(*storageMock.MockAuditStore).AuditTable
+	if len(entries) != 1 || entries[0].ProjectID != "work" || entries[0].UserID != common.TEST_USER_2_ID || entries[0].EntryID == "" {
+		t.Errorf("audit = %+v, want one entry of the project", entries)
+	}
+
+	// failures are logged since the change has already been made
+	s.audit = &storageMock.MockAuditStore{AddAuditEntryErr: errors.New("test error")}
+	s.recordAudit(context.Background(), "work", common.TEST_USER_2_ID, auditTaskAdded, common.TASK_1A_ID, "")
+}
+
+// Test_TodoServer_sharedTasks checks that every task handler authorizes members by their role and only reaches the
+// tasks of the shared project, rather than every task kept with the owner.
+func Test_TodoServer_sharedTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		call    func(ctx context.Context, s *TodoServer) error
+		wantErr bool
+	}{
+		{
+			name:   "viewer gets task",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.GetTask(ctx, &proto.GetTaskReq{Id: common.TASK_1A_ID, ProjectId: "work"})
+				return err
+			},
+		},
+		{
+			name:   "viewer may not get tasks outside the project",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.GetTask(ctx, &proto.GetTaskReq{Id: common.TASK_1B_ID, ProjectId: "work"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "viewer lists tasks",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				resp, err := s.GetAllTasks(ctx, &proto.GetAllTasksReq{ProjectId: "work"})
+				if err == nil && (len(resp.Tasks) != 1 || resp.Tasks[0].Id != common.TASK_1A_ID) {
+					return errors.New("unexpected tasks")
+				}
+				return err
+			},
+		},
+		{
+			name:   "viewer may not update",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.UpdateTask(ctx, &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1A_ID, Title: "x", ProjectId: "work"}})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "viewer may not delete",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.DeleteTask(ctx, &proto.DeleteTaskReq{TaskId: common.TASK_1A_ID, ProjectId: "work"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "viewer may not add checklist items",
+			userID: testViewerID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.AddChecklistItem(ctx, &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID, Text: "x", ProjectId: "work"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "editor adds task",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.AddTask(ctx, &proto.AddTaskReq{Title: "x", ProjectId: "work"})
+				return err
+			},
+		},
+		{
+			name:   "editor updates task",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.UpdateTask(ctx, &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1A_ID, Title: "x", ProjectId: "work"}})
+				return err
+			},
+		},
+		{
+			name:   "editor may not move tasks out of the project",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.UpdateTask(ctx, &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1A_ID, Title: "x"}})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "editor may not update tasks outside the project",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.UpdateTask(ctx, &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1B_ID, Title: "x", ProjectId: "work"}})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "editor adds checklist item",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.AddChecklistItem(ctx, &proto.AddChecklistItemReq{TaskId: common.TASK_1A_ID, Text: "x", ProjectId: "work"})
+				return err
+			},
+		},
+		{
+			name:   "editor deletes task",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.DeleteTask(ctx, &proto.DeleteTaskReq{TaskId: common.TASK_1A_ID, ProjectId: "work"})
+				return err
+			},
+		},
+		{
+			name:   "editor may not delete tasks outside the project",
+			userID: common.TEST_USER_2_ID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.DeleteTask(ctx, &proto.DeleteTaskReq{TaskId: common.TASK_1B_ID, ProjectId: "work"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invited user may not get tasks",
+			userID: testInviteeID,
+			call: func(ctx context.Context, s *TodoServer) error {
+				_, err := s.GetTask(ctx, &proto.GetTaskReq{Id: common.TASK_1A_ID, ProjectId: "work"})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			ownTasks := len(s.tasks.(*storageMock.MockTaskStore).TasksTable[tt.userID])
+			err := tt.call(userContext(tt.userID), s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(s.tasks.(*storageMock.MockTaskStore).TasksTable[tt.userID]); got != ownTasks {
+				t.Errorf("member has %d tasks of their own, want %d", got, ownTasks)
+			}
+			if tt.wantErr {
+				if entries := s.audit.(*storageMock.MockAuditStore).AuditTable; len(entries) != 0 {
+					t.Errorf("audit = %+v, want no entries", entries)
+				}
+			}
+		})
+	}
+}
+
+func Test_TodoServer_sharedTasks_audit(t *testing.T) {
+	s := newMembersTestServer()
+	ctx := userContext(common.TEST_USER_2_ID)
+	if _, err := s.UpdateTask(ctx, &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1A_ID, Title: "renamed", ProjectId: "work"}}); err != nil {
+		t.Fatalf("TodoServer.UpdateTask() error = %v", err)
+	}
+	// tasks outside projects are not audited
+	if _, err := s.UpdateTask(userContext(common.TEST_USER_1_ID), &proto.UpdateTaskReq{Task: &proto.Task{Id: common.TASK_1B_ID, Title: "renamed"}}); err != nil {
+		t.Fatalf("TodoServer.UpdateTask() error = %v", err)
+	}
+	entries := s.audit.(*storageMock.MockAuditStore).AuditTable
+	if len(entries) != 1 {
+		t.Fatalf("audit = %+v, want 1 entry", entries)
+	}
+	entry := entries[0]
+	if entry.ProjectID != "work" || entry.UserID != common.TEST_USER_2_ID || entry.Action != auditTaskUpdated || entry.TaskID != common.TASK_1A_ID {
+		t.Errorf("audit entry = %+v, want the editor's update of %s", entry, common.TASK_1A_ID)
+	}
+}
+
+func Test_TodoServer_sharedTasks_bulkAudit(t *testing.T) {
+	ctx := userContext(common.TEST_USER_1_ID)
+	tests := []struct {
+		name        string
+		call        func(s *TodoServer) error
+		wantDetails string
+	}{
+		{
+			name: "move out of a project",
+			call: func(s *TodoServer) error {
+				_, err := s.MoveTasks(ctx, &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID, common.TASK_1B_ID}})
+				return err
+			},
+			wantDetails: "moved out of the project",
+		},
+		{
+			name: "delete project",
+			call: func(s *TodoServer) error {
+				_, err := s.DeleteProject(ctx, &proto.DeleteProjectReq{Id: "work"})
+				return err
+			},
+			wantDetails: "moved out of the deleted project",
+		},
+		{
+			name: "rename tag",
+			call: func(s *TodoServer) error {
+				tasks := s.tasks.(*storageMock.MockTaskStore).TasksTable[common.TEST_USER_1_ID]
+				for i := range tasks {
+					tasks[i].Tags = []string{"home", "urgent"}
+				}
+				_, err := s.RenameTag(ctx, &proto.RenameTagReq{Tag: "urgent", NewTag: "soon"})
+				return err
+			},
+			wantDetails: "replaced tags urgent with soon",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newMembersTestServer()
+			if err := tt.call(s); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			// tasks outside projects are not audited
+			entries := s.audit.(*storageMock.MockAuditStore).AuditTable
+			if len(entries) != 1 {
+				t.Fatalf("audit = %+v, want 1 entry", entries)
+			}
+			entry := entries[0]
+			if entry.ProjectID != "work" || entry.UserID != common.TEST_USER_1_ID || entry.Action != auditTaskUpdated || entry.TaskID != common.TASK_1A_ID || entry.Details != tt.wantDetails {
+				t.Errorf("audit entry = %+v, want the update of %s with details %q", entry, common.TASK_1A_ID, tt.wantDetails)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// maxProjects is the number of projects, archived or not, each user may have
+	maxProjects = 100
+	// maxProjectNameLength is the number of characters a project name may hold
+	maxProjectNameLength = 100
+	// maxMovedTasks is the most tasks a single move may hold, which are moved in one unit of work
+	maxMovedTasks = 100
+)
+
+// colorPattern matches the hex colors of projects.
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// validateProject returns the project's name without surrounding white space, or why the project
+// cannot be stored.
+func validateProject(name, color string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("project name cannot be blank")
+	}
+	if utf8.RuneCountInString(name) > maxProjectNameLength {
+		return "", fmt.Errorf("project name cannot be longer than %d characters", maxProjectNameLength)
+	}
+	if color != "" && !colorPattern.MatchString(color) {
+		return "", fmt.Errorf("invalid color %q: expected a hex color such as #1e90ff", color)
+	}
+	return name, nil
+}
+
+// AddProject creates a project of the user.
+func (t *TodoServer) AddProject(ctx context.Context, req *proto.AddProjectReq) (*proto.AddProjectResp, error) {
+	// validate req
+	name, err := validateProject(req.Name, req.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// enforce the limit of projects
+	getAllProjectsResp, err := t.projects.GetAllProjects(ctx, &storage.GetAllProjectsReq{
+		UserID: userIDs[0],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %v", err)
+	}
+	if len(getAllProjectsResp.Projects) >= maxProjects {
+		return nil, fmt.Errorf("unable to have more than %d projects", maxProjects)
+	}
+
+	// add project
+	projectID := uuid.New().String()
+	_, err = t.projects.AddProject(ctx, &storage.AddProjectReq{
+		Project: storage.Project{
+			UserID:      userIDs[0],
+			ProjectID:   projectID,
+			Name:        name,
+			Description: req.Description,
+			Color:       req.Color,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add project: %v", err)
+	}
+
+	return &proto.AddProjectResp{
+		Id: projectID,
+	}, nil
+}
+
+func (t *TodoServer) GetProject(ctx context.Context, req *proto.GetProjectReq) (*proto.GetProjectResp, error) {
+	// validate req
+	if req.Id == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.Id, proto.Role_VIEWER)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetProjectResp{
+		Project: toProtoProject(access.project, access.role),
+	}, nil
+}
+
+// ListProjects returns the projects of the user and those shared with the user in name order, leaving out
+// archived projects unless asked for.
+func (t *TodoServer) ListProjects(ctx context.Context, req *proto.ListProjectsReq) (*proto.ListProjectsResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	getAllProjectsResp, err := t.projects.GetAllProjects(ctx, &storage.GetAllProjectsReq{
+		UserID: userIDs[0],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %v", err)
+	}
+	projects := []*proto.Project{}
+	for i := range getAllProjectsResp.Projects {
+		project := &getAllProjectsResp.Projects[i]
+		if project.Archived && !req.IncludeArchived {
+			continue
+		}
+		projects = append(projects, toProtoProject(project, proto.Role_OWNER))
+	}
+
+	// add the projects shared with the user
+	getMembershipsResp, err := t.members.GetMemberships(ctx, &storage.GetMembershipsReq{
+		UserID: userIDs[0],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memberships: %v", err)
+	}
+	for _, member := range getMembershipsResp.Members {
+		if !member.Accepted {
+			continue
+		}
+		getProjectResp, err := t.projects.GetProject(ctx, &storage.GetProjectReq{
+			UserID:    member.OwnerID,
+			ProjectID: member.ProjectID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %v", err)
+		}
+		project := getProjectResp.Project
+		if project == nil || (project.Archived && !req.IncludeArchived) {
+			continue
+		}
+		projects = append(projects, toProtoProject(project, proto.Role(proto.Role_value[member.Role])))
+	}
+	slices.SortStableFunc(projects, func(a, b *proto.Project) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return &proto.ListProjectsResp{
+		Projects: projects,
+	}, nil
+}
+
+// UpdateProject replaces the name, description, color and archived flag of a project the user owns.
+func (t *TodoServer) UpdateProject(ctx context.Context, req *proto.UpdateProjectReq) (*proto.UpdateProjectResp, error) {
+	// validate req
+	if req.Project == nil {
+		return nil, errors.New("project cannot be blank")
+	}
+	if req.Project.Id == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+	name, err := validateProject(req.Project.Name, req.Project.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.Project.Id, proto.Role_OWNER)
+	if err != nil {
+		return nil, err
+	}
+	updateProjectResp, err := t.projects.UpdateProject(ctx, &storage.UpdateProjectReq{
+		Project: storage.Project{
+			UserID:      access.ownerID,
+			ProjectID:   req.Project.Id,
+			Name:        name,
+			Description: req.Project.Description,
+			Color:       req.Project.Color,
+			Archived:    req.Project.Archived,
+		},
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("project %s does not exist", req.Project.Id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project: %v", err)
+	}
+	t.recordAudit(ctx, req.Project.Id, userIDs[0], auditProjectUpdated, "", fmt.Sprintf("name %q, archived %v", name, req.Project.Archived))
+
+	return &proto.UpdateProjectResp{
+		Project: toProtoProject(&updateProjectResp.Project, access.role),
+	}, nil
+}
+
+// DeleteProject moves every task out of a project the user owns, removes its members and then deletes the
+// project. The tasks are moved in batches, so a deletion that fails may leave some of them moved or members
+// removed; retrying moves and removes the rest.
+func (t *TodoServer) DeleteProject(ctx context.Context, req *proto.DeleteProjectReq) (*proto.DeleteProjectResp, error) {
+	// validate req
+	if req.Id == "" {
+		return nil, errors.New("project id cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	access, err := t.authorizeProject(ctx, userIDs[0], req.Id, proto.Role_OWNER)
+	if err != nil {
+		return nil, err
+	}
+
+	// move the tasks out of the project before deleting it, so that no task is left in a missing project
+	tasks, err := t.getAllTasks(ctx, access.ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tasks: %v", err)
+	}
+	var updates []storage.UpdateTaskReq
+	for _, task := range tasks {
+		if task.ProjectID == req.Id {
+			updates = append(updates, storage.UpdateTaskReq{
+				UserID:  access.ownerID,
+				TaskID:  task.TaskID,
+				KVPairs: map[string]interface{}{storage.ProjectIDKey: ""},
+			})
+		}
+	}
+	moved, err := t.commitTaskUpdates(ctx, updates)
+	for _, update := range updates[:moved] {
+		t.recordAudit(ctx, req.Id, userIDs[0], auditTaskUpdated, update.TaskID, "moved out of the deleted project")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// remove the members, while the project remains for a retry to find them
+	getProjectMembersResp, err := t.members.GetProjectMembers(ctx, &storage.GetProjectMembersReq{
+		ProjectID: req.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %v", err)
+	}
+	for _, member := range getProjectMembersResp.Members {
+		_, err := t.members.DeleteMember(ctx, &storage.DeleteMemberReq{
+			UserID:    member.UserID,
+			ProjectID: req.Id,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete member: %v", err)
+		}
+	}
+
+	_, err = t.projects.DeleteProject(ctx, &storage.DeleteProjectReq{
+		UserID:    access.ownerID,
+		ProjectID: req.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %v", err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "deleted project", "project_id", req.Id, "moved_tasks", moved, "removed_members", len(getProjectMembersResp.Members))
+
+	return &proto.DeleteProjectResp{}, nil
+}
+
+// MoveTasks moves tasks of the user into a project of the user, or out of their project, in a single unit of work.
+// Each task moved out of a project is audited against it.
+func (t *TodoServer) MoveTasks(ctx context.Context, req *proto.MoveTasksReq) (*proto.MoveTasksResp, error) {
+	// validate req
+	if len(req.TaskIds) == 0 {
+		return nil, errors.New("task ids cannot be empty")
+	}
+	if len(req.TaskIds) > maxMovedTasks {
+		return nil, fmt.Errorf("unable to move more than %d tasks at once", maxMovedTasks)
+	}
+	for i, taskID := range req.TaskIds {
+		if taskID == "" {
+			return nil, errors.New("task id cannot be blank")
+		}
+		// a unit of work writes each task at most once
+		if slices.Contains(req.TaskIds[:i], taskID) {
+			return nil, fmt.Errorf("duplicate task id %s", taskID)
+		}
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// tasks are kept with the owner of their project, so only the user's own projects can receive them
+	access, err := t.authorizeProject(ctx, userIDs[0], req.ProjectId, proto.Role_OWNER)
+	if err != nil {
+		return nil, err
+	}
+	if access.ownerID != userIDs[0] {
+		return nil, fmt.Errorf("unable to move tasks into project %s, which is shared with you", req.ProjectId)
+	}
+	if err := access.checkAcceptsTasks(); err != nil {
+		return nil, err
+	}
+
+	// get the tasks to audit their moves out of their current projects
+	previousProjectIDs := make([]string, len(req.TaskIds))
+	for i, taskID := range req.TaskIds {
+		getTaskResp, err := t.tasks.GetTask(ctx, &storage.GetTaskReq{
+			UserID: userIDs[0],
+			TaskID: taskID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %v", err)
+		}
+		if getTaskResp.Task == nil {
+			return nil, errors.New("unable to move tasks: a task does not exist")
+		}
+		previousProjectIDs[i] = getTaskResp.Task.ProjectID
+	}
+
+	// move every task or none
+	uow := t.newUnitOfWork()
+	for _, taskID := range req.TaskIds {
+		uow.UpdateTask(storage.UpdateTaskReq{
+			UserID:  userIDs[0],
+			TaskID:  taskID,
+			KVPairs: map[string]interface{}{storage.ProjectIDKey: req.ProjectId},
+		})
+	}
+	err = uow.Commit(ctx)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, errors.New("unable to move tasks: a task does not exist")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to move tasks: %v", err)
+	}
+	t.recordAudit(ctx, req.ProjectId, userIDs[0], auditTasksMoved, "", fmt.Sprintf("moved %d tasks into the project", len(req.TaskIds)))
+	for i, taskID := range req.TaskIds {
+		if previousProjectIDs[i] != req.ProjectId {
+			t.recordAudit(ctx, previousProjectIDs[i], userIDs[0], auditTaskUpdated, taskID, "moved out of the project")
+		}
+	}
+
+	return &proto.MoveTasksResp{}, nil
+}
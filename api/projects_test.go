@@ -0,0 +1,552 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// testProjects returns an active and an archived project of the first test user.
+func testProjects() []storage.Project {
+	return []storage.Project{
+		{UserID: common.TEST_USER_1_ID, ProjectID: "work", Name: "Work", Color: "#1e90ff"},
+		{UserID: common.TEST_USER_1_ID, ProjectID: "old", Name: "Archive", Archived: true},
+	}
+}
+
+func Test_validateProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		projName string
+		color    string
+		want     string
+		wantErr  bool
+	}{
+		{name: "trims name", projName: " Work ", color: "#1E90ff", want: "Work"},
+		{name: "no color", projName: "Work", want: "Work"},
+		{name: "blank name", projName: "  ", wantErr: true},
+		{name: "long name", projName: fmt.Sprintf("%0*d", maxProjectNameLength+1, 0), wantErr: true},
+		{name: "named color", projName: "Work", color: "blue", wantErr: true},
+		{name: "short hex color", projName: "Work", color: "#fff", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateProject(tt.projName, tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validateProject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_AddProject(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	var fullProjects []storage.Project
+	for i := 0; i < maxProjects; i++ {
+		fullProjects = append(fullProjects, storage.Project{UserID: common.TEST_USER_1_ID, ProjectID: fmt.Sprint(i), Archived: i%2 == 0})
+	}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		existing []storage.Project
+		req      *proto.AddProjectReq
+		addErr   error
+		wantErr  bool
+	}{
+		{
+			name: "adds project",
+			ctx:  userCtx,
+			req:  &proto.AddProjectReq{Name: " Home ", Description: "chores", Color: "#00ff00"},
+		},
+		{
+			name:    "invalid color",
+			ctx:     userCtx,
+			req:     &proto.AddProjectReq{Name: "Home", Color: "green"},
+			wantErr: true,
+		},
+		{
+			name:     "too many projects",
+			ctx:      userCtx,
+			existing: fullProjects,
+			req:      &proto.AddProjectReq{Name: "Home"},
+			wantErr:  true,
+		},
+		{
+			name:    "failed to add project",
+			ctx:     userCtx,
+			req:     &proto.AddProjectReq{Name: "Home"},
+			addErr:  errors.New("test error"),
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.AddProjectReq{Name: "Home"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := &storageMock.MockProjectStore{
+				ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: tt.existing},
+				AddProjectErr: tt.addErr,
+			}
+			s := &TodoServer{projects: projects, members: &storageMock.MockMemberStore{}, audit: &storageMock.MockAuditStore{}}
+			got, err := s.AddProject(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.AddProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			stored := projects.ProjectsTable[common.TEST_USER_1_ID]
+			if len(stored) != 1 {
+				t.Fatalf("stored projects = %+v, want one", stored)
+			}
+			want := storage.Project{
+				UserID:      common.TEST_USER_1_ID,
+				ProjectID:   got.Id,
+				Name:        "Home",
+				Description: "chores",
+				Color:       "#00ff00",
+				CreatedAt:   stored[0].CreatedAt,
+				UpdatedAt:   stored[0].UpdatedAt,
+			}
+			if stored[0] != want {
+				t.Errorf("stored project = %+v, want %+v", stored[0], want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_GetProject(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *proto.GetProjectReq
+		want    *proto.Project
+		wantErr bool
+	}{
+		{
+			name: "gets project",
+			ctx:  userCtx,
+			req:  &proto.GetProjectReq{Id: "work"},
+			want: &proto.Project{Id: "work", Name: "Work", Color: "#1e90ff", OwnerId: common.TEST_USER_1_ID, Role: proto.Role_OWNER},
+		},
+		{
+			name:    "missing project",
+			ctx:     userCtx,
+			req:     &proto.GetProjectReq{Id: "missing"},
+			wantErr: true,
+		},
+		{
+			name:    "blank id",
+			ctx:     userCtx,
+			req:     &proto.GetProjectReq{},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.GetProjectReq{Id: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				projects: &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()}},
+				members:  &storageMock.MockMemberStore{},
+				audit:    &storageMock.MockAuditStore{},
+			}
+			got, err := s.GetProject(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.GetProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Project, tt.want) {
+				t.Errorf("TodoServer.GetProject() = %v, want %v", got.Project, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListProjects(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       *proto.ListProjectsReq
+		getAllErr error
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "leaves out archived projects",
+			ctx:  userCtx,
+			req:  &proto.ListProjectsReq{},
+			want: []string{"Work"},
+		},
+		{
+			name: "includes archived projects in name order",
+			ctx:  userCtx,
+			req:  &proto.ListProjectsReq{IncludeArchived: true},
+			want: []string{"Archive", "Work"},
+		},
+		{
+			name:      "failed to get projects",
+			ctx:       userCtx,
+			req:       &proto.ListProjectsReq{},
+			getAllErr: errors.New("test error"),
+			wantErr:   true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.ListProjectsReq{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				projects: &storageMock.MockProjectStore{
+					ProjectsTable:     map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()},
+					GetAllProjectsErr: tt.getAllErr,
+				},
+				members: &storageMock.MockMemberStore{},
+				audit:   &storageMock.MockAuditStore{},
+			}
+			got, err := s.ListProjects(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListProjects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var names []string
+			for _, project := range got.Projects {
+				names = append(names, project.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("TodoServer.ListProjects() names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_UpdateProject(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		req     *proto.UpdateProjectReq
+		want    *proto.Project
+		wantErr bool
+	}{
+		{
+			name: "archives project",
+			ctx:  userCtx,
+			req:  &proto.UpdateProjectReq{Project: &proto.Project{Id: "work", Name: " Job ", Archived: true, CreatedAt: 5}},
+			want: &proto.Project{Id: "work", Name: "Job", Archived: true, OwnerId: common.TEST_USER_1_ID, Role: proto.Role_OWNER},
+		},
+		{
+			name:    "missing project",
+			ctx:     userCtx,
+			req:     &proto.UpdateProjectReq{Project: &proto.Project{Id: "missing", Name: "Job"}},
+			wantErr: true,
+		},
+		{
+			name:    "blank project",
+			ctx:     userCtx,
+			req:     &proto.UpdateProjectReq{},
+			wantErr: true,
+		},
+		{
+			name:    "blank id",
+			ctx:     userCtx,
+			req:     &proto.UpdateProjectReq{Project: &proto.Project{Name: "Job"}},
+			wantErr: true,
+		},
+		{
+			name:    "blank name",
+			ctx:     userCtx,
+			req:     &proto.UpdateProjectReq{Project: &proto.Project{Id: "work"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.UpdateProjectReq{Project: &proto.Project{Id: "work", Name: "Job"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				projects: &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()}},
+				members:  &storageMock.MockMemberStore{},
+				audit:    &storageMock.MockAuditStore{},
+			}
+			got, err := s.UpdateProject(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.UpdateProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tt.want.UpdatedAt = got.Project.UpdatedAt
+			if !reflect.DeepEqual(got.Project, tt.want) {
+				t.Errorf("TodoServer.UpdateProject() = %v, want %v", got.Project, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_DeleteProject(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		req             *proto.DeleteProjectReq
+		commitErr       error
+		wantProjectIDs  map[string]string
+		wantNoProjectID bool
+		wantErr         bool
+	}{
+		{
+			name: "moves tasks out of deleted project",
+			ctx:  userCtx,
+			req:  &proto.DeleteProjectReq{Id: "work"},
+			wantProjectIDs: map[string]string{
+				common.TASK_1A_ID: "",
+				common.TASK_1B_ID: "old",
+				common.TASK_1C_ID: "",
+			},
+		},
+		{
+			name:      "failed to move tasks",
+			ctx:       userCtx,
+			req:       &proto.DeleteProjectReq{Id: "work"},
+			commitErr: errors.New("test error"),
+			wantErr:   true,
+		},
+		{
+			name:    "blank id",
+			ctx:     userCtx,
+			req:     &proto.DeleteProjectReq{},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.DeleteProjectReq{Id: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, ProjectID: "work"},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, ProjectID: "old"},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID},
+			}}}
+			projects := &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()}}
+			s := &TodoServer{
+				tasks:    tasks,
+				projects: projects,
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: tasks, CommitErr: tt.commitErr}
+				},
+				members: &storageMock.MockMemberStore{},
+				audit:   &storageMock.MockAuditStore{},
+			}
+			_, err := s.DeleteProject(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.DeleteProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(projects.ProjectsTable[common.TEST_USER_1_ID]) != 2 {
+					t.Errorf("stored projects = %+v, want project kept", projects.ProjectsTable[common.TEST_USER_1_ID])
+				}
+				return
+			}
+			if got := projects.ProjectsTable[common.TEST_USER_1_ID]; len(got) != 1 || got[0].ProjectID != "old" {
+				t.Errorf("stored projects = %+v, want only old", got)
+			}
+			projectIDs := map[string]string{}
+			for _, task := range tasks.TasksTable[common.TEST_USER_1_ID] {
+				projectIDs[task.TaskID] = task.ProjectID
+			}
+			if !reflect.DeepEqual(projectIDs, tt.wantProjectIDs) {
+				t.Errorf("stored project ids = %v, want %v", projectIDs, tt.wantProjectIDs)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_MoveTasks(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tooMany := make([]string, maxMovedTasks+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint(i)
+	}
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		req            *proto.MoveTasksReq
+		wantProjectIDs map[string]string
+		wantErr        bool
+	}{
+		{
+			name: "moves tasks into project",
+			ctx:  userCtx,
+			req:  &proto.MoveTasksReq{TaskIds: []string{common.TASK_1B_ID, common.TASK_1C_ID}, ProjectId: "work"},
+			wantProjectIDs: map[string]string{
+				common.TASK_1A_ID: "",
+				common.TASK_1B_ID: "work",
+				common.TASK_1C_ID: "work",
+			},
+		},
+		{
+			name: "moves tasks out of their project",
+			ctx:  userCtx,
+			req:  &proto.MoveTasksReq{TaskIds: []string{common.TASK_1B_ID}},
+			wantProjectIDs: map[string]string{
+				common.TASK_1A_ID: "",
+				common.TASK_1B_ID: "",
+				common.TASK_1C_ID: "",
+			},
+		},
+		{
+			name:    "missing task moves none",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID, common.TASK_2A_ID}, ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "archived project",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID}, ProjectId: "old"},
+			wantErr: true,
+		},
+		{
+			name:    "missing project",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID}, ProjectId: "missing"},
+			wantErr: true,
+		},
+		{
+			name:    "no tasks",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "too many tasks",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{TaskIds: tooMany, ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate task",
+			ctx:     userCtx,
+			req:     &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID, common.TASK_1A_ID}, ProjectId: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.MoveTasksReq{TaskIds: []string{common.TASK_1A_ID}, ProjectId: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, ProjectID: "old"},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID},
+			}}}
+			s := &TodoServer{
+				tasks:    tasks,
+				projects: &storageMock.MockProjectStore{ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()}},
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: tasks}
+				},
+				members: &storageMock.MockMemberStore{},
+				audit:   &storageMock.MockAuditStore{},
+			}
+			_, err := s.MoveTasks(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.MoveTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			projectIDs := map[string]string{}
+			for _, task := range tasks.TasksTable[common.TEST_USER_1_ID] {
+				projectIDs[task.TaskID] = task.ProjectID
+			}
+			if !reflect.DeepEqual(projectIDs, tt.wantProjectIDs) {
+				t.Errorf("stored project ids = %v, want %v", projectIDs, tt.wantProjectIDs)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_AddTask_Project(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name          string
+		projectID     string
+		getProjectErr error
+		wantErr       bool
+	}{
+		{name: "adds task to project", projectID: "work"},
+		{name: "no project"},
+		{name: "archived project", projectID: "old", wantErr: true},
+		{name: "missing project", projectID: "missing", wantErr: true},
+		{name: "failed to get project", projectID: "work", getProjectErr: errors.New("test error"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &storageMock.MockTaskStore{}
+			s := &TodoServer{
+				tasks: tasks,
+				projects: &storageMock.MockProjectStore{
+					ProjectsTable: map[string][]storage.Project{common.TEST_USER_1_ID: testProjects()},
+					GetProjectErr: tt.getProjectErr,
+				},
+				index:   search.NewIndex(),
+				members: &storageMock.MockMemberStore{},
+				audit:   &storageMock.MockAuditStore{},
+			}
+			_, err := s.AddTask(userCtx, &proto.AddTaskReq{Title: "do something", ProjectId: tt.projectID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.AddTask() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := tasks.TasksTable[common.TEST_USER_1_ID][0].ProjectID; got != tt.projectID {
+				t.Errorf("stored project id = %q, want %q", got, tt.projectID)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSearchLimit and maxSearchLimit are the default and maximum number of tasks a search returns.
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// indexScanPageSize is the number of tasks read at a time while building the search index.
+	indexScanPageSize = 1000
+)
+
+// taskDocument returns the searchable text of a task last updated at the given time.
+func taskDocument(task *storage.Task, updatedAt int64) search.Document {
+	return search.Document{
+		UserID:      task.UserID,
+		TaskID:      task.TaskID,
+		Title:       task.Title,
+		Description: task.Description,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// indexTasks indexes every stored task for search, returning the number of tasks indexed.
+func (t *TodoServer) indexTasks(ctx context.Context) (int, error) {
+	indexed := 0
+	pageToken := ""
+	for {
+		scanTasksResp, err := t.tasks.ScanTasks(ctx, &storage.ScanTasksReq{
+			Limit:     indexScanPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return indexed, err
+		}
+		for i := range scanTasksResp.Tasks {
+			task := &scanTasksResp.Tasks[i]
+			t.index.Put(taskDocument(task, task.UpdatedAt))
+		}
+		indexed += len(scanTasksResp.Tasks)
+		if scanTasksResp.NextPageToken == "" {
+			return indexed, nil
+		}
+		pageToken = scanTasksResp.NextPageToken
+	}
+}
+
+// BuildSearchIndex indexes every stored task for search, retrying every interval until it succeeds or ctx is done.
+// Tasks written while the index is built are indexed as they are written, and searches fail until it is built.
+//
+// The index is held in memory by each server and only learns of the writes made through that server, so search
+// assumes a single server per storage backend. Behind several servers, a task added or updated through another
+// server is not found until this server restarts and rebuilds its index; only deleted tasks are noticed, when
+// a search finds them.
+func (t *TodoServer) BuildSearchIndex(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		indexed, err := t.indexTasks(ctx)
+		if err == nil {
+			t.indexReady.Store(true)
+			slog.Info("built search index", "tasks", indexed, "duration", time.Since(start))
+			return
+		}
+		slog.Warn("failed to build search index", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SearchTasks returns the user's tasks matching the query, most relevant first. Tasks that were deleted
+// by another server since they were indexed are left out of the results and the index.
+//
+// Tasks are indexed under the user they are kept with, so only the user's own tasks are searched, including
+// those of the user's projects shared with others but not those of projects others share with the user.
+// Like the index, search assumes a single server, as BuildSearchIndex describes.
+func (t *TodoServer) SearchTasks(ctx context.Context, req *proto.SearchTasksReq) (*proto.SearchTasksResp, error) {
+	// validate req
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("query cannot be blank")
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+	if !t.indexReady.Load() {
+		return nil, status.Error(codes.Unavailable, "the search index is still being built, try again shortly")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// search index, then get the matching tasks
+	results := t.index.Search(userIDs[0], req.Query, limit, time.Now())
+	tasks := []*proto.Task{}
+	for _, result := range results {
+		getTaskResp, err := t.tasks.GetTask(ctx, &storage.GetTaskReq{
+			UserID: userIDs[0],
+			TaskID: result.TaskID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %v", err)
+		}
+		if getTaskResp.Task == nil {
+			logging.FromContext(ctx).WarnContext(ctx, "removing deleted task from search index", "task_id", result.TaskID)
+			t.index.Delete(userIDs[0], result.TaskID)
+			continue
+		}
+		task, err := toProtoTask(getTaskResp.Task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return &proto.SearchTasksResp{
+		Tasks: tasks,
+	}, nil
+}
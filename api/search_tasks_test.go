@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+	"todo/search"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_TodoServer_SearchTasks(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	now := time.Now().Unix()
+	taxes := storage.Task{UserID: common.TEST_USER_1_ID, TaskID: "taxes", Title: "File taxes", Description: "Find the dentist receipts", Status: proto.Status_INCOMPLETE.String(), UpdatedAt: now}
+	dentist := storage.Task{UserID: common.TEST_USER_1_ID, TaskID: "dentist", Title: "Book dentist", Status: proto.Status_INCOMPLETE.String(), UpdatedAt: now}
+	deleted := storage.Task{UserID: common.TEST_USER_1_ID, TaskID: "deleted", Title: "Dentist follow up", Status: proto.Status_INCOMPLETE.String(), UpdatedAt: now}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.SearchTasksReq
+		notReady    bool
+		getTaskErr  error
+		want        []string
+		wantErr     bool
+		wantCode    codes.Code
+		wantIndexed []string
+	}{
+		{
+			name: "matches titles and descriptions, leaving out deleted tasks",
+			ctx:  userCtx,
+			req:  &proto.SearchTasksReq{Query: "dentist"},
+			want: []string{"dentist", "taxes"},
+			// the deleted task is removed from the index
+			wantIndexed: []string{"dentist", "taxes"},
+		},
+		{
+			name: "limit",
+			ctx:  userCtx,
+			req:  &proto.SearchTasksReq{Query: "dentist", Limit: 1},
+			want: []string{"dentist"},
+		},
+		{
+			name: "no matches",
+			ctx:  userCtx,
+			req:  &proto.SearchTasksReq{Query: "groceries"},
+			want: []string{},
+		},
+		{
+			name:    "blank query",
+			ctx:     userCtx,
+			req:     &proto.SearchTasksReq{Query: "  "},
+			wantErr: true,
+		},
+		{
+			name:    "negative limit",
+			ctx:     userCtx,
+			req:     &proto.SearchTasksReq{Query: "dentist", Limit: -1},
+			wantErr: true,
+		},
+		{
+			name:     "index not built",
+			ctx:      userCtx,
+			req:      &proto.SearchTasksReq{Query: "dentist"},
+			notReady: true,
+			wantErr:  true,
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:    "user id not provided",
+			ctx:     context.Background(),
+			req:     &proto.SearchTasksReq{Query: "dentist"},
+			wantErr: true,
+		},
+		{
+			name:       "GetTask returns error",
+			ctx:        userCtx,
+			req:        &proto.SearchTasksReq{Query: "dentist"},
+			getTaskErr: errors.New("test error"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {taxes, dentist}},
+					GetTaskErr: tt.getTaskErr,
+				},
+				index: search.NewIndex(),
+			}
+			for _, task := range []storage.Task{taxes, dentist, deleted} {
+				s.index.Put(taskDocument(&task, task.UpdatedAt))
+			}
+			s.indexReady.Store(!tt.notReady)
+
+			got, err := s.SearchTasks(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.SearchTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("TodoServer.SearchTasks() code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotIDs := []string{}
+			for _, task := range got.Tasks {
+				gotIDs = append(gotIDs, task.Id)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("TodoServer.SearchTasks() = %v, want %v", gotIDs, tt.want)
+			}
+			if tt.wantIndexed != nil {
+				var indexed []string
+				for _, result := range s.index.Search(common.TEST_USER_1_ID, tt.req.Query, 0, time.Now()) {
+					indexed = append(indexed, result.TaskID)
+				}
+				if !reflect.DeepEqual(indexed, tt.wantIndexed) {
+					t.Errorf("index after TodoServer.SearchTasks() = %v, want %v", indexed, tt.wantIndexed)
+				}
+			}
+		})
+	}
+}
+
+func Test_TodoServer_BuildSearchIndex(t *testing.T) {
+	t.Run("indexes the tasks of every user", func(t *testing.T) {
+		s := &TodoServer{
+			tasks: &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{
+				"user-1": {{UserID: "user-1", TaskID: "task-1", Title: "File taxes"}},
+				"user-2": {{UserID: "user-2", TaskID: "task-2", Description: "taxes are due"}},
+			}},
+			index: search.NewIndex(),
+		}
+		s.BuildSearchIndex(context.Background(), time.Millisecond)
+		if !s.indexReady.Load() {
+			t.Fatal("TodoServer.BuildSearchIndex() did not mark the index ready")
+		}
+		for userID, taskID := range map[string]string{"user-1": "task-1", "user-2": "task-2"} {
+			results := s.index.Search(userID, "tax", 0, time.Now())
+			if len(results) != 1 || results[0].TaskID != taskID {
+				t.Errorf("index.Search(%s) = %v, want %s", userID, results, taskID)
+			}
+		}
+	})
+
+	t.Run("retries until ctx is done", func(t *testing.T) {
+		s := &TodoServer{
+			tasks: &storageMock.MockTaskStore{ScanTasksErr: errors.New("test error")},
+			index: search.NewIndex(),
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		s.BuildSearchIndex(ctx, time.Millisecond)
+		if s.indexReady.Load() {
+			t.Error("TodoServer.BuildSearchIndex() marked the index ready after failing")
+		}
+	})
+}
+
+func Test_TodoServer_indexesWrites(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	s := &TodoServer{
+		tasks: &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{}},
+		index: search.NewIndex(),
+	}
+	s.indexReady.Store(true)
+	find := func(query string) []*proto.Task {
+		t.Helper()
+		resp, err := s.SearchTasks(userCtx, &proto.SearchTasksReq{Query: query})
+		if err != nil {
+			t.Fatalf("TodoServer.SearchTasks() error = %v", err)
+		}
+		return resp.Tasks
+	}
+
+	addResp, err := s.AddTask(userCtx, &proto.AddTaskReq{Title: "Renew passport", Description: "Bring two photos"})
+	if err != nil {
+		t.Fatalf("TodoServer.AddTask() error = %v", err)
+	}
+	if got := find("photo"); len(got) != 1 || got[0].Id != addResp.Id {
+		t.Errorf("search after AddTask = %v, want the added task", got)
+	}
+
+	_, err = s.UpdateTask(userCtx, &proto.UpdateTaskReq{Task: &proto.Task{Id: addResp.Id, Title: "Renew driving licence"}})
+	if err != nil {
+		t.Fatalf("TodoServer.UpdateTask() error = %v", err)
+	}
+	if got := find("passport"); len(got) != 0 {
+		t.Errorf("search for replaced title after UpdateTask = %v, want no tasks", got)
+	}
+	if got := find("licence"); len(got) != 1 {
+		t.Errorf("search for new title after UpdateTask = %v, want the updated task", got)
+	}
+
+	if _, err := s.DeleteTask(userCtx, &proto.DeleteTaskReq{TaskId: addResp.Id}); err != nil {
+		t.Fatalf("TodoServer.DeleteTask() error = %v", err)
+	}
+	if got := find("licence"); len(got) != 0 {
+		t.Errorf("search after DeleteTask = %v, want no tasks", got)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	proto "todo/proto/gen/go/api"
+)
+
+// statusTransitions maps each status to the statuses a task may move to from it.
+// A task may always keep its current status.
+var statusTransitions = map[proto.Status][]proto.Status{
+	proto.Status_INCOMPLETE: {
+		proto.Status_IN_PROGRESS,
+		proto.Status_BLOCKED,
+		proto.Status_DEFERRED,
+		proto.Status_COMPLETE,
+		proto.Status_CANCELLED,
+	},
+	proto.Status_IN_PROGRESS: {
+		proto.Status_INCOMPLETE,
+		proto.Status_BLOCKED,
+		proto.Status_DEFERRED,
+		proto.Status_COMPLETE,
+		proto.Status_CANCELLED,
+	},
+	proto.Status_BLOCKED: {
+		proto.Status_INCOMPLETE,
+		proto.Status_IN_PROGRESS,
+		proto.Status_DEFERRED,
+		proto.Status_CANCELLED,
+	},
+	proto.Status_DEFERRED: {
+		proto.Status_INCOMPLETE,
+		proto.Status_IN_PROGRESS,
+		proto.Status_CANCELLED,
+	},
+	proto.Status_COMPLETE: {
+		proto.Status_INCOMPLETE,
+	},
+	proto.Status_CANCELLED: {
+		proto.Status_INCOMPLETE,
+	},
+}
+
+// parseStatus converts a stored status into its proto representation,
+// returning an error rather than defaulting to INCOMPLETE when the status is unknown.
+func parseStatus(status string) (proto.Status, error) {
+	value, ok := proto.Status_value[status]
+	if !ok {
+		return 0, fmt.Errorf("unknown status: %q", status)
+	}
+	return proto.Status(value), nil
+}
+
+// validateStatus returns an error if the status is not one of the defined statuses.
+func validateStatus(status proto.Status) error {
+	if _, ok := proto.Status_name[int32(status)]; !ok {
+		return fmt.Errorf("unknown status: %v", status)
+	}
+	return nil
+}
+
+// validateStatusTransition returns an error if a task is not allowed to move from one status to the other.
+func validateStatusTransition(from, to proto.Status) error {
+	if err := validateStatus(to); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	if !slices.Contains(statusTransitions[from], to) {
+		return fmt.Errorf("a task cannot move from %s to %s", from, to)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+	proto "todo/proto/gen/go/api"
+)
+
+func Test_validateStatusTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    proto.Status
+		to      proto.Status
+		wantErr bool
+	}{
+		{name: "keep status", from: proto.Status_BLOCKED, to: proto.Status_BLOCKED, wantErr: false},
+		{name: "start task", from: proto.Status_INCOMPLETE, to: proto.Status_IN_PROGRESS, wantErr: false},
+		{name: "complete task", from: proto.Status_IN_PROGRESS, to: proto.Status_COMPLETE, wantErr: false},
+		{name: "reopen cancelled task", from: proto.Status_CANCELLED, to: proto.Status_INCOMPLETE, wantErr: false},
+		{name: "complete blocked task", from: proto.Status_BLOCKED, to: proto.Status_COMPLETE, wantErr: true},
+		{name: "defer completed task", from: proto.Status_COMPLETE, to: proto.Status_DEFERRED, wantErr: true},
+		{name: "unknown status", from: proto.Status_INCOMPLETE, to: proto.Status(100), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateStatusTransition(tt.from, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("validateStatusTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseStatus(t *testing.T) {
+	if got, err := parseStatus("IN_PROGRESS"); err != nil || got != proto.Status_IN_PROGRESS {
+		t.Errorf("parseStatus() = %v, %v, want %v", got, err, proto.Status_IN_PROGRESS)
+	}
+	if _, err := parseStatus(""); err == nil {
+		t.Error("parseStatus() expected error for empty status")
+	}
+	if _, err := parseStatus("DONE"); err == nil {
+		t.Error("parseStatus() expected error for unknown status")
+	}
+}
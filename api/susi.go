@@ -4,23 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage"
+	"todo/logging"
+	"todo/metrics"
 	proto "todo/proto/gen/go/api"
+	"todo/tracing"
 
 	"github.com/alexedwards/argon2id"
 	"github.com/google/uuid"
 )
 
-// hashPassword uses argon2id default params to salt and hash a user's given password.
+// hashPassword uses argon2id with the given params to salt and hash a user's given password.
 // https://github.com/alexedwards/argon2id
-func hashPassword(password string) (string, error) {
-	hash, err := argon2id.CreateHash(password, argon2id.DefaultParams)
+func hashPassword(ctx context.Context, password string, params *argon2id.Params) (string, error) {
+	_, span := tracing.Tracer.Start(ctx, "argon2id.CreateHash")
+	defer span.End()
+	hash, err := argon2id.CreateHash(password, params)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return "", err
 	}
 	return hash, nil
 }
 
+// comparePassword reports whether the given password matches the argon2id hash of a user's password.
+func comparePassword(ctx context.Context, password, hash string) (bool, error) {
+	_, span := tracing.Tracer.Start(ctx, "argon2id.ComparePasswordAndHash")
+	defer span.End()
+	match, err := argon2id.ComparePasswordAndHash(password, hash)
+	tracing.RecordError(span, err)
+	return match, err
+}
+
 // Signup hashes the password, generates a user id, and then adds the user to the database,
 // before returning an access jwt and the user id.
 func (t *TodoServer) Signup(ctx context.Context, req *proto.SignupReq) (*proto.SignupResp, error) {
@@ -38,7 +53,7 @@ func (t *TodoServer) Signup(ctx context.Context, req *proto.SignupReq) (*proto.S
 	}
 
 	// hash password
-	hashedPassword, err := hashPassword(req.Password)
+	hashedPassword, err := hashPassword(ctx, req.Password, t.argon2)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %v", err)
 	}
@@ -47,9 +62,9 @@ func (t *TodoServer) Signup(ctx context.Context, req *proto.SignupReq) (*proto.S
 	userID := uuid.New()
 
 	// add user to DDB
-	_, err = t.ddb.AddUser(ctx, &dynamodb.AddUserReq{
-		User: dynamodb.User{
-			ID:             hashedPassword,
+	_, err = t.users.AddUser(ctx, &storage.AddUserReq{
+		User: storage.User{
+			ID:             userID.String(),
 			FirstName:      req.FirstName,
 			LastName:       req.LastName,
 			Email:          req.Email,
@@ -59,6 +74,8 @@ func (t *TodoServer) Signup(ctx context.Context, req *proto.SignupReq) (*proto.S
 	if err != nil {
 		return nil, fmt.Errorf("failed to add user: %v", err)
 	}
+	logging.With(ctx, "user_id", userID.String())
+	logging.FromContext(ctx).InfoContext(ctx, "signed up user")
 
 	// generate access token
 	token, err := t.jwt.IssueToken(userID.String())
@@ -82,23 +99,28 @@ func (t *TodoServer) Signin(ctx context.Context, req *proto.SigninReq) (*proto.S
 		return nil, errors.New("userid cannot be blank")
 	}
 
+	logging.With(ctx, "user_id", req.UserID)
+
 	// get user's stored hash password
-	getUserResp, err := t.ddb.GetUser(ctx, &dynamodb.GetUserReq{
+	getUserResp, err := t.users.GetUser(ctx, &storage.GetUserReq{
 		ID: req.UserID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %v", err)
 	}
 	if getUserResp.User == nil {
+		metrics.Signins.WithLabelValues("failure").Inc()
 		return nil, fmt.Errorf("user %s does not exist", req.UserID)
 	}
 
 	// compare password
-	match, err := argon2id.ComparePasswordAndHash(req.Password, getUserResp.User.HashedPassword)
+	match, err := comparePassword(ctx, req.Password, getUserResp.User.HashedPassword)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compare password to hash: %v", err)
 	}
 	if !match {
+		logging.FromContext(ctx).WarnContext(ctx, "signin with invalid password")
+		metrics.Signins.WithLabelValues("failure").Inc()
 		return nil, errors.New("invalid password")
 	}
 
@@ -110,6 +132,7 @@ func (t *TodoServer) Signin(ctx context.Context, req *proto.SigninReq) (*proto.S
 
 	// TODO: generate refresh token
 
+	metrics.Signins.WithLabelValues("success").Inc()
 	return &proto.SigninResp{
 		AccessJWT: token,
 	}, nil
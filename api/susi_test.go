@@ -6,17 +6,19 @@ import (
 	"reflect"
 	"testing"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
+
+	"github.com/alexedwards/argon2id"
 )
 
 func Test_TodoServer_Signup(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		users                   storage.UserStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -33,8 +35,8 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				users: &storageMock.MockUserStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -53,8 +55,8 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "empty first name",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				users: &storageMock.MockUserStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -71,8 +73,8 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "empty email",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				users: &storageMock.MockUserStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -89,8 +91,8 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "empty password",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				users: &storageMock.MockUserStore{},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
@@ -107,7 +109,7 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "AddUser returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
+				users: &storageMock.MockUserStore{
 					AddUserErr: errors.New("test error"),
 				},
 				jwt: &tmMock.MockTokenManager{},
@@ -127,7 +129,7 @@ func Test_TodoServer_Signup(t *testing.T) {
 		{
 			name: "IssueToken returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
+				users: &storageMock.MockUserStore{},
 				jwt: &tmMock.MockTokenManager{
 					IssueTokenErr: errors.New("test error"),
 				},
@@ -149,8 +151,9 @@ func Test_TodoServer_Signup(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				users:                   tt.fields.users,
 				jwt:                     tt.fields.jwt,
+				argon2:                  argon2id.DefaultParams,
 			}
 			got, err := tr.Signup(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
@@ -171,14 +174,32 @@ func Test_TodoServer_Signup(t *testing.T) {
 	}
 }
 
+func Test_TodoServer_Signup_Signin(t *testing.T) {
+	users := &storageMock.MockUserStore{}
+	tr := &TodoServer{users: users, jwt: &tmMock.MockTokenManager{}, argon2: argon2id.DefaultParams}
+	ctx := context.Background()
+	signupResp, err := tr.Signup(ctx, &proto.SignupReq{FirstName: "Travis", Email: "Williams44T@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("TodoServer.Signup() error = %v", err)
+	}
+
+	// the user is stored under the returned user id, which signing in takes
+	if _, ok := users.UsersTable[signupResp.UserID]; !ok || len(users.UsersTable) != 1 {
+		t.Errorf("TodoServer.Signup() stored users %v, want one under user id %s", users.UsersTable, signupResp.UserID)
+	}
+	if _, err := tr.Signin(ctx, &proto.SigninReq{UserID: signupResp.UserID, Password: "password"}); err != nil {
+		t.Errorf("TodoServer.Signin() after Signup() error = %v", err)
+	}
+}
+
 func Test_TodoServer_Signin(t *testing.T) {
-	hashedPassword, err := hashPassword(common.TEST_USER_1_PASSWORD)
+	hashedPassword, err := hashPassword(context.Background(), common.TEST_USER_1_PASSWORD, argon2id.DefaultParams)
 	if err != nil {
 		t.Errorf("TodoServer.Signin() failed to hash password: %v", err)
 	}
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		users                   storage.UserStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -195,8 +216,8 @@ func Test_TodoServer_Signin(t *testing.T) {
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					UsersTable: map[string]dynamodb.User{
+				users: &storageMock.MockUserStore{
+					UsersTable: map[string]storage.User{
 						common.TEST_USER_1_ID: {
 							HashedPassword: hashedPassword,
 						},
@@ -219,8 +240,8 @@ func Test_TodoServer_Signin(t *testing.T) {
 		{
 			name: "empty user id",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					UsersTable: map[string]dynamodb.User{
+				users: &storageMock.MockUserStore{
+					UsersTable: map[string]storage.User{
 						common.TEST_USER_1_ID: {
 							HashedPassword: hashedPassword,
 						},
@@ -241,8 +262,8 @@ func Test_TodoServer_Signin(t *testing.T) {
 		{
 			name: "invalid password",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					UsersTable: map[string]dynamodb.User{
+				users: &storageMock.MockUserStore{
+					UsersTable: map[string]storage.User{
 						common.TEST_USER_1_ID: {
 							HashedPassword: hashedPassword,
 						},
@@ -263,8 +284,8 @@ func Test_TodoServer_Signin(t *testing.T) {
 		{
 			name: "GetUser returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					UsersTable: map[string]dynamodb.User{
+				users: &storageMock.MockUserStore{
+					UsersTable: map[string]storage.User{
 						common.TEST_USER_1_ID: {
 							HashedPassword: hashedPassword,
 						},
@@ -286,8 +307,8 @@ func Test_TodoServer_Signin(t *testing.T) {
 		{
 			name: "IssueToken returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
-					UsersTable: map[string]dynamodb.User{
+				users: &storageMock.MockUserStore{
+					UsersTable: map[string]storage.User{
 						common.TEST_USER_1_ID: {
 							HashedPassword: hashedPassword,
 						},
@@ -312,7 +333,7 @@ func Test_TodoServer_Signin(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				users:                   tt.fields.users,
 				jwt:                     tt.fields.jwt,
 			}
 			got, err := tr.Signin(tt.args.ctx, tt.args.req)
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// maxMergedTags is the most tags a single merge may replace
+const maxMergedTags = 100
+
+// replaceTags returns the tags with each of the replaced tags swapped for the target, leaving out repeats.
+func replaceTags(tags, replaced []string, target string) []string {
+	var result []string
+	for _, tag := range tags {
+		if slices.Contains(replaced, tag) {
+			tag = target
+		}
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// retagTasks replaces the tags with the target on every task of the user holding any of them, returning the
+// number of tasks updated as commitTaskUpdates does. Tasks that are updated no longer hold the replaced tags,
+// so retrying after an error resumes where the failed batch left off. Each updated task of a project is
+// audited against it.
+func (t *TodoServer) retagTasks(ctx context.Context, userID string, replaced []string, target string) (int, error) {
+	tasks, err := t.getAllTasks(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get all tasks: %v", err)
+	}
+	var updates []storage.UpdateTaskReq
+	var audits []storage.AuditEntry
+	for _, task := range tasks {
+		tags := replaceTags(task.Tags, replaced, target)
+		if slices.Equal(tags, task.Tags) {
+			continue
+		}
+		updates = append(updates, storage.UpdateTaskReq{
+			UserID:  userID,
+			TaskID:  task.TaskID,
+			KVPairs: map[string]interface{}{storage.TagsKey: tags},
+		})
+		held := slices.DeleteFunc(slices.Clone(task.Tags), func(tag string) bool {
+			return !slices.Contains(replaced, tag)
+		})
+		audits = append(audits, storage.AuditEntry{
+			ProjectID: task.ProjectID,
+			TaskID:    task.TaskID,
+			Details:   fmt.Sprintf("replaced tags %s with %s", strings.Join(held, ", "), target),
+		})
+	}
+	updated, err := t.commitTaskUpdates(ctx, updates)
+	for _, audit := range audits[:updated] {
+		t.recordAudit(ctx, audit.ProjectID, userID, auditTaskUpdated, audit.TaskID, audit.Details)
+	}
+	return updated, err
+}
+
+// ListTags returns every tag of the user's tasks with the number of tasks holding it.
+func (t *TodoServer) ListTags(ctx context.Context, req *proto.ListTagsReq) (*proto.ListTagsResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// count the tasks of each tag
+	tasks, err := t.getAllTasks(ctx, userIDs[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tasks: %v", err)
+	}
+	counts := map[string]int32{}
+	for _, task := range tasks {
+		for i, tag := range task.Tags {
+			// a tag repeated on a task is counted once
+			if !slices.Contains(task.Tags[:i], tag) {
+				counts[tag]++
+			}
+		}
+	}
+	tags := make([]*proto.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, &proto.TagCount{Tag: tag, TaskCount: count})
+	}
+	slices.SortFunc(tags, func(a, b *proto.TagCount) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	return &proto.ListTagsResp{
+		Tags: tags,
+	}, nil
+}
+
+// RenameTag replaces a tag with a new tag on every task of the user holding it.
+func (t *TodoServer) RenameTag(ctx context.Context, req *proto.RenameTagReq) (*proto.RenameTagResp, error) {
+	// validate req
+	if req.Tag == "" {
+		return nil, errors.New("tag cannot be blank")
+	}
+	newTag := storage.NormalizeTag(req.NewTag)
+	if newTag == "" {
+		return nil, errors.New("new tag cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	updated, err := t.retagTasks(ctx, userIDs[0], []string{req.Tag}, newTag)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "renamed tag", "tag", req.Tag, "new_tag", newTag, "updated_tasks", updated)
+
+	return &proto.RenameTagResp{
+		UpdatedTasks: int32(updated),
+	}, nil
+}
+
+// MergeTags replaces each of the tags with the target tag on every task of the user holding any of them.
+func (t *TodoServer) MergeTags(ctx context.Context, req *proto.MergeTagsReq) (*proto.MergeTagsResp, error) {
+	// validate req
+	if len(req.Tags) == 0 {
+		return nil, errors.New("tags to merge cannot be empty")
+	}
+	if len(req.Tags) > maxMergedTags {
+		return nil, fmt.Errorf("unable to merge more than %d tags at once", maxMergedTags)
+	}
+	if slices.Contains(req.Tags, "") {
+		return nil, errors.New("tags to merge cannot be blank")
+	}
+	target := storage.NormalizeTag(req.Target)
+	if target == "" {
+		return nil, errors.New("target tag cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	updated, err := t.retagTasks(ctx, userIDs[0], req.Tags, target)
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "merged tags", "tags", req.Tags, "target", target, "updated_tasks", updated)
+
+	return &proto.MergeTagsResp{
+		UpdatedTasks: int32(updated),
+	}, nil
+}
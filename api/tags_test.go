@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// storedTags returns the tags of each stored task of the user, keyed by task id.
+func storedTags(tasks *storageMock.MockTaskStore, userID string) map[string][]string {
+	tags := map[string][]string{}
+	for _, task := range tasks.TasksTable[userID] {
+		tags[task.TaskID] = task.Tags
+	}
+	return tags
+}
+
+func Test_replaceTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		replaced []string
+		target   string
+		want     []string
+	}{
+		{name: "replaces tag in place", tags: []string{"a", "old", "b"}, replaced: []string{"old"}, target: "new", want: []string{"a", "new", "b"}},
+		{name: "merges into existing target", tags: []string{"new", "old"}, replaced: []string{"old"}, target: "new", want: []string{"new"}},
+		{name: "merges several tags", tags: []string{"x", "y", "z"}, replaced: []string{"x", "z"}, target: "w", want: []string{"w", "y"}},
+		{name: "leaves other tags", tags: []string{"a", "b"}, replaced: []string{"old"}, target: "new", want: []string{"a", "b"}},
+		{name: "no tags", tags: nil, replaced: []string{"old"}, target: "new", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceTags(tt.tags, tt.replaced, tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replaceTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListTags(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		tasks     []storage.Task
+		getAllErr error
+		want      []*proto.TagCount
+		wantErr   bool
+	}{
+		{
+			name: "counts tasks of each tag",
+			ctx:  userCtx,
+			tasks: []storage.Task{
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Tags: []string{"work", "urgent"}},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, Tags: []string{"work", "work"}},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID},
+			},
+			want: []*proto.TagCount{{Tag: "urgent", TaskCount: 1}, {Tag: "work", TaskCount: 2}},
+		},
+		{
+			name: "no tags",
+			ctx:  userCtx,
+			want: []*proto.TagCount{},
+		},
+		{
+			name:      "failed to get tasks",
+			ctx:       userCtx,
+			getAllErr: errors.New("test error"),
+			wantErr:   true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TodoServer{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:     map[string][]storage.Task{common.TEST_USER_1_ID: tt.tasks},
+					GetAllTasksErr: tt.getAllErr,
+				},
+			}
+			got, err := s.ListTags(tt.ctx, &proto.ListTagsReq{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.ListTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Tags, tt.want) {
+				t.Errorf("TodoServer.ListTags() = %v, want %v", got.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_RenameTag(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tasks := func() []storage.Task {
+		return []storage.Task{
+			{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Tags: []string{"wrk", "urgent"}},
+			{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, Tags: []string{"home"}},
+			{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID, Tags: []string{"work", "wrk"}},
+		}
+	}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.RenameTagReq
+		commitErr   error
+		wantUpdated int32
+		wantTags    map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "renames tag",
+			ctx:         userCtx,
+			req:         &proto.RenameTagReq{Tag: "wrk", NewTag: " Work "},
+			wantUpdated: 2,
+			wantTags: map[string][]string{
+				common.TASK_1A_ID: {"work", "urgent"},
+				common.TASK_1B_ID: {"home"},
+				common.TASK_1C_ID: {"work"},
+			},
+		},
+		{
+			name:        "unused tag",
+			ctx:         userCtx,
+			req:         &proto.RenameTagReq{Tag: "garden", NewTag: "yard"},
+			wantUpdated: 0,
+			wantTags: map[string][]string{
+				common.TASK_1A_ID: {"wrk", "urgent"},
+				common.TASK_1B_ID: {"home"},
+				common.TASK_1C_ID: {"work", "wrk"},
+			},
+		},
+		{
+			name:    "blank tag",
+			ctx:     userCtx,
+			req:     &proto.RenameTagReq{NewTag: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "blank new tag",
+			ctx:     userCtx,
+			req:     &proto.RenameTagReq{Tag: "wrk", NewTag: "  "},
+			wantErr: true,
+		},
+		{
+			name:      "commit fails",
+			ctx:       userCtx,
+			req:       &proto.RenameTagReq{Tag: "wrk", NewTag: "work"},
+			commitErr: errors.New("test error"),
+			wantErr:   true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.RenameTagReq{Tag: "wrk", NewTag: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: tasks()}}
+			s := &TodoServer{
+				tasks: store,
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: store, CommitErr: tt.commitErr}
+				},
+			}
+			got, err := s.RenameTag(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.RenameTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.UpdatedTasks != tt.wantUpdated {
+				t.Errorf("TodoServer.RenameTag() updated = %d, want %d", got.UpdatedTasks, tt.wantUpdated)
+			}
+			if tags := storedTags(store, common.TEST_USER_1_ID); !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("stored tags = %q, want %q", tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_MergeTags(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	var manyTags []string
+	for i := 0; i <= maxMergedTags; i++ {
+		manyTags = append(manyTags, fmt.Sprintf("tag%d", i))
+	}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.MergeTagsReq
+		wantUpdated int32
+		wantTags    map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "merges tags",
+			ctx:         userCtx,
+			req:         &proto.MergeTagsReq{Tags: []string{"job", "office"}, Target: "work"},
+			wantUpdated: 2,
+			wantTags: map[string][]string{
+				common.TASK_1A_ID: {"work", "urgent"},
+				common.TASK_1B_ID: {"home"},
+				common.TASK_1C_ID: {"work"},
+			},
+		},
+		{
+			name:    "no tags",
+			ctx:     userCtx,
+			req:     &proto.MergeTagsReq{Target: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "too many tags",
+			ctx:     userCtx,
+			req:     &proto.MergeTagsReq{Tags: manyTags, Target: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "blank tag",
+			ctx:     userCtx,
+			req:     &proto.MergeTagsReq{Tags: []string{"job", ""}, Target: "work"},
+			wantErr: true,
+		},
+		{
+			name:    "blank target",
+			ctx:     userCtx,
+			req:     &proto.MergeTagsReq{Tags: []string{"job"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing user id",
+			ctx:     context.Background(),
+			req:     &proto.MergeTagsReq{Tags: []string{"job"}, Target: "work"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{common.TEST_USER_1_ID: {
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1A_ID, Tags: []string{"job", "urgent"}},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1B_ID, Tags: []string{"home"}},
+				{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1C_ID, Tags: []string{"office", "job", "work"}},
+			}}}
+			s := &TodoServer{
+				tasks: store,
+				newUnitOfWork: func() storage.UnitOfWork {
+					return &storageMock.MockUnitOfWork{Tasks: store}
+				},
+			}
+			got, err := s.MergeTags(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.MergeTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.UpdatedTasks != tt.wantUpdated {
+				t.Errorf("TodoServer.MergeTags() updated = %d, want %d", got.UpdatedTasks, tt.wantUpdated)
+			}
+			if tags := storedTags(store, common.TEST_USER_1_ID); !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("stored tags = %q, want %q", tags, tt.wantTags)
+			}
+		})
+	}
+}
@@ -5,17 +5,46 @@ import (
 	"errors"
 	"fmt"
 	"todo/common"
-	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage"
 	proto "todo/proto/gen/go/api"
 
 	"google.golang.org/grpc/metadata"
 )
 
+// taskUpdateBatchSize is the most tasks updated in each unit of work of an update of many tasks,
+// since a DynamoDB transaction holds at most 100 writes.
+const taskUpdateBatchSize = 100
+
+// commitTaskUpdates commits the updates in batches, returning the number of tasks updated, including those
+// of the batches committed before an error.
+func (t *TodoServer) commitTaskUpdates(ctx context.Context, updates []storage.UpdateTaskReq) (int, error) {
+	updated := 0
+	for start := 0; start < len(updates); start += taskUpdateBatchSize {
+		batch := updates[start:min(start+taskUpdateBatchSize, len(updates))]
+		uow := t.newUnitOfWork()
+		for _, update := range batch {
+			uow.UpdateTask(update)
+		}
+		if err := uow.Commit(ctx); err != nil {
+			return updated, fmt.Errorf("failed to update tasks after updating %d of %d, retry to update the rest: %v", updated, len(updates), err)
+		}
+		updated += len(batch)
+	}
+	return updated, nil
+}
+
 func (t *TodoServer) UpdateTask(ctx context.Context, req *proto.UpdateTaskReq) (*proto.UpdateTaskResp, error) {
 	// validate request
 	if req.Task.Id == "" {
 		return nil, errors.New("task id cannot be blank")
 	}
+	// title is a local secondary index sort key, which cannot be an empty string
+	if req.Task.Title == "" {
+		return nil, errors.New("title cannot be blank")
+	}
+	if _, ok := proto.Priority_name[int32(req.Task.Priority)]; !ok {
+		return nil, fmt.Errorf("unknown priority: %v", req.Task.Priority)
+	}
 
 	// get userid from ctx
 	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
@@ -23,53 +52,97 @@ func (t *TodoServer) UpdateTask(ctx context.Context, req *proto.UpdateTaskReq) (
 		return nil, fmt.Errorf("user id is not provided in metadata")
 	}
 
-	// update task
-	var ddbRecurringRule *dynamodb.RecurringRule
+	access, err := t.authorizeProject(ctx, userIDs[0], req.Task.ProjectId, proto.Role_EDITOR)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the current task to validate the status transition. The tasks of the user may move between the
+	// user's projects, while those of a project shared with the user are kept with its owner and cannot leave it.
+	taskAccess := *access
+	if access.ownerID == userIDs[0] {
+		taskAccess.project = nil
+	}
+	current, err := t.getAccessibleTask(ctx, &taskAccess, req.Task.Id)
+	if err != nil {
+		return nil, err
+	}
+	currentStatus, err := parseStatus(current.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current status of task %s: %v", req.Task.Id, err)
+	}
+	if err := validateStatusTransition(currentStatus, req.Task.Status); err != nil {
+		return nil, err
+	}
+	if req.Task.ProjectId != current.ProjectID {
+		if err := access.checkAcceptsTasks(); err != nil {
+			return nil, err
+		}
+	}
+	// tasks stay assigned to members who leave their project, so the assignee is only checked when it changes
+	// or the task moves to another project
+	if req.Task.AssigneeId != current.AssigneeID || req.Task.ProjectId != current.ProjectID {
+		if err := t.validateAssignee(ctx, access, req.Task.AssigneeId); err != nil {
+			return nil, err
+		}
+	}
+	completing := req.Task.Status == proto.Status_COMPLETE && currentStatus != proto.Status_COMPLETE
+	// the stored requirement is checked too, so that it cannot be cleared by the update that completes the task
+	requireChecklist := current.RequireChecklistComplete || req.Task.RequireChecklistComplete
+	if completing && requireChecklist && !checklistComplete(current.Checklist) {
+		return nil, errors.New("task cannot be complete until every checklist item is done")
+	}
+
+	// update task, failing if its status changed since it was read, or if its checklist did while it is completed
+	// by the update, so that an item left open meanwhile keeps it from being completed
+	var ddbRecurringRule *storage.RecurringRule
 	if req.Task.RecurringRule != nil {
-		ddbRecurringRule = &dynamodb.RecurringRule{
+		ddbRecurringRule = &storage.RecurringRule{
 			CronExpression: req.Task.RecurringRule.CronExpression,
 			StartDate:      req.Task.RecurringRule.StartDate,
 			EndDate:        req.Task.RecurringRule.EndDate,
 		}
 	}
-	updateTaskResp, err := t.ddb.UpdateTask(ctx, &dynamodb.UpdateTaskReq{
-		UserID: userIDs[0],
+	updateTaskResp, err := t.tasks.UpdateTask(ctx, &storage.UpdateTaskReq{
+		UserID: access.ownerID,
 		TaskID: req.Task.Id,
 		KVPairs: map[string]interface{}{
-			dynamodb.TitleKey:         req.Task.Title,
-			dynamodb.DescriptionKey:   req.Task.Title,
-			dynamodb.StatusKey:        req.Task.Status.String(),
-			dynamodb.TagsKey:          req.Task.Tags,
-			dynamodb.ParentsKey:       req.Task.Parents,
-			dynamodb.DueDateKey:       req.Task.DueDate,
-			dynamodb.RecurringRuleKey: ddbRecurringRule,
+			storage.TitleKey:                    req.Task.Title,
+			storage.DescriptionKey:              req.Task.Description,
+			storage.StatusKey:                   req.Task.Status.String(),
+			storage.TagsKey:                     storage.NormalizeTags(req.Task.Tags),
+			storage.ParentsKey:                  req.Task.Parents,
+			storage.DueDateKey:                  req.Task.DueDate,
+			storage.RecurringRuleKey:            ddbRecurringRule,
+			storage.PriorityKey:                 req.Task.Priority.String(),
+			storage.EffortMinutesKey:            req.Task.EffortMinutes,
+			storage.ProjectIDKey:                req.Task.ProjectId,
+			storage.AssigneeIDKey:               req.Task.AssigneeId,
+			storage.RequireChecklistCompleteKey: req.Task.RequireChecklistComplete,
 		},
+		ExpectedStatus:    current.Status,
+		ExpectChecklist:   completing && requireChecklist,
+		ExpectedChecklist: current.Checklist,
 	})
+	if errors.Is(err, storage.ErrConditionFailed) {
+		return nil, fmt.Errorf("task %s was modified by another request, try again", req.Task.Id)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update task: %v", err)
 	}
-
-	// simplify
-	ddbTask := updateTaskResp.Task
+	t.index.Put(taskDocument(&updateTaskResp.Task, updateTaskResp.Task.UpdatedAt))
+	t.recordAudit(ctx, req.Task.ProjectId, userIDs[0], auditTaskUpdated, req.Task.Id, req.Task.Title)
+	if current.ProjectID != req.Task.ProjectId {
+		t.recordAudit(ctx, current.ProjectID, userIDs[0], auditTaskUpdated, req.Task.Id, "moved out of the project")
+	}
+	t.notifyAssignment(ctx, userIDs[0], current.AssigneeID, &updateTaskResp.Task)
 
 	// form and send response
-	var recurringRule *proto.RecurringRule
-	if ddbTask.RecurringRule != nil {
-		recurringRule = &proto.RecurringRule{}
-		recurringRule.CronExpression = ddbTask.RecurringRule.CronExpression
-		recurringRule.StartDate = ddbTask.RecurringRule.StartDate
-		recurringRule.EndDate = ddbTask.RecurringRule.EndDate
+	task, err := toProtoTask(&updateTaskResp.Task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert task: %v", err)
 	}
 	return &proto.UpdateTaskResp{
-		Task: &proto.Task{
-			Id:            ddbTask.TaskID,
-			Title:         ddbTask.Title,
-			Description:   ddbTask.Description,
-			Status:        proto.Status(proto.Status_value[ddbTask.Status]),
-			Tags:          ddbTask.Tags,
-			Parents:       ddbTask.Parents,
-			DueDate:       ddbTask.DueDate,
-			RecurringRule: recurringRule,
-		},
+		Task: task,
 	}, nil
 }
@@ -3,21 +3,46 @@ package api
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"todo/common"
-	"todo/interfaces/dynamodb"
-	ddbMock "todo/interfaces/dynamodb/mock"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
 	"todo/interfaces/token_manager"
 	tmMock "todo/interfaces/token_manager/mock"
 	proto "todo/proto/gen/go/api"
+	"todo/search"
 
 	"google.golang.org/grpc/metadata"
 )
 
+// newUpdateTaskTestTable returns a fresh tasks table for each test case since UpdateTask modifies it.
+func newUpdateTaskTestTable() map[string][]storage.Task {
+	return map[string][]storage.Task{
+		common.TEST_USER_1_ID: {
+			{
+				TaskID:    common.TASK_1A_ID,
+				Title:     "title",
+				Status:    proto.Status_INCOMPLETE.String(),
+				Checklist: []storage.ChecklistItem{{ID: "item-b", Text: "b"}},
+			},
+			{TaskID: common.TASK_1C_ID, Title: "title", Status: proto.Status_COMPLETE.String(), CompletedAt: 1},
+			{TaskID: common.TASK_1D_ID, Title: "title", Status: "DONE"},
+			{
+				TaskID:                   common.TASK_1_ID,
+				Title:                    "title",
+				Status:                   proto.Status_INCOMPLETE.String(),
+				Checklist:                []storage.ChecklistItem{{ID: "item-a", Text: "a"}},
+				RequireChecklistComplete: true,
+			},
+		},
+	}
+}
+
 func Test_TodoServer_UpdateTask(t *testing.T) {
 	type fields struct {
 		UnimplementedTodoServer proto.UnimplementedTodoServer
-		ddb                     dynamodb.DynamoDBInterface
+		tasks                   storage.TaskStore
 		jwt                     token_manager.TokenManagerInterface
 	}
 	type args struct {
@@ -25,33 +50,187 @@ func Test_TodoServer_UpdateTask(t *testing.T) {
 		req *proto.UpdateTaskReq
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *proto.UpdateTaskResp
-		wantErr bool
+		name           string
+		fields         fields
+		args           args
+		wantStatus     proto.Status
+		wantHistoryLen int
+		wantErr        bool
 	}{
 		{
 			name: "happy path",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
 				req: &proto.UpdateTaskReq{
 					Task: &proto.Task{
-						Id: common.TASK_1A_ID,
+						Id:    common.TASK_1A_ID,
+						Title: "new title",
+					},
+				},
+			},
+			wantStatus:     proto.Status_INCOMPLETE,
+			wantHistoryLen: 0,
+			wantErr:        false,
+		},
+		{
+			name: "happy path - start task",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:     common.TASK_1A_ID,
+						Title:  "title",
+						Status: proto.Status_IN_PROGRESS,
+					},
+				},
+			},
+			wantStatus:     proto.Status_IN_PROGRESS,
+			wantHistoryLen: 1,
+			wantErr:        false,
+		},
+		{
+			name: "happy path - reopen completed task",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:     common.TASK_1C_ID,
+						Title:  "title",
+						Status: proto.Status_INCOMPLETE,
+					},
+				},
+			},
+			wantStatus:     proto.Status_INCOMPLETE,
+			wantHistoryLen: 1,
+			wantErr:        false,
+		},
+		{
+			name: "invalid status transition",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:     common.TASK_1C_ID,
+						Title:  "title",
+						Status: proto.Status_IN_PROGRESS,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "happy path - complete task with unfinished checklist that is not required",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:     common.TASK_1A_ID,
+						Title:  "title",
+						Status: proto.Status_COMPLETE,
 					},
 				},
 			},
-			wantErr: false,
+			wantStatus:     proto.Status_COMPLETE,
+			wantHistoryLen: 1,
+			wantErr:        false,
+		},
+		{
+			name: "complete task with unfinished required checklist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:                       common.TASK_1_ID,
+						Title:                    "title",
+						Status:                   proto.Status_COMPLETE,
+						RequireChecklistComplete: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "complete task while clearing its required checklist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:                       common.TASK_1_ID,
+						Title:                    "title",
+						Status:                   proto.Status_COMPLETE,
+						RequireChecklistComplete: false,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown stored status",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:    common.TASK_1D_ID,
+						Title: "title",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "task does not exist",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id:    common.TASK_1B_ID,
+						Title: "title",
+					},
+				},
+			},
+			wantErr: true,
 		},
 		{
 			name: "no task id",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
@@ -61,17 +240,34 @@ func Test_TodoServer_UpdateTask(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "no title",
+			fields: fields{
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
+			},
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
+				req: &proto.UpdateTaskReq{
+					Task: &proto.Task{
+						Id: common.TASK_1A_ID,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "no user id in context",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{},
-				jwt: &tmMock.MockTokenManager{},
+				tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()},
+				jwt:   &tmMock.MockTokenManager{},
 			},
 			args: args{
 				ctx: context.Background(),
 				req: &proto.UpdateTaskReq{
 					Task: &proto.Task{
-						Id: common.TASK_1A_ID,
+						Id:    common.TASK_1A_ID,
+						Title: "new title",
 					},
 				},
 			},
@@ -80,7 +276,8 @@ func Test_TodoServer_UpdateTask(t *testing.T) {
 		{
 			name: "UpdateTask returns error",
 			fields: fields{
-				ddb: &ddbMock.MockDynamoDBClient{
+				tasks: &storageMock.MockTaskStore{
+					TasksTable:    newUpdateTaskTestTable(),
 					UpdateTaskErr: errors.New("test error"),
 				},
 				jwt: &tmMock.MockTokenManager{},
@@ -89,7 +286,8 @@ func Test_TodoServer_UpdateTask(t *testing.T) {
 				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID)),
 				req: &proto.UpdateTaskReq{
 					Task: &proto.Task{
-						Id: common.TASK_1A_ID,
+						Id:    common.TASK_1A_ID,
+						Title: "new title",
 					},
 				},
 			},
@@ -100,14 +298,88 @@ func Test_TodoServer_UpdateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &TodoServer{
 				UnimplementedTodoServer: tt.fields.UnimplementedTodoServer,
-				ddb:                     tt.fields.ddb,
+				tasks:                   tt.fields.tasks,
 				jwt:                     tt.fields.jwt,
+				index:                   search.NewIndex(),
 			}
-			_, err := tr.UpdateTask(tt.args.ctx, tt.args.req)
+			got, err := tr.UpdateTask(tt.args.ctx, tt.args.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TodoServer.UpdateTask() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
+			if got.Task.Status != tt.wantStatus {
+				t.Errorf("TodoServer.UpdateTask() status = %v, want %v", got.Task.Status, tt.wantStatus)
+			}
+			if len(got.Task.StatusHistory) != tt.wantHistoryLen {
+				t.Errorf("TodoServer.UpdateTask() status history = %v, want %d changes", got.Task.StatusHistory, tt.wantHistoryLen)
+			}
+			if (got.Task.CompletedAt != 0) != (got.Task.Status == proto.Status_COMPLETE) {
+				t.Errorf("TodoServer.UpdateTask() completed at = %v with status %v", got.Task.CompletedAt, got.Task.Status)
+			}
 		})
 	}
 }
+
+func Test_TodoServer_UpdateTask_NormalizesTags(t *testing.T) {
+	tr := &TodoServer{tasks: &storageMock.MockTaskStore{TasksTable: newUpdateTaskTestTable()}, index: search.NewIndex()}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	got, err := tr.UpdateTask(ctx, &proto.UpdateTaskReq{
+		Task: &proto.Task{Id: common.TASK_1A_ID, Title: "title", Tags: []string{"Work ", "work", "Later"}},
+	})
+	if err != nil {
+		t.Fatalf("TodoServer.UpdateTask() error = %v", err)
+	}
+	if want := []string{"work", "later"}; !reflect.DeepEqual(got.Task.Tags, want) {
+		t.Errorf("TodoServer.UpdateTask() tags = %q, want %q", got.Task.Tags, want)
+	}
+}
+
+// racingTaskStore runs race once before the first task update, as if another request changed the task
+// between the read and the write of the update.
+type racingTaskStore struct {
+	storage.TaskStore
+	race func()
+}
+
+func (r *racingTaskStore) UpdateTask(ctx context.Context, req *storage.UpdateTaskReq) (*storage.UpdateTaskResp, error) {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.TaskStore.UpdateTask(ctx, req)
+}
+
+func Test_TodoServer_UpdateTask_checklistChangedWhileCompleting(t *testing.T) {
+	table := newUpdateTaskTestTable()
+	table[common.TEST_USER_1_ID][3].Checklist[0].Done = true
+	tasks := &storageMock.MockTaskStore{TasksTable: table}
+	// an open item is added after the task's complete checklist is read
+	addItem := func() {
+		_, err := tasks.AddChecklistItem(context.Background(), &storage.AddChecklistItemReq{
+			UserID: common.TEST_USER_1_ID,
+			TaskID: common.TASK_1_ID,
+			Item:   storage.ChecklistItem{ID: "item-b", Text: "b"},
+		})
+		if err != nil {
+			t.Fatalf("TaskStore.AddChecklistItem() error = %v", err)
+		}
+	}
+	tr := &TodoServer{tasks: &racingTaskStore{TaskStore: tasks, race: addItem}, index: search.NewIndex()}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	_, err := tr.UpdateTask(ctx, &proto.UpdateTaskReq{
+		Task: &proto.Task{Id: common.TASK_1_ID, Title: "title", Status: proto.Status_COMPLETE, RequireChecklistComplete: true},
+	})
+	if err == nil {
+		t.Fatalf("TodoServer.UpdateTask() error = nil, want an error")
+	}
+	getTaskResp, err := tasks.GetTask(context.Background(), &storage.GetTaskReq{UserID: common.TEST_USER_1_ID, TaskID: common.TASK_1_ID})
+	if err != nil {
+		t.Fatalf("TaskStore.GetTask() error = %v", err)
+	}
+	if got := getTaskResp.Task.Status; got != proto.Status_INCOMPLETE.String() {
+		t.Errorf("status = %v, want the task left %v", got, proto.Status_INCOMPLETE)
+	}
+}
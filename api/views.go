@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"todo/common"
+	"todo/interfaces/storage"
+	"todo/logging"
+	proto "todo/proto/gen/go/api"
+	"todo/query"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// maxViews is the number of views each user may save
+	maxViews = 100
+	// maxViewNameLength is the number of characters a view name may hold
+	maxViewNameLength = 100
+)
+
+// builtInView is a view every user has.
+type builtInView struct {
+	name   string
+	query  string
+	sortBy proto.SortBy
+	// blocked limits the view to tasks with a parent that is neither complete nor cancelled
+	blocked bool
+}
+
+// builtInViews are listed before the views of the user.
+var builtInViews = []builtInView{
+	{name: "Today", query: "status:open due:today", sortBy: proto.SortBy_SORT_BY_PRIORITY},
+	{name: "Overdue", query: "status:open due<today", sortBy: proto.SortBy_SORT_BY_DUE_DATE},
+	{name: "Upcoming 7 days", query: "status:open due>=today due<7d", sortBy: proto.SortBy_SORT_BY_DUE_DATE},
+	{name: "Blocked", query: "status:open", sortBy: proto.SortBy_SORT_BY_PRIORITY, blocked: true},
+}
+
+// findBuiltInView returns the built-in view of the name, ignoring case, or nil if there is none.
+func findBuiltInView(name string) *builtInView {
+	for i := range builtInViews {
+		if strings.EqualFold(builtInViews[i].name, name) {
+			return &builtInViews[i]
+		}
+	}
+	return nil
+}
+
+func (v *builtInView) toProto() *proto.View {
+	return &proto.View{
+		Name:    v.name,
+		Query:   v.query,
+		SortBy:  v.sortBy,
+		BuiltIn: true,
+	}
+}
+
+func toProtoView(view *storage.View) *proto.View {
+	sortDirection := proto.SortDirection_ASCENDING
+	if view.Descending {
+		sortDirection = proto.SortDirection_DESCENDING
+	}
+	return &proto.View{
+		Name:          view.Name,
+		Query:         view.Query,
+		SortBy:        proto.SortBy(proto.SortBy_value[view.SortBy]),
+		SortDirection: sortDirection,
+	}
+}
+
+// hasOpenParent matches tasks with a parent among the ids of open tasks.
+type hasOpenParent map[string]bool
+
+func (f hasOpenParent) Match(task *storage.Task) bool {
+	return slices.ContainsFunc(task.Parents, func(id string) bool { return f[id] })
+}
+
+// getOpenTaskIDs returns the ids of the user's tasks that are neither complete nor cancelled.
+func (t *TodoServer) getOpenTaskIDs(ctx context.Context, userID string) (hasOpenParent, error) {
+	tasks, err := t.getAllTasks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tasks: %v", err)
+	}
+	open := hasOpenParent{}
+	for _, task := range tasks {
+		if task.Status != proto.Status_COMPLETE.String() && task.Status != proto.Status_CANCELLED.String() {
+			open[task.TaskID] = true
+		}
+	}
+	return open, nil
+}
+
+// SaveView saves a view of the user, replacing the view of the same name.
+func (t *TodoServer) SaveView(ctx context.Context, req *proto.SaveViewReq) (*proto.SaveViewResp, error) {
+	// validate req
+	if req.View == nil {
+		return nil, errors.New("view cannot be blank")
+	}
+	name := strings.TrimSpace(req.View.Name)
+	if name == "" {
+		return nil, errors.New("view name cannot be blank")
+	}
+	if utf8.RuneCountInString(name) > maxViewNameLength {
+		return nil, fmt.Errorf("view name cannot be longer than %d characters", maxViewNameLength)
+	}
+	if findBuiltInView(name) != nil {
+		return nil, fmt.Errorf("view name %q is taken by a built-in view", name)
+	}
+	if _, ok := sortKeys[req.View.SortBy]; !ok {
+		return nil, fmt.Errorf("unknown sort option: %v", req.View.SortBy)
+	}
+	if _, err := query.Parse(req.View.Query, time.Now()); err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// limit the number of views, unless the view replaces one
+	getAllViewsResp, err := t.views.GetAllViews(ctx, &storage.GetAllViewsReq{UserID: userIDs[0]})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all views: %v", err)
+	}
+	replaces := slices.ContainsFunc(getAllViewsResp.Views, func(view storage.View) bool { return view.Name == name })
+	if !replaces && len(getAllViewsResp.Views) >= maxViews {
+		return nil, fmt.Errorf("unable to save more than %d views", maxViews)
+	}
+
+	// save view
+	_, err = t.views.SaveView(ctx, &storage.SaveViewReq{
+		View: storage.View{
+			UserID:     userIDs[0],
+			Name:       name,
+			Query:      req.View.Query,
+			SortBy:     req.View.SortBy.String(),
+			Descending: req.View.SortDirection == proto.SortDirection_DESCENDING,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save view: %v", err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "saved view", "name", name, "replaced", replaces)
+
+	return &proto.SaveViewResp{}, nil
+}
+
+// ListViews returns the built-in views followed by the views of the user.
+func (t *TodoServer) ListViews(ctx context.Context, req *proto.ListViewsReq) (*proto.ListViewsResp, error) {
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// get all views
+	getAllViewsResp, err := t.views.GetAllViews(ctx, &storage.GetAllViewsReq{UserID: userIDs[0]})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all views: %v", err)
+	}
+	views := make([]*proto.View, 0, len(builtInViews)+len(getAllViewsResp.Views))
+	for i := range builtInViews {
+		views = append(views, builtInViews[i].toProto())
+	}
+	for _, view := range getAllViewsResp.Views {
+		views = append(views, toProtoView(&view))
+	}
+
+	return &proto.ListViewsResp{
+		Views: views,
+	}, nil
+}
+
+// RunView returns a page of the tasks of a built-in or saved view.
+func (t *TodoServer) RunView(ctx context.Context, req *proto.RunViewReq) (*proto.RunViewResp, error) {
+	// validate req
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("view name cannot be blank")
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	// find view
+	builtIn := findBuiltInView(req.Name)
+	var view *proto.View
+	if builtIn != nil {
+		view = builtIn.toProto()
+	} else {
+		getViewResp, err := t.views.GetView(ctx, &storage.GetViewReq{
+			UserID: userIDs[0],
+			Name:   req.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get view: %v", err)
+		}
+		if getViewResp.View == nil {
+			return nil, fmt.Errorf("view %q does not exist", req.Name)
+		}
+		view = toProtoView(getViewResp.View)
+	}
+
+	// list the tasks of the view
+	getAllTasksReq, err := toStorageGetAllTasksReq(&proto.GetAllTasksReq{
+		SortBy:        view.SortBy,
+		SortDirection: view.SortDirection,
+		PageSize:      req.PageSize,
+		PageToken:     req.PageToken,
+		Query:         view.Query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	getAllTasksReq.UserID = userIDs[0]
+	if builtIn != nil && builtIn.blocked {
+		// parents may be any of the user's tasks, so every task is read to find the open ones
+		openTaskIDs, err := t.getOpenTaskIDs(ctx, userIDs[0])
+		if err != nil {
+			return nil, err
+		}
+		filter := storage.And{openTaskIDs}
+		if getAllTasksReq.Filter != nil {
+			filter = append(filter, getAllTasksReq.Filter)
+		}
+		getAllTasksReq.Filter = filter
+	}
+	tasks, nextPageToken, err := t.getTasksPage(ctx, getAllTasksReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RunViewResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// DeleteView deletes a saved view of the user.
+func (t *TodoServer) DeleteView(ctx context.Context, req *proto.DeleteViewReq) (*proto.DeleteViewResp, error) {
+	// validate req
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("view name cannot be blank")
+	}
+	if findBuiltInView(req.Name) != nil {
+		return nil, fmt.Errorf("built-in view %q cannot be deleted", req.Name)
+	}
+
+	// get userid from ctx
+	userIDs := metadata.ValueFromIncomingContext(ctx, common.USERID_METADATA_KEY)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("user id is not provided in metadata")
+	}
+
+	_, err := t.views.DeleteView(ctx, &storage.DeleteViewReq{
+		UserID: userIDs[0],
+		Name:   req.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete view: %v", err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "deleted view", "name", req.Name)
+
+	return &proto.DeleteViewResp{}, nil
+}
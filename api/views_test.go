@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+	"todo/common"
+	"todo/interfaces/storage"
+	storageMock "todo/interfaces/storage/mock"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_TodoServer_SaveView(t *testing.T) {
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	work := storage.View{UserID: common.TEST_USER_1_ID, Name: "Work", Query: "tag:work", SortBy: proto.SortBy_SORT_BY_DUE_DATE.String()}
+	var fullViews []storage.View
+	for i := 0; i < maxViews; i++ {
+		fullViews = append(fullViews, storage.View{UserID: common.TEST_USER_1_ID, Name: fmt.Sprintf("View %03d", i)})
+	}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		existing []storage.View
+		req      *proto.SaveViewReq
+		saveErr  error
+		want     []storage.View
+		wantErr  bool
+	}{
+		{
+			name: "saves view",
+			ctx:  userCtx,
+			req: &proto.SaveViewReq{View: &proto.View{
+				Name:          " Home ",
+				Query:         "tag:home -status:complete",
+				SortBy:        proto.SortBy_SORT_BY_TITLE,
+				SortDirection: proto.SortDirection_DESCENDING,
+				BuiltIn:       true,
+			}},
+			existing: []storage.View{work},
+			want: []storage.View{
+				work,
+				{UserID: common.TEST_USER_1_ID, Name: "Home", Query: "tag:home -status:complete", SortBy: "SORT_BY_TITLE", Descending: true},
+			},
+		},
+		{
+			name:     "replaces view of the same name",
+			ctx:      userCtx,
+			req:      &proto.SaveViewReq{View: &proto.View{Name: "Work", Query: "tag:work priority<=p1"}},
+			existing: []storage.View{work},
+			want:     []storage.View{{UserID: common.TEST_USER_1_ID, Name: "Work", Query: "tag:work priority<=p1", SortBy: "SORT_BY_UNSPECIFIED"}},
+		},
+		{
+			name:     "replaces view when the user has as many views as allowed",
+			ctx:      userCtx,
+			req:      &proto.SaveViewReq{View: &proto.View{Name: fullViews[0].Name}},
+			existing: fullViews,
+		},
+		{
+			name:     "too many views",
+			ctx:      userCtx,
+			req:      &proto.SaveViewReq{View: &proto.View{Name: "One more"}},
+			existing: fullViews,
+			wantErr:  true,
+		},
+		{
+			name:    "missing view",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{},
+			wantErr: true,
+		},
+		{
+			name:    "blank name",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{View: &proto.View{Name: " "}},
+			wantErr: true,
+		},
+		{
+			name:    "name of a built-in view",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{View: &proto.View{Name: "overdue"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid query",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{View: &proto.View{Name: "Work", Query: "color:red"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort option",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{View: &proto.View{Name: "Work", SortBy: proto.SortBy(100)}},
+			wantErr: true,
+		},
+		{
+			name:    "SaveView returns error",
+			ctx:     userCtx,
+			req:     &proto.SaveViewReq{View: &proto.View{Name: "Work"}},
+			saveErr: errors.New("test error"),
+			wantErr: true,
+		},
+		{
+			name:    "user id not provided",
+			ctx:     context.Background(),
+			req:     &proto.SaveViewReq{View: &proto.View{Name: "Work"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &storageMock.MockViewStore{
+				ViewsTable:  map[string][]storage.View{common.TEST_USER_1_ID: append([]storage.View(nil), tt.existing...)},
+				SaveViewErr: tt.saveErr,
+			}
+			tr := &TodoServer{views: views}
+			_, err := tr.SaveView(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.SaveView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !reflect.DeepEqual(views.ViewsTable[common.TEST_USER_1_ID], tt.want) {
+				t.Errorf("TodoServer.SaveView() stored %+v, want %+v", views.ViewsTable[common.TEST_USER_1_ID], tt.want)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_ListViews(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tr := &TodoServer{views: &storageMock.MockViewStore{ViewsTable: map[string][]storage.View{
+		common.TEST_USER_1_ID: {
+			{UserID: common.TEST_USER_1_ID, Name: "Work", Query: "tag:work", SortBy: "SORT_BY_DUE_DATE", Descending: true},
+			{UserID: common.TEST_USER_1_ID, Name: "Home", Query: "tag:home", SortBy: "SORT_BY_UNSPECIFIED"},
+		},
+	}}}
+	got, err := tr.ListViews(ctx, &proto.ListViewsReq{})
+	if err != nil {
+		t.Fatalf("TodoServer.ListViews() error = %v", err)
+	}
+	var names []string
+	for _, view := range got.Views {
+		names = append(names, view.Name)
+	}
+	if want := []string{"Today", "Overdue", "Upcoming 7 days", "Blocked", "Home", "Work"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("TodoServer.ListViews() names = %v, want %v", names, want)
+	}
+	if !got.Views[0].BuiltIn || got.Views[4].BuiltIn {
+		t.Errorf("TodoServer.ListViews() built in = %v and %v, want true and false", got.Views[0].BuiltIn, got.Views[4].BuiltIn)
+	}
+	wantWork := &proto.View{Name: "Work", Query: "tag:work", SortBy: proto.SortBy_SORT_BY_DUE_DATE, SortDirection: proto.SortDirection_DESCENDING}
+	if got := got.Views[5]; got.Name != wantWork.Name || got.Query != wantWork.Query || got.SortBy != wantWork.SortBy || got.SortDirection != wantWork.SortDirection {
+		t.Errorf("TodoServer.ListViews() saved view = %v, want %v", got, wantWork)
+	}
+
+	if _, err := tr.ListViews(context.Background(), &proto.ListViewsReq{}); err == nil {
+		t.Error("TodoServer.ListViews() without user id error = nil, want error")
+	}
+}
+
+func Test_TodoServer_RunView(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	dueIn := func(days int) int64 { return today.AddDate(0, 0, days).Unix() }
+	incomplete, complete := proto.Status_INCOMPLETE.String(), proto.Status_COMPLETE.String()
+	tasks := &storageMock.MockTaskStore{TasksTable: map[string][]storage.Task{
+		common.TEST_USER_1_ID: {
+			{TaskID: "today", Title: "b", Status: incomplete, DueDate: dueIn(0), Priority: "P1", Tags: []string{"work"}},
+			{TaskID: "overdue", Title: "a", Status: incomplete, DueDate: dueIn(-1), Priority: "P0"},
+			{TaskID: "upcoming", Title: "c", Status: incomplete, DueDate: dueIn(3), Priority: "P2", Tags: []string{"work"}},
+			{TaskID: "done", Title: "d", Status: complete, DueDate: dueIn(-2), Priority: "P0"},
+			{TaskID: "blocked", Title: "e", Status: incomplete, Parents: []string{"today"}, Priority: "P3"},
+			{TaskID: "unblocked", Title: "f", Status: incomplete, Parents: []string{"done"}, Priority: "P3"},
+		},
+	}}
+	views := &storageMock.MockViewStore{ViewsTable: map[string][]storage.View{
+		common.TEST_USER_1_ID: {{UserID: common.TEST_USER_1_ID, Name: "Work", Query: "tag:work", SortBy: "SORT_BY_TITLE", Descending: true}},
+	}}
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         *proto.RunViewReq
+		wantTaskIDs []string
+		wantNext    bool
+		wantErr     bool
+	}{
+		{name: "today ignoring case", ctx: ctx, req: &proto.RunViewReq{Name: "today"}, wantTaskIDs: []string{"today"}},
+		{name: "overdue", ctx: ctx, req: &proto.RunViewReq{Name: "Overdue"}, wantTaskIDs: []string{"overdue"}},
+		{name: "upcoming 7 days", ctx: ctx, req: &proto.RunViewReq{Name: "Upcoming 7 days"}, wantTaskIDs: []string{"today", "upcoming"}},
+		{name: "blocked by an open parent", ctx: ctx, req: &proto.RunViewReq{Name: "Blocked"}, wantTaskIDs: []string{"blocked"}},
+		{name: "saved view", ctx: ctx, req: &proto.RunViewReq{Name: "Work"}, wantTaskIDs: []string{"upcoming", "today"}},
+		{
+			name:        "first page",
+			ctx:         ctx,
+			req:         &proto.RunViewReq{Name: "Upcoming 7 days", PageSize: 1},
+			wantTaskIDs: []string{"today"},
+			wantNext:    true,
+		},
+		{name: "missing view", ctx: ctx, req: &proto.RunViewReq{Name: "work"}, wantErr: true},
+		{name: "blank name", ctx: ctx, req: &proto.RunViewReq{}, wantErr: true},
+		{name: "negative page size", ctx: ctx, req: &proto.RunViewReq{Name: "Today", PageSize: -1}, wantErr: true},
+		{name: "user id not provided", ctx: context.Background(), req: &proto.RunViewReq{Name: "Today"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &TodoServer{tasks: tasks, views: views}
+			got, err := tr.RunView(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.RunView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotTaskIDs := []string{}
+			for _, task := range got.Tasks {
+				gotTaskIDs = append(gotTaskIDs, task.Id)
+			}
+			if !reflect.DeepEqual(gotTaskIDs, tt.wantTaskIDs) {
+				t.Errorf("TodoServer.RunView() task ids = %v, want %v", gotTaskIDs, tt.wantTaskIDs)
+			}
+			if (got.NextPageToken != "") != tt.wantNext {
+				t.Errorf("TodoServer.RunView() next page token = %q, wantNext %v", got.NextPageToken, tt.wantNext)
+			}
+		})
+	}
+}
+
+func Test_TodoServer_DeleteView(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.USERID_METADATA_KEY, common.TEST_USER_1_ID))
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       *proto.DeleteViewReq
+		deleteErr error
+		wantViews int
+		wantErr   bool
+	}{
+		{name: "deletes view", ctx: ctx, req: &proto.DeleteViewReq{Name: "Work"}, wantViews: 0},
+		{name: "missing view", ctx: ctx, req: &proto.DeleteViewReq{Name: "Home"}, wantViews: 1},
+		{name: "built-in view", ctx: ctx, req: &proto.DeleteViewReq{Name: "Blocked"}, wantViews: 1, wantErr: true},
+		{name: "blank name", ctx: ctx, req: &proto.DeleteViewReq{}, wantViews: 1, wantErr: true},
+		{name: "DeleteView returns error", ctx: ctx, req: &proto.DeleteViewReq{Name: "Work"}, deleteErr: errors.New("test error"), wantViews: 1, wantErr: true},
+		{name: "user id not provided", ctx: context.Background(), req: &proto.DeleteViewReq{Name: "Work"}, wantViews: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &storageMock.MockViewStore{
+				ViewsTable:    map[string][]storage.View{common.TEST_USER_1_ID: {{UserID: common.TEST_USER_1_ID, Name: "Work"}}},
+				DeleteViewErr: tt.deleteErr,
+			}
+			tr := &TodoServer{views: views}
+			_, err := tr.DeleteView(tt.ctx, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TodoServer.DeleteView() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(views.ViewsTable[common.TEST_USER_1_ID]); got != tt.wantViews {
+				t.Errorf("TodoServer.DeleteView() left %d views, want %d", got, tt.wantViews)
+			}
+		})
+	}
+}
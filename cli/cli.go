@@ -1,30 +1,112 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"sort"
 	"todo/cli/interceptor"
+	"todo/common"
+	"todo/interfaces/cert_manager"
 	proto "todo/proto/gen/go/api"
+	"todo/tracing"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// usage prints the global flags and the available commands.
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <command> [command flags]\n\nflags:\n", os.Args[0])
+	flag.PrintDefaults()
+	fmt.Fprintln(flag.CommandLine.Output(), "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %-10s %s\n", name, commands[name].description)
+	}
+}
+
 func main() {
+	addr := flag.String("addr", ":9001", "address of the todo server")
+	caFile := flag.String("ca", "", "path of the PEM encoded CA to trust the server's certificate with instead of the system's CAs")
+	certFile := flag.String("cert", "", "path of the PEM encoded client certificate, for servers requiring mutual TLS")
+	keyFile := flag.String("key", "", "path of the PEM encoded client private key, for servers requiring mutual TLS")
+	serverName := flag.String("server-name", "", "name to verify the server's certificate against, instead of the host of -addr")
+	plaintext := flag.Bool("insecure", false, "connect without TLS, to servers run with tls insecure set, sending passwords and tokens in plaintext")
+	traceExporter := flag.String("trace", envOr(common.TRACE_EXPORTER_ENV_VAR, "none"), "where the command's spans are exported: none, stderr or otlp, to the OTEL_EXPORTER_OTLP_ENDPOINT collector")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		return
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		log.Fatalf("unknown command: %s", flag.Arg(0))
+	}
+
+	// set up tracing, propagating the command's trace to the server
+	ctx := context.Background()
+	shutdownTracing, err := tracing.Setup(ctx, "todo-cli", *traceExporter, "", 1)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %s", err)
+	}
+
 	// get interceptors
 	interceptor, err := interceptor.NewInterceptor()
 	if err != nil {
 		log.Fatalf("failed to get interceptors: %s", err)
 	}
 
+	// get transport credentials
+	creds := insecure.NewCredentials()
+	if !*plaintext {
+		tlsConfig, err := cert_manager.ClientTLSConfig(*caFile, *certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("failed to get tls config: %s", err)
+		}
+		tlsConfig.ServerName = *serverName
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
 	// create client
 	conn, err := grpc.NewClient(
-		":9001",
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		*addr,
+		grpc.WithTransportCredentials(creds),
 		grpc.WithUnaryInterceptor(interceptor.UnaryAuthMiddleware),
+		grpc.WithStreamInterceptor(interceptor.StreamAuthMiddleware),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		log.Fatalf("failed to create client conn: %s", err)
 	}
 	defer conn.Close()
-	_ = proto.NewTodoClient(conn)
+
+	// run command in a span, exporting it before exiting
+	ctx, span := tracing.Tracer.Start(ctx, "todo "+flag.Arg(0))
+	err = cmd.run(ctx, proto.NewTodoClient(conn), flag.Args()[1:])
+	tracing.RecordError(span, err)
+	span.End()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("failed to export spans: %s", err)
+	}
+	if err != nil {
+		log.Fatalf("%s failed: %s", flag.Arg(0), err)
+	}
+}
+
+// envOr returns the value of the environment variable, or fallback if it is unset or empty.
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
 }
@@ -2,9 +2,11 @@ package main
 
 import (
 	"os/exec"
+	"reflect"
 	"testing"
 	"time"
 	"todo/common"
+	proto "todo/proto/gen/go/api"
 
 	"github.com/golang-jwt/jwt"
 )
@@ -38,6 +40,111 @@ func Test_CLI_Integration(t *testing.T) {
 			commandArgs: []string{},
 			wantErr:     false,
 		},
+		{
+			name:        "unknown command",
+			commandArgs: []string{"unknown"},
+			wantErr:     true,
+		},
+		{
+			name:        "add with unknown priority",
+			commandArgs: []string{"add", "-title", "task", "-priority", "P9"},
+			wantErr:     true,
+		},
+		{
+			name:        "list with unknown sort option",
+			commandArgs: []string{"list", "-sort", "color"},
+			wantErr:     true,
+		},
+		{
+			name:        "view without name",
+			commandArgs: []string{"view"},
+			wantErr:     true,
+		},
+		{
+			name:        "view query without save",
+			commandArgs: []string{"view", "-q", "tag:work", "Work"},
+			wantErr:     true,
+		},
+		{
+			name:        "rename tag without new tag",
+			commandArgs: []string{"tags", "-rename", "wrk"},
+			wantErr:     true,
+		},
+		{
+			name:        "rename and merge tags together",
+			commandArgs: []string{"tags", "-rename", "wrk", "-merge", "job", "-to", "work"},
+			wantErr:     true,
+		},
+		{
+			name:        "project without action",
+			commandArgs: []string{"project", "Work"},
+			wantErr:     true,
+		},
+		{
+			name:        "project color without add",
+			commandArgs: []string{"project", "-archive", "-color", "#ffffff", "1b2c"},
+			wantErr:     true,
+		},
+		{
+			name:        "move without tasks",
+			commandArgs: []string{"move", "-project", "1b2c"},
+			wantErr:     true,
+		},
+		{
+			name:        "assign without task",
+			commandArgs: []string{"assign", "-to", "user"},
+			wantErr:     true,
+		},
+		{
+			name:        "assign many tasks",
+			commandArgs: []string{"assign", "-to", "user", "1a2b", "1b2c"},
+			wantErr:     true,
+		},
+		{
+			name:        "members without project",
+			commandArgs: []string{"members"},
+			wantErr:     true,
+		},
+		{
+			name:        "member without project",
+			commandArgs: []string{"member", "-invite", "-role", "viewer", "user"},
+			wantErr:     true,
+		},
+		{
+			name:        "invite member without role",
+			commandArgs: []string{"member", "-project", "1b2c", "-invite", "user"},
+			wantErr:     true,
+		},
+		{
+			name:        "invite member with unknown role",
+			commandArgs: []string{"member", "-project", "1b2c", "-invite", "-role", "admin", "user"},
+			wantErr:     true,
+		},
+		{
+			name:        "remove member with role",
+			commandArgs: []string{"member", "-project", "1b2c", "-remove", "-role", "viewer", "user"},
+			wantErr:     true,
+		},
+		{
+			name:        "audit without project",
+			commandArgs: []string{"audit"},
+			wantErr:     true,
+		},
+		{
+			name:        "export with unknown format",
+			commandArgs: []string{"export", "-format", "xml"},
+			wantErr:     true,
+		},
+		{
+			name:        "import without file",
+			commandArgs: []string{"import"},
+			wantErr:     true,
+		},
+		{
+			name:        "import missing calendar",
+			commandArgs: []string{"import", "missing.ics"},
+			wantErr:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,3 +157,81 @@ func Test_CLI_Integration(t *testing.T) {
 		})
 	}
 }
+
+func Test_parsePriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    proto.Priority
+		wantErr bool
+	}{
+		{name: "empty", s: "", want: proto.Priority_PRIORITY_UNSPECIFIED},
+		{name: "upper case", s: "P0", want: proto.Priority_P0},
+		{name: "lower case", s: "p3", want: proto.Priority_P3},
+		{name: "unknown", s: "P4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePriority(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parsePriority() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parsePriority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parsePriorities(t *testing.T) {
+	got, err := parsePriorities("P0, p1,,")
+	if err != nil {
+		t.Fatalf("parsePriorities() error = %v", err)
+	}
+	want := []proto.Priority{proto.Priority_P0, proto.Priority_P1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePriorities() = %v, want %v", got, want)
+	}
+	if _, err := parsePriorities("P0,urgent"); err == nil {
+		t.Error("parsePriorities() expected error for unknown priority")
+	}
+}
+
+func Test_effortMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		effort  time.Duration
+		want    uint32
+		wantErr bool
+	}{
+		{name: "no effort", effort: 0, want: 0},
+		{name: "whole minutes", effort: 2 * time.Hour, want: 120},
+		{name: "rounds up", effort: 90 * time.Second, want: 2},
+		{name: "negative", effort: -time.Minute, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := effortMinutes(tt.effort)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("effortMinutes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("effortMinutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSortBy(t *testing.T) {
+	if got, err := parseSortBy("priority"); err != nil || got != proto.SortBy_SORT_BY_PRIORITY {
+		t.Errorf("parseSortBy() = %v, %v, want %v", got, err, proto.SortBy_SORT_BY_PRIORITY)
+	}
+	if got, err := parseSortBy(""); err != nil || got != proto.SortBy_SORT_BY_UNSPECIFIED {
+		t.Errorf("parseSortBy() = %v, %v, want %v", got, err, proto.SortBy_SORT_BY_UNSPECIFIED)
+	}
+	if _, err := parseSortBy("color"); err == nil {
+		t.Error("parseSortBy() expected error for unknown sort option")
+	}
+}
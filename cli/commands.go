@@ -0,0 +1,1002 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	proto "todo/proto/gen/go/api"
+)
+
+// dateLayout is the layout of dates accepted and printed by the CLI.
+const dateLayout = "2006-01-02"
+
+// command is a subcommand of the CLI.
+type command struct {
+	description string
+	run         func(ctx context.Context, client proto.TodoClient, args []string) error
+}
+
+var commands = map[string]command{
+	"add":      {description: "add a task", run: runAdd},
+	"list":     {description: "list tasks", run: runList},
+	"search":   {description: "search the titles and descriptions of your own tasks, most relevant first", run: runSearch},
+	"views":    {description: "list built-in and saved views", run: runViews},
+	"view":     {description: "list the tasks of a view by name, or save or delete a view", run: runView},
+	"tags":     {description: "list tags with their number of tasks, or rename or merge tags", run: runTags},
+	"projects": {description: "list projects", run: runProjects},
+	"project":  {description: "add, archive, unarchive or delete a project", run: runProject},
+	"move":     {description: "move tasks into a project, or out of their project", run: runMove},
+	"assign":   {description: "assign a task to a member of its project, or unassign it", run: runAssign},
+	"assigned": {description: "list tasks assigned to you, soonest due first", run: runAssigned},
+	"members":  {description: "list the members of a project", run: runMembers},
+	"member":   {description: "invite a user to a project, change their role or remove them", run: runMember},
+	"invites":  {description: "list invitations to projects, or accept one", run: runInvites},
+	"audit":    {description: "list who changed what in a project, newest first", run: runAudit},
+	"export":   {description: "export all tasks as json, csv or todo.txt, or tasks and events as ics", run: runExport},
+	"import":   {description: "import tasks exported as json, csv or todo.txt, or tasks and events as ics", run: runImport},
+	"feed":     {description: "issue a new calendar feed url, revoking the previous one", run: runFeed},
+}
+
+// importBatchSize is the number of tasks sent in each message of an import.
+const importBatchSize = 100
+
+// calendarChunkSize is the number of bytes of a calendar sent in each message of an import.
+const calendarChunkSize = 64 * 1024
+
+// sortOptions maps the names accepted by the -sort flag to their proto sort option.
+var sortOptions = map[string]proto.SortBy{
+	"due_date":   proto.SortBy_SORT_BY_DUE_DATE,
+	"created_at": proto.SortBy_SORT_BY_CREATED_AT,
+	"updated_at": proto.SortBy_SORT_BY_UPDATED_AT,
+	"title":      proto.SortBy_SORT_BY_TITLE,
+	"priority":   proto.SortBy_SORT_BY_PRIORITY,
+}
+
+// parsePriority converts a priority such as "P1" into its proto representation.
+// An empty string is an unspecified priority.
+func parsePriority(s string) (proto.Priority, error) {
+	if s == "" {
+		return proto.Priority_PRIORITY_UNSPECIFIED, nil
+	}
+	priority, ok := proto.Priority_value[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown priority %q: expected P0, P1, P2 or P3", s)
+	}
+	return proto.Priority(priority), nil
+}
+
+// parsePriorities converts a comma separated list of priorities into their proto representations.
+func parsePriorities(s string) ([]proto.Priority, error) {
+	var priorities []proto.Priority
+	for _, name := range splitList(s) {
+		priority, err := parsePriority(name)
+		if err != nil {
+			return nil, err
+		}
+		priorities = append(priorities, priority)
+	}
+	return priorities, nil
+}
+
+// parseSortBy converts the name of a sort option into its proto representation.
+// An empty string is an unspecified sort option.
+func parseSortBy(s string) (proto.SortBy, error) {
+	if s == "" {
+		return proto.SortBy_SORT_BY_UNSPECIFIED, nil
+	}
+	sortBy, ok := sortOptions[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown sort option %q", s)
+	}
+	return sortBy, nil
+}
+
+// effortMinutes rounds the given effort up to whole minutes.
+func effortMinutes(effort time.Duration) (uint32, error) {
+	if effort < 0 {
+		return 0, fmt.Errorf("effort cannot be negative: %v", effort)
+	}
+	minutes := math.Ceil(effort.Minutes())
+	if minutes > math.MaxUint32 {
+		return 0, fmt.Errorf("effort is too large: %v", effort)
+	}
+	return uint32(minutes), nil
+}
+
+// parseDate converts a date formatted as YYYY-MM-DD into a unix timestamp in local time.
+// An empty string is no date.
+func parseDate(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	date, err := time.ParseInLocation(dateLayout, s, time.Local)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", s)
+	}
+	return date.Unix(), nil
+}
+
+// formatDate converts a unix timestamp into a date, or "-" when there is no date.
+func formatDate(timestamp int64) string {
+	if timestamp == 0 {
+		return "-"
+	}
+	return time.Unix(timestamp, 0).Format(dateLayout)
+}
+
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+func runAdd(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	title := fs.String("title", "", "title of the task")
+	description := fs.String("description", "", "description of the task")
+	tags := fs.String("tags", "", "comma separated tags of the task")
+	due := fs.String("due", "", "due date of the task formatted as YYYY-MM-DD")
+	priority := fs.String("priority", "", "priority of the task: P0, P1, P2 or P3")
+	effort := fs.Duration("effort", 0, "estimated effort of the task, e.g. 30m or 2h")
+	project := fs.String("project", "", "id of the project of the task")
+	assignee := fs.String("assignee", "", "id of the user the task is assigned to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	protoPriority, err := parsePriority(*priority)
+	if err != nil {
+		return err
+	}
+	minutes, err := effortMinutes(*effort)
+	if err != nil {
+		return err
+	}
+	dueDate, err := parseDate(*due)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.AddTask(ctx, &proto.AddTaskReq{
+		Title:         *title,
+		Description:   *description,
+		Tags:          splitList(*tags),
+		DueDate:       dueDate,
+		Priority:      protoPriority,
+		EffortMinutes: minutes,
+		ProjectId:     *project,
+		AssigneeId:    *assignee,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(resp.Id)
+	return nil
+}
+
+func runList(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	sortBy := fs.String("sort", "", "sort tasks by due_date, created_at, updated_at, title or priority")
+	descending := fs.Bool("desc", false, "sort tasks in descending order")
+	priorities := fs.String("priority", "", "comma separated priorities of the tasks to list")
+	maxEffort := fs.Duration("max-effort", 0, "list tasks estimated to take no longer than this, e.g. 30m or 2h")
+	query := fs.String("q", "", `list tasks matching a query, e.g. 'status:incomplete tag:work due<7d -tag:someday "release notes"'`)
+	project := fs.String("project", "", "id of the project of the tasks to list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	protoSortBy, err := parseSortBy(*sortBy)
+	if err != nil {
+		return err
+	}
+	protoPriorities, err := parsePriorities(*priorities)
+	if err != nil {
+		return err
+	}
+	maxEffortMinutes, err := effortMinutes(*maxEffort)
+	if err != nil {
+		return err
+	}
+	direction := proto.SortDirection_ASCENDING
+	if *descending {
+		direction = proto.SortDirection_DESCENDING
+	}
+
+	resp, err := client.GetAllTasks(ctx, &proto.GetAllTasksReq{
+		SortBy:           protoSortBy,
+		SortDirection:    direction,
+		Priorities:       protoPriorities,
+		MaxEffortMinutes: maxEffortMinutes,
+		Query:            *query,
+		ProjectId:        *project,
+	})
+	if err != nil {
+		return err
+	}
+	printTasks(resp.Tasks)
+	return nil
+}
+
+func runSearch(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "maximum number of tasks to list, by default 20")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: search [-limit n] <words>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		return errors.New("words to search for are required")
+	}
+	if *limit < 0 || *limit > math.MaxInt32 {
+		return fmt.Errorf("invalid limit %d", *limit)
+	}
+
+	resp, err := client.SearchTasks(ctx, &proto.SearchTasksReq{
+		Query: query,
+		Limit: int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+	printTasks(resp.Tasks)
+	return nil
+}
+
+func runViews(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("views", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := client.ListViews(ctx, &proto.ListViewsReq{})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tQUERY\tSORT\tBUILT-IN")
+	for _, view := range resp.Views {
+		sort := "-"
+		for name, option := range sortOptions {
+			if option == view.SortBy {
+				sort = name
+			}
+		}
+		if sort != "-" && view.SortDirection == proto.SortDirection_DESCENDING {
+			sort += " desc"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", view.Name, view.Query, sort, view.BuiltIn)
+	}
+	w.Flush()
+	return nil
+}
+
+func runView(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("view", flag.ContinueOnError)
+	save := fs.Bool("save", false, "save the view, replacing the view of the same name")
+	deleteView := fs.Bool("delete", false, "delete the view")
+	query := fs.String("q", "", "query of the saved view, e.g. 'tag:work -status:complete'")
+	sortBy := fs.String("sort", "", "sort the tasks of the saved view by due_date, created_at, updated_at, title or priority")
+	descending := fs.Bool("desc", false, "sort the tasks of the saved view in descending order")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: view <name>\n       view -save [-q query] [-sort key] [-desc] <name>\n       view -delete <name>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	name := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(name) == "" {
+		return errors.New("view name is required")
+	}
+	if *save && *deleteView {
+		return errors.New("-save and -delete cannot be used together")
+	}
+	if !*save && (*query != "" || *sortBy != "" || *descending) {
+		return errors.New("-q, -sort and -desc can only be used with -save")
+	}
+	protoSortBy, err := parseSortBy(*sortBy)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *save:
+		direction := proto.SortDirection_ASCENDING
+		if *descending {
+			direction = proto.SortDirection_DESCENDING
+		}
+		_, err := client.SaveView(ctx, &proto.SaveViewReq{View: &proto.View{
+			Name:          name,
+			Query:         *query,
+			SortBy:        protoSortBy,
+			SortDirection: direction,
+		}})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("saved view %q\n", name)
+	case *deleteView:
+		if _, err := client.DeleteView(ctx, &proto.DeleteViewReq{Name: name}); err != nil {
+			return err
+		}
+		fmt.Printf("deleted view %q\n", name)
+	default:
+		resp, err := client.RunView(ctx, &proto.RunViewReq{Name: name})
+		if err != nil {
+			return err
+		}
+		printTasks(resp.Tasks)
+	}
+	return nil
+}
+
+func runTags(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("tags", flag.ContinueOnError)
+	rename := fs.String("rename", "", "tag to rename on every task")
+	merge := fs.String("merge", "", "comma separated tags to merge into a single tag on every task")
+	to := fs.String("to", "", "new tag of -rename, or tag that -merge merges into")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: tags\n       tags -rename tag -to new_tag\n       tags -merge tag,... -to tag")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %q", fs.Args())
+	}
+	if *rename != "" && *merge != "" {
+		return errors.New("-rename and -merge cannot be used together")
+	}
+	if (*rename != "" || *merge != "") != (*to != "") {
+		return errors.New("-to is required by -rename and -merge, and only used with them")
+	}
+
+	switch {
+	case *rename != "":
+		resp, err := client.RenameTag(ctx, &proto.RenameTagReq{Tag: *rename, NewTag: *to})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("renamed tag %q on %d tasks\n", *rename, resp.UpdatedTasks)
+	case *merge != "":
+		resp, err := client.MergeTags(ctx, &proto.MergeTagsReq{Tags: splitList(*merge), Target: *to})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("merged tags into %q on %d tasks\n", *to, resp.UpdatedTasks)
+	default:
+		resp, err := client.ListTags(ctx, &proto.ListTagsReq{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TAG\tTASKS")
+		for _, tag := range resp.Tags {
+			fmt.Fprintf(w, "%s\t%d\n", tag.Tag, tag.TaskCount)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+func runProjects(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("projects", flag.ContinueOnError)
+	all := fs.Bool("all", false, "list archived projects as well")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := client.ListProjects(ctx, &proto.ListProjectsReq{IncludeArchived: *all})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCOLOR\tARCHIVED\tROLE\tDESCRIPTION")
+	for _, project := range resp.Projects {
+		color := "-"
+		if project.Color != "" {
+			color = project.Color
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%s\n", project.Id, project.Name, color, project.Archived, formatRole(project.Role), project.Description)
+	}
+	w.Flush()
+	return nil
+}
+
+func runProject(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("project", flag.ContinueOnError)
+	add := fs.Bool("add", false, "add a project with the given name")
+	description := fs.String("description", "", "description of the added project")
+	color := fs.String("color", "", "color of the added project formatted as #rrggbb")
+	archive := fs.Bool("archive", false, "archive the project with the given id, so tasks can no longer be added to it")
+	unarchive := fs.Bool("unarchive", false, "unarchive the project with the given id")
+	deleteProject := fs.Bool("delete", false, "delete the project with the given id, moving its tasks out of it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: project -add [-description text] [-color #rrggbb] <name>\n       project -archive <id>\n       project -unarchive <id>\n       project -delete <id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	actions := 0
+	for _, action := range []bool{*add, *archive, *unarchive, *deleteProject} {
+		if action {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return errors.New("exactly one of -add, -archive, -unarchive and -delete is required")
+	}
+	if !*add && (*description != "" || *color != "") {
+		return errors.New("-description and -color can only be used with -add")
+	}
+	arg := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(arg) == "" {
+		return errors.New("project name or id is required")
+	}
+
+	switch {
+	case *add:
+		resp, err := client.AddProject(ctx, &proto.AddProjectReq{
+			Name:        arg,
+			Description: *description,
+			Color:       *color,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println(resp.Id)
+	case *archive, *unarchive:
+		resp, err := client.GetProject(ctx, &proto.GetProjectReq{Id: arg})
+		if err != nil {
+			return err
+		}
+		resp.Project.Archived = *archive
+		if _, err := client.UpdateProject(ctx, &proto.UpdateProjectReq{Project: resp.Project}); err != nil {
+			return err
+		}
+		if *archive {
+			fmt.Printf("archived project %q\n", resp.Project.Name)
+		} else {
+			fmt.Printf("unarchived project %q\n", resp.Project.Name)
+		}
+	case *deleteProject:
+		if _, err := client.DeleteProject(ctx, &proto.DeleteProjectReq{Id: arg}); err != nil {
+			return err
+		}
+		fmt.Printf("deleted project %s\n", arg)
+	}
+	return nil
+}
+
+func runMove(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("move", flag.ContinueOnError)
+	project := fs.String("project", "", "id of the project to move the tasks into, or empty to move them out of their project")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: move [-project id] <task id>...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if fs.NArg() == 0 {
+		return errors.New("ids of the tasks to move are required")
+	}
+
+	if _, err := client.MoveTasks(ctx, &proto.MoveTasksReq{TaskIds: fs.Args(), ProjectId: *project}); err != nil {
+		return err
+	}
+	if *project == "" {
+		fmt.Printf("moved %d tasks out of their project\n", fs.NArg())
+	} else {
+		fmt.Printf("moved %d tasks into project %s\n", fs.NArg(), *project)
+	}
+	return nil
+}
+
+func runAssign(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("assign", flag.ContinueOnError)
+	project := fs.String("project", "", "id of the project of the task, if shared with you")
+	to := fs.String("to", "", "id of the user to assign the task to, or empty to unassign it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: assign [-project id] [-to user id] <task id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if fs.NArg() != 1 {
+		return errors.New("task id is required")
+	}
+
+	// tasks are updated whole, so the assignee is set on the current task
+	resp, err := client.GetTask(ctx, &proto.GetTaskReq{Id: fs.Arg(0), ProjectId: *project})
+	if err != nil {
+		return err
+	}
+	task := resp.Task
+	task.AssigneeId = *to
+	if _, err := client.UpdateTask(ctx, &proto.UpdateTaskReq{Task: task}); err != nil {
+		return err
+	}
+	if *to == "" {
+		fmt.Printf("unassigned task %s\n", task.Id)
+	} else {
+		fmt.Printf("assigned task %s to %s\n", task.Id, *to)
+	}
+	return nil
+}
+
+func runAssigned(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("assigned", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := client.ListAssignedToMe(ctx, &proto.ListAssignedToMeReq{})
+	if err != nil {
+		return err
+	}
+	printTasks(resp.Tasks)
+	return nil
+}
+
+// parseRole converts a role such as "editor" into its proto representation.
+func parseRole(s string) (proto.Role, error) {
+	role, ok := proto.Role_value[strings.ToUpper(s)]
+	if !ok || proto.Role(role) == proto.Role_ROLE_UNSPECIFIED {
+		return proto.Role_ROLE_UNSPECIFIED, fmt.Errorf("unknown role %q, expected viewer, editor or owner", s)
+	}
+	return proto.Role(role), nil
+}
+
+// formatRole formats a role as accepted by parseRole.
+func formatRole(role proto.Role) string {
+	return strings.ToLower(role.String())
+}
+
+func runMembers(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("members", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: members <project id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if fs.NArg() != 1 {
+		return errors.New("project id is required")
+	}
+
+	resp, err := client.ListMembers(ctx, &proto.ListMembersReq{ProjectId: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tROLE\tACCEPTED\tINVITED BY")
+	for _, member := range resp.Members {
+		invitedBy := "-"
+		if member.InvitedBy != "" {
+			invitedBy = member.InvitedBy
+		}
+		fmt.Fprintf(w, "%s\t%s\t%v\t%s\n", member.UserId, formatRole(member.Role), member.Accepted, invitedBy)
+	}
+	w.Flush()
+	return nil
+}
+
+func runMember(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("member", flag.ContinueOnError)
+	project := fs.String("project", "", "id of the project")
+	invite := fs.Bool("invite", false, "invite the user with the given id to the project with -role")
+	role := fs.String("role", "", "role of the user: viewer, editor or owner")
+	remove := fs.Bool("remove", false, "remove the user with the given id from the project, or decline or leave it when it is your own id")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: member -project id -invite -role role <user id>\n       member -project id -role role <user id>\n       member -project id -remove <user id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if *project == "" {
+		return errors.New("-project is required")
+	}
+	if fs.NArg() != 1 {
+		return errors.New("user id is required")
+	}
+	if *invite && *remove {
+		return errors.New("-invite and -remove cannot be used together")
+	}
+	if *remove && *role != "" {
+		return errors.New("-role cannot be used with -remove")
+	}
+	if !*remove && *role == "" {
+		return errors.New("-role is required unless removing the user")
+	}
+	userID := fs.Arg(0)
+
+	if *remove {
+		if _, err := client.RemoveMember(ctx, &proto.RemoveMemberReq{ProjectId: *project, UserId: userID}); err != nil {
+			return err
+		}
+		fmt.Printf("removed %s from project %s\n", userID, *project)
+		return nil
+	}
+	r, err := parseRole(*role)
+	if err != nil {
+		return err
+	}
+	if *invite {
+		if _, err := client.InviteMember(ctx, &proto.InviteMemberReq{ProjectId: *project, UserId: userID, Role: r}); err != nil {
+			return err
+		}
+		fmt.Printf("invited %s to project %s as %s\n", userID, *project, formatRole(r))
+		return nil
+	}
+	if _, err := client.UpdateMemberRole(ctx, &proto.UpdateMemberRoleReq{ProjectId: *project, UserId: userID, Role: r}); err != nil {
+		return err
+	}
+	fmt.Printf("%s is now %s of project %s\n", userID, formatRole(r), *project)
+	return nil
+}
+
+func runInvites(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("invites", flag.ContinueOnError)
+	accept := fs.String("accept", "", "id of the project to accept the invitation to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *accept != "" {
+		resp, err := client.AcceptInvitation(ctx, &proto.AcceptInvitationReq{ProjectId: *accept})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("joined project %q as %s\n", resp.Project.Name, formatRole(resp.Project.Role))
+		return nil
+	}
+
+	resp, err := client.ListInvitations(ctx, &proto.ListInvitationsReq{})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tNAME\tROLE\tINVITED BY")
+	for _, invitation := range resp.Invitations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", invitation.ProjectId, invitation.ProjectName, formatRole(invitation.Role), invitation.InvitedBy)
+	}
+	w.Flush()
+	return nil
+}
+
+func runAudit(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	limit := fs.Int("limit", 0, "maximum number of entries to list, 50 when 0")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: audit [-limit n] <project id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	if fs.NArg() != 1 {
+		return errors.New("project id is required")
+	}
+
+	resp, err := client.ListAuditEntries(ctx, &proto.ListAuditEntriesReq{ProjectId: fs.Arg(0), Limit: int32(*limit)})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tACTION\tTASK\tDETAILS")
+	for _, entry := range resp.Entries {
+		task := "-"
+		if entry.TaskId != "" {
+			task = entry.TaskId
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", time.Unix(entry.CreatedAt, 0).Format(time.DateTime), entry.UserId, entry.Action, task, entry.Details)
+	}
+	w.Flush()
+	return nil
+}
+
+// printTasks prints the tasks as a table.
+func printTasks(tasks []*proto.Task) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tPRIORITY\tEFFORT\tDUE\tASSIGNEE")
+	for _, task := range tasks {
+		priority := "-"
+		if task.Priority != proto.Priority_PRIORITY_UNSPECIFIED {
+			priority = task.Priority.String()
+		}
+		effort := "-"
+		if task.EffortMinutes > 0 {
+			effort = (time.Duration(task.EffortMinutes) * time.Minute).String()
+		}
+		assignee := "-"
+		if task.AssigneeId != "" {
+			assignee = task.AssigneeId
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", task.Id, task.Title, task.Status, priority, effort, formatDate(task.DueDate), assignee)
+	}
+	w.Flush()
+}
+
+func runExport(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	formatName := fs.String("format", "", "format of the export: json, csv, todotxt or ics, by default inferred from -o")
+	output := fs.String("o", "", "path of the file to export to, by default stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// validate flags
+	var format taskFormat
+	isCalendar := resolveFormatName(*formatName, *output) == calendarFormat
+	if !isCalendar {
+		var err error
+		if format, err = parseTaskFormat(*formatName, *output); err != nil {
+			return err
+		}
+	}
+
+	// open output
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %v", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if isCalendar {
+		if err := exportCalendar(ctx, client, w); err != nil {
+			return err
+		}
+		if *output != "" {
+			return w.Close()
+		}
+		return nil
+	}
+
+	stream, err := client.ExportTasks(ctx, &proto.ExportTasksReq{})
+	if err != nil {
+		return err
+	}
+	encoder := format.newEncoder(w)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, task := range resp.Tasks {
+			if err := encoder.Encode(task); err != nil {
+				return fmt.Errorf("failed to encode task %s: %v", task.Id, err)
+			}
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode tasks: %v", err)
+	}
+	if *output != "" {
+		return w.Close()
+	}
+	return nil
+}
+
+func runImport(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	formatName := fs.String("format", "", "format of the file: json, csv, todotxt or ics, by default inferred from its extension")
+	dryRun := fs.Bool("dry-run", false, "check the tasks for conflicts without importing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected the path of the file to import")
+	}
+	path := fs.Arg(0)
+
+	// validate flags
+	isCalendar := resolveFormatName(*formatName, path) == calendarFormat
+	var format taskFormat
+	if !isCalendar {
+		var err error
+		if format, err = parseTaskFormat(*formatName, path); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	if isCalendar {
+		return importCalendar(ctx, client, f, *dryRun)
+	}
+
+	// decode tasks
+	tasks, err := format.decode(f)
+	if err != nil {
+		return err
+	}
+
+	// send tasks in batches
+	stream, err := client.ImportTasks(ctx)
+	if err != nil {
+		return err
+	}
+	for start := 0; start == 0 || start < len(tasks); start += importBatchSize {
+		end := min(start+importBatchSize, len(tasks))
+		if err := stream.Send(&proto.ImportTasksReq{DryRun: *dryRun, Tasks: tasks[start:end]}); err != nil {
+			return err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Conflicts) > 0 {
+		printConflicts(resp.Conflicts)
+		return fmt.Errorf("%d conflicts, no tasks were imported", len(resp.Conflicts))
+	}
+	if *dryRun {
+		fmt.Printf("would import %d tasks\n", resp.Imported)
+		return nil
+	}
+	fmt.Printf("imported %d tasks\n", resp.Imported)
+	return nil
+}
+
+// printConflicts prints the conflicts of an import as a table.
+func printConflicts(conflicts []*proto.ImportConflict) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tREASON")
+	for _, conflict := range conflicts {
+		id := conflict.TaskId
+		if id == "" {
+			id = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", id, conflict.Title, conflict.Reason)
+	}
+	w.Flush()
+}
+
+// exportCalendar writes every task and event of the user to w as an iCalendar file.
+func exportCalendar(ctx context.Context, client proto.TodoClient, w io.Writer) error {
+	stream, err := client.ExportCalendar(ctx, &proto.ExportCalendarReq{})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(resp.Data); err != nil {
+			return fmt.Errorf("failed to write calendar: %v", err)
+		}
+	}
+}
+
+// importCalendar sends the iCalendar file read from r to be imported in chunks.
+func importCalendar(ctx context.Context, client proto.TodoClient, r io.Reader, dryRun bool) error {
+	stream, err := client.ImportCalendar(ctx)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, calendarChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := stream.Send(&proto.ImportCalendarReq{DryRun: dryRun, Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read calendar: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Conflicts) > 0 {
+		printCalendarConflicts(resp.Conflicts)
+		return fmt.Errorf("%d conflicts, nothing was imported", len(resp.Conflicts))
+	}
+	if dryRun {
+		fmt.Printf("would import %d tasks and %d events\n", resp.ImportedTasks, resp.ImportedEvents)
+		return nil
+	}
+	fmt.Printf("imported %d tasks and %d events\n", resp.ImportedTasks, resp.ImportedEvents)
+	return nil
+}
+
+// printCalendarConflicts prints the conflicts of a calendar import as a table.
+func printCalendarConflicts(conflicts []*proto.CalendarConflict) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tUID\tSUMMARY\tREASON")
+	for _, conflict := range conflicts {
+		uid := conflict.Uid
+		if uid == "" {
+			uid = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", conflict.Component, uid, conflict.Summary, conflict.Reason)
+	}
+	w.Flush()
+}
+
+func runFeed(ctx context.Context, client proto.TodoClient, args []string) error {
+	fs := flag.NewFlagSet("feed", flag.ContinueOnError)
+	revoke := fs.Bool("revoke", false, "disable the feed instead of issuing a new url")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := client.RegenerateFeedToken(ctx, &proto.RegenerateFeedTokenReq{Revoke: *revoke})
+	if err != nil {
+		return err
+	}
+	switch {
+	case *revoke:
+		fmt.Println("revoked calendar feed")
+	case resp.Url != "":
+		fmt.Println(resp.Url)
+	default:
+		// the server does not know its public url
+		fmt.Printf("/feed/%s.ics\n", resp.Token)
+	}
+	return nil
+}
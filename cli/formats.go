@@ -0,0 +1,478 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	proto "todo/proto/gen/go/api"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// taskEncoder writes tasks in a format, one at a time.
+type taskEncoder interface {
+	Encode(task *proto.Task) error
+	// Close writes anything that follows the last task.
+	Close() error
+}
+
+// taskFormat is a format tasks are exported and imported in.
+type taskFormat struct {
+	newEncoder func(w io.Writer) taskEncoder
+	decode     func(r io.Reader) ([]*proto.Task, error)
+}
+
+// taskFormats are the formats accepted by the -format flags of export and import.
+// JSON holds every field of a task; CSV leaves out checklists, and todo.txt also leaves out descriptions.
+var taskFormats = map[string]taskFormat{
+	"json":    {newEncoder: newJSONEncoder, decode: decodeJSON},
+	"csv":     {newEncoder: newCSVEncoder, decode: decodeCSV},
+	"todotxt": {newEncoder: newTodoTxtEncoder, decode: decodeTodoTxt},
+}
+
+// calendarFormat is the name of the iCalendar format, which the server encodes and decodes
+// since calendars hold events as well as tasks.
+const calendarFormat = "ics"
+
+// resolveFormatName returns name, or the name of the format of the file's extension if name is empty.
+func resolveFormatName(name, path string) string {
+	if name != "" {
+		return name
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".txt":
+		return "todotxt"
+	case ".ics":
+		return calendarFormat
+	default:
+		return "json"
+	}
+}
+
+// parseTaskFormat returns the named format, or the format of the file's extension if name is empty.
+func parseTaskFormat(name, path string) (taskFormat, error) {
+	name = resolveFormatName(name, path)
+	format, ok := taskFormats[name]
+	if !ok {
+		return taskFormat{}, fmt.Errorf("unknown format %q: expected json, csv, todotxt or ics", name)
+	}
+	return format, nil
+}
+
+// formatOptionalDate converts a unix timestamp into a date, or an empty string when there is no date.
+func formatOptionalDate(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return formatDate(timestamp)
+}
+
+// jsonMarshalOptions name the fields of exported tasks as in the proto definition, such as due_date.
+var jsonMarshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// jsonEncoder writes tasks as a JSON array.
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONEncoder(w io.Writer) taskEncoder { return &jsonEncoder{w: w} }
+
+func (e *jsonEncoder) Encode(task *proto.Task) error {
+	b, err := jsonMarshalOptions.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task %s: %v", task.Id, err)
+	}
+	separator := ",\n  "
+	if e.count == 0 {
+		separator = "[\n  "
+	}
+	e.count++
+	_, err = fmt.Fprintf(e.w, "%s%s", separator, b)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+// decodeJSON reads a JSON array of tasks.
+func decodeJSON(r io.Reader) ([]*proto.Task, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode json: %v", err)
+	}
+	tasks := make([]*proto.Task, len(raw))
+	for i, b := range raw {
+		tasks[i] = &proto.Task{}
+		if err := protojson.Unmarshal(b, tasks[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode task %d: %v", i+1, err)
+		}
+	}
+	return tasks, nil
+}
+
+// csvColumns are the columns of exported CSV files, which hold the dates of tasks as YYYY-MM-DD
+// and their tags and parents as comma separated lists.
+var csvColumns = []string{
+	"id", "title", "description", "status", "priority", "tags", "parents", "due_date", "effort_minutes",
+	"cron_expression", "recurring_start_date", "recurring_end_date", "require_checklist_complete",
+}
+
+// csvEncoder writes tasks as CSV rows following a header.
+type csvEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVEncoder(w io.Writer) taskEncoder { return &csvEncoder{w: csv.NewWriter(w)} }
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(csvColumns)
+}
+
+func (e *csvEncoder) Encode(task *proto.Task) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	priority := ""
+	if task.Priority != proto.Priority_PRIORITY_UNSPECIFIED {
+		priority = task.Priority.String()
+	}
+	rule := task.RecurringRule
+	if rule == nil {
+		rule = &proto.RecurringRule{}
+	}
+	return e.w.Write([]string{
+		task.Id,
+		task.Title,
+		task.Description,
+		task.Status.String(),
+		priority,
+		strings.Join(task.Tags, ","),
+		strings.Join(task.Parents, ","),
+		formatOptionalDate(task.DueDate),
+		strconv.FormatUint(uint64(task.EffortMinutes), 10),
+		rule.CronExpression,
+		formatOptionalDate(rule.StartDate),
+		formatOptionalDate(rule.EndDate),
+		strconv.FormatBool(task.RequireChecklistComplete),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// decodeCSV reads tasks from CSV rows following a header naming their columns, which may be any of csvColumns
+// in any order. Only the title column is required.
+func decodeCSV(r io.Reader) ([]*proto.Task, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv has no header")
+	}
+	header := records[0]
+	known := map[string]bool{}
+	for _, column := range csvColumns {
+		known[column] = true
+	}
+	hasTitle := false
+	for _, column := range header {
+		if !known[column] {
+			return nil, fmt.Errorf("unknown csv column %q", column)
+		}
+		hasTitle = hasTitle || column == "title"
+	}
+	if !hasTitle {
+		return nil, errors.New("csv has no title column")
+	}
+
+	var tasks []*proto.Task
+	for i, record := range records[1:] {
+		task, err := decodeCSVRecord(header, record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv row %d: %v", i+2, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// decodeCSVRecord converts a CSV row with the given header into a task.
+func decodeCSVRecord(header, record []string) (*proto.Task, error) {
+	task := &proto.Task{}
+	rule := &proto.RecurringRule{}
+	for i, column := range header {
+		value := record[i]
+		var err error
+		switch column {
+		case "id":
+			task.Id = value
+		case "title":
+			task.Title = value
+		case "description":
+			task.Description = value
+		case "status":
+			task.Status, err = parseTaskStatus(value)
+		case "priority":
+			task.Priority, err = parsePriority(value)
+		case "tags":
+			task.Tags = splitList(value)
+		case "parents":
+			task.Parents = splitList(value)
+		case "due_date":
+			task.DueDate, err = parseDate(value)
+		case "effort_minutes":
+			if value != "" {
+				var minutes uint64
+				minutes, err = strconv.ParseUint(value, 10, 32)
+				task.EffortMinutes = uint32(minutes)
+			}
+		case "cron_expression":
+			rule.CronExpression = value
+		case "recurring_start_date":
+			rule.StartDate, err = parseDate(value)
+		case "recurring_end_date":
+			rule.EndDate, err = parseDate(value)
+		case "require_checklist_complete":
+			if value != "" {
+				task.RequireChecklistComplete, err = strconv.ParseBool(value)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", column, err)
+		}
+	}
+	if rule.CronExpression != "" {
+		task.RecurringRule = rule
+	}
+	return task, nil
+}
+
+// parseTaskStatus converts a status such as "in_progress" into its proto representation.
+// An empty string is INCOMPLETE.
+func parseTaskStatus(s string) (proto.Status, error) {
+	if s == "" {
+		return proto.Status_INCOMPLETE, nil
+	}
+	status, ok := proto.Status_value[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown status %q", s)
+	}
+	return proto.Status(status), nil
+}
+
+// todoTxtPriorities maps the priorities of todo.txt to the proto priorities. Priorities after D are P3.
+var todoTxtPriorities = map[byte]proto.Priority{
+	'A': proto.Priority_P0,
+	'B': proto.Priority_P1,
+	'C': proto.Priority_P2,
+	'D': proto.Priority_P3,
+}
+
+var (
+	todoTxtPriorityPattern = regexp.MustCompile(`^\([A-Z]\)$`)
+	todoTxtDatePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// todoTxtPriority returns the todo.txt letter of a priority, or 0 if it is unspecified.
+func todoTxtPriority(priority proto.Priority) byte {
+	for letter, p := range todoTxtPriorities {
+		if p == priority {
+			return letter
+		}
+	}
+	return 0
+}
+
+// parseTodoTxtPriority converts a todo.txt priority letter into its proto representation.
+func parseTodoTxtPriority(letter byte) proto.Priority {
+	if priority, ok := todoTxtPriorities[letter]; ok {
+		return priority
+	}
+	return proto.Priority_P3
+}
+
+// todoTxtEncoder writes tasks as todo.txt lines (https://github.com/todotxt/todo.txt).
+// Tags are written as +projects, or as @contexts when they start with @, and spaces in tags become underscores.
+// The fields todo.txt has no syntax for are written as key:value pairs: id, parents, due, status, effort (minutes),
+// and cron, cron_start and cron_end for recurring rules, with the spaces of cron expressions as underscores.
+// The priority of complete tasks is kept as pri, as is customary.
+type todoTxtEncoder struct {
+	w io.Writer
+}
+
+func newTodoTxtEncoder(w io.Writer) taskEncoder { return &todoTxtEncoder{w: w} }
+
+func (e *todoTxtEncoder) Encode(task *proto.Task) error {
+	var fields []string
+	priority := todoTxtPriority(task.Priority)
+	if task.Status == proto.Status_COMPLETE {
+		fields = append(fields, "x")
+		if task.CompletedAt != 0 {
+			fields = append(fields, formatDate(task.CompletedAt))
+			if task.CreatedAt != 0 {
+				fields = append(fields, formatDate(task.CreatedAt))
+			}
+		}
+	} else {
+		if priority != 0 {
+			fields = append(fields, fmt.Sprintf("(%c)", priority))
+		}
+		if task.CreatedAt != 0 {
+			fields = append(fields, formatDate(task.CreatedAt))
+		}
+	}
+	fields = append(fields, strings.Fields(task.Title)...)
+	for _, tag := range task.Tags {
+		tag = strings.Join(strings.Fields(tag), "_")
+		if !strings.HasPrefix(tag, "@") {
+			tag = "+" + tag
+		}
+		fields = append(fields, tag)
+	}
+	if task.Id != "" {
+		fields = append(fields, "id:"+task.Id)
+	}
+	if len(task.Parents) > 0 {
+		fields = append(fields, "parents:"+strings.Join(task.Parents, ","))
+	}
+	if task.DueDate != 0 {
+		fields = append(fields, "due:"+formatDate(task.DueDate))
+	}
+	if task.Status != proto.Status_COMPLETE && task.Status != proto.Status_INCOMPLETE {
+		fields = append(fields, "status:"+strings.ToLower(task.Status.String()))
+	}
+	if task.Status == proto.Status_COMPLETE && priority != 0 {
+		fields = append(fields, fmt.Sprintf("pri:%c", priority))
+	}
+	if task.EffortMinutes > 0 {
+		fields = append(fields, fmt.Sprintf("effort:%d", task.EffortMinutes))
+	}
+	if rule := task.RecurringRule; rule != nil && rule.CronExpression != "" {
+		fields = append(fields, "cron:"+strings.Join(strings.Fields(rule.CronExpression), "_"))
+		if rule.StartDate != 0 {
+			fields = append(fields, "cron_start:"+formatDate(rule.StartDate))
+		}
+		if rule.EndDate != 0 {
+			fields = append(fields, "cron_end:"+formatDate(rule.EndDate))
+		}
+	}
+	_, err := fmt.Fprintln(e.w, strings.Join(fields, " "))
+	return err
+}
+
+func (e *todoTxtEncoder) Close() error { return nil }
+
+// decodeTodoTxt reads tasks from todo.txt lines as written by todoTxtEncoder, skipping blank lines.
+// Completion and creation dates are ignored, since the server manages them.
+func decodeTodoTxt(r io.Reader) ([]*proto.Task, error) {
+	var tasks []*proto.Task
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		task, err := decodeTodoTxtLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("invalid todo.txt line %d: %v", line, err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt: %v", err)
+	}
+	return tasks, nil
+}
+
+// decodeTodoTxtLine converts a todo.txt line into a task.
+func decodeTodoTxtLine(line string) (*proto.Task, error) {
+	task := &proto.Task{}
+	fields := strings.Fields(line)
+
+	// completion, priority and dates
+	if len(fields) > 0 && fields[0] == "x" {
+		task.Status = proto.Status_COMPLETE
+		fields = fields[1:]
+	} else if len(fields) > 0 && todoTxtPriorityPattern.MatchString(fields[0]) {
+		task.Priority = parseTodoTxtPriority(fields[0][1])
+		fields = fields[1:]
+	}
+	for i := 0; i < 2 && len(fields) > 0 && todoTxtDatePattern.MatchString(fields[0]); i++ {
+		fields = fields[1:]
+	}
+
+	// title, tags and key:value pairs
+	rule := &proto.RecurringRule{}
+	var title []string
+	for _, field := range fields {
+		var err error
+		key, value, _ := strings.Cut(field, ":")
+		switch {
+		case len(field) > 1 && (field[0] == '+' || field[0] == '@'):
+			tag := field
+			if field[0] == '+' {
+				tag = field[1:]
+			}
+			task.Tags = append(task.Tags, tag)
+		case value == "":
+			title = append(title, field)
+		case key == "id":
+			task.Id = value
+		case key == "parents":
+			task.Parents = splitList(value)
+		case key == "due":
+			task.DueDate, err = parseDate(value)
+		case key == "status":
+			task.Status, err = parseTaskStatus(value)
+		case key == "pri" && len(value) == 1:
+			task.Priority = parseTodoTxtPriority(strings.ToUpper(value)[0])
+		case key == "effort":
+			var minutes uint64
+			minutes, err = strconv.ParseUint(value, 10, 32)
+			task.EffortMinutes = uint32(minutes)
+		case key == "cron":
+			rule.CronExpression = strings.ReplaceAll(value, "_", " ")
+		case key == "cron_start":
+			rule.StartDate, err = parseDate(value)
+		case key == "cron_end":
+			rule.EndDate, err = parseDate(value)
+		default:
+			// such as a url
+			title = append(title, field)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", key, err)
+		}
+	}
+	task.Title = strings.Join(title, " ")
+	if rule.CronExpression != "" {
+		task.RecurringRule = rule
+	}
+	return task, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	proto "todo/proto/gen/go/api"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func mustParseDate(t *testing.T, s string) int64 {
+	t.Helper()
+	date, err := parseDate(s)
+	if err != nil {
+		t.Fatalf("failed to parse date: %v", err)
+	}
+	return date
+}
+
+func Test_taskFormats_roundTrip(t *testing.T) {
+	tasks := []*proto.Task{
+		{
+			Id:            "task-1",
+			Title:         "write report",
+			Description:   "quarterly, with charts",
+			Status:        proto.Status_IN_PROGRESS,
+			Priority:      proto.Priority_P1,
+			Tags:          []string{"work", "@office"},
+			DueDate:       mustParseDate(t, "2024-05-01"),
+			EffortMinutes: 90,
+		},
+		{
+			Id:       "task-2",
+			Title:    "send report",
+			Status:   proto.Status_COMPLETE,
+			Priority: proto.Priority_P0,
+			Parents:  []string{"task-1"},
+			RecurringRule: &proto.RecurringRule{
+				CronExpression: "0 9 * * 1",
+				StartDate:      mustParseDate(t, "2024-01-01"),
+				EndDate:        mustParseDate(t, "2024-12-31"),
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		// drop clears the fields the format does not hold.
+		drop func(task *proto.Task)
+	}{
+		{name: "json", drop: func(task *proto.Task) {}},
+		{name: "csv", drop: func(task *proto.Task) { task.Checklist = nil }},
+		{name: "todotxt", drop: func(task *proto.Task) { task.Checklist, task.Description = nil, "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := taskFormats[tt.name]
+			var buf bytes.Buffer
+			encoder := format.newEncoder(&buf)
+			for _, task := range tasks {
+				if err := encoder.Encode(task); err != nil {
+					t.Fatalf("Encode() error = %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			got, err := format.decode(&buf)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if len(got) != len(tasks) {
+				t.Fatalf("decode() got %d tasks, want %d", len(got), len(tasks))
+			}
+			for i, task := range tasks {
+				want := protobuf.Clone(task).(*proto.Task)
+				tt.drop(want)
+				if !protobuf.Equal(got[i], want) {
+					t.Errorf("decode() task %d = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_taskFormats_empty(t *testing.T) {
+	for name, format := range taskFormats {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := format.newEncoder(&buf)
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			got, err := format.decode(&buf)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if len(got) != 0 {
+				t.Errorf("decode() got %d tasks, want none", len(got))
+			}
+		})
+	}
+}
+
+func Test_decodeCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []*proto.Task
+		wantErr bool
+	}{
+		{
+			name: "subset of columns in any order",
+			csv:  "priority,title\nP2,buy milk\n",
+			want: []*proto.Task{{Title: "buy milk", Priority: proto.Priority_P2}},
+		},
+		{name: "no title column", csv: "id,priority\n1,P2\n", wantErr: true},
+		{name: "unknown column", csv: "title,color\nbuy milk,red\n", wantErr: true},
+		{name: "invalid due date", csv: "title,due_date\nbuy milk,tomorrow\n", wantErr: true},
+		{name: "no header", csv: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCSV(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("decodeCSV() got %d tasks, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if !protobuf.Equal(got[i], tt.want[i]) {
+					t.Errorf("decodeCSV() task %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_decodeTodoTxtLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    *proto.Task
+		wantErr bool
+	}{
+		{
+			name: "priority, creation date, project and context",
+			line: "(A) 2024-01-02 call mom +family @phone",
+			want: &proto.Task{Title: "call mom", Priority: proto.Priority_P0, Tags: []string{"family", "@phone"}},
+		},
+		{
+			name: "complete with dates and pri",
+			line: "x 2024-01-03 2024-01-02 call mom pri:B",
+			want: &proto.Task{Title: "call mom", Status: proto.Status_COMPLETE, Priority: proto.Priority_P1},
+		},
+		{
+			name: "priority after D",
+			line: "(F) water plants",
+			want: &proto.Task{Title: "water plants", Priority: proto.Priority_P3},
+		},
+		{
+			name: "unknown key stays in title",
+			line: "read https://example.com",
+			want: &proto.Task{Title: "read https://example.com"},
+		},
+		{name: "invalid due date", line: "pay rent due:soon", wantErr: true},
+		{name: "invalid effort", line: "pay rent effort:1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeTodoTxtLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeTodoTxtLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !protobuf.Equal(got, tt.want) {
+				t.Errorf("decodeTodoTxtLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resolveFormatName(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		path   string
+		want   string
+	}{
+		{name: "explicit", format: "ics", path: "tasks.json", want: "ics"},
+		{name: "ics extension", path: "calendar.ICS", want: "ics"},
+		{name: "csv extension", path: "tasks.csv", want: "csv"},
+		{name: "default", path: "", want: "json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveFormatName(tt.format, tt.path); got != tt.want {
+				t.Errorf("resolveFormatName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseTaskFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "explicit", format: "csv", path: "tasks.json", want: "csv"},
+		{name: "csv extension", path: "tasks.CSV", want: "csv"},
+		{name: "txt extension", path: "todo.txt", want: "todotxt"},
+		{name: "default", path: "", want: "json"},
+		{name: "unknown", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTaskFormat(tt.format, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTaskFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && reflect.ValueOf(got.decode).Pointer() != reflect.ValueOf(taskFormats[tt.want].decode).Pointer() {
+				t.Errorf("parseTaskFormat() did not return the %s format", tt.want)
+			}
+		})
+	}
+}
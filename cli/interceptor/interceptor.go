@@ -30,3 +30,16 @@ func (i *Interceptor) UnaryAuthMiddleware(
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", os.Getenv(common.ACCESS_JWT_ENV_VAR))
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
+
+// StreamAuthMiddleware adds the existing access jwt to the outgoing metadata of streams.
+func (i *Interceptor) StreamAuthMiddleware(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", os.Getenv(common.ACCESS_JWT_ENV_VAR))
+	return streamer(ctx, desc, cc, method, opts...)
+}
@@ -3,8 +3,10 @@ package interceptor
 import (
 	"context"
 	"testing"
+	"todo/common"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestInterceptor_UnaryAuthMiddleware(t *testing.T) {
@@ -46,3 +48,20 @@ func TestInterceptor_UnaryAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestInterceptor_StreamAuthMiddleware(t *testing.T) {
+	t.Setenv(common.ACCESS_JWT_ENV_VAR, "token")
+	i := &Interceptor{}
+	var got []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		got = md.Get("authorization")
+		return nil, nil
+	}
+	if _, err := i.StreamAuthMiddleware(context.Background(), &grpc.StreamDesc{}, &grpc.ClientConn{}, "AnyRPC", streamer); err != nil {
+		t.Fatalf("Interceptor.StreamAuthMiddleware() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "token" {
+		t.Errorf("Interceptor.StreamAuthMiddleware() authorization = %v, want [token]", got)
+	}
+}
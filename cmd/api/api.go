@@ -2,47 +2,198 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net"
-	proto "todo/proto/gen/go/api"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"todo/api"
 	"todo/api/interceptor"
+	"todo/config"
+	"todo/interfaces/cert_manager"
+	"todo/logging"
+	"todo/metrics"
+	proto "todo/proto/gen/go/api"
+	"todo/tracing"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	ctx := context.Background()
+	// stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// load config
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("failed to load config: %s", err)
+	}
+	logger := logging.New(os.Stderr, cfg.LogFormat, cfg.Level())
+	slog.SetDefault(logger)
+
+	// set up tracing
+	shutdownTracing, err := tracing.Setup(ctx, "todo-api", cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %s", err)
+	}
 
 	// create listener; it's over 9000!
-	lis, err := net.Listen("tcp", ":9001")
+	lis, err := net.Listen("tcp", cfg.ListenAddress)
 	if err != nil {
 		log.Fatalf("failed to create listener: %s", err)
 	}
 
 	// get interceptor
-	interceptor, err := interceptor.NewInterceptor()
+	interceptor, err := interceptor.NewInterceptor(cfg, logger)
 	if err != nil {
 		log.Fatalf("failed to get interceptor: %s", err)
 	}
 
-	// create server
-	server := grpc.NewServer(grpc.UnaryInterceptor(interceptor.UnaryAuthMiddleware))
+	// create server, tracing, logging and measuring every request before authenticating and rate limiting it,
+	// and serving tls if configured
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryLoggingMiddleware,
+			interceptor.UnaryMetricsMiddleware,
+			interceptor.UnaryAuthMiddleware,
+			interceptor.UnaryRateLimitMiddleware,
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamLoggingMiddleware,
+			interceptor.StreamMetricsMiddleware,
+			interceptor.StreamAuthMiddleware,
+			interceptor.StreamRateLimitMiddleware,
+		),
+	}
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := cert_manager.ServerTLSConfig(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			log.Fatalf("failed to get tls config: %s", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else {
+		slog.Warn("tls insecure is set and no certificate is configured, serving plaintext")
+	}
+	server := grpc.NewServer(serverOpts...)
 
 	// register server
-	todoService, err := api.NewTodoServer(ctx)
+	todoService, err := api.NewTodoServer(ctx, cfg)
 	if err != nil {
 		log.Fatalf("failed to get todo api. %s", err)
 	}
 	proto.RegisterTodoServer(server, todoService)
 
+	// register health service, reporting serving once storage is ready
+	healthServer := api.NewHealthServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go todoService.ReportHealth(ctx, healthServer, cfg.ReadinessInterval)
+
+	// index the stored tasks for search in the background, retrying until storage is ready
+	go todoService.BuildSearchIndex(ctx, cfg.ReadinessInterval)
+
 	// egister reflection api.on server
 	reflection.Register(server)
 
 	// start server
-	err = server.Serve(lis)
-	if err != nil {
+	serveErr := make(chan error, 3)
+	go func() {
+		serveErr <- server.Serve(lis)
+	}()
+
+	// serve metrics alongside the server
+	var metricsServer *http.Server
+	if cfg.MetricsAddress != "" {
+		metricsServer = metrics.NewServer(cfg.MetricsAddress)
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("failed to serve metrics: %v", err)
+			}
+		}()
+	}
+
+	// serve calendar feeds alongside the server, over https if it uses tls. Calendar apps cannot present
+	// client certificates, so feeds only share the server's certificate, reloaded when it is rotated.
+	var feedServer *http.Server
+	if cfg.Feed.Address != "" {
+		feedServer = todoService.NewFeedServer(cfg.Feed.Address)
+		if cfg.TLS.Enabled() {
+			feedServer.TLSConfig, err = cert_manager.ServerTLSConfig(cfg.TLS.CertFile, cfg.TLS.KeyFile, "")
+			if err != nil {
+				log.Fatalf("failed to get feed tls config: %s", err)
+			}
+		}
+		go func() {
+			var err error
+			if feedServer.TLSConfig != nil {
+				err = feedServer.ListenAndServeTLS("", "")
+			} else {
+				err = feedServer.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("failed to serve feeds: %v", err)
+			}
+		}()
+	}
+	select {
+	case err := <-serveErr:
 		log.Fatalf("failed to serve: %s", err)
+	case <-ctx.Done():
+	}
+
+	// stop reporting serving so that no new requests are routed here, then let in-flight requests, scrapes
+	// and feed downloads finish
+	slog.Info("shutting down", "timeout", cfg.ShutdownTimeout)
+	healthServer.Shutdown()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancelShutdown()
+	var httpStopped sync.WaitGroup
+	for _, httpServer := range []*http.Server{metricsServer, feedServer} {
+		if httpServer == nil {
+			continue
+		}
+		httpStopped.Add(1)
+		go func() {
+			defer httpStopped.Done()
+			if err := httpServer.Shutdown(shutdownCtx); err != nil {
+				slog.Warn("http shutdown timed out, closing open connections", "address", httpServer.Addr, "error", err)
+				httpServer.Close()
+			}
+		}()
+	}
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("shutdown timed out, cancelling in-flight requests")
+		server.Stop()
+	}
+	httpStopped.Wait()
+
+	// close storage once nothing uses it
+	if err := todoService.Close(); err != nil {
+		slog.Warn("failed to close storage", "error", err)
+	}
+
+	// export the remaining spans
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Warn("failed to export remaining spans", "error", err)
 	}
 }
@@ -4,11 +4,54 @@ const (
 	// environment variables
 	ACCESS_JWT_ENV_VAR = "TODO_SERVICE_ACCESS_JWT"
 	JWT_SECRET_ENV_VAR = "JWT_SECRET"
+	// STORAGE_BACKEND_ENV_VAR selects where the server stores data: "dynamodb" (the default), "sqlite" or "memory"
+	STORAGE_BACKEND_ENV_VAR = "TODO_STORAGE_BACKEND"
+	// TRACE_EXPORTER_ENV_VAR is where the server and CLI export spans: "none" (the default), "stderr" or "otlp"
+	TRACE_EXPORTER_ENV_VAR = "TODO_TRACE_EXPORTER"
+	// SQLITE_PATH_ENV_VAR is the path of the SQLite database file, "todo.db" by default
+	SQLITE_PATH_ENV_VAR = "TODO_SQLITE_PATH"
+	// CONFIG_FILE_ENV_VAR is the path of the server's YAML config file
+	CONFIG_FILE_ENV_VAR           = "TODO_CONFIG_FILE"
+	LISTEN_ADDRESS_ENV_VAR        = "TODO_LISTEN_ADDRESS"
+	LOG_LEVEL_ENV_VAR             = "TODO_LOG_LEVEL"
+	LOG_FORMAT_ENV_VAR            = "TODO_LOG_FORMAT"
+	METRICS_ADDRESS_ENV_VAR       = "TODO_METRICS_ADDRESS"
+	FEED_ADDRESS_ENV_VAR          = "TODO_FEED_ADDRESS"
+	FEED_URL_ENV_VAR              = "TODO_FEED_URL"
+	TRACE_ENDPOINT_ENV_VAR        = "TODO_TRACE_ENDPOINT"
+	TRACE_SAMPLE_RATIO_ENV_VAR    = "TODO_TRACE_SAMPLE_RATIO"
+	RATE_LIMIT_ENV_VAR            = "TODO_RATE_LIMIT"
+	RATE_LIMIT_BURST_ENV_VAR      = "TODO_RATE_LIMIT_BURST"
+	SHUTDOWN_TIMEOUT_ENV_VAR      = "TODO_SHUTDOWN_TIMEOUT"
+	READINESS_INTERVAL_ENV_VAR    = "TODO_READINESS_INTERVAL"
+	TLS_CERT_FILE_ENV_VAR         = "TODO_TLS_CERT_FILE"
+	TLS_KEY_FILE_ENV_VAR          = "TODO_TLS_KEY_FILE"
+	TLS_CLIENT_CA_FILE_ENV_VAR    = "TODO_TLS_CLIENT_CA_FILE"
+	TLS_INSECURE_ENV_VAR          = "TODO_TLS_INSECURE"
+	DYNAMODB_ENDPOINT_ENV_VAR     = "TODO_DYNAMODB_ENDPOINT"
+	TABLE_PREFIX_ENV_VAR          = "TODO_TABLE_PREFIX"
+	USERS_TABLE_ENV_VAR           = "TODO_USERS_TABLE"
+	TASKS_TABLE_ENV_VAR           = "TODO_TASKS_TABLE"
+	EVENTS_TABLE_ENV_VAR          = "TODO_EVENTS_TABLE"
+	VIEWS_TABLE_ENV_VAR           = "TODO_VIEWS_TABLE"
+	PROJECTS_TABLE_ENV_VAR        = "TODO_PROJECTS_TABLE"
+	MEMBERS_TABLE_ENV_VAR         = "TODO_MEMBERS_TABLE"
+	AUDIT_TABLE_ENV_VAR           = "TODO_AUDIT_TABLE"
+	ACCESS_TOKEN_LIFETIME_ENV_VAR = "TODO_ACCESS_TOKEN_LIFETIME"
+	ARGON2_MEMORY_ENV_VAR         = "TODO_ARGON2_MEMORY"
+	ARGON2_ITERATIONS_ENV_VAR     = "TODO_ARGON2_ITERATIONS"
+	ARGON2_PARALLELISM_ENV_VAR    = "TODO_ARGON2_PARALLELISM"
+	ARGON2_SALT_LENGTH_ENV_VAR    = "TODO_ARGON2_SALT_LENGTH"
+	ARGON2_KEY_LENGTH_ENV_VAR     = "TODO_ARGON2_KEY_LENGTH"
 
 	// metadata keys
 	AUTHORIZATION_METADATA_KEY = "authorization"
 	USERID_METADATA_KEY        = "user_id"
 	JWT_METADATA_KEY           = "jwt"
+	// REQUEST_ID_METADATA_KEY correlates a request's logs; it is accepted from clients and echoed in the header
+	REQUEST_ID_METADATA_KEY = "x-request-id"
+	// RETRY_AFTER_METADATA_KEY is how many seconds a rate limited client must wait before retrying
+	RETRY_AFTER_METADATA_KEY = "retry-after"
 
 	// testing
 	JWT_TEST_SECRET = "jwt_secret"
@@ -0,0 +1,526 @@
+// Package config loads the configuration of the todo server.
+//
+// Every setting has a default and may be overridden, in increasing order of precedence,
+// by a YAML config file, an environment variable and a command-line flag. Config files in other
+// formats, such as TOML, are not supported.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"todo/common"
+	"todo/tracing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the todo server.
+type Config struct {
+	// ListenAddress is the host and port the gRPC server listens on.
+	ListenAddress string `yaml:"listen_address"`
+	// LogLevel is the minimum level of logs written: debug, info, warn or error.
+	LogLevel string `yaml:"log_level"`
+	// LogFormat is how logs are written: json or text.
+	LogFormat string `yaml:"log_format"`
+	// MetricsAddress is the host and port Prometheus metrics are served on over HTTP at /metrics,
+	// or empty to not serve them. Metrics are served without authentication, so the default only
+	// listens on the loopback interface.
+	MetricsAddress string `yaml:"metrics_address"`
+	// Feed is where users' calendar feeds are served.
+	Feed FeedConfig `yaml:"feed"`
+	// ShutdownTimeout is how long in-flight requests may take to finish once the server is stopped,
+	// after which they are cancelled.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReadinessInterval is how often the storage backend is probed to report the server's health.
+	ReadinessInterval time.Duration   `yaml:"readiness_interval"`
+	TLS               TLSConfig       `yaml:"tls"`
+	Tracing           TracingConfig   `yaml:"tracing"`
+	RateLimit         RateLimitConfig `yaml:"rate_limit"`
+	Storage           StorageConfig   `yaml:"storage"`
+	Auth              AuthConfig      `yaml:"auth"`
+}
+
+// FeedConfig sets where the iCalendar feeds calendar apps subscribe to are served over HTTP,
+// at /feed/<token>.ics. Feeds are served over HTTPS when the server uses TLS.
+type FeedConfig struct {
+	// Address is the host and port feeds are served on, or empty to not serve them.
+	Address string `yaml:"address"`
+	// URL is the public URL feeds are reached at, such as https://todo.example.com,
+	// returned with each new feed token. If empty, only the token is returned.
+	URL string `yaml:"url"`
+}
+
+// TLSConfig holds the PEM files the server uses for TLS. Like the CLI, the server uses TLS unless
+// told otherwise: without a certificate it only serves plaintext when Insecure is set.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile, if set, requires clients to present a certificate signed by one of its CAs (mutual TLS).
+	ClientCAFile string `yaml:"client_ca_file"`
+	// Insecure allows serving plaintext when no certificate is set, sending passwords and tokens unencrypted,
+	// for clients connecting with -insecure.
+	Insecure bool `yaml:"insecure"`
+}
+
+// Enabled reports whether the server uses TLS.
+func (c TLSConfig) Enabled() bool { return c.CertFile != "" }
+
+// TracingConfig sets where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	// Exporter is where spans are exported: none, stderr or otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the URL of the OTLP collector spans are exported to over gRPC, such as http://localhost:4317.
+	// If empty, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or the OTLP default is used.
+	Endpoint string `yaml:"endpoint"`
+	// SampleRatio is the fraction of traces started by the server that are sampled, from 0 to 1.
+	// Traces started by clients are sampled as the client decided.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// RateLimitConfig limits how often each user, or each peer address for methods called without a jwt,
+// may call methods. Each caller has a token bucket per limit, which is refilled at the limit's rate up to its burst,
+// and each call takes its method's cost from the bucket or is rejected.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the rate the default bucket is refilled at, or 0 to not limit methods without their own limit.
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is the size of the default bucket.
+	Burst int `yaml:"burst"`
+	// Methods holds the costs and limits of methods by full method name, such as /api.Todo/GetAllTasks.
+	// Entries replace the defaults of the same method as a whole.
+	Methods map[string]MethodRateLimitConfig `yaml:"methods"`
+}
+
+// MethodRateLimitConfig is the cost of a method and, if RequestsPerSecond is positive, its own limit.
+type MethodRateLimitConfig struct {
+	// Cost is the number of tokens a call takes, 1 if unset.
+	Cost int `yaml:"cost"`
+	// RequestsPerSecond is the rate the method's own bucket is refilled at, or 0 to take from the default bucket.
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is the size of the method's own bucket.
+	Burst int `yaml:"burst"`
+}
+
+type StorageConfig struct {
+	// Backend is where data is stored: dynamodb, sqlite or memory.
+	Backend    string         `yaml:"backend"`
+	SQLitePath string         `yaml:"sqlite_path"`
+	DynamoDB   DynamoDBConfig `yaml:"dynamodb"`
+}
+
+type DynamoDBConfig struct {
+	// Endpoint overrides the DynamoDB endpoint, such as to use DynamoDB Local.
+	Endpoint string `yaml:"endpoint"`
+	// TablePrefix is prepended to each table name, so that several stages can share an account.
+	TablePrefix   string `yaml:"table_prefix"`
+	UsersTable    string `yaml:"users_table"`
+	TasksTable    string `yaml:"tasks_table"`
+	EventsTable   string `yaml:"events_table"`
+	ViewsTable    string `yaml:"views_table"`
+	ProjectsTable string `yaml:"projects_table"`
+	MembersTable  string `yaml:"members_table"`
+	AuditTable    string `yaml:"audit_table"`
+}
+
+type AuthConfig struct {
+	// JWTSecret signs access tokens. It may only be set by the config file or environment.
+	JWTSecret           string        `yaml:"jwt_secret"`
+	AccessTokenLifetime time.Duration `yaml:"access_token_lifetime"`
+	Argon2              Argon2Config  `yaml:"argon2"`
+}
+
+// Argon2Config holds the argon2id parameters used to hash passwords.
+type Argon2Config struct {
+	// Memory is the amount of memory used in KiB.
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+	SaltLength  uint32 `yaml:"salt_length"`
+	KeyLength   uint32 `yaml:"key_length"`
+}
+
+// Default returns the configuration used when nothing is overridden.
+// It has no JWT secret, which must always be provided.
+func Default() *Config {
+	return &Config{
+		ListenAddress:     ":9001",
+		LogLevel:          "info",
+		LogFormat:         "json",
+		MetricsAddress:    "127.0.0.1:9090",
+		ShutdownTimeout:   30 * time.Second,
+		ReadinessInterval: 10 * time.Second,
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 10,
+			Burst:             20,
+			Methods: map[string]MethodRateLimitConfig{
+				// read every task of the user, or every task matching a search or view
+				"/api.Todo/GetAllTasks": {Cost: 5},
+				"/api.Todo/SearchTasks": {Cost: 5},
+				"/api.Todo/RunView":     {Cost: 5},
+				"/api.Todo/ListTags":    {Cost: 5},
+				// read the tasks of every user that are assigned to the user
+				"/api.Todo/ListAssignedToMe": {Cost: 5},
+				// read or write up to every task and event of the user in one call
+				"/api.Todo/ExportTasks":    {Cost: 10},
+				"/api.Todo/ImportTasks":    {Cost: 10},
+				"/api.Todo/ExportCalendar": {Cost: 10},
+				"/api.Todo/ImportCalendar": {Cost: 10},
+				"/api.Todo/RenameTag":      {Cost: 10},
+				"/api.Todo/MergeTags":      {Cost: 10},
+				"/api.Todo/DeleteProject":  {Cost: 10},
+				// slows down guessing passwords and creating accounts
+				"/api.Todo/Signin": {RequestsPerSecond: 1, Burst: 5},
+				"/api.Todo/Signup": {RequestsPerSecond: 1, Burst: 5},
+			},
+		},
+		Storage: StorageConfig{
+			Backend:    "dynamodb",
+			SQLitePath: "todo.db",
+			DynamoDB: DynamoDBConfig{
+				TablePrefix:   "todo-",
+				UsersTable:    "users",
+				TasksTable:    "tasks",
+				EventsTable:   "events",
+				ViewsTable:    "views",
+				ProjectsTable: "projects",
+				MembersTable:  "members",
+				AuditTable:    "audit",
+			},
+		},
+		Auth: AuthConfig{
+			AccessTokenLifetime: 5 * time.Minute,
+			// the defaults of github.com/alexedwards/argon2id
+			Argon2: Argon2Config{
+				Memory:      64 * 1024,
+				Iterations:  1,
+				Parallelism: 2,
+				SaltLength:  16,
+				KeyLength:   32,
+			},
+		},
+	}
+}
+
+// UsersTableName returns the full name of the users table.
+func (c DynamoDBConfig) UsersTableName() string { return c.TablePrefix + c.UsersTable }
+
+// TasksTableName returns the full name of the tasks table.
+func (c DynamoDBConfig) TasksTableName() string { return c.TablePrefix + c.TasksTable }
+
+// EventsTableName returns the full name of the events table.
+func (c DynamoDBConfig) EventsTableName() string { return c.TablePrefix + c.EventsTable }
+
+// ViewsTableName returns the full name of the views table.
+func (c DynamoDBConfig) ViewsTableName() string { return c.TablePrefix + c.ViewsTable }
+
+// ProjectsTableName returns the full name of the projects table.
+func (c DynamoDBConfig) ProjectsTableName() string { return c.TablePrefix + c.ProjectsTable }
+
+// MembersTableName returns the full name of the members table.
+func (c DynamoDBConfig) MembersTableName() string { return c.TablePrefix + c.MembersTable }
+
+// AuditTableName returns the full name of the audit table.
+func (c DynamoDBConfig) AuditTableName() string { return c.TablePrefix + c.AuditTable }
+
+// Level returns the log level. It must only be called on a valid config.
+func (c *Config) Level() slog.Level {
+	var level slog.Level
+	level.UnmarshalText([]byte(c.LogLevel))
+	return level
+}
+
+// setting is a value that may be set by an environment variable and, unless flag is empty, a command-line flag.
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+func setString(field func(c *Config) *string) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+func setUint32(field func(c *Config) *uint32) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		*field(c) = uint32(n)
+		return nil
+	}
+}
+
+func setFloat64(field func(c *Config) *float64) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field(c) = f
+		return nil
+	}
+}
+
+func setInt(field func(c *Config) *int) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func setBool(field func(c *Config) *bool) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+func setDuration(field func(c *Config) *time.Duration) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+var settings = []setting{
+	{common.LISTEN_ADDRESS_ENV_VAR, "listen", "address to listen on", setString(func(c *Config) *string { return &c.ListenAddress })},
+	{common.LOG_LEVEL_ENV_VAR, "log-level", "minimum log level: debug, info, warn or error", setString(func(c *Config) *string { return &c.LogLevel })},
+	{common.LOG_FORMAT_ENV_VAR, "log-format", "log format: json or text", setString(func(c *Config) *string { return &c.LogFormat })},
+	{common.METRICS_ADDRESS_ENV_VAR, "metrics-listen", "address to serve Prometheus metrics on, or empty to not serve them", setString(func(c *Config) *string { return &c.MetricsAddress })},
+	{common.FEED_ADDRESS_ENV_VAR, "feed-listen", "address to serve calendar feeds on, or empty to not serve them", setString(func(c *Config) *string { return &c.Feed.Address })},
+	{common.FEED_URL_ENV_VAR, "feed-url", "public URL calendar feeds are reached at, such as https://todo.example.com", setString(func(c *Config) *string { return &c.Feed.URL })},
+	{common.SHUTDOWN_TIMEOUT_ENV_VAR, "shutdown-timeout", "how long in-flight requests may take to finish on shutdown, such as 30s", setDuration(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{common.READINESS_INTERVAL_ENV_VAR, "readiness-interval", "how often storage is probed to report health, such as 10s", setDuration(func(c *Config) *time.Duration { return &c.ReadinessInterval })},
+	{common.TLS_CERT_FILE_ENV_VAR, "tls-cert", "path of the PEM encoded TLS certificate", setString(func(c *Config) *string { return &c.TLS.CertFile })},
+	{common.TLS_KEY_FILE_ENV_VAR, "tls-key", "path of the PEM encoded TLS private key", setString(func(c *Config) *string { return &c.TLS.KeyFile })},
+	{common.TLS_CLIENT_CA_FILE_ENV_VAR, "tls-client-ca", "path of the PEM encoded CAs client certificates must be signed by", setString(func(c *Config) *string { return &c.TLS.ClientCAFile })},
+	{common.TLS_INSECURE_ENV_VAR, "tls-insecure", "serve plaintext when no TLS certificate is set: true or false", setBool(func(c *Config) *bool { return &c.TLS.Insecure })},
+	{common.TRACE_EXPORTER_ENV_VAR, "trace-exporter", "where spans are exported: none, stderr or otlp", setString(func(c *Config) *string { return &c.Tracing.Exporter })},
+	{common.TRACE_ENDPOINT_ENV_VAR, "trace-endpoint", "URL of the OTLP collector, such as http://localhost:4317", setString(func(c *Config) *string { return &c.Tracing.Endpoint })},
+	{common.TRACE_SAMPLE_RATIO_ENV_VAR, "trace-sample-ratio", "fraction of traces started by the server that are sampled, from 0 to 1", setFloat64(func(c *Config) *float64 { return &c.Tracing.SampleRatio })},
+	{common.RATE_LIMIT_ENV_VAR, "rate-limit", "requests per second each user may make, or 0 to not limit methods without their own limit", setFloat64(func(c *Config) *float64 { return &c.RateLimit.RequestsPerSecond })},
+	{common.RATE_LIMIT_BURST_ENV_VAR, "rate-limit-burst", "requests each user may make at once", setInt(func(c *Config) *int { return &c.RateLimit.Burst })},
+	{common.STORAGE_BACKEND_ENV_VAR, "storage-backend", "storage backend: dynamodb, sqlite or memory", setString(func(c *Config) *string { return &c.Storage.Backend })},
+	{common.SQLITE_PATH_ENV_VAR, "sqlite-path", "path of the SQLite database file", setString(func(c *Config) *string { return &c.Storage.SQLitePath })},
+	{common.DYNAMODB_ENDPOINT_ENV_VAR, "dynamodb-endpoint", "DynamoDB endpoint override, such as http://localhost:8000", setString(func(c *Config) *string { return &c.Storage.DynamoDB.Endpoint })},
+	{common.TABLE_PREFIX_ENV_VAR, "table-prefix", "prefix of every DynamoDB table name", setString(func(c *Config) *string { return &c.Storage.DynamoDB.TablePrefix })},
+	{common.USERS_TABLE_ENV_VAR, "users-table", "name of the DynamoDB users table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.UsersTable })},
+	{common.TASKS_TABLE_ENV_VAR, "tasks-table", "name of the DynamoDB tasks table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.TasksTable })},
+	{common.EVENTS_TABLE_ENV_VAR, "events-table", "name of the DynamoDB events table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.EventsTable })},
+	{common.VIEWS_TABLE_ENV_VAR, "views-table", "name of the DynamoDB views table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.ViewsTable })},
+	{common.PROJECTS_TABLE_ENV_VAR, "projects-table", "name of the DynamoDB projects table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.ProjectsTable })},
+	{common.MEMBERS_TABLE_ENV_VAR, "members-table", "name of the DynamoDB members table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.MembersTable })},
+	{common.AUDIT_TABLE_ENV_VAR, "audit-table", "name of the DynamoDB audit table, after the prefix", setString(func(c *Config) *string { return &c.Storage.DynamoDB.AuditTable })},
+	{common.JWT_SECRET_ENV_VAR, "", "", setString(func(c *Config) *string { return &c.Auth.JWTSecret })},
+	{common.ACCESS_TOKEN_LIFETIME_ENV_VAR, "access-token-lifetime", "lifetime of access tokens, such as 5m", setDuration(func(c *Config) *time.Duration { return &c.Auth.AccessTokenLifetime })},
+	{common.ARGON2_MEMORY_ENV_VAR, "argon2-memory", "argon2id memory in KiB", setUint32(func(c *Config) *uint32 { return &c.Auth.Argon2.Memory })},
+	{common.ARGON2_ITERATIONS_ENV_VAR, "argon2-iterations", "argon2id iterations", setUint32(func(c *Config) *uint32 { return &c.Auth.Argon2.Iterations })},
+	{common.ARGON2_PARALLELISM_ENV_VAR, "argon2-parallelism", "argon2id parallelism", func(c *Config, value string) error {
+		n, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return err
+		}
+		c.Auth.Argon2.Parallelism = uint8(n)
+		return nil
+	}},
+	{common.ARGON2_SALT_LENGTH_ENV_VAR, "argon2-salt-length", "argon2id salt length in bytes", setUint32(func(c *Config) *uint32 { return &c.Auth.Argon2.SaltLength })},
+	{common.ARGON2_KEY_LENGTH_ENV_VAR, "argon2-key-length", "argon2id key length in bytes", setUint32(func(c *Config) *uint32 { return &c.Auth.Argon2.KeyLength })},
+}
+
+// Load returns the configuration given by the defaults, the config file, the environment and
+// the command-line arguments, in increasing order of precedence, and validates it.
+// The config file is given by the -config flag or the TODO_CONFIG_FILE environment variable.
+func Load(args []string) (*Config, error) {
+	// parse flags, keeping only those that were set
+	fs := flag.NewFlagSet("todo-server", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(common.CONFIG_FILE_ENV_VAR), "path of the YAML config file")
+	flagValues := make(map[string]*string)
+	for _, s := range settings {
+		if s.flag != "" {
+			flagValues[s.flag] = fs.String(s.flag, "", s.usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	// apply the config file, environment and flags in order
+	cfg := Default()
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range settings {
+		value, ok := os.LookupEnv(s.env)
+		if !ok {
+			continue
+		}
+		if err := s.set(cfg, value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", s.env, err)
+		}
+	}
+	for _, s := range settings {
+		if !setFlags[s.flag] {
+			continue
+		}
+		if err := s.set(cfg, *flagValues[s.flag]); err != nil {
+			return nil, fmt.Errorf("invalid -%s: %v", s.flag, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile overrides the config with the settings in the YAML file at path, rejecting unknown settings.
+// Config files in other formats, such as TOML, are not supported.
+func (c *Config) loadFile(path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return fmt.Errorf("config file %s: only YAML config files are supported", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %v", err)
+	}
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Validate returns every problem with the config joined into one error, or nil if it is valid.
+func (c *Config) Validate() error {
+	var errs []error
+	if _, _, err := net.SplitHostPort(c.ListenAddress); err != nil {
+		errs = append(errs, fmt.Errorf("listen address %q: %v", c.ListenAddress, err))
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("log level %q must be debug, info, warn or error", c.LogLevel))
+	}
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		errs = append(errs, fmt.Errorf("log format %q must be json or text", c.LogFormat))
+	}
+	if c.MetricsAddress != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddress); err != nil {
+			errs = append(errs, fmt.Errorf("metrics address %q: %v", c.MetricsAddress, err))
+		}
+	}
+	if c.Feed.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Feed.Address); err != nil {
+			errs = append(errs, fmt.Errorf("feed address %q: %v", c.Feed.Address, err))
+		}
+	}
+	if c.Feed.URL != "" {
+		if u, err := url.Parse(c.Feed.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("feed url %q must be an absolute http or https url", c.Feed.URL))
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown timeout must be positive"))
+	}
+	if c.ReadinessInterval <= 0 {
+		errs = append(errs, errors.New("readiness interval must be positive"))
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls cert file and key file must be set together"))
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		errs = append(errs, errors.New("tls client ca file requires a tls cert file and key file"))
+	}
+	if !c.TLS.Enabled() && !c.TLS.Insecure {
+		errs = append(errs, errors.New("tls cert file and key file are required unless tls insecure is set to serve plaintext"))
+	}
+	if !slices.Contains(tracing.Exporters, c.Tracing.Exporter) {
+		errs = append(errs, fmt.Errorf("unknown trace exporter %q: expected one of %s", c.Tracing.Exporter, strings.Join(tracing.Exporters, ", ")))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("trace sample ratio %v must be from 0 to 1", c.Tracing.SampleRatio))
+	}
+	if c.RateLimit.RequestsPerSecond < 0 {
+		errs = append(errs, errors.New("rate limit cannot be negative"))
+	}
+	if c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst < 1 {
+		errs = append(errs, errors.New("rate limit burst must be positive"))
+	}
+	for method, limit := range c.RateLimit.Methods {
+		if limit.Cost < 0 || limit.RequestsPerSecond < 0 {
+			errs = append(errs, fmt.Errorf("rate limit cost and rate of method %s cannot be negative", method))
+		}
+		burst := c.RateLimit.Burst
+		if limit.RequestsPerSecond > 0 {
+			burst = limit.Burst
+		}
+		if (limit.RequestsPerSecond > 0 || c.RateLimit.RequestsPerSecond > 0) && max(limit.Cost, 1) > burst {
+			errs = append(errs, fmt.Errorf("rate limit cost of method %s cannot exceed the burst of its bucket", method))
+		}
+	}
+	switch c.Storage.Backend {
+	case "dynamodb":
+		dynamoDB := c.Storage.DynamoDB
+		if dynamoDB.UsersTable == "" || dynamoDB.TasksTable == "" || dynamoDB.EventsTable == "" || dynamoDB.ViewsTable == "" ||
+			dynamoDB.ProjectsTable == "" || dynamoDB.MembersTable == "" || dynamoDB.AuditTable == "" {
+			errs = append(errs, errors.New("dynamodb table names cannot be blank"))
+		}
+	case "sqlite":
+		if c.Storage.SQLitePath == "" {
+			errs = append(errs, errors.New("sqlite path cannot be blank"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage backend %q: expected dynamodb, sqlite or memory", c.Storage.Backend))
+	}
+	if c.Auth.JWTSecret == "" {
+		errs = append(errs, fmt.Errorf("jwt secret must be provided, such as by the %s environment variable", common.JWT_SECRET_ENV_VAR))
+	}
+	if c.Auth.AccessTokenLifetime <= 0 {
+		errs = append(errs, errors.New("access token lifetime must be positive"))
+	}
+	argon2 := c.Auth.Argon2
+	if argon2.Memory == 0 || argon2.Iterations == 0 || argon2.Parallelism == 0 || argon2.KeyLength == 0 {
+		errs = append(errs, errors.New("argon2 memory, iterations, parallelism and key length must be positive"))
+	}
+	if argon2.SaltLength < 8 {
+		errs = append(errs, errors.New("argon2 salt length must be at least 8 bytes"))
+	}
+	return errors.Join(errs...)
+}
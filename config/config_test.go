@@ -0,0 +1,248 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"todo/common"
+)
+
+// writeConfigFile writes the YAML config to a temporary file and returns its path.
+func writeConfigFile(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func Test_Load(t *testing.T) {
+	file := writeConfigFile(t, `
+listen_address: ":9100"
+log_level: debug
+storage:
+  dynamodb:
+    table_prefix: todo-staging-
+    endpoint: http://localhost:8000
+auth:
+  access_token_lifetime: 10m
+`)
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		check   func(t *testing.T, cfg *Config)
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{common.JWT_SECRET_ENV_VAR: "secret", common.TLS_INSECURE_ENV_VAR: "true"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.ListenAddress != ":9001" || cfg.Storage.DynamoDB.TasksTableName() != "todo-tasks" || cfg.Auth.AccessTokenLifetime != 5*time.Minute {
+					t.Errorf("Load() = %+v, want defaults", cfg)
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "config file",
+			args: []string{"-config", file},
+			env:  map[string]string{common.JWT_SECRET_ENV_VAR: "secret", common.TLS_INSECURE_ENV_VAR: "true"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.ListenAddress != ":9100" || cfg.LogLevel != "debug" || cfg.Auth.AccessTokenLifetime != 10*time.Minute {
+					t.Errorf("Load() = %+v, want settings of config file", cfg)
+				}
+				if cfg.Storage.DynamoDB.UsersTableName() != "todo-staging-users" || cfg.Storage.DynamoDB.Endpoint != "http://localhost:8000" {
+					t.Errorf("Load() storage = %+v, want settings of config file", cfg.Storage)
+				}
+				if cfg.Auth.Argon2.Memory != 64*1024 {
+					t.Errorf("Load() argon2 = %+v, want defaults kept", cfg.Auth.Argon2)
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "environment overrides config file",
+			env: map[string]string{
+				common.CONFIG_FILE_ENV_VAR:     file,
+				common.JWT_SECRET_ENV_VAR:      "secret",
+				common.LISTEN_ADDRESS_ENV_VAR:  ":9200",
+				common.TABLE_PREFIX_ENV_VAR:    "todo-prod-",
+				common.ARGON2_MEMORY_ENV_VAR:   "1024",
+				common.STORAGE_BACKEND_ENV_VAR: "memory",
+				common.TLS_INSECURE_ENV_VAR:    "true",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.ListenAddress != ":9200" || cfg.Storage.DynamoDB.TablePrefix != "todo-prod-" || cfg.Auth.Argon2.Memory != 1024 {
+					t.Errorf("Load() = %+v, want settings of environment", cfg)
+				}
+				if cfg.LogLevel != "debug" || cfg.Storage.Backend != "memory" {
+					t.Errorf("Load() = %+v, want unset settings of config file kept", cfg)
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "flags override environment",
+			args: []string{"-config", file, "-listen", ":9300", "-access-token-lifetime", "1h", "-argon2-parallelism", "4", "-trace-sample-ratio", "0.25", "-tls-insecure", "true"},
+			env: map[string]string{
+				common.JWT_SECRET_ENV_VAR:     "secret",
+				common.LISTEN_ADDRESS_ENV_VAR: ":9200",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.ListenAddress != ":9300" || cfg.Auth.AccessTokenLifetime != time.Hour || cfg.Auth.Argon2.Parallelism != 4 || cfg.Tracing.SampleRatio != 0.25 {
+					t.Errorf("Load() = %+v, want settings of flags", cfg)
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing jwt secret",
+			env:     map[string]string{common.TLS_INSECURE_ENV_VAR: "true"},
+			wantErr: true,
+		},
+		{
+			name:    "plaintext without tls insecure",
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid tls insecure",
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret", common.TLS_INSECURE_ENV_VAR: "maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "missing config file",
+			args:    []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")},
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown config file setting",
+			args:    []string{"-config", writeConfigFile(t, "listen: \":9100\"\n")},
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid environment variable",
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret", common.ACCESS_TOKEN_LIFETIME_ENV_VAR: "forever"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-jwt-secret", "secret"},
+			env:     map[string]string{common.JWT_SECRET_ENV_VAR: "secret"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// clear every setting so that the environment running the tests cannot affect them
+			t.Setenv(common.CONFIG_FILE_ENV_VAR, "")
+			for _, s := range settings {
+				t.Setenv(s.env, "")
+				os.Unsetenv(s.env)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			got, err := Load(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.check != nil && got != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func Test_Config_Validate(t *testing.T) {
+	valid := func() *Config {
+		cfg := Default()
+		cfg.Auth.JWTSecret = "secret"
+		cfg.TLS.Insecure = true
+		return cfg
+	}
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(cfg *Config) {}, wantErr: false},
+		{name: "invalid listen address", modify: func(cfg *Config) { cfg.ListenAddress = "9001" }, wantErr: true},
+		{name: "invalid log level", modify: func(cfg *Config) { cfg.LogLevel = "verbose" }, wantErr: true},
+		{name: "invalid log format", modify: func(cfg *Config) { cfg.LogFormat = "xml" }, wantErr: true},
+		{name: "metrics disabled", modify: func(cfg *Config) { cfg.MetricsAddress = "" }, wantErr: false},
+		{name: "invalid metrics address", modify: func(cfg *Config) { cfg.MetricsAddress = "9090" }, wantErr: true},
+		{name: "feed", modify: func(cfg *Config) { cfg.Feed = FeedConfig{Address: ":8080", URL: "https://todo.example.com"} }, wantErr: false},
+		{name: "invalid feed address", modify: func(cfg *Config) { cfg.Feed.Address = "8080" }, wantErr: true},
+		{name: "relative feed url", modify: func(cfg *Config) { cfg.Feed.URL = "todo.example.com" }, wantErr: true},
+		{name: "unknown trace exporter", modify: func(cfg *Config) { cfg.Tracing.Exporter = "jaeger" }, wantErr: true},
+		{name: "trace sample ratio above one", modify: func(cfg *Config) { cfg.Tracing.SampleRatio = 1.5 }, wantErr: true},
+		{name: "rate limit disabled", modify: func(cfg *Config) { cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst = 0, 0 }, wantErr: false},
+		{name: "zero rate limit burst", modify: func(cfg *Config) { cfg.RateLimit.Burst = 0 }, wantErr: true},
+		{
+			name: "method cost above burst",
+			modify: func(cfg *Config) {
+				cfg.RateLimit.Methods["/api.Todo/AddTask"] = MethodRateLimitConfig{Cost: 50}
+			},
+			wantErr: true,
+		},
+		{
+			name: "method cost above own burst",
+			modify: func(cfg *Config) {
+				cfg.RateLimit.Methods["/api.Todo/AddTask"] = MethodRateLimitConfig{Cost: 2, RequestsPerSecond: 1, Burst: 1}
+			},
+			wantErr: true,
+		},
+		{name: "unknown storage backend", modify: func(cfg *Config) { cfg.Storage.Backend = "postgres" }, wantErr: true},
+		{name: "blank table name", modify: func(cfg *Config) { cfg.Storage.DynamoDB.TasksTable = "" }, wantErr: true},
+		{name: "blank sqlite path", modify: func(cfg *Config) { cfg.Storage.Backend, cfg.Storage.SQLitePath = "sqlite", "" }, wantErr: true},
+		{name: "non-positive shutdown timeout", modify: func(cfg *Config) { cfg.ShutdownTimeout = 0 }, wantErr: true},
+		{name: "tls", modify: func(cfg *Config) { cfg.TLS = TLSConfig{CertFile: "server.crt", KeyFile: "server.key"} }, wantErr: false},
+		{name: "plaintext without tls insecure", modify: func(cfg *Config) { cfg.TLS.Insecure = false }, wantErr: true},
+		{name: "tls cert without key", modify: func(cfg *Config) { cfg.TLS.CertFile = "server.crt" }, wantErr: true},
+		{name: "tls client ca without cert", modify: func(cfg *Config) { cfg.TLS.ClientCAFile = "ca.crt" }, wantErr: true},
+		{name: "non-positive token lifetime", modify: func(cfg *Config) { cfg.Auth.AccessTokenLifetime = 0 }, wantErr: true},
+		{name: "zero argon2 iterations", modify: func(cfg *Config) { cfg.Auth.Argon2.Iterations = 0 }, wantErr: true},
+		{name: "short argon2 salt", modify: func(cfg *Config) { cfg.Auth.Argon2.SaltLength = 4 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Config_loadFile_toml(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("listen_address = \":9100\"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	err := (&Config{}).loadFile(path)
+	if err == nil || !strings.Contains(err.Error(), "only YAML") {
+		t.Errorf("loadFile() error = %v, want only YAML to be supported", err)
+	}
+}
+
+func Test_exampleConfigFile(t *testing.T) {
+	// load into an empty config so that every default must be in the example
+	cfg := &Config{}
+	if err := cfg.loadFile("example.yaml"); err != nil {
+		t.Fatalf("loadFile() error = %v", err)
+	}
+	cfg.Auth.JWTSecret = Default().Auth.JWTSecret
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("example config = %+v, want defaults %+v", cfg, Default())
+	}
+}
@@ -0,0 +1,295 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest a content line may be before it is folded onto the next line.
+const maxLineOctets = 75
+
+// Date and date-time layouts of property values, which are in UTC when they end with Z.
+const (
+	dateLayout        = "20060102"
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+)
+
+// property is a content line: a name with optional parameters and a value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// param returns the value of the named parameter, or an empty string if it is not set.
+func (p *property) param(name string) string {
+	return p.params[name]
+}
+
+// lineWriter writes content lines, folding them at maxLineOctets and ending them with CRLF.
+// The first error is kept and returned by every later write.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newLineWriter(w io.Writer) *lineWriter {
+	return &lineWriter{w: bufio.NewWriter(w)}
+}
+
+// write writes a property whose value is already formatted, such as a date.
+// params are pairs of parameter names and values.
+func (lw *lineWriter) write(name, value string, params ...string) {
+	var line strings.Builder
+	line.WriteString(name)
+	for i := 0; i+1 < len(params); i += 2 {
+		line.WriteString(";" + params[i] + "=" + params[i+1])
+	}
+	line.WriteString(":" + value)
+	lw.writeLine(line.String())
+}
+
+// writeText writes a property whose value is text, escaping it.
+func (lw *lineWriter) writeText(name, value string, params ...string) {
+	lw.write(name, escapeText(value), params...)
+}
+
+// writeUTC writes a property whose value is a unix timestamp, as a UTC date-time.
+func (lw *lineWriter) writeUTC(name string, timestamp int64) {
+	lw.write(name, time.Unix(timestamp, 0).UTC().Format(utcDateTimeLayout))
+}
+
+// writeDate writes a property whose value is the UTC date of a unix timestamp.
+func (lw *lineWriter) writeDate(name string, timestamp int64) {
+	lw.write(name, time.Unix(timestamp, 0).UTC().Format(dateLayout), "VALUE", "DATE")
+}
+
+// writeLine writes a content line, folding it so that no line exceeds maxLineOctets
+// without splitting a UTF-8 sequence.
+func (lw *lineWriter) writeLine(line string) {
+	if lw.err != nil {
+		return
+	}
+	limit := maxLineOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		if _, lw.err = lw.w.WriteString(line[:i] + "\r\n "); lw.err != nil {
+			return
+		}
+		line = line[i:]
+		// continuation lines start with a space, which counts towards their length
+		limit = maxLineOctets - 1
+	}
+	_, lw.err = lw.w.WriteString(line + "\r\n")
+}
+
+// flush writes any buffered lines, returning the first error of any write.
+func (lw *lineWriter) flush() error {
+	if lw.err != nil {
+		return lw.err
+	}
+	return lw.w.Flush()
+}
+
+// textEscaper escapes the characters of text values that RFC 5545 requires to be escaped.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// unescapeText reverses escapeText, also accepting \N for a newline.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// splitText splits a list of text values at unescaped commas, unescaping each value.
+func splitText(s string) []string {
+	var values []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeText(s[start:]))
+}
+
+// readLines reads the unfolded content lines of r, skipping blank lines.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %v", err)
+	}
+	return lines, nil
+}
+
+// parseLine parses a content line into a property with an upper case name and parameter names.
+// Parameter values may be quoted, in which case they may hold the separators ; : and ,.
+func parseLine(line string) (*property, error) {
+	p := &property{params: map[string]string{}}
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid content line %q", line)
+	}
+	p.name = strings.ToUpper(line[:i])
+	for line[i] == ';' {
+		line = line[i+1:]
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid parameter in content line of %s", p.name)
+		}
+		name := strings.ToUpper(line[:eq])
+		line = line[eq+1:]
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := strings.IndexByte(line[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted parameter in content line of %s", p.name)
+			}
+			value, line = line[1:end+1], line[end+2:]
+		} else {
+			end := strings.IndexAny(line, ";:")
+			if end < 0 {
+				return nil, fmt.Errorf("content line of %s has no value", p.name)
+			}
+			value, line = line[:end], line[end:]
+		}
+		p.params[name] = value
+		if line == "" {
+			return nil, fmt.Errorf("content line of %s has no value", p.name)
+		}
+		i = 0
+	}
+	if line[i] != ':' {
+		return nil, fmt.Errorf("invalid content line of %s", p.name)
+	}
+	p.value = line[i+1:]
+	return p, nil
+}
+
+// parseTime parses a date or date-time property. Dates are midnight UTC and are reported as such,
+// UTC date-times end with Z, and other date-times are in the zone of the TZID parameter,
+// or in UTC when they have none.
+func parseTime(p *property) (t time.Time, isDate bool, err error) {
+	value := p.value
+	if p.param("VALUE") == "DATE" || len(value) == len(dateLayout) {
+		t, err = time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date of %s: %q", p.name, value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(utcDateTimeLayout, value)
+	} else {
+		loc := time.UTC
+		if tzid := p.param("TZID"); tzid != "" {
+			loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+			if err != nil {
+				return time.Time{}, false, fmt.Errorf("unknown time zone of %s: %q", p.name, tzid)
+			}
+		}
+		t, err = time.ParseInLocation(dateTimeLayout, value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time of %s: %q", p.name, value)
+	}
+	return t, false, nil
+}
+
+// formatDuration formats a positive duration as a property value, to the second.
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("PT")
+	for _, unit := range []struct {
+		size time.Duration
+		name string
+	}{{time.Hour, "H"}, {time.Minute, "M"}, {time.Second, "S"}} {
+		if n := d / unit.size; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.name)
+			d -= n * unit.size
+		}
+	}
+	if b.Len() == 2 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// parseDuration parses a duration value such as PT1H30M or P1D. Days are 24 hours long.
+func parseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var d time.Duration
+	n, digits := 0, false
+	for _, c := range []byte(s) {
+		switch {
+		case c >= '0' && c <= '9':
+			n, digits = n*10+int(c-'0'), true
+		case c == 'T' && !digits:
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		case units[c] != 0 && digits:
+			d += time.Duration(n) * units[c]
+			n, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * d, nil
+}
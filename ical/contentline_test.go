@@ -0,0 +1,148 @@
+package ical
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_lineWriter_writeLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:hello"},
+		{name: "long", line: "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{name: "multibyte", line: "SUMMARY:" + strings.Repeat("äöü€", 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			lw := newLineWriter(&buf)
+			lw.writeLine(tt.line)
+			if err := lw.flush(); err != nil {
+				t.Fatalf("flush() error = %v", err)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+				if len(line) > maxLineOctets {
+					t.Errorf("writeLine() wrote line of %d octets", len(line))
+				}
+			}
+			got, err := readLines(&buf)
+			if err != nil {
+				t.Fatalf("readLines() error = %v", err)
+			}
+			if len(got) != 1 || got[0] != tt.line {
+				t.Errorf("readLines() = %q, want [%q]", got, tt.line)
+			}
+		})
+	}
+}
+
+func Test_escapeText(t *testing.T) {
+	for _, s := range []string{"plain", `back\slash`, "semi;colon, comma", "multi\nline"} {
+		if got := unescapeText(escapeText(s)); got != s {
+			t.Errorf("unescapeText(escapeText(%q)) = %q", s, got)
+		}
+	}
+	if got, want := splitText(`a\,b,c,d\\`), []string{"a,b", "c", `d\`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("splitText() = %q, want %q", got, want)
+	}
+}
+
+func Test_parseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    *property
+		wantErr bool
+	}{
+		{
+			name: "plain",
+			line: "summary:hello: world",
+			want: &property{name: "SUMMARY", params: map[string]string{}, value: "hello: world"},
+		},
+		{
+			name: "params",
+			line: `DTSTART;tzid="America/New_York";VALUE=DATE-TIME:20240101T090000`,
+			want: &property{
+				name:   "DTSTART",
+				params: map[string]string{"TZID": "America/New_York", "VALUE": "DATE-TIME"},
+				value:  "20240101T090000",
+			},
+		},
+		{
+			name: "quoted separators",
+			line: `ATTENDEE;CN="Doe, Jane; PhD":mailto:jane@example.com`,
+			want: &property{name: "ATTENDEE", params: map[string]string{"CN": "Doe, Jane; PhD"}, value: "mailto:jane@example.com"},
+		},
+		{name: "no value", line: "SUMMARY", wantErr: true},
+		{name: "no name", line: ":value", wantErr: true},
+		{name: "unterminated quote", line: `X;A="b:c`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P1W", want: 7 * 24 * time.Hour},
+		{value: "+P1DT2H", want: 26 * time.Hour},
+		{value: "PT45S", want: 45 * time.Second},
+		{value: "-PT10M", want: -10 * time.Minute},
+		{value: "P", wantErr: true},
+		{value: "PT5", wantErr: true},
+		{value: "1H", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: time.Hour, want: "PT1H"},
+		{d: 90 * time.Minute, want: "PT1H30M"},
+		{d: 26*time.Hour + 5*time.Second, want: "PT26H5S"},
+		{d: 0, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := formatDuration(tt.d)
+			if got != tt.want {
+				t.Errorf("formatDuration() = %q, want %q", got, tt.want)
+			}
+			if d, err := parseDuration(got); err != nil || d != tt.d {
+				t.Errorf("parseDuration(%q) = %v, %v, want %v", got, d, err, tt.d)
+			}
+		})
+	}
+}
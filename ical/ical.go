@@ -0,0 +1,560 @@
+// Package ical encodes tasks and events as iCalendar (RFC 5545) objects and decodes them back,
+// with tasks as VTODO components and events as VEVENT components. Recurring rules are translated
+// between cron expressions and RRULEs where the recurrence can be expressed by both.
+//
+// Checklists and status histories have no iCalendar equivalent and are left out, as are
+// the components and properties decoding does not recognize, such as VJOURNAL and VALARM.
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	proto "todo/proto/gen/go/api"
+)
+
+// Component names.
+const (
+	ComponentTodo  = "VTODO"
+	ComponentEvent = "VEVENT"
+)
+
+// productID identifies the product that created the calendars it encodes.
+const productID = "-//todo//todo//EN"
+
+// statusProperty holds the statuses STATUS cannot express, as the name of the status.
+const statusProperty = "X-TODO-STATUS"
+
+// effortProperty holds the effort of a task in minutes.
+const effortProperty = "X-TODO-EFFORT-MINUTES"
+
+// Calendar is the tasks and events of an iCalendar object.
+type Calendar struct {
+	// Name and RefreshInterval, if set, are the name calendar apps show for the calendar
+	// and how often apps subscribed to it should fetch it again. Neither is decoded.
+	Name            string
+	RefreshInterval time.Duration
+
+	Tasks  []*proto.Task
+	Events []*proto.Event
+	// Invalid is the components decoding could not convert into tasks or events.
+	Invalid []*InvalidComponent
+}
+
+// InvalidComponent is a component that could not be decoded, and why.
+type InvalidComponent struct {
+	Component string
+	UID       string
+	Summary   string
+	Err       error
+}
+
+// toStatus maps statuses to their STATUS, and statuses that have none to NEEDS-ACTION.
+var toStatus = map[proto.Status]string{
+	proto.Status_INCOMPLETE:  "NEEDS-ACTION",
+	proto.Status_IN_PROGRESS: "IN-PROCESS",
+	proto.Status_COMPLETE:    "COMPLETED",
+	proto.Status_CANCELLED:   "CANCELLED",
+	proto.Status_BLOCKED:     "NEEDS-ACTION",
+	proto.Status_DEFERRED:    "NEEDS-ACTION",
+}
+
+// fromStatus maps each STATUS of a VTODO to a status.
+var fromStatus = map[string]proto.Status{
+	"NEEDS-ACTION": proto.Status_INCOMPLETE,
+	"IN-PROCESS":   proto.Status_IN_PROGRESS,
+	"COMPLETED":    proto.Status_COMPLETE,
+	"CANCELLED":    proto.Status_CANCELLED,
+}
+
+// toPriority maps priorities to the PRIORITY in the middle of the range fromPriority maps back to them.
+var toPriority = map[proto.Priority]int{
+	proto.Priority_P0: 1,
+	proto.Priority_P1: 3,
+	proto.Priority_P2: 5,
+	proto.Priority_P3: 7,
+}
+
+// fromPriority maps a PRIORITY, where 1 is the highest, 9 the lowest and 0 undefined, to a priority.
+func fromPriority(value string) (proto.Priority, error) {
+	n, err := strconv.Atoi(value)
+	switch {
+	case err != nil || n < 0 || n > 9:
+		return 0, fmt.Errorf("invalid PRIORITY %q", value)
+	case n == 0:
+		return proto.Priority_PRIORITY_UNSPECIFIED, nil
+	case n <= 2:
+		return proto.Priority_P0, nil
+	case n <= 4:
+		return proto.Priority_P1, nil
+	case n <= 6:
+		return proto.Priority_P2, nil
+	default:
+		return proto.Priority_P3, nil
+	}
+}
+
+// Encode writes the calendar's tasks and events as an iCalendar object. If the recurring rule of a task or event
+// cannot be expressed as an RRULE, it returns an error naming the task or event without writing anything.
+func Encode(w io.Writer, cal *Calendar) error {
+	var buf bytes.Buffer
+	lw := newLineWriter(&buf)
+	lw.write("BEGIN", "VCALENDAR")
+	lw.write("VERSION", "2.0")
+	lw.write("PRODID", productID)
+	lw.write("CALSCALE", "GREGORIAN")
+	// RFC 7986 properties, along with the older ones calendar apps still read instead
+	if cal.Name != "" {
+		lw.writeText("NAME", cal.Name)
+		lw.writeText("X-WR-CALNAME", cal.Name)
+	}
+	if cal.RefreshInterval > 0 {
+		lw.write("REFRESH-INTERVAL", formatDuration(cal.RefreshInterval), "VALUE", "DURATION")
+		lw.write("X-PUBLISHED-TTL", formatDuration(cal.RefreshInterval))
+	}
+	for _, task := range cal.Tasks {
+		if err := encodeTask(lw, task); err != nil {
+			return fmt.Errorf("task %s %q: %v", task.Id, task.Title, err)
+		}
+	}
+	for _, event := range cal.Events {
+		if err := encodeEvent(lw, event); err != nil {
+			return fmt.Errorf("event %s %q: %v", event.Id, event.Title, err)
+		}
+	}
+	lw.write("END", "VCALENDAR")
+	if err := lw.flush(); err != nil {
+		return err
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write calendar: %v", err)
+	}
+	return nil
+}
+
+// writeCommon writes the properties tasks and events share.
+func writeCommon(lw *lineWriter, id, title, description string, createdAt, updatedAt int64) {
+	lw.writeText("UID", id)
+	// DTSTAMP is required, and is the time the component was last changed when it was not created by a method
+	stamp := updatedAt
+	if stamp == 0 {
+		stamp = time.Now().Unix()
+	}
+	lw.writeUTC("DTSTAMP", stamp)
+	if createdAt != 0 {
+		lw.writeUTC("CREATED", createdAt)
+	}
+	if updatedAt != 0 {
+		lw.writeUTC("LAST-MODIFIED", updatedAt)
+	}
+	lw.writeText("SUMMARY", title)
+	if description != "" {
+		lw.writeText("DESCRIPTION", description)
+	}
+}
+
+// recurrence returns the RRULE of a recurring rule with its UNTIL, and the DTSTART it needs,
+// which is the first occurrence of the rule at or after start.
+func recurrence(rule *proto.RecurringRule, start int64, date bool) (string, time.Time, error) {
+	rrule, err := ToRRule(rule.CronExpression, date)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	dtstart, err := FirstOccurrence(rule.CronExpression, time.Unix(start, 0))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if rule.EndDate != 0 {
+		until := time.Unix(rule.EndDate, 0).UTC()
+		if date {
+			rrule += ";UNTIL=" + until.Format(dateLayout)
+		} else {
+			rrule += ";UNTIL=" + until.Format(utcDateTimeLayout)
+		}
+	}
+	return rrule, dtstart, nil
+}
+
+// encodeTask writes a task as a VTODO. A recurring task starts at the first occurrence of its rule
+// at or after the rule's start date, or else its creation.
+func encodeTask(lw *lineWriter, task *proto.Task) error {
+	// translate rule first, so that nothing is written when it cannot be
+	var rrule string
+	var dtstart time.Time
+	if rule := task.RecurringRule; rule != nil && rule.CronExpression != "" {
+		start := rule.StartDate
+		if start == 0 {
+			start = task.CreatedAt
+		}
+		if start == 0 {
+			start = time.Now().Unix()
+		}
+		var err error
+		if rrule, dtstart, err = recurrence(rule, start, false); err != nil {
+			return err
+		}
+	}
+
+	lw.write("BEGIN", ComponentTodo)
+	writeCommon(lw, task.Id, task.Title, task.Description, task.CreatedAt, task.UpdatedAt)
+	lw.write("STATUS", toStatus[task.Status])
+	if task.Status == proto.Status_BLOCKED || task.Status == proto.Status_DEFERRED {
+		lw.write(statusProperty, task.Status.String())
+	}
+	if priority, ok := toPriority[task.Priority]; ok {
+		lw.write("PRIORITY", strconv.Itoa(priority))
+	}
+	if len(task.Tags) > 0 {
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = escapeText(tag)
+		}
+		lw.write("CATEGORIES", strings.Join(tags, ","))
+	}
+	for _, parent := range task.Parents {
+		lw.writeText("RELATED-TO", parent, "RELTYPE", "PARENT")
+	}
+	if task.DueDate != 0 {
+		lw.writeUTC("DUE", task.DueDate)
+	}
+	if task.CompletedAt != 0 {
+		lw.writeUTC("COMPLETED", task.CompletedAt)
+	}
+	if rrule != "" {
+		lw.writeUTC("DTSTART", dtstart.Unix())
+		lw.write("RRULE", rrule)
+	}
+	if task.EffortMinutes != 0 {
+		lw.write(effortProperty, strconv.FormatUint(uint64(task.EffortMinutes), 10))
+	}
+	lw.write("END", ComponentTodo)
+	return nil
+}
+
+// encodeEvent writes an event as a VEVENT. A recurring event starts at the first occurrence of its rule
+// at or after its start time and the rule's start date, and lasts as long as the event.
+func encodeEvent(lw *lineWriter, event *proto.Event) error {
+	start, end := event.StartTime, event.EndTime
+	var rrule string
+	if rule := event.RecurringRule; rule != nil && rule.CronExpression != "" {
+		var dtstart time.Time
+		var err error
+		if rrule, dtstart, err = recurrence(rule, max(start, rule.StartDate), event.AllDay); err != nil {
+			return err
+		}
+		start, end = dtstart.Unix(), dtstart.Unix()+end-start
+	}
+
+	lw.write("BEGIN", ComponentEvent)
+	writeCommon(lw, event.Id, event.Title, event.Description, event.CreatedAt, event.UpdatedAt)
+	if event.Location != "" {
+		lw.writeText("LOCATION", event.Location)
+	}
+	if event.AllDay {
+		lw.writeDate("DTSTART", start)
+		lw.writeDate("DTEND", end)
+	} else {
+		lw.writeUTC("DTSTART", start)
+		lw.writeUTC("DTEND", end)
+	}
+	if rrule != "" {
+		lw.write("RRULE", rrule)
+	}
+	lw.write("END", ComponentEvent)
+	return nil
+}
+
+// component is the properties of a VTODO or VEVENT being decoded.
+type component struct {
+	name  string
+	props []*property
+	// err is the first content line of the component that could not be parsed
+	err error
+}
+
+// get returns the first property with the name, or nil if there is none.
+func (c *component) get(name string) *property {
+	for _, p := range c.props {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// text returns the unescaped value of the first property with the name, or an empty string if there is none.
+func (c *component) text(name string) string {
+	if p := c.get(name); p != nil {
+		return unescapeText(p.value)
+	}
+	return ""
+}
+
+// unix returns the first date or date-time property with the name as a unix timestamp, or 0 if there is none.
+func (c *component) unix(name string) (int64, error) {
+	p := c.get(name)
+	if p == nil {
+		return 0, nil
+	}
+	t, _, err := parseTime(p)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// recurringRule converts the DTSTART and RRULE of the component into a recurring rule,
+// or returns nil if it does not recur.
+func (c *component) recurringRule() (*proto.RecurringRule, error) {
+	for _, name := range []string{"RDATE", "EXDATE"} {
+		if c.get(name) != nil {
+			return nil, fmt.Errorf("%s cannot be expressed as a cron expression", name)
+		}
+	}
+	var rrules []*property
+	for _, p := range c.props {
+		if p.name == "RRULE" {
+			rrules = append(rrules, p)
+		}
+	}
+	switch {
+	case len(rrules) == 0:
+		return nil, nil
+	case len(rrules) > 1:
+		return nil, errors.New("more than one RRULE cannot be expressed as a cron expression")
+	}
+	dtstartProp := c.get("DTSTART")
+	if dtstartProp == nil {
+		return nil, errors.New("RRULE requires DTSTART")
+	}
+	dtstart, _, err := parseTime(dtstartProp)
+	if err != nil {
+		return nil, err
+	}
+	cron, until, err := FromRRule(rrules[0].value, dtstart)
+	if err != nil {
+		return nil, err
+	}
+	rule := &proto.RecurringRule{
+		CronExpression: cron,
+		StartDate:      dtstart.Unix(),
+	}
+	if !until.IsZero() {
+		rule.EndDate = until.Unix()
+	}
+	return rule, nil
+}
+
+// Decode reads the tasks and events of an iCalendar object. Components that cannot be converted into a task
+// or an event are returned as invalid with the reason, while an object that is not a calendar returns an error.
+// Date-times with a TZID are converted from the IANA time zone of that name, not from VTIMEZONE components.
+func Decode(r io.Reader) (*Calendar, error) {
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar object: expected BEGIN:VCALENDAR")
+	}
+
+	cal := &Calendar{}
+	var current *component
+	// nesting holds the names of the components the line is in, outermost first
+	nesting := []string{"VCALENDAR"}
+	for i, line := range lines[1:] {
+		p, err := parseLine(line)
+		if err != nil {
+			if current == nil {
+				return nil, fmt.Errorf("line %d: %v", i+2, err)
+			}
+			if current.err == nil {
+				current.err = err
+			}
+			continue
+		}
+		switch p.name {
+		case "BEGIN":
+			name := strings.ToUpper(p.value)
+			if len(nesting) == 1 && (name == ComponentTodo || name == ComponentEvent) {
+				current = &component{name: name}
+			}
+			nesting = append(nesting, name)
+		case "END":
+			name := strings.ToUpper(p.value)
+			if len(nesting) == 0 || nesting[len(nesting)-1] != name {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+2, p.value)
+			}
+			nesting = nesting[:len(nesting)-1]
+			if current != nil && len(nesting) == 1 {
+				cal.add(current)
+				current = nil
+			}
+			if len(nesting) == 0 && i+2 != len(lines) {
+				return nil, fmt.Errorf("line %d: content after END:VCALENDAR", i+3)
+			}
+		default:
+			// properties of nested components, such as VALARM, are left out
+			if current != nil && len(nesting) == 2 {
+				current.props = append(current.props, p)
+			}
+		}
+	}
+	if len(nesting) != 0 {
+		return nil, fmt.Errorf("missing END:%s", nesting[len(nesting)-1])
+	}
+	return cal, nil
+}
+
+// add converts a decoded component into a task or an event of the calendar, or records why it cannot.
+func (cal *Calendar) add(c *component) {
+	err := c.err
+	if err == nil && c.get("RECURRENCE-ID") != nil {
+		err = errors.New("RECURRENCE-ID overrides an occurrence of a recurring component, which cannot be imported")
+	}
+	if err == nil {
+		switch c.name {
+		case ComponentTodo:
+			var task *proto.Task
+			if task, err = decodeTask(c); err == nil {
+				cal.Tasks = append(cal.Tasks, task)
+			}
+		case ComponentEvent:
+			var event *proto.Event
+			if event, err = decodeEvent(c); err == nil {
+				cal.Events = append(cal.Events, event)
+			}
+		}
+	}
+	if err != nil {
+		cal.Invalid = append(cal.Invalid, &InvalidComponent{
+			Component: c.name,
+			UID:       c.text("UID"),
+			Summary:   c.text("SUMMARY"),
+			Err:       err,
+		})
+	}
+}
+
+// decodeTask converts a VTODO into a task.
+func decodeTask(c *component) (*proto.Task, error) {
+	task := &proto.Task{
+		Id:          c.text("UID"),
+		Title:       c.text("SUMMARY"),
+		Description: c.text("DESCRIPTION"),
+	}
+	var err error
+
+	// status
+	if p := c.get("STATUS"); p != nil {
+		status, ok := fromStatus[strings.ToUpper(p.value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown STATUS %q", p.value)
+		}
+		task.Status = status
+	}
+	if p := c.get(statusProperty); p != nil {
+		status, ok := proto.Status_value[strings.ToUpper(p.value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown %s %q", statusProperty, p.value)
+		}
+		task.Status = proto.Status(status)
+	}
+
+	if p := c.get("PRIORITY"); p != nil {
+		if task.Priority, err = fromPriority(p.value); err != nil {
+			return nil, err
+		}
+	}
+
+	// tags and parents may be spread across several properties
+	for _, p := range c.props {
+		switch p.name {
+		case "CATEGORIES":
+			for _, tag := range splitText(p.value) {
+				if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(task.Tags, tag) {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+		case "RELATED-TO":
+			switch strings.ToUpper(p.param("RELTYPE")) {
+			case "", "PARENT", "DEPENDS-ON":
+				task.Parents = append(task.Parents, unescapeText(p.value))
+			}
+		}
+	}
+
+	if task.DueDate, err = c.unix("DUE"); err != nil {
+		return nil, err
+	}
+	if task.CompletedAt, err = c.unix("COMPLETED"); err != nil {
+		return nil, err
+	}
+	if task.RecurringRule, err = c.recurringRule(); err != nil {
+		return nil, err
+	}
+	if p := c.get(effortProperty); p != nil {
+		effort, err := strconv.ParseUint(p.value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", effortProperty, p.value)
+		}
+		task.EffortMinutes = uint32(effort)
+	}
+	return task, nil
+}
+
+// decodeEvent converts a VEVENT into an event. An event without DTEND or DURATION lasts a day
+// if it starts on a date, and no time otherwise.
+func decodeEvent(c *component) (*proto.Event, error) {
+	event := &proto.Event{
+		Id:          c.text("UID"),
+		Title:       c.text("SUMMARY"),
+		Description: c.text("DESCRIPTION"),
+		Location:    c.text("LOCATION"),
+	}
+
+	// times
+	dtstart := c.get("DTSTART")
+	if dtstart == nil {
+		return nil, errors.New("VEVENT has no DTSTART")
+	}
+	start, isDate, err := parseTime(dtstart)
+	if err != nil {
+		return nil, err
+	}
+	event.StartTime = start.Unix()
+	event.AllDay = isDate
+	switch dtend, duration := c.get("DTEND"), c.get("DURATION"); {
+	case dtend != nil:
+		end, endIsDate, err := parseTime(dtend)
+		if err != nil {
+			return nil, err
+		}
+		if endIsDate != isDate {
+			return nil, errors.New("DTSTART and DTEND must both be dates or both be date-times")
+		}
+		event.EndTime = end.Unix()
+	case duration != nil:
+		d, err := parseDuration(duration.value)
+		if err != nil {
+			return nil, err
+		}
+		event.EndTime = start.Add(d).Unix()
+	case isDate:
+		event.EndTime = start.AddDate(0, 0, 1).Unix()
+	default:
+		event.EndTime = event.StartTime
+	}
+	if event.EndTime < event.StartTime {
+		return nil, errors.New("VEVENT ends before it starts")
+	}
+
+	if event.RecurringRule, err = c.recurringRule(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,326 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	proto "todo/proto/gen/go/api"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func TestCalendar_roundTrip(t *testing.T) {
+	created := mustParseTime(t, "2024-01-01T08:00:00Z").Unix()
+	updated := mustParseTime(t, "2024-01-02T08:00:00Z").Unix()
+	cal := &Calendar{
+		Tasks: []*proto.Task{
+			{
+				Id:            "task-1",
+				Title:         "write report; draft, then final",
+				Description:   "quarterly\nwith charts",
+				Status:        proto.Status_BLOCKED,
+				Priority:      proto.Priority_P1,
+				Tags:          []string{"work", "a,b"},
+				DueDate:       mustParseTime(t, "2024-05-01T17:00:00Z").Unix(),
+				CreatedAt:     created,
+				UpdatedAt:     updated,
+				EffortMinutes: 90,
+			},
+			{
+				Id:          "task-2",
+				Title:       "send report",
+				Status:      proto.Status_COMPLETE,
+				Priority:    proto.Priority_P0,
+				Parents:     []string{"task-1"},
+				CompletedAt: updated,
+				CreatedAt:   created,
+				UpdatedAt:   updated,
+				RecurringRule: &proto.RecurringRule{
+					CronExpression: "0 9 * * 1",
+					// a monday at 9:00, the first occurrence
+					StartDate: mustParseTime(t, "2024-01-08T09:00:00Z").Unix(),
+					EndDate:   mustParseTime(t, "2024-12-31T00:00:00Z").Unix(),
+				},
+			},
+		},
+		Events: []*proto.Event{
+			{
+				Id:          "event-1",
+				Title:       "standup",
+				Description: "daily sync",
+				Location:    "room 1",
+				StartTime:   mustParseTime(t, "2024-01-08T09:00:00Z").Unix(),
+				EndTime:     mustParseTime(t, "2024-01-08T09:15:00Z").Unix(),
+				RecurringRule: &proto.RecurringRule{
+					CronExpression: "0 9 * * 1-5",
+					StartDate:      mustParseTime(t, "2024-01-08T09:00:00Z").Unix(),
+				},
+				CreatedAt: created,
+				UpdatedAt: updated,
+			},
+			{
+				Id:        "event-2",
+				Title:     "holiday",
+				StartTime: mustParseTime(t, "2024-12-25T00:00:00Z").Unix(),
+				EndTime:   mustParseTime(t, "2024-12-27T00:00:00Z").Unix(),
+				AllDay:    true,
+				CreatedAt: created,
+				UpdatedAt: updated,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Encode() wrote line of %d octets: %q", len(line), line)
+		}
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(got.Invalid) > 0 {
+		t.Fatalf("Decode() invalid = %v", got.Invalid[0].Err)
+	}
+
+	// created_at and updated_at are managed by the server, so they are not decoded
+	if len(got.Tasks) != len(cal.Tasks) {
+		t.Fatalf("Decode() got %d tasks, want %d", len(got.Tasks), len(cal.Tasks))
+	}
+	for i, task := range cal.Tasks {
+		want := protobuf.Clone(task).(*proto.Task)
+		want.CreatedAt, want.UpdatedAt = 0, 0
+		if !protobuf.Equal(got.Tasks[i], want) {
+			t.Errorf("Decode() task = %v, want %v", got.Tasks[i], want)
+		}
+	}
+	if len(got.Events) != len(cal.Events) {
+		t.Fatalf("Decode() got %d events, want %d", len(got.Events), len(cal.Events))
+	}
+	for i, event := range cal.Events {
+		want := protobuf.Clone(event).(*proto.Event)
+		want.CreatedAt, want.UpdatedAt = 0, 0
+		if !protobuf.Equal(got.Events[i], want) {
+			t.Errorf("Decode() event = %v, want %v", got.Events[i], want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		cal     *Calendar
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "recurring task starts at first occurrence",
+			cal: &Calendar{Tasks: []*proto.Task{{
+				Id:        "task-1",
+				Title:     "review",
+				UpdatedAt: mustParseTime(t, "2024-01-01T00:00:00Z").Unix(),
+				RecurringRule: &proto.RecurringRule{
+					CronExpression: "0 9 * * 1",
+					StartDate:      mustParseTime(t, "2024-01-03T00:00:00Z").Unix(),
+				},
+			}}},
+			want: []string{"DTSTART:20240108T090000Z", "RRULE:FREQ=WEEKLY;BYDAY=MO;BYHOUR=9;BYMINUTE=0", "STATUS:NEEDS-ACTION"},
+		},
+		{
+			name: "recurring all-day event",
+			cal: &Calendar{Events: []*proto.Event{{
+				Id:        "event-1",
+				Title:     "payday",
+				StartTime: mustParseTime(t, "2024-01-01T00:00:00Z").Unix(),
+				EndTime:   mustParseTime(t, "2024-01-02T00:00:00Z").Unix(),
+				AllDay:    true,
+				RecurringRule: &proto.RecurringRule{
+					CronExpression: "0 0 15 * *",
+					EndDate:        mustParseTime(t, "2024-06-30T00:00:00Z").Unix(),
+				},
+			}}},
+			want: []string{"DTSTART;VALUE=DATE:20240115", "DTEND;VALUE=DATE:20240116", "RRULE:FREQ=MONTHLY;BYMONTHDAY=15;UNTIL=20240630"},
+		},
+		{
+			name: "name and refresh interval",
+			cal:  &Calendar{Name: "todo, tasks", RefreshInterval: time.Hour},
+			want: []string{"NAME:todo\\, tasks", "X-WR-CALNAME:todo\\, tasks", "REFRESH-INTERVAL;VALUE=DURATION:PT1H", "X-PUBLISHED-TTL:PT1H"},
+		},
+		{
+			name: "inexpressible rule",
+			cal: &Calendar{Tasks: []*proto.Task{{
+				Id:            "task-1",
+				Title:         "pay rent",
+				RecurringRule: &proto.RecurringRule{CronExpression: "0 0 L * *"},
+			}}},
+			want:    []string{"task-1", "pay rent"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Encode(&buf, tt.cal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := buf.String()
+			if tt.wantErr {
+				if buf.Len() != 0 {
+					t.Errorf("Encode() wrote %q despite error", got)
+				}
+				got = err.Error()
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Encode() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+// googleCalendar is an export in the style of a calendar app, with time zones, alarms,
+// folded lines and components that cannot be imported.
+const googleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"PRODID:-//Google Inc//Google Calendar 70.9054//EN\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VTIMEZONE\r\n" +
+	"TZID:Europe/Berlin\r\n" +
+	"BEGIN:STANDARD\r\n" +
+	"DTSTART:19701025T030000\r\n" +
+	"END:STANDARD\r\n" +
+	"END:VTIMEZONE\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240305T100000\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=TU;UNTIL=20240630T000000Z\r\n" +
+	"UID:abc@google.com\r\n" +
+	"SUMMARY:Team meeting\\, weekly\r\n" +
+	"DESCRIPTION:Agenda:\\n1. updates\\n2. plans that are long enough to be folded \r\n" +
+	" onto the next line\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"TRIGGER:-PT10M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240401\r\n" +
+	"UID:holiday@google.com\r\n" +
+	"SUMMARY:Easter Monday\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240301T100000Z\r\n" +
+	"RRULE:FREQ=MONTHLY;BYDAY=1FR\r\n" +
+	"UID:first-friday@google.com\r\n" +
+	"SUMMARY:First Friday\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:todo@google.com\r\n" +
+	"SUMMARY:Buy milk\r\n" +
+	"STATUS:NEEDS-ACTION\r\n" +
+	"PRIORITY:9\r\n" +
+	"CATEGORIES:home,errands\r\n" +
+	"CATEGORIES:shopping\r\n" +
+	"DUE;VALUE=DATE:20240310\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VTODO\r\n" +
+	"UID:bad@google.com\r\n" +
+	"SUMMARY:Bad status\r\n" +
+	"STATUS:MAYBE\r\n" +
+	"END:VTODO\r\n" +
+	"BEGIN:VJOURNAL\r\n" +
+	"UID:journal@google.com\r\n" +
+	"END:VJOURNAL\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestDecode(t *testing.T) {
+	got, err := Decode(strings.NewReader(googleCalendar))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	wantEvents := []*proto.Event{
+		{
+			Id:          "abc@google.com",
+			Title:       "Team meeting, weekly",
+			Description: "Agenda:\n1. updates\n2. plans that are long enough to be folded onto the next line",
+			// 10:00 in Berlin is 9:00 UTC in March
+			StartTime: mustParseTime(t, "2024-03-05T09:00:00Z").Unix(),
+			EndTime:   mustParseTime(t, "2024-03-05T10:30:00Z").Unix(),
+			RecurringRule: &proto.RecurringRule{
+				CronExpression: "0 9 * * 2",
+				StartDate:      mustParseTime(t, "2024-03-05T09:00:00Z").Unix(),
+				EndDate:        mustParseTime(t, "2024-06-30T00:00:00Z").Unix(),
+			},
+		},
+		{
+			Id:        "holiday@google.com",
+			Title:     "Easter Monday",
+			StartTime: mustParseTime(t, "2024-04-01T00:00:00Z").Unix(),
+			EndTime:   mustParseTime(t, "2024-04-02T00:00:00Z").Unix(),
+			AllDay:    true,
+		},
+	}
+	if len(got.Events) != len(wantEvents) {
+		t.Fatalf("Decode() got %d events, want %d", len(got.Events), len(wantEvents))
+	}
+	for i, want := range wantEvents {
+		if !protobuf.Equal(got.Events[i], want) {
+			t.Errorf("Decode() event = %v, want %v", got.Events[i], want)
+		}
+	}
+
+	wantTasks := []*proto.Task{{
+		Id:       "todo@google.com",
+		Title:    "Buy milk",
+		Status:   proto.Status_INCOMPLETE,
+		Priority: proto.Priority_P3,
+		Tags:     []string{"home", "errands", "shopping"},
+		DueDate:  mustParseTime(t, "2024-03-10T00:00:00Z").Unix(),
+	}}
+	if len(got.Tasks) != len(wantTasks) {
+		t.Fatalf("Decode() got %d tasks, want %d", len(got.Tasks), len(wantTasks))
+	}
+	for i, want := range wantTasks {
+		if !protobuf.Equal(got.Tasks[i], want) {
+			t.Errorf("Decode() task = %v, want %v", got.Tasks[i], want)
+		}
+	}
+
+	wantInvalid := []string{"first-friday@google.com", "bad@google.com"}
+	if len(got.Invalid) != len(wantInvalid) {
+		t.Fatalf("Decode() got %d invalid components, want %d", len(got.Invalid), len(wantInvalid))
+	}
+	for i, uid := range wantInvalid {
+		if got.Invalid[i].UID != uid || got.Invalid[i].Err == nil {
+			t.Errorf("Decode() invalid = %+v, want %s with an error", got.Invalid[i], uid)
+		}
+	}
+}
+
+func TestDecode_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{name: "empty", ics: ""},
+		{name: "not a calendar", ics: "BEGIN:VCARD\r\nEND:VCARD\r\n"},
+		{name: "unterminated", ics: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VTODO\r\n"},
+		{name: "mismatched end", ics: "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{name: "malformed line", ics: "BEGIN:VCALENDAR\r\nnot a content line\r\nEND:VCALENDAR\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(tt.ics)); err == nil {
+				t.Errorf("Decode() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adhocore/gronx"
+)
+
+// Frequencies of recurrence rules, from the finest to the coarsest.
+var frequencies = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// weekdays are the RRULE codes of the days of the week, indexed by cron day of the week.
+var weekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// cronField is a field of a cron expression as normalized by gronx.Segments, which puts seconds first.
+type cronField struct {
+	name     string
+	min, max int
+	// part is the RRULE part that restricts the field.
+	part string
+}
+
+var (
+	secondField   = cronField{name: "second", min: 0, max: 59, part: "BYSECOND"}
+	minuteField   = cronField{name: "minute", min: 0, max: 59, part: "BYMINUTE"}
+	hourField     = cronField{name: "hour", min: 0, max: 23, part: "BYHOUR"}
+	monthDayField = cronField{name: "day of month", min: 1, max: 31, part: "BYMONTHDAY"}
+	monthField    = cronField{name: "month", min: 1, max: 12, part: "BYMONTH"}
+	weekdayField  = cronField{name: "day of week", min: 0, max: 6, part: "BYDAY"}
+)
+
+// parseCronField returns the sorted values a cron field matches, or nil if it matches every value.
+func parseCronField(field cronField, s string) ([]int, error) {
+	if strings.ContainsAny(s, "LW#") {
+		return nil, fmt.Errorf("the %s %q uses L, W or #, which an RRULE cannot express", field.name, s)
+	}
+	var values []int
+	for _, item := range strings.Split(s, ",") {
+		// split into a range and a step
+		rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepSpec); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step of the %s %q", field.name, s)
+			}
+		}
+		first, last := field.min, field.max
+		if rangeSpec != "*" && rangeSpec != "?" {
+			from, to, isRange := strings.Cut(rangeSpec, "-")
+			var err error
+			if first, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid %s %q", field.name, s)
+			}
+			last = first
+			if isRange {
+				if last, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("invalid %s %q", field.name, s)
+				}
+			} else if hasStep {
+				last = field.max
+			}
+		}
+		for value := first; value <= last; value += step {
+			v := value
+			// cron allows 7 for sunday
+			if field == weekdayField && v == 7 {
+				v = 0
+			}
+			if v < field.min || v > field.max {
+				return nil, fmt.Errorf("the %s %q is out of range", field.name, s)
+			}
+			values = append(values, v)
+		}
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+	if len(values) == field.max-field.min+1 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+// formatList formats values as an RRULE list, converting each with format.
+func formatList(values []int, format func(int) string) string {
+	items := make([]string, len(values))
+	for i, value := range values {
+		items[i] = format(value)
+	}
+	return strings.Join(items, ",")
+}
+
+// ToRRule translates a cron expression into an RRULE value, without UNTIL, that describes the same recurrence
+// when its DTSTART is an occurrence of the cron expression in UTC. Cron expressions no RRULE can express,
+// such as those restricting the year, using L, W or # or restricting both the day of the month and the day of
+// the week, which cron matches if either matches, return an error saying why. A rule for a DATE DTSTART,
+// as of an all-day event, cannot recur at a time of day other than midnight.
+func ToRRule(expr string, date bool) (string, error) {
+	if !gronx.IsValid(expr) {
+		return "", fmt.Errorf("invalid cron expression %q", expr)
+	}
+	segments, err := gronx.Segments(expr)
+	if err != nil {
+		return "", fmt.Errorf("invalid cron expression %q: %v", expr, err)
+	}
+	if len(segments) == 7 && segments[6] != "*" && segments[6] != "?" {
+		return "", errors.New("the cron expression restricts the year, which an RRULE cannot express")
+	}
+
+	// parse each field
+	fields := []cronField{secondField, minuteField, hourField, monthDayField, monthField, weekdayField}
+	values := make([][]int, len(fields))
+	for i, field := range fields {
+		if values[i], err = parseCronField(field, segments[i]); err != nil {
+			return "", err
+		}
+	}
+	seconds, minutes, hours, monthDays, months, days := values[0], values[1], values[2], values[3], values[4], values[5]
+	if monthDays != nil && days != nil {
+		return "", errors.New("the cron expression restricts both the day of the month and the day of the week, " +
+			"matching days that match either, which an RRULE cannot express")
+	}
+
+	// the frequency is that of the finest field matching every value
+	var freq string
+	switch {
+	case seconds == nil:
+		freq = "SECONDLY"
+	case minutes == nil:
+		freq = "MINUTELY"
+	case hours == nil:
+		freq = "HOURLY"
+	case days != nil:
+		freq = "WEEKLY"
+	case monthDays != nil:
+		freq = "MONTHLY"
+	default:
+		freq = "DAILY"
+	}
+	atMidnight := slices.Equal(seconds, []int{0}) && slices.Equal(minutes, []int{0}) && slices.Equal(hours, []int{0})
+	if date && !atMidnight {
+		return "", errors.New("the cron expression recurs at a time of day, which an RRULE of an all-day event cannot express")
+	}
+
+	parts := []string{"FREQ=" + freq}
+	if months != nil {
+		parts = append(parts, "BYMONTH="+formatList(months, strconv.Itoa))
+	}
+	if monthDays != nil {
+		parts = append(parts, "BYMONTHDAY="+formatList(monthDays, strconv.Itoa))
+	}
+	if days != nil {
+		parts = append(parts, "BYDAY="+formatList(days, func(day int) string { return weekdays[day] }))
+	}
+	if !date {
+		if hours != nil {
+			parts = append(parts, "BYHOUR="+formatList(hours, strconv.Itoa))
+		}
+		if minutes != nil {
+			parts = append(parts, "BYMINUTE="+formatList(minutes, strconv.Itoa))
+		}
+		// DTSTART holds the seconds of occurrences on the minute
+		if seconds != nil && !slices.Equal(seconds, []int{0}) {
+			parts = append(parts, "BYSECOND="+formatList(seconds, strconv.Itoa))
+		}
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// FirstOccurrence returns the first occurrence of the cron expression in UTC at or after the given time,
+// which synchronizes the DTSTART of the RRULE returned by ToRRule with the expression.
+func FirstOccurrence(expr string, after time.Time) (time.Time, error) {
+	t, err := gronx.NextTickAfter(expr, after.UTC().Truncate(time.Second), true)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cron expression %q never occurs: %v", expr, err)
+	}
+	return t.UTC(), nil
+}
+
+// rrule is a parsed RRULE value.
+type rrule struct {
+	freq     int
+	interval int
+	until    time.Time
+	// by maps the BYxxx parts to their values
+	by map[string][]string
+}
+
+// parseRRule parses an RRULE value, rejecting the parts no cron expression can express.
+func parseRRule(value string, dtstart time.Time) (*rrule, error) {
+	r := &rrule{freq: -1, interval: 1, by: map[string][]string{}}
+	for _, part := range strings.Split(value, ";") {
+		name, partValue, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+		name = strings.ToUpper(name)
+		switch name {
+		case "FREQ":
+			r.freq = slices.Index(frequencies, strings.ToUpper(partValue))
+			if r.freq < 0 {
+				return nil, fmt.Errorf("unknown RRULE frequency %q", partValue)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(partValue)
+			if err != nil || interval <= 0 {
+				return nil, fmt.Errorf("invalid RRULE interval %q", partValue)
+			}
+			r.interval = interval
+		case "UNTIL":
+			until, _, err := parseTime(&property{name: name, value: partValue, params: map[string]string{"TZID": dtstart.Location().String()}})
+			if err != nil {
+				return nil, err
+			}
+			r.until = until
+		case "BYSECOND", "BYMINUTE", "BYHOUR", "BYDAY", "BYMONTHDAY", "BYMONTH":
+			r.by[name] = strings.Split(strings.ToUpper(partValue), ",")
+		case "WKST":
+			// only affects rules with an interval or week numbers, neither of which is expressible
+		case "COUNT", "BYYEARDAY", "BYWEEKNO", "BYSETPOS":
+			return nil, fmt.Errorf("the RRULE uses %s, which a cron expression cannot express", name)
+		default:
+			return nil, fmt.Errorf("unknown RRULE part %s", name)
+		}
+	}
+	if r.freq < 0 {
+		return nil, errors.New("the RRULE has no FREQ")
+	}
+	return r, nil
+}
+
+// cronList converts the values of a BYxxx part into a cron field, checking that each is within the field's range.
+func cronList(field cronField, values []string) (string, error) {
+	numbers := make([]int, len(values))
+	for i, value := range values {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < field.min || n > field.max {
+			return "", fmt.Errorf("the RRULE %s %q cannot be expressed as a cron expression", field.part, value)
+		}
+		numbers[i] = n
+	}
+	return formatCronList(numbers), nil
+}
+
+// formatCronList formats values as a cron field, with runs of three or more consecutive values as ranges.
+func formatCronList(values []int) string {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	values = slices.Compact(values)
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			items = append(items, fmt.Sprintf("%d-%d", values[i], values[j]))
+		case j-i == 1:
+			items = append(items, strconv.Itoa(values[i]), strconv.Itoa(values[j]))
+		default:
+			items = append(items, strconv.Itoa(values[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(items, ",")
+}
+
+// FromRRule translates an RRULE value and its DTSTART into a cron expression in UTC and the UNTIL of the rule,
+// which is zero if the rule has none. Parts an RRULE leaves out are taken from DTSTART as RFC 5545 specifies.
+// RRULEs no cron expression can express, such as those with a COUNT or an ordinal BYDAY, return an error
+// saying why, as do rules with BYHOUR or BYMINUTE whose DTSTART is not in UTC, which would shift between
+// daylight saving time and standard time.
+func FromRRule(value string, dtstart time.Time) (string, time.Time, error) {
+	r, err := parseRRule(value, dtstart)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	utc := dtstart.UTC()
+	if _, offset := dtstart.Zone(); offset != 0 {
+		if r.by["BYHOUR"] != nil || r.by["BYMINUTE"] != nil {
+			return "", time.Time{}, errors.New("the RRULE sets BYHOUR or BYMINUTE in a time zone other than UTC, which a cron expression cannot express")
+		}
+		if (r.by["BYDAY"] != nil || r.by["BYMONTHDAY"] != nil) && utc.Day() != dtstart.Day() {
+			return "", time.Time{}, errors.New("the RRULE sets BYDAY or BYMONTHDAY of a start whose date differs in UTC, which a cron expression cannot express")
+		}
+	}
+	if r.by["BYDAY"] != nil && r.by["BYMONTHDAY"] != nil {
+		return "", time.Time{}, errors.New("the RRULE sets both BYDAY and BYMONTHDAY, matching days that match both, which a cron expression cannot express")
+	}
+	freq := frequencies[r.freq]
+	if freq == "WEEKLY" && r.by["BYMONTHDAY"] != nil {
+		return "", time.Time{}, errors.New("the RRULE sets BYMONTHDAY of a WEEKLY rule")
+	}
+
+	// each time field is the BYxxx part, every value at a finer frequency, or DTSTART's value
+	timeField := func(field cronField, freqIndex int, dtstartValue int) (string, error) {
+		if values := r.by[field.part]; values != nil {
+			return cronList(field, values)
+		}
+		if r.freq > freqIndex {
+			return strconv.Itoa(dtstartValue), nil
+		}
+		return "*", nil
+	}
+	second, err := timeField(secondField, 0, utc.Second())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	minute, err := timeField(minuteField, 1, utc.Minute())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	hour, err := timeField(hourField, 2, utc.Hour())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// day fields
+	monthDay, month, weekday := "*", "*", "*"
+	if values := r.by["BYMONTHDAY"]; values != nil {
+		if monthDay, err = cronList(monthDayField, values); err != nil {
+			return "", time.Time{}, err
+		}
+	}
+	if values := r.by["BYMONTH"]; values != nil {
+		if month, err = cronList(monthField, values); err != nil {
+			return "", time.Time{}, err
+		}
+	}
+	if values := r.by["BYDAY"]; values != nil {
+		days := make([]int, len(values))
+		for i, value := range values {
+			if days[i] = slices.Index(weekdays, value); days[i] < 0 {
+				return "", time.Time{}, fmt.Errorf("the RRULE BYDAY %q is not a plain day of the week, such as one with an ordinal, which a cron expression cannot express", value)
+			}
+		}
+		weekday = formatCronList(days)
+	}
+	noDays := r.by["BYDAY"] == nil && r.by["BYMONTHDAY"] == nil
+	switch freq {
+	case "WEEKLY":
+		if weekday == "*" {
+			weekday = strconv.Itoa(int(utc.Weekday()))
+		}
+	case "MONTHLY":
+		if noDays {
+			monthDay = strconv.Itoa(utc.Day())
+		}
+	case "YEARLY":
+		if noDays {
+			monthDay = strconv.Itoa(utc.Day())
+			if month == "*" {
+				month = strconv.Itoa(int(utc.Month()))
+			}
+		}
+	}
+
+	// intervals are steps of the field that varies at the rule's frequency, which must divide its cycle
+	if r.interval > 1 {
+		var field *string
+		var cycle, start int
+		switch freq {
+		case "SECONDLY":
+			field, cycle, start = &second, 60, utc.Second()
+		case "MINUTELY":
+			field, cycle, start = &minute, 60, utc.Minute()
+		case "HOURLY":
+			field, cycle, start = &hour, 24, utc.Hour()
+		case "MONTHLY":
+			field, cycle, start = &month, 12, int(utc.Month())-1
+		}
+		if field == nil || *field != "*" || cycle%r.interval != 0 {
+			return "", time.Time{}, fmt.Errorf("the RRULE INTERVAL=%d of a %s rule cannot be expressed as a cron expression", r.interval, freq)
+		}
+		if freq == "MONTHLY" {
+			*field = fmt.Sprintf("%d-12/%d", start%r.interval+1, r.interval)
+		} else {
+			*field = fmt.Sprintf("%d-%d/%d", start%r.interval, cycle-1, r.interval)
+		}
+	}
+
+	fields := []string{minute, hour, monthDay, month, weekday}
+	if second != "0" {
+		fields = append([]string{second}, fields...)
+	}
+	expr := strings.Join(fields, " ")
+	if !gronx.IsValid(expr) {
+		return "", time.Time{}, fmt.Errorf("the RRULE translates into the invalid cron expression %q", expr)
+	}
+	return expr, r.until, nil
+}
@@ -0,0 +1,157 @@
+package ical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adhocore/gronx"
+)
+
+func mustParseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("failed to parse time: %v", err)
+	}
+	return ts
+}
+
+func TestToRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		date    bool
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", expr: "30 9 * * *", want: "FREQ=DAILY;BYHOUR=9;BYMINUTE=30"},
+		{name: "weekly", expr: "0 9 * * MON,FRI", want: "FREQ=WEEKLY;BYDAY=MO,FR;BYHOUR=9;BYMINUTE=0"},
+		{name: "weekdays", expr: "0 9 * * 1-5", want: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0"},
+		{name: "sunday as 7", expr: "0 0 * * 7", want: "FREQ=WEEKLY;BYDAY=SU;BYHOUR=0;BYMINUTE=0"},
+		{name: "monthly", expr: "0 0 1,15 * *", want: "FREQ=MONTHLY;BYMONTHDAY=1,15;BYHOUR=0;BYMINUTE=0"},
+		{name: "yearly", expr: "0 0 25 12 *", want: "FREQ=MONTHLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=0;BYMINUTE=0"},
+		{name: "macro", expr: "@daily", want: "FREQ=DAILY;BYHOUR=0;BYMINUTE=0"},
+		{name: "hourly", expr: "15 * * * *", want: "FREQ=HOURLY;BYMINUTE=15"},
+		{name: "every 15 minutes", expr: "*/15 * * * *", want: "FREQ=HOURLY;BYMINUTE=0,15,30,45"},
+		{name: "every minute", expr: "* * * * *", want: "FREQ=MINUTELY"},
+		{name: "seconds", expr: "30 0 12 * * *", want: "FREQ=DAILY;BYHOUR=12;BYMINUTE=0;BYSECOND=30"},
+		{name: "all-day", expr: "0 0 * * 1", date: true, want: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "all-day at a time", expr: "0 9 * * 1", date: true, wantErr: true},
+		{name: "day of month and week", expr: "0 0 1 * 1", wantErr: true},
+		{name: "last day of month", expr: "0 0 L * *", wantErr: true},
+		{name: "nth weekday", expr: "0 0 * * 1#2", wantErr: true},
+		{name: "year", expr: "0 0 0 1 1 * 2030", wantErr: true},
+		{name: "invalid", expr: "not cron", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToRRule(tt.expr, tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToRRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ToRRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRRule(t *testing.T) {
+	utcStart := mustParseTime(t, "2024-03-05T09:30:00Z") // a tuesday
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	tests := []struct {
+		name      string
+		rrule     string
+		dtstart   time.Time
+		want      string
+		wantUntil time.Time
+		wantErr   bool
+	}{
+		{name: "daily", rrule: "FREQ=DAILY", dtstart: utcStart, want: "30 9 * * *"},
+		{name: "weekly", rrule: "FREQ=WEEKLY", dtstart: utcStart, want: "30 9 * * 2"},
+		{name: "weekly by day", rrule: "FREQ=WEEKLY;BYDAY=MO,WE", dtstart: utcStart, want: "30 9 * * 1,3"},
+		{name: "monthly", rrule: "FREQ=MONTHLY", dtstart: utcStart, want: "30 9 5 * *"},
+		{name: "monthly by day", rrule: "FREQ=MONTHLY;BYDAY=FR", dtstart: utcStart, want: "30 9 * * 5"},
+		{name: "yearly", rrule: "FREQ=YEARLY", dtstart: utcStart, want: "30 9 5 3 *"},
+		{name: "yearly by month", rrule: "FREQ=YEARLY;BYMONTH=1,7", dtstart: utcStart, want: "30 9 5 1,7 *"},
+		{name: "hourly by minute", rrule: "FREQ=HOURLY;BYMINUTE=0,30", dtstart: utcStart, want: "0,30 * * * *"},
+		{name: "every 15 minutes", rrule: "FREQ=MINUTELY;INTERVAL=15", dtstart: utcStart, want: "0-59/15 * * * *"},
+		{name: "every 6 hours", rrule: "FREQ=HOURLY;INTERVAL=6", dtstart: utcStart, want: "30 3-23/6 * * *"},
+		{name: "quarterly", rrule: "FREQ=MONTHLY;INTERVAL=3", dtstart: utcStart, want: "30 9 5 3-12/3 *"},
+		{name: "seconds", rrule: "FREQ=DAILY", dtstart: utcStart.Add(15 * time.Second), want: "15 30 9 * * *"},
+		{
+			name: "until", rrule: "FREQ=DAILY;UNTIL=20241231T000000Z", dtstart: utcStart,
+			want: "30 9 * * *", wantUntil: mustParseTime(t, "2024-12-31T00:00:00Z"),
+		},
+		{name: "time zone", rrule: "FREQ=WEEKLY", dtstart: time.Date(2024, 3, 5, 9, 0, 0, 0, newYork), want: "0 14 * * 2"},
+		{name: "time zone with hour", rrule: "FREQ=DAILY;BYHOUR=9", dtstart: time.Date(2024, 3, 5, 9, 0, 0, 0, newYork), wantErr: true},
+		{name: "time zone across dates", rrule: "FREQ=WEEKLY;BYDAY=MO", dtstart: time.Date(2024, 3, 5, 22, 0, 0, 0, newYork), wantErr: true},
+		{name: "count", rrule: "FREQ=DAILY;COUNT=5", dtstart: utcStart, wantErr: true},
+		{name: "ordinal day", rrule: "FREQ=MONTHLY;BYDAY=2MO", dtstart: utcStart, wantErr: true},
+		{name: "last day of month", rrule: "FREQ=MONTHLY;BYMONTHDAY=-1", dtstart: utcStart, wantErr: true},
+		{name: "day of month and week", rrule: "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", dtstart: utcStart, wantErr: true},
+		{name: "every other week", rrule: "FREQ=WEEKLY;INTERVAL=2", dtstart: utcStart, wantErr: true},
+		{name: "uneven interval", rrule: "FREQ=MINUTELY;INTERVAL=7", dtstart: utcStart, wantErr: true},
+		{name: "set position", rrule: "FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=1", dtstart: utcStart, wantErr: true},
+		{name: "no frequency", rrule: "INTERVAL=2", dtstart: utcStart, wantErr: true},
+		{name: "unknown frequency", rrule: "FREQ=FORTNIGHTLY", dtstart: utcStart, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotUntil, err := FromRRule(tt.rrule, tt.dtstart)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromRRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FromRRule() = %q, want %q", got, tt.want)
+			}
+			if !gotUntil.Equal(tt.wantUntil) {
+				t.Errorf("FromRRule() until = %v, want %v", gotUntil, tt.wantUntil)
+			}
+		})
+	}
+}
+
+// TestRRule_roundTrip checks that translating a cron expression into an RRULE and back keeps its occurrences.
+func TestRRule_roundTrip(t *testing.T) {
+	start := mustParseTime(t, "2024-01-01T00:00:00Z")
+	for _, expr := range []string{
+		"0 9 * * 1-5",
+		"30 18 1,15 * *",
+		"0 0 25 12 *",
+		"*/20 * * * *",
+		"0 */6 * * *",
+		"45 7 * 6-8 6,0",
+		"10 0 12 * * *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			rrule, err := ToRRule(expr, false)
+			if err != nil {
+				t.Fatalf("ToRRule() error = %v", err)
+			}
+			dtstart, err := FirstOccurrence(expr, start)
+			if err != nil {
+				t.Fatalf("FirstOccurrence() error = %v", err)
+			}
+			got, _, err := FromRRule(rrule, dtstart)
+			if err != nil {
+				t.Fatalf("FromRRule(%q) error = %v", rrule, err)
+			}
+			want, next := dtstart, dtstart
+			for i := 0; i < 50; i++ {
+				if want, err = gronx.NextTickAfter(expr, want, false); err != nil {
+					t.Fatalf("NextTickAfter() error = %v", err)
+				}
+				if next, err = gronx.NextTickAfter(got, next, false); err != nil {
+					t.Fatalf("NextTickAfter() error = %v", err)
+				}
+				if !next.Equal(want) {
+					t.Fatalf("%q from RRULE %q occurs at %v, want %v", got, rrule, next, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package cert_manager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertManager serves a certificate and key loaded from PEM files, reloading them whenever either file changes
+// so that certificates can be rotated without restarting.
+type CertManager struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// NewCertManager loads the certificate and key in the given files and returns a new instance of CertManager.
+func NewCertManager(certFile, keyFile string) (*CertManager, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("cert file and key file cannot be empty")
+	}
+	cm := &CertManager{certFile: certFile, keyFile: keyFile}
+	if err := cm.reload(); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// modTimes returns the modification times of the certificate and key files.
+func (cm *CertManager) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(cm.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat cert file: %v", err)
+	}
+	keyInfo, err := os.Stat(cm.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat key file: %v", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// reload loads the certificate and key if either file changed since they were last loaded.
+// The caller must not hold the lock.
+func (cm *CertManager) reload() error {
+	certModTime, keyModTime, err := cm.modTimes()
+	if err != nil {
+		return err
+	}
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.cert != nil && certModTime.Equal(cm.certModTime) && keyModTime.Equal(cm.keyModTime) {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(cm.certFile, cm.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %v", err)
+	}
+	cm.cert = &cert
+	cm.certModTime = certModTime
+	cm.keyModTime = keyModTime
+	return nil
+}
+
+// certificate returns the current certificate, reloading it first if its files changed.
+// If reloading fails, such as while the files are being replaced, the previous certificate is kept.
+func (cm *CertManager) certificate() *tls.Certificate {
+	if err := cm.reload(); err != nil {
+		slog.Warn("failed to reload certificate, keeping previous certificate", "cert_file", cm.certFile, "error", err)
+	}
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.cert
+}
+
+// GetCertificate implements tls.Config.GetCertificate for servers.
+func (cm *CertManager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return cm.certificate(), nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate for clients using mutual TLS.
+func (cm *CertManager) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return cm.certificate(), nil
+}
+
+// loadCertPool returns a pool of the PEM encoded certificates in the given file.
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", file)
+	}
+	return pool, nil
+}
+
+// CAManager serves a pool of the CAs in a PEM file, reloading it whenever the file changes so that CAs
+// can be rotated without restarting.
+type CAManager struct {
+	file string
+
+	mu      sync.Mutex
+	pool    *x509.CertPool
+	modTime time.Time
+}
+
+// NewCAManager loads the CAs in the given file and returns a new instance of CAManager.
+func NewCAManager(file string) (*CAManager, error) {
+	if file == "" {
+		return nil, errors.New("CA file cannot be empty")
+	}
+	cam := &CAManager{file: file}
+	if err := cam.reload(); err != nil {
+		return nil, err
+	}
+	return cam, nil
+}
+
+// reload loads the CAs if their file changed since they were last loaded.
+// The caller must not hold the lock.
+func (cam *CAManager) reload() error {
+	info, err := os.Stat(cam.file)
+	if err != nil {
+		return fmt.Errorf("failed to stat CA file: %v", err)
+	}
+	cam.mu.Lock()
+	defer cam.mu.Unlock()
+	if cam.pool != nil && info.ModTime().Equal(cam.modTime) {
+		return nil
+	}
+	pool, err := loadCertPool(cam.file)
+	if err != nil {
+		return err
+	}
+	cam.pool = pool
+	cam.modTime = info.ModTime()
+	return nil
+}
+
+// Pool returns the current pool of CAs, reloading it first if its file changed.
+// If reloading fails, such as while the file is being replaced, the previous pool is kept.
+func (cam *CAManager) Pool() *x509.CertPool {
+	if err := cam.reload(); err != nil {
+		slog.Warn("failed to reload CAs, keeping previous CAs", "ca_file", cam.file, "error", err)
+	}
+	cam.mu.Lock()
+	defer cam.mu.Unlock()
+	return cam.pool
+}
+
+// ServerTLSConfig returns the TLS config of a server presenting the certificate in the given files.
+// If clientCAFile is not empty, clients must present a certificate signed by one of its CAs (mutual TLS).
+// The certificate and the client CAs are reloaded when their files change.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cm, err := NewCertManager(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: cm.GetCertificate,
+	}
+	if clientCAFile != "" {
+		cam, err := NewCAManager(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = cam.Pool()
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		// each handshake verifies the client against the current CAs
+		base := cfg.Clone()
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			clientCfg := base.Clone()
+			clientCfg.ClientCAs = cam.Pool()
+			return clientCfg, nil
+		}
+	}
+	return cfg, nil
+}
+
+// ClientTLSConfig returns the TLS config of a client. If caFile is not empty, only servers with certificates
+// signed by one of its CAs are trusted instead of those trusted by the system. If certFile and keyFile are
+// not empty, their certificate is presented to servers requiring mutual TLS.
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cm, err := NewCertManager(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = cm.GetClientCertificate
+	}
+	return cfg, nil
+}
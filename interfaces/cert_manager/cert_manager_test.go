@@ -0,0 +1,268 @@
+package cert_manager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a throwaway certificate and its key.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert generates a certificate valid for localhost, signed by parent or self-signed as a CA if parent is nil.
+func newTestCert(t *testing.T, parent *testCert, serial int64) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+// write writes the certificate and key as PEM files into dir and returns their paths.
+func (c *testCert) write(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600); err != nil {
+		t.Fatalf("failed to write cert file: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// handshake performs a TLS handshake between the server and client configs over a local connection.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) error {
+	t.Helper()
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lis.Close()
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte{1})
+	}()
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientCfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// with TLS 1.3 a rejected client certificate is only reported on the first read
+	_, err = conn.Read(make([]byte, 1))
+	return err
+}
+
+func Test_TLSConfig_handshake(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, nil, 1)
+	caFile, _ := ca.write(t, dir, "ca")
+	otherCAFile, _ := newTestCert(t, nil, 2).write(t, dir, "other-ca")
+	serverCert, serverKey := newTestCert(t, ca, 3).write(t, dir, "server")
+	clientCert, clientKey := newTestCert(t, ca, 4).write(t, dir, "client")
+
+	tests := []struct {
+		name         string
+		clientCAFile string
+		caFile       string
+		certFile     string
+		keyFile      string
+		wantErr      bool
+	}{
+		{name: "tls", caFile: caFile, wantErr: false},
+		{name: "untrusted server", caFile: otherCAFile, wantErr: true},
+		{name: "mutual tls", clientCAFile: caFile, caFile: caFile, certFile: clientCert, keyFile: clientKey, wantErr: false},
+		{name: "mutual tls without client cert", clientCAFile: caFile, caFile: caFile, wantErr: true},
+		{name: "mutual tls with untrusted client cert", clientCAFile: otherCAFile, caFile: caFile, certFile: clientCert, keyFile: clientKey, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverCfg, err := ServerTLSConfig(serverCert, serverKey, tt.clientCAFile)
+			if err != nil {
+				t.Fatalf("ServerTLSConfig() error = %v", err)
+			}
+			clientCfg, err := ClientTLSConfig(tt.caFile, tt.certFile, tt.keyFile)
+			if err != nil {
+				t.Fatalf("ClientTLSConfig() error = %v", err)
+			}
+			clientCfg.ServerName = "localhost"
+			if err := handshake(t, serverCfg, clientCfg); (err != nil) != tt.wantErr {
+				t.Errorf("handshake error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_CertManager_reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, nil, 1)
+	certFile, keyFile := newTestCert(t, ca, 10).write(t, dir, "server")
+	cm, err := NewCertManager(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewCertManager() error = %v", err)
+	}
+	serial := func() int64 {
+		cert, err := cm.GetCertificate(nil)
+		if err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatalf("failed to parse certificate: %v", err)
+		}
+		return parsed.SerialNumber.Int64()
+	}
+	touch := func(at time.Time) {
+		for _, file := range []string{certFile, keyFile} {
+			if err := os.Chtimes(file, at, at); err != nil {
+				t.Fatalf("failed to change file times: %v", err)
+			}
+		}
+	}
+	if got := serial(); got != 10 {
+		t.Fatalf("GetCertificate() serial = %d, want 10", got)
+	}
+
+	// rotated files are reloaded
+	newTestCert(t, ca, 11).write(t, dir, "server")
+	touch(time.Now().Add(time.Minute))
+	if got := serial(); got != 11 {
+		t.Errorf("GetCertificate() after rotation serial = %d, want 11", got)
+	}
+
+	// invalid files keep the previous certificate
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write cert file: %v", err)
+	}
+	touch(time.Now().Add(2 * time.Minute))
+	if got := serial(); got != 11 {
+		t.Errorf("GetCertificate() after invalid rotation serial = %d, want 11", got)
+	}
+}
+
+func Test_ServerTLSConfig_reloadsClientCAs(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, nil, 1)
+	caFile, _ := ca.write(t, dir, "ca")
+	otherCA := newTestCert(t, nil, 2)
+	clientCAFile, _ := otherCA.write(t, dir, "client-ca")
+	serverCert, serverKey := newTestCert(t, ca, 3).write(t, dir, "server")
+	clientCert, clientKey := newTestCert(t, ca, 4).write(t, dir, "client")
+
+	serverCfg, err := ServerTLSConfig(serverCert, serverKey, clientCAFile)
+	if err != nil {
+		t.Fatalf("ServerTLSConfig() error = %v", err)
+	}
+	clientCfg, err := ClientTLSConfig(caFile, clientCert, clientKey)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig() error = %v", err)
+	}
+	clientCfg.ServerName = "localhost"
+	if err := handshake(t, serverCfg, clientCfg); err == nil {
+		t.Fatalf("handshake with untrusted client cert succeeded")
+	}
+
+	// a rotated client CA file is reloaded without restarting
+	ca.write(t, dir, "client-ca")
+	at := time.Now().Add(time.Minute)
+	if err := os.Chtimes(clientCAFile, at, at); err != nil {
+		t.Fatalf("failed to change file times: %v", err)
+	}
+	if err := handshake(t, serverCfg, clientCfg); err != nil {
+		t.Errorf("handshake after client CA rotation error = %v", err)
+	}
+}
+
+func Test_NewCAManager(t *testing.T) {
+	dir := t.TempDir()
+	caFile, keyFile := newTestCert(t, nil, 1).write(t, dir, "ca")
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "happy path", file: caFile, wantErr: false},
+		{name: "empty file", file: "", wantErr: true},
+		{name: "missing file", file: filepath.Join(dir, "missing.crt"), wantErr: true},
+		{name: "no certificates", file: keyFile, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCAManager(tt.file); (err != nil) != tt.wantErr {
+				t.Errorf("NewCAManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_NewCertManager(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := newTestCert(t, nil, 1).write(t, dir, "cert")
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		wantErr  bool
+	}{
+		{name: "happy path", certFile: certFile, keyFile: keyFile, wantErr: false},
+		{name: "empty key file", certFile: certFile, keyFile: "", wantErr: true},
+		{name: "missing files", certFile: filepath.Join(dir, "missing.crt"), keyFile: keyFile, wantErr: true},
+		{name: "mismatched files", certFile: keyFile, keyFile: certFile, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCertManager(tt.certFile, tt.keyFile); (err != nil) != tt.wantErr {
+				t.Errorf("NewCertManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// AddAuditEntry puts an entry into the audit table, overwriting the creation time of the given entry
+// with the current time.
+func (ddb *DynamoDBClient) AddAuditEntry(ctx context.Context, req *storage.AddAuditEntryReq) (*storage.AddAuditEntryResp, error) {
+	entry := req.Entry
+	entry.CreatedAt = time.Now().Unix()
+	item, err := attributevalue.MarshalMapWithOptions(entry, encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit entry: %v", err)
+	}
+	_, err = ddb.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &ddb.auditTableName,
+		Item:      item,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put entry into audit table: %v", err)
+	}
+	return &storage.AddAuditEntryResp{}, nil
+}
+
+// GetAuditEntries queries the entries of the project backwards, since the audit table sorts them by entry id,
+// stopping once the limit is reached.
+func (ddb *DynamoDBClient) GetAuditEntries(ctx context.Context, req *storage.GetAuditEntriesReq) (*storage.GetAuditEntriesResp, error) {
+	keyEx := expression.Key("project_id").Equal(modelValue(req.ProjectID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(ddb.auditTableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(false),
+	}
+	if req.Limit > 0 {
+		input.Limit = aws.Int32(req.Limit)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, input)
+	var entries []storage.AuditEntry
+	for queryPaginator.HasMorePages() && (req.Limit <= 0 || len(entries) < int(req.Limit)) {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		var entryPage []storage.AuditEntry
+		err = attributevalue.UnmarshalListOfMapsWithOptions(response.Items, &entryPage, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		entries = append(entries, entryPage...)
+	}
+	return &storage.GetAuditEntriesResp{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// checklistItemPath returns the document path of an attribute of the checklist item at the given index.
+func checklistItemPath(index int, attribute string) string {
+	return fmt.Sprintf("%s[%d].%s", storage.ChecklistKey, index, attribute)
+}
+
+// updateChecklist applies the update to an existing task if the condition holds, also setting updated_at,
+// and returns the updated task. It returns storage.ErrNotFound if the task does not exist and storage.ErrConditionFailed
+// if the condition does not hold.
+func (ddb *DynamoDBClient) updateChecklist(
+	ctx context.Context,
+	userID, taskID string,
+	update expression.UpdateBuilder,
+	cond *expression.ConditionBuilder,
+) (*storage.Task, error) {
+	update = update.Set(expression.Name(storage.UpdatedAtKey), modelValue(time.Now().Unix()))
+	exists := expression.Equal(expression.Name(storage.TaskIDKey), modelValue(taskID))
+	if cond != nil {
+		exists = exists.And(*cond)
+	}
+	expr, err := expression.NewBuilder().WithCondition(exists).WithUpdate(update).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	resp, err := ddb.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                           aws.String(ddb.tasksTableName),
+		Key:                                 taskKey(userID, taskID),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ConditionExpression:                 expr.Condition(),
+		UpdateExpression:                    expr.Update(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update item: %w", conditionCheckError(err))
+	}
+	task := &storage.Task{}
+	if err = unmarshalTask(resp.Attributes, task); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attribute map: %v", err)
+	}
+	return task, nil
+}
+
+// itemAtIndex matches tasks whose checklist item at the given index has the given id,
+// guarding index based updates against concurrent changes to the checklist.
+func itemAtIndex(index int, itemID string) expression.ConditionBuilder {
+	return expression.Equal(expression.Name(checklistItemPath(index, "id")), modelValue(itemID))
+}
+
+// checklistEquals matches tasks whose checklist equals the given checklist. Empty checklists are not stored,
+// so an empty checklist also matches tasks without one.
+func checklistEquals(checklist []storage.ChecklistItem) expression.ConditionBuilder {
+	cond := expression.Equal(expression.Name(storage.ChecklistKey), modelValue(checklist))
+	if len(checklist) == 0 {
+		cond = expression.AttributeNotExists(expression.Name(storage.ChecklistKey)).Or(cond)
+	}
+	return cond
+}
+
+// AddChecklistItem appends an item to the end of a task's checklist.
+func (ddb *DynamoDBClient) AddChecklistItem(ctx context.Context, req *storage.AddChecklistItemReq) (*storage.AddChecklistItemResp, error) {
+	checklist := expression.IfNotExists(expression.Name(storage.ChecklistKey), modelValue([]storage.ChecklistItem{}))
+	update := expression.Set(expression.Name(storage.ChecklistKey), expression.ListAppend(checklist, modelValue([]storage.ChecklistItem{req.Item})))
+	task, err := ddb.updateChecklist(ctx, req.UserID, req.TaskID, update, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.AddChecklistItemResp{Task: *task}, nil
+}
+
+// SetChecklistItemDone marks a checklist item as done or not done. It fails with storage.ErrConditionFailed
+// if the item is no longer at the given index or is already in the requested state.
+func (ddb *DynamoDBClient) SetChecklistItemDone(ctx context.Context, req *storage.SetChecklistItemDoneReq) (*storage.SetChecklistItemDoneResp, error) {
+	donePath := expression.Name(checklistItemPath(req.Index, "done"))
+	update := expression.Set(donePath, modelValue(req.Done))
+	cond := itemAtIndex(req.Index, req.ItemID).And(expression.NotEqual(donePath, modelValue(req.Done)))
+	task, err := ddb.updateChecklist(ctx, req.UserID, req.TaskID, update, &cond)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.SetChecklistItemDoneResp{Task: *task}, nil
+}
+
+// RemoveChecklistItem removes an item from a task's checklist. It fails with storage.ErrConditionFailed
+// if the item is no longer at the given index.
+func (ddb *DynamoDBClient) RemoveChecklistItem(ctx context.Context, req *storage.RemoveChecklistItemReq) (*storage.RemoveChecklistItemResp, error) {
+	update := expression.Remove(expression.Name(fmt.Sprintf("%s[%d]", storage.ChecklistKey, req.Index)))
+	cond := itemAtIndex(req.Index, req.ItemID)
+	task, err := ddb.updateChecklist(ctx, req.UserID, req.TaskID, update, &cond)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.RemoveChecklistItemResp{Task: *task}, nil
+}
+
+// ReplaceChecklist replaces a task's checklist, such as to reorder it. It fails with storage.ErrConditionFailed
+// if the stored checklist is not the expected checklist.
+func (ddb *DynamoDBClient) ReplaceChecklist(ctx context.Context, req *storage.ReplaceChecklistReq) (*storage.ReplaceChecklistResp, error) {
+	update := expression.Set(expression.Name(storage.ChecklistKey), modelValue(req.Checklist))
+	cond := checklistEquals(req.ExpectedChecklist)
+	task, err := ddb.updateChecklist(ctx, req.UserID, req.TaskID, update, &cond)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.ReplaceChecklistResp{Task: *task}, nil
+}
@@ -0,0 +1,26 @@
+//go:build integration
+
+package dynamodb_test
+
+import (
+	"context"
+	"testing"
+	"todo/interfaces/dynamodb"
+	"todo/interfaces/storage/conformance"
+)
+
+func Test_Integration_DynamoDBClient_Conformance(t *testing.T) {
+	client, err := dynamodb.NewDynamoDBClient(context.Background(), dynamodb.TableNames{
+		Users:    "todo-users",
+		Tasks:    "todo-tasks",
+		Events:   "todo-events",
+		Views:    "todo-views",
+		Projects: "todo-projects",
+		Members:  "todo-members",
+		Audit:    "todo-audit",
+	}, "")
+	if err != nil {
+		t.Fatalf("NewDynamoDBClient() error = %v", err)
+	}
+	conformance.Run(t, client)
+}
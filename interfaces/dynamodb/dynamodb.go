@@ -3,30 +3,93 @@ package dynamodb
 import (
 	"context"
 	"fmt"
+	"todo/interfaces/storage"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 type DynamoDBClient struct {
-	client          *dynamodb.Client
-	usersTableName  string
-	tasksTableName  string
-	eventsTableName string
+	client            *dynamodb.Client
+	usersTableName    string
+	tasksTableName    string
+	eventsTableName   string
+	viewsTableName    string
+	projectsTableName string
+	membersTableName  string
+	auditTableName    string
 }
 
 // make client implement defined interface
-var _ DynamoDBInterface = &DynamoDBClient{}
+var _ storage.Backend = &DynamoDBClient{}
 
-func NewDynamoDBClient(ctx context.Context) (*DynamoDBClient, error) {
+// tagKey is the struct tag naming the attributes of the stored models.
+const tagKey = "json"
+
+func encoderOptions(o *attributevalue.EncoderOptions) { o.TagKey = tagKey }
+func decoderOptions(o *attributevalue.DecoderOptions) { o.TagKey = tagKey }
+
+// modelValue is expression.Value for values that may hold stored models.
+func modelValue(value interface{}) expression.ValueBuilder {
+	return expression.ValueWithOptions(value, func(o *expression.ValueBuilderOptions) {
+		o.EncoderOptions = append(o.EncoderOptions, encoderOptions)
+	})
+}
+
+// TableNames are the full names of the tables the client uses.
+type TableNames struct {
+	Users    string
+	Tasks    string
+	Events   string
+	Views    string
+	Projects string
+	Members  string
+	Audit    string
+}
+
+// NewDynamoDBClient returns a client of the given tables using the default aws config.
+// A non-empty endpoint overrides the DynamoDB endpoint, such as to use DynamoDB Local.
+func NewDynamoDBClient(ctx context.Context, tables TableNames, endpoint string) (*DynamoDBClient, error) {
 	defaultConfig, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load default aws config: %v", err)
 	}
+	client := dynamodb.NewFromConfig(defaultConfig, func(o *dynamodb.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.APIOptions = append(o.APIOptions, addTraceOperation, addLogOperation, addRecordOperation)
+	})
 	return &DynamoDBClient{
-		client:          dynamodb.NewFromConfig(defaultConfig),
-		usersTableName:  "todo-users",
-		tasksTableName:  "todo-tasks",
-		eventsTableName: "todo-events",
+		client:            client,
+		usersTableName:    tables.Users,
+		tasksTableName:    tables.Tasks,
+		eventsTableName:   tables.Events,
+		viewsTableName:    tables.Views,
+		projectsTableName: tables.Projects,
+		membersTableName:  tables.Members,
+		auditTableName:    tables.Audit,
 	}, nil
 }
+
+// Ping describes every table, returning an error if any cannot be described.
+func (ddb *DynamoDBClient) Ping(ctx context.Context) error {
+	for _, table := range []string{ddb.usersTableName, ddb.tasksTableName, ddb.eventsTableName, ddb.viewsTableName, ddb.projectsTableName, ddb.membersTableName, ddb.auditTableName} {
+		_, err := ddb.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(table),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe table %s: %v", table, err)
+		}
+	}
+	return nil
+}
+
+// Close does nothing, since the DynamoDB client sends each request over http and holds no connections
+// that need to be released.
+func (ddb *DynamoDBClient) Close() error {
+	return nil
+}
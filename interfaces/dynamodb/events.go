@@ -3,39 +3,131 @@ package dynamodb
 import (
 	"context"
 	"errors"
-)
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
 
-type AddEventReq struct{}
-type AddEventResp struct{}
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
 
-func (ddb *DynamoDBClient) AddEvent(ctx context.Context, req *AddEventReq) (*AddEventResp, error) {
-	return nil, errors.New("not implemented yet")
+// eventKey returns the primary key of an event in the events table.
+func eventKey(userID, eventID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id":  &types.AttributeValueMemberS{Value: userID},
+		"event_id": &types.AttributeValueMemberS{Value: eventID},
+	}
 }
 
-type GetEventReq struct{}
-type GetEventResp struct{}
-
-func (ddb *DynamoDBClient) GetEvent(ctx context.Context, req *GetEventReq) (*GetEventResp, error) {
-	return nil, errors.New("not implemented yet")
+// AddEvent puts an event into the events table, overwriting any timestamps on the given event with the current time.
+func (ddb *DynamoDBClient) AddEvent(ctx context.Context, req *storage.AddEventReq) (*storage.AddEventResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewEvent(req.Event, time.Now().Unix()), encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %v", err)
+	}
+	_, err = ddb.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &ddb.eventsTableName,
+		Item:      item,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put event into events table: %v", err)
+	}
+	return &storage.AddEventResp{}, nil
 }
 
-type BatchGetEventReq struct{}
-type BatchGetEventResp struct{}
+func (ddb *DynamoDBClient) GetEvent(ctx context.Context, req *storage.GetEventReq) (*storage.GetEventResp, error) {
+	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &ddb.eventsTableName,
+		Key:       eventKey(req.UserID, req.EventID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %v", err)
+	}
+	var event *storage.Event
+	if getItemResp.Item != nil {
+		event = &storage.Event{}
+		err = attributevalue.UnmarshalMapWithOptions(getItemResp.Item, event, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %v", err)
+		}
+	}
+	return &storage.GetEventResp{
+		Event: event,
+	}, nil
+}
 
-func (ddb *DynamoDBClient) BatchGetEvent(ctx context.Context, req *BatchGetEventReq) (*BatchGetEventResp, error) {
+func (ddb *DynamoDBClient) BatchGetEvent(ctx context.Context, req *storage.BatchGetEventReq) (*storage.BatchGetEventResp, error) {
 	return nil, errors.New("not implemented yet")
 }
 
-type UpdateEventReq struct{}
-type UpdateEventResp struct{}
+// GetAllEvents queries the user's events, which the events table sorts by event id.
+func (ddb *DynamoDBClient) GetAllEvents(ctx context.Context, req *storage.GetAllEventsReq) (*storage.GetAllEventsResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	startKey, err := decodePageToken(req.PageToken, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %v", err)
+	}
+	keyEx := expression.Key("user_id").Equal(modelValue(req.UserID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(ddb.eventsTableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExclusiveStartKey:         startKey,
+	}
+	if req.Limit > 0 {
+		input.Limit = aws.Int32(req.Limit)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, input)
+	var events []storage.Event
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for queryPaginator.HasMorePages() {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		var eventPage []storage.Event
+		err = attributevalue.UnmarshalListOfMapsWithOptions(response.Items, &eventPage, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		events = append(events, eventPage...)
+		// a limited query returns a single page
+		if req.Limit > 0 {
+			lastEvaluatedKey = response.LastEvaluatedKey
+			break
+		}
+	}
+	nextPageToken, err := encodePageToken(lastEvaluatedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next page token: %v", err)
+	}
+	return &storage.GetAllEventsResp{
+		Events:        events,
+		NextPageToken: nextPageToken,
+	}, nil
+}
 
-func (ddb *DynamoDBClient) UpdateEvent(ctx context.Context, req *UpdateEventReq) (*UpdateEventResp, error) {
+func (ddb *DynamoDBClient) UpdateEvent(ctx context.Context, req *storage.UpdateEventReq) (*storage.UpdateEventResp, error) {
 	return nil, errors.New("not implemented yet")
 }
 
-type DeleteEventReq struct{}
-type DeleteEventResp struct{}
-
-func (ddb *DynamoDBClient) DeleteEvent(ctx context.Context, req *DeleteEventReq) (*DeleteEventResp, error) {
-	return nil, errors.New("not implemented yet")
+func (ddb *DynamoDBClient) DeleteEvent(ctx context.Context, req *storage.DeleteEventReq) (*storage.DeleteEventResp, error) {
+	_, err := ddb.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &ddb.eventsTableName,
+		Key:       eventKey(req.UserID, req.EventID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete item: %v", err)
+	}
+	return &storage.DeleteEventResp{}, nil
 }
@@ -0,0 +1,120 @@
+package dynamodb
+
+import (
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+)
+
+// everyTask is a condition every task satisfies, since every task has a user id.
+var everyTask = expression.AttributeExists(expression.Name(storage.UserIDKey))
+
+// translateFilter returns a condition matching the tasks the filter matches, or false if the filter holds
+// a condition DynamoDB does not evaluate as Match does, such as one matching text regardless of case.
+// Comparisons of attributes a task is stored without are false, as they are in Match.
+func translateFilter(f storage.Filter) (expression.ConditionBuilder, bool) {
+	switch f := f.(type) {
+	case storage.And:
+		conds, ok := translateFilters(f)
+		if !ok {
+			return expression.ConditionBuilder{}, false
+		}
+		switch len(conds) {
+		case 0:
+			return everyTask, true
+		case 1:
+			return conds[0], true
+		default:
+			return expression.And(conds[0], conds[1], conds[2:]...), true
+		}
+	case storage.Or:
+		conds, ok := translateFilters(f)
+		if !ok {
+			return expression.ConditionBuilder{}, false
+		}
+		switch len(conds) {
+		case 0:
+			return expression.Not(everyTask), true
+		case 1:
+			return conds[0], true
+		default:
+			return expression.Or(conds[0], conds[1], conds[2:]...), true
+		}
+	case storage.Not:
+		cond, ok := translateFilter(f.Filter)
+		if !ok {
+			return expression.ConditionBuilder{}, false
+		}
+		return expression.Not(cond), true
+	case storage.Compare:
+		return translateCompare(f)
+	case storage.Contains:
+		// contains is case sensitive, so only tags, which are matched exactly, can be translated
+		if f.Key == storage.TagsKey {
+			return expression.Name(storage.TagsKey).Contains(f.Value), true
+		}
+	}
+	return expression.ConditionBuilder{}, false
+}
+
+// translateFilters translates every filter, returning false if any of them cannot be translated.
+func translateFilters(filters []storage.Filter) ([]expression.ConditionBuilder, bool) {
+	conds := make([]expression.ConditionBuilder, 0, len(filters))
+	for _, f := range filters {
+		cond, ok := translateFilter(f)
+		if !ok {
+			return nil, false
+		}
+		conds = append(conds, cond)
+	}
+	return conds, true
+}
+
+// translateCompare returns the condition of a comparison, which excludes tasks whose optional attribute is zero.
+func translateCompare(f storage.Compare) (expression.ConditionBuilder, bool) {
+	switch value := f.Value.(type) {
+	case string:
+		switch f.Key {
+		case storage.StatusKey, storage.PriorityKey:
+		case storage.ProjectIDKey:
+			// tasks stored before projects existed have no project id, which only equals no other project id
+			if f.Op != storage.OpEq || value == "" {
+				return expression.ConditionBuilder{}, false
+			}
+		default:
+			return expression.ConditionBuilder{}, false
+		}
+	case int64:
+		switch f.Key {
+		case storage.DueDateKey, storage.CreatedAtKey, storage.UpdatedAtKey, storage.CompletedAtKey, storage.EffortMinutesKey:
+		default:
+			return expression.ConditionBuilder{}, false
+		}
+	default:
+		return expression.ConditionBuilder{}, false
+	}
+	name, value := expression.Name(f.Key), modelValue(f.Value)
+	var cond expression.ConditionBuilder
+	switch f.Op {
+	case storage.OpEq:
+		cond = name.Equal(value)
+	case storage.OpLt:
+		cond = name.LessThan(value)
+	case storage.OpLe:
+		cond = name.LessThanEqual(value)
+	case storage.OpGt:
+		cond = name.GreaterThan(value)
+	case storage.OpGe:
+		cond = name.GreaterThanEqual(value)
+	default:
+		return expression.ConditionBuilder{}, false
+	}
+	if storage.OptionalAttribute(f.Key) {
+		cond = expression.And(name.NotEqual(modelValue(int64(0))), cond)
+	}
+	// tasks stored before priorities existed have no priority attribute and match as PRIORITY_UNSPECIFIED does
+	if f.Key == storage.PriorityKey && f.Match(&storage.Task{Priority: storage.UnspecifiedPriority}) {
+		cond = expression.Or(cond, name.AttributeNotExists())
+	}
+	return cond, true
+}
@@ -0,0 +1,106 @@
+package dynamodb
+
+import (
+	"testing"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+)
+
+func Test_translateFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter storage.Filter
+		// want is the built filter expression, or empty if the filter cannot be translated
+		want string
+	}{
+		{
+			name:   "compare",
+			filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "INCOMPLETE"},
+			want:   "#0 = :0",
+		},
+		{
+			name:   "compare optional attribute",
+			filter: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: int64(100)},
+			want:   "(#0 <> :0) AND (#0 < :1)",
+		},
+		{
+			name:   "not tag",
+			filter: storage.Not{Filter: storage.Contains{Key: storage.TagsKey, Value: "someday"}},
+			want:   "NOT (contains (#0, :0))",
+		},
+		{
+			name: "or",
+			filter: storage.Or{
+				storage.Compare{Key: storage.PriorityKey, Op: storage.OpLe, Value: "P1"},
+				storage.Compare{Key: storage.CreatedAtKey, Op: storage.OpGe, Value: int64(100)},
+			},
+			want: "(#0 <= :0) OR (#1 >= :1)",
+		},
+		{
+			name:   "priority before no priority",
+			filter: storage.Compare{Key: storage.PriorityKey, Op: storage.OpLt, Value: "P2"},
+			want:   "#0 < :0",
+		},
+		{
+			name:   "priority matching no priority",
+			filter: storage.Compare{Key: storage.PriorityKey, Op: storage.OpGt, Value: "P3"},
+			want:   "(#0 > :0) OR (attribute_not_exists (#0))",
+		},
+		{
+			name:   "empty and",
+			filter: storage.And{},
+			want:   "attribute_exists (#0)",
+		},
+		{
+			name:   "text",
+			filter: storage.Contains{Key: storage.TitleKey, Value: "notes"},
+		},
+		{
+			name: "or holding text",
+			filter: storage.Or{
+				storage.Contains{Key: storage.TagsKey, Value: "notes"},
+				storage.Contains{Key: storage.TitleKey, Value: "notes"},
+			},
+		},
+		{
+			name:   "project",
+			filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: "project"},
+			want:   "#0 = :0",
+		},
+		{
+			name:   "no project",
+			filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: ""},
+		},
+		{
+			name:   "project compared by order",
+			filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpLt, Value: "project"},
+		},
+		{
+			name:   "value of the wrong type",
+			filter: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: "tomorrow"},
+		},
+		{
+			name:   "unknown operator",
+			filter: storage.Compare{Key: storage.DueDateKey, Op: "!=", Value: int64(100)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, ok := translateFilter(tt.filter)
+			if ok != (tt.want != "") {
+				t.Fatalf("translateFilter() ok = %v, want %v", ok, tt.want != "")
+			}
+			if !ok {
+				return
+			}
+			expr, err := expression.NewBuilder().WithFilter(cond).Build()
+			if err != nil {
+				t.Fatalf("failed to build filter expression: %v", err)
+			}
+			if got := *expr.Filter(); got != tt.want {
+				t.Errorf("translateFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+	"todo/logging"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// logOperation logs every DynamoDB operation with its duration at debug level, using the logger of
+// the request that made it.
+var logOperation = middleware.InitializeMiddlewareFunc("LogOperation", func(
+	ctx context.Context,
+	in middleware.InitializeInput,
+	next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	attrs := []any{"operation", awsmiddleware.GetOperationName(ctx), "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	logging.FromContext(ctx).DebugContext(ctx, "dynamodb operation", attrs...)
+	return out, metadata, err
+})
+
+// addLogOperation adds logOperation to the stack of every operation.
+func addLogOperation(stack *middleware.Stack) error {
+	return stack.Initialize.Add(logOperation, middleware.After)
+}
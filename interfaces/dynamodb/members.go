@@ -0,0 +1,170 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// projectMembersIndex is the global secondary index of the members table keyed by project id and user id.
+const projectMembersIndex = "project_id-index"
+
+// memberKey returns the primary key of a member in the members table.
+func memberKey(userID, projectID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id":    &types.AttributeValueMemberS{Value: userID},
+		"project_id": &types.AttributeValueMemberS{Value: projectID},
+	}
+}
+
+// AddMember puts a member into the members table, overwriting any timestamps on the given member
+// with the current time.
+func (ddb *DynamoDBClient) AddMember(ctx context.Context, req *storage.AddMemberReq) (*storage.AddMemberResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewMember(req.Member, time.Now().Unix()), encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal member: %v", err)
+	}
+	_, err = ddb.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &ddb.membersTableName,
+		Item:      item,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put member into members table: %v", err)
+	}
+	return &storage.AddMemberResp{}, nil
+}
+
+func (ddb *DynamoDBClient) GetMember(ctx context.Context, req *storage.GetMemberReq) (*storage.GetMemberResp, error) {
+	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &ddb.membersTableName,
+		Key:       memberKey(req.UserID, req.ProjectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	var member *storage.Member
+	if getItemResp.Item != nil {
+		member = &storage.Member{}
+		err = attributevalue.UnmarshalMapWithOptions(getItemResp.Item, member, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal member: %v", err)
+		}
+	}
+	return &storage.GetMemberResp{
+		Member: member,
+	}, nil
+}
+
+// queryMembers returns every member matching the key condition in the members table or one of its indexes.
+func (ddb *DynamoDBClient) queryMembers(ctx context.Context, indexName *string, keyEx expression.KeyConditionBuilder) ([]storage.Member, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(ddb.membersTableName),
+		IndexName:                 indexName,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	var members []storage.Member
+	for queryPaginator.HasMorePages() {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		var memberPage []storage.Member
+		err = attributevalue.UnmarshalListOfMapsWithOptions(response.Items, &memberPage, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		members = append(members, memberPage...)
+	}
+	return members, nil
+}
+
+// GetMemberships queries the user's memberships, which the members table sorts by project id.
+func (ddb *DynamoDBClient) GetMemberships(ctx context.Context, req *storage.GetMembershipsReq) (*storage.GetMembershipsResp, error) {
+	members, err := ddb.queryMembers(ctx, nil, expression.Key("user_id").Equal(modelValue(req.UserID)))
+	if err != nil {
+		return nil, err
+	}
+	return &storage.GetMembershipsResp{
+		Members: members,
+	}, nil
+}
+
+// GetProjectMembers queries the members of the project through the project members index, which sorts them
+// by user id. Global secondary indexes are eventually consistent, so a member added or deleted just before
+// may be missing or still listed.
+func (ddb *DynamoDBClient) GetProjectMembers(ctx context.Context, req *storage.GetProjectMembersReq) (*storage.GetProjectMembersResp, error) {
+	members, err := ddb.queryMembers(ctx, aws.String(projectMembersIndex), expression.Key("project_id").Equal(modelValue(req.ProjectID)))
+	if err != nil {
+		return nil, err
+	}
+	return &storage.GetProjectMembersResp{
+		Members: members,
+	}, nil
+}
+
+// UpdateMember replaces the role and accepted flag of a member, on the condition that the member exists
+// with the expected role and accepted flag if any, and returns the updated member.
+func (ddb *DynamoDBClient) UpdateMember(ctx context.Context, req *storage.UpdateMemberReq) (*storage.UpdateMemberResp, error) {
+	member := req.Member
+	update := expression.Set(expression.Name(storage.RoleKey), modelValue(member.Role)).
+		Set(expression.Name(storage.AcceptedKey), modelValue(member.Accepted)).
+		Set(expression.Name(storage.UpdatedAtKey), modelValue(time.Now().Unix()))
+	cond := expression.AttributeExists(expression.Name(storage.ProjectIDKey))
+	if req.ExpectedRole != "" {
+		cond = cond.And(
+			expression.Equal(expression.Name(storage.RoleKey), modelValue(req.ExpectedRole)),
+			expression.Equal(expression.Name(storage.AcceptedKey), modelValue(req.ExpectedAccepted)),
+		)
+	}
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(cond).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update expression: %v", err)
+	}
+	resp, err := ddb.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                           &ddb.membersTableName,
+		Key:                                 memberKey(member.UserID, member.ProjectID),
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", conditionCheckError(err))
+	}
+	updated := storage.Member{}
+	if err = attributevalue.UnmarshalMapWithOptions(resp.Attributes, &updated, decoderOptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attribute map: %v", err)
+	}
+	return &storage.UpdateMemberResp{
+		Member: updated,
+	}, nil
+}
+
+func (ddb *DynamoDBClient) DeleteMember(ctx context.Context, req *storage.DeleteMemberReq) (*storage.DeleteMemberResp, error) {
+	_, err := ddb.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &ddb.membersTableName,
+		Key:       memberKey(req.UserID, req.ProjectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete item: %v", err)
+	}
+	return &storage.DeleteMemberResp{}, nil
+}
@@ -0,0 +1,106 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+	"todo/metrics"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// isThrottle reports whether an attempt's error is DynamoDB throttling the request.
+var isThrottle = retry.ThrottleErrorCode{Codes: retry.DefaultThrottleErrorCodes}
+
+// recordOperation records the duration, result, retries, throttles and consumed capacity of every DynamoDB operation.
+// It asks DynamoDB to return the total consumed capacity of the operations that support it.
+var recordOperation = middleware.InitializeMiddlewareFunc("RecordOperation", func(
+	ctx context.Context,
+	in middleware.InitializeInput,
+	next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	requestConsumedCapacity(in.Parameters)
+
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	metrics.DynamoDBDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	metrics.DynamoDBOperations.WithLabelValues(operation, result).Inc()
+	if attempts, ok := retry.GetAttemptResults(metadata); ok {
+		recordAttempts(operation, attempts.Results)
+	}
+	for _, capacity := range consumedCapacity(out.Result) {
+		metrics.DynamoDBConsumedCapacity.WithLabelValues(aws.ToString(capacity.TableName), operation).Add(aws.ToFloat64(capacity.CapacityUnits))
+	}
+	return out, metadata, err
+})
+
+// addRecordOperation adds recordOperation to the stack of every operation.
+func addRecordOperation(stack *middleware.Stack) error {
+	return stack.Initialize.Add(recordOperation, middleware.After)
+}
+
+// requestConsumedCapacity sets the input of the operations the client uses to return their total consumed capacity.
+func requestConsumedCapacity(input any) {
+	switch input := input.(type) {
+	case *dynamodb.GetItemInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.PutItemInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.UpdateItemInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.DeleteItemInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.QueryInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.ScanInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	case *dynamodb.TransactWriteItemsInput:
+		input.ReturnConsumedCapacity = types.ReturnConsumedCapacityTotal
+	}
+}
+
+// consumedCapacity returns the consumed capacity of the output of an operation the client uses, if any.
+func consumedCapacity(output any) []types.ConsumedCapacity {
+	var capacity *types.ConsumedCapacity
+	switch output := output.(type) {
+	case *dynamodb.GetItemOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.PutItemOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.UpdateItemOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.DeleteItemOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.QueryOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.ScanOutput:
+		capacity = output.ConsumedCapacity
+	case *dynamodb.TransactWriteItemsOutput:
+		return output.ConsumedCapacity
+	}
+	if capacity == nil {
+		return nil
+	}
+	return []types.ConsumedCapacity{*capacity}
+}
+
+// recordAttempts records the attempts made after the first and the attempts that were throttled.
+func recordAttempts(operation string, attempts []retry.AttemptResult) {
+	if len(attempts) > 1 {
+		metrics.DynamoDBRetries.WithLabelValues(operation).Add(float64(len(attempts) - 1))
+	}
+	for _, attempt := range attempts {
+		if attempt.Err != nil && isThrottle.IsErrorThrottle(attempt.Err) == aws.TrueTernary {
+			metrics.DynamoDBThrottles.WithLabelValues(operation).Inc()
+		}
+	}
+}
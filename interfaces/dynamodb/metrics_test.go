@@ -0,0 +1,78 @@
+package dynamodb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"todo/metrics"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_recordAttempts(t *testing.T) {
+	throttled := &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException"}
+	tests := []struct {
+		name          string
+		operation     string
+		attempts      []retry.AttemptResult
+		wantRetries   float64
+		wantThrottles float64
+	}{
+		{
+			name:      "single attempt",
+			operation: "SingleAttempt",
+			attempts:  []retry.AttemptResult{{}},
+		},
+		{
+			name:          "retried after throttling",
+			operation:     "RetriedAfterThrottling",
+			attempts:      []retry.AttemptResult{{Err: throttled}, {Err: throttled}, {}},
+			wantRetries:   2,
+			wantThrottles: 2,
+		},
+		{
+			name:        "retried after other error",
+			operation:   "RetriedAfterOtherError",
+			attempts:    []retry.AttemptResult{{Err: errors.New("connection reset")}, {}},
+			wantRetries: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recordAttempts(tt.operation, tt.attempts)
+			if got := testutil.ToFloat64(metrics.DynamoDBRetries.WithLabelValues(tt.operation)); got != tt.wantRetries {
+				t.Errorf("retries = %v, want %v", got, tt.wantRetries)
+			}
+			if got := testutil.ToFloat64(metrics.DynamoDBThrottles.WithLabelValues(tt.operation)); got != tt.wantThrottles {
+				t.Errorf("throttles = %v, want %v", got, tt.wantThrottles)
+			}
+		})
+	}
+}
+
+func Test_consumedCapacity(t *testing.T) {
+	tasks := types.ConsumedCapacity{TableName: aws.String("tasks"), CapacityUnits: aws.Float64(1)}
+	users := types.ConsumedCapacity{TableName: aws.String("users"), CapacityUnits: aws.Float64(2)}
+	tests := []struct {
+		name   string
+		output any
+		want   []types.ConsumedCapacity
+	}{
+		{name: "get item", output: &dynamodb.GetItemOutput{ConsumedCapacity: &tasks}, want: []types.ConsumedCapacity{tasks}},
+		{name: "transaction", output: &dynamodb.TransactWriteItemsOutput{ConsumedCapacity: []types.ConsumedCapacity{tasks, users}}, want: []types.ConsumedCapacity{tasks, users}},
+		{name: "not returned", output: &dynamodb.PutItemOutput{}, want: nil},
+		{name: "unsupported operation", output: &dynamodb.DescribeTableOutput{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := consumedCapacity(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("consumedCapacity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// projectKey returns the primary key of a project in the projects table.
+func projectKey(userID, projectID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id":    &types.AttributeValueMemberS{Value: userID},
+		"project_id": &types.AttributeValueMemberS{Value: projectID},
+	}
+}
+
+// AddProject puts a project into the projects table, overwriting any timestamps on the given project
+// with the current time.
+func (ddb *DynamoDBClient) AddProject(ctx context.Context, req *storage.AddProjectReq) (*storage.AddProjectResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewProject(req.Project, time.Now().Unix()), encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project: %v", err)
+	}
+	_, err = ddb.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &ddb.projectsTableName,
+		Item:      item,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put project into projects table: %v", err)
+	}
+	return &storage.AddProjectResp{}, nil
+}
+
+func (ddb *DynamoDBClient) GetProject(ctx context.Context, req *storage.GetProjectReq) (*storage.GetProjectResp, error) {
+	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &ddb.projectsTableName,
+		Key:       projectKey(req.UserID, req.ProjectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %v", err)
+	}
+	var project *storage.Project
+	if getItemResp.Item != nil {
+		project = &storage.Project{}
+		err = attributevalue.UnmarshalMapWithOptions(getItemResp.Item, project, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal project: %v", err)
+		}
+	}
+	return &storage.GetProjectResp{
+		Project: project,
+	}, nil
+}
+
+// GetAllProjects queries the user's projects, which the projects table sorts by project id.
+func (ddb *DynamoDBClient) GetAllProjects(ctx context.Context, req *storage.GetAllProjectsReq) (*storage.GetAllProjectsResp, error) {
+	keyEx := expression.Key("user_id").Equal(modelValue(req.UserID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(ddb.projectsTableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	var projects []storage.Project
+	for queryPaginator.HasMorePages() {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		var projectPage []storage.Project
+		err = attributevalue.UnmarshalListOfMapsWithOptions(response.Items, &projectPage, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		projects = append(projects, projectPage...)
+	}
+	return &storage.GetAllProjectsResp{
+		Projects: projects,
+	}, nil
+}
+
+// UpdateProject replaces the name, description, color and archived flag of a project, on the condition
+// that the project exists, and returns the updated project.
+func (ddb *DynamoDBClient) UpdateProject(ctx context.Context, req *storage.UpdateProjectReq) (*storage.UpdateProjectResp, error) {
+	project := req.Project
+	update := expression.Set(expression.Name(storage.NameKey), modelValue(project.Name)).
+		Set(expression.Name(storage.DescriptionKey), modelValue(project.Description)).
+		Set(expression.Name(storage.ColorKey), modelValue(project.Color)).
+		Set(expression.Name(storage.ArchivedKey), modelValue(project.Archived)).
+		Set(expression.Name(storage.UpdatedAtKey), modelValue(time.Now().Unix()))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name(storage.ProjectIDKey))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update expression: %v", err)
+	}
+	resp, err := ddb.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                           &ddb.projectsTableName,
+		Key:                                 projectKey(project.UserID, project.ProjectID),
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", conditionCheckError(err))
+	}
+	updated := storage.Project{}
+	if err = attributevalue.UnmarshalMapWithOptions(resp.Attributes, &updated, decoderOptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attribute map: %v", err)
+	}
+	return &storage.UpdateProjectResp{
+		Project: updated,
+	}, nil
+}
+
+func (ddb *DynamoDBClient) DeleteProject(ctx context.Context, req *storage.DeleteProjectReq) (*storage.DeleteProjectResp, error) {
+	_, err := ddb.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &ddb.projectsTableName,
+		Key:       projectKey(req.UserID, req.ProjectID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete item: %v", err)
+	}
+	return &storage.DeleteProjectResp{}, nil
+}
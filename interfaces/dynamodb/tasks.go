@@ -2,9 +2,13 @@ package dynamodb
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
+	"todo/interfaces/storage"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -13,43 +17,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
-const (
-	UserIDKey        = "user_id"
-	TaskIDKey        = "task_id"
-	TitleKey         = "title"
-	DescriptionKey   = "description"
-	StatusKey        = "status"
-	TagsKey          = "tags"
-	ParentsKey       = "parents"
-	DueDateKey       = "due_date"
-	RecurringRuleKey = "recurring_rule"
-)
-
-type RecurringRule struct {
-	CronExpression string `dynamodbav:"cron_expression"`
-	StartDate      int64  `dynamodbav:"start_date"`
-	EndDate        int64  `dynamodbav:"end_date"`
-}
-
-type Task struct {
-	UserID        string         `dynamodbav:"user_id"`
-	TaskID        string         `dynamodbav:"task_id"`
-	Title         string         `dynamodbav:"title"`
-	Description   string         `dynamodbav:"description"`
-	Status        string         `dynamodbav:"status"`
-	Tags          []string       `dynamodbav:"tags"`
-	Parents       []string       `dynamodbav:"parents"`
-	DueDate       int64          `dynamodbav:"due_date"`
-	RecurringRule *RecurringRule `dynamodbav:"recurring_rule"`
-}
-
-type AddTaskReq struct {
-	Task Task
-}
-type AddTaskResp struct{}
-
-func (ddb *DynamoDBClient) AddTask(ctx context.Context, req *AddTaskReq) (*AddTaskResp, error) {
-	item, err := attributevalue.MarshalMap(req.Task)
+// AddTask puts a task into the tasks table, overwriting any timestamps on the given task
+// with the current time and starting its status history with its status.
+func (ddb *DynamoDBClient) AddTask(ctx context.Context, req *storage.AddTaskReq) (*storage.AddTaskResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewTask(req.Task, time.Now().Unix()), encoderOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal task: %v", err)
 	}
@@ -60,180 +31,475 @@ func (ddb *DynamoDBClient) AddTask(ctx context.Context, req *AddTaskReq) (*AddTa
 	if err != nil {
 		return nil, fmt.Errorf("failed to put task into tasks table: %v", err)
 	}
-	return &AddTaskResp{}, nil
+	return &storage.AddTaskResp{}, nil
 }
 
-type GetTaskReq struct {
-	UserID string
-	TaskID string
-}
-type GetTaskResp struct {
-	Task *Task
-}
-
-func (ddb *DynamoDBClient) GetTask(ctx context.Context, req *GetTaskReq) (*GetTaskResp, error) {
+func (ddb *DynamoDBClient) GetTask(ctx context.Context, req *storage.GetTaskReq) (*storage.GetTaskResp, error) {
 	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &ddb.tasksTableName,
-		Key: map[string]types.AttributeValue{
-			"user_id": &types.AttributeValueMemberS{Value: req.UserID},
-			"task_id": &types.AttributeValueMemberS{Value: req.TaskID},
-		},
+		Key:       taskKey(req.UserID, req.TaskID),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task: %v", err)
 	}
-	var task *Task
+	var task *storage.Task
 	if getItemResp.Item != nil {
-		task = &Task{}
-		err = attributevalue.UnmarshalMap(getItemResp.Item, task)
+		task = &storage.Task{}
+		err = unmarshalTask(getItemResp.Item, task)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal task: %v", err)
 		}
 	}
-	return &GetTaskResp{
+	return &storage.GetTaskResp{
 		Task: task,
 	}, nil
 }
 
-type BatchGetTaskReq struct{}
-type BatchGetTaskResp struct{}
-
-func (ddb *DynamoDBClient) BatchGetTask(ctx context.Context, req *BatchGetTaskReq) (*BatchGetTaskResp, error) {
+func (ddb *DynamoDBClient) BatchGetTask(ctx context.Context, req *storage.BatchGetTaskReq) (*storage.BatchGetTaskResp, error) {
 	return nil, errors.New("not implemented yet")
 }
 
-type GetAllTasksReq struct {
-	UserID   string
-	Statuses []string
+// unmarshalTask unmarshals a task item. Tasks stored before priorities existed have no priority attribute,
+// so they are given PRIORITY_UNSPECIFIED as every other backend stores them.
+func unmarshalTask(item map[string]types.AttributeValue, task *storage.Task) error {
+	if err := attributevalue.UnmarshalMapWithOptions(item, task, decoderOptions); err != nil {
+		return err
+	}
+	if task.Priority == "" {
+		task.Priority = storage.UnspecifiedPriority
+	}
+	return nil
 }
-type GetAllTasksResp struct {
-	Tasks []Task
+
+// unmarshalTasks unmarshals task items as unmarshalTask does.
+func unmarshalTasks(items []map[string]types.AttributeValue) ([]storage.Task, error) {
+	tasks := make([]storage.Task, len(items))
+	for i, item := range items {
+		if err := unmarshalTask(item, &tasks[i]); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
 }
 
-func (ddb *DynamoDBClient) GetAllTasks(ctx context.Context, req *GetAllTasksReq) (*GetAllTasksResp, error) {
-	keyEx := expression.Key("user_id").Equal(expression.Value(req.UserID))
-	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+// sortIndexes maps each sortable task attribute to the local secondary index that serves it. The priority
+// index only holds tasks with a priority attribute, which tasks stored before priorities existed must be
+// given, as infrastructure/dynamodb/README.md describes.
+var sortIndexes = map[string]string{
+	storage.DueDateKey:   "due_date-index",
+	storage.CreatedAtKey: "created_at-index",
+	storage.UpdatedAtKey: "updated_at-index",
+	storage.TitleKey:     "title-index",
+	storage.PriorityKey:  "priority-index",
+}
+
+// assigneeIndex is the global secondary index of the tasks table keyed by assignee id and task id. Only
+// assigned tasks have an assignee id, so the index holds no other tasks.
+const assigneeIndex = "assignee_id-index"
+
+// encodePageToken converts the last evaluated key of a query into an opaque page token.
+func encodePageToken(lastEvaluatedKey map[string]types.AttributeValue) (string, error) {
+	if len(lastEvaluatedKey) == 0 {
+		return "", nil
+	}
+	var key map[string]interface{}
+	if err := attributevalue.UnmarshalMapWithOptions(lastEvaluatedKey, &key, decoderOptions); err != nil {
+		return "", fmt.Errorf("failed to unmarshal last evaluated key: %v", err)
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal last evaluated key: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken converts a page token back into the exclusive start key of a query,
+// asserting that it belongs to the given user.
+func decodePageToken(pageToken, userID string) (map[string]types.AttributeValue, error) {
+	if pageToken == "" {
+		return nil, nil
+	}
+	key, err := decodeKey(pageToken)
+	if err != nil {
+		return nil, err
+	}
+	if key[storage.UserIDKey] != userID {
+		return nil, errors.New("page token does not belong to user")
+	}
+	return marshalStartKey(key)
+}
+
+// decodeKey converts a page token back into the key it holds, which may be of any user.
+func decodeKey(pageToken string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page token: %v", err)
+	}
+	var key map[string]interface{}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page token: %v", err)
+	}
+	return key, nil
+}
+
+// marshalStartKey converts a key decoded from a page token into the exclusive start key of a query or scan.
+func marshalStartKey(key map[string]interface{}) (map[string]types.AttributeValue, error) {
+	startKey, err := attributevalue.MarshalMapWithOptions(key, encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal exclusive start key: %v", err)
+	}
+	return startKey, nil
+}
+
+// buildFilterExpression returns a condition that matches tasks satisfying every filter of the request,
+// or false if the request has no filters, along with the parts of the request's Filter that DynamoDB
+// cannot evaluate, which the tasks read must be matched against.
+func buildFilterExpression(req *storage.GetAllTasksReq) (expression.ConditionBuilder, bool, storage.And) {
+	var conds []expression.ConditionBuilder
+	if len(req.Statuses) > 0 {
+		conds = append(conds, inCondition(storage.StatusKey, req.Statuses))
+	}
+	if len(req.Priorities) > 0 {
+		cond := inCondition(storage.PriorityKey, req.Priorities)
+		// tasks stored before priorities existed have no priority attribute
+		if slices.Contains(req.Priorities, storage.UnspecifiedPriority) {
+			cond = expression.Or(cond, expression.Name(storage.PriorityKey).AttributeNotExists())
+		}
+		conds = append(conds, cond)
+	}
+	if req.MaxEffortMinutes > 0 {
+		conds = append(conds, expression.Name(storage.EffortMinutesKey).Between(modelValue(1), modelValue(req.MaxEffortMinutes)))
+	}
+	var residual storage.And
+	if req.Filter != nil {
+		for _, conjunct := range storage.Conjuncts(req.Filter) {
+			if cond, ok := translateFilter(conjunct); ok {
+				conds = append(conds, cond)
+			} else {
+				residual = append(residual, conjunct)
+			}
+		}
+	}
+	switch len(conds) {
+	case 0:
+		return expression.ConditionBuilder{}, false, residual
+	case 1:
+		return conds[0], true, residual
+	default:
+		return expression.And(conds[0], conds[1], conds[2:]...), true, residual
+	}
+}
+
+// inCondition matches items whose attribute equals one of the given values.
+func inCondition(name string, values []string) expression.ConditionBuilder {
+	operands := make([]expression.OperandBuilder, 0, len(values))
+	for _, value := range values {
+		operands = append(operands, modelValue(value))
+	}
+	return expression.Name(name).In(operands[0], operands[1:]...)
+}
+
+// GetAllTasks queries the user's tasks, using the local secondary index of the sort key when one is given.
+func (ddb *DynamoDBClient) GetAllTasks(ctx context.Context, req *storage.GetAllTasksReq) (*storage.GetAllTasksResp, error) {
+	var indexName *string
+	if req.SortKey != "" {
+		index, ok := sortIndexes[req.SortKey]
+		if !ok {
+			return nil, fmt.Errorf("unable to sort by %s", req.SortKey)
+		}
+		indexName = aws.String(index)
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	startKey, err := decodePageToken(req.PageToken, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %v", err)
+	}
+	keyEx := expression.Key("user_id").Equal(modelValue(req.UserID))
+	builder := expression.NewBuilder().WithKeyCondition(keyEx)
+	filter, ok, residual := buildFilterExpression(req)
+	if ok {
+		builder = builder.WithFilter(filter)
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build expression: %v", err)
 	}
-	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, &dynamodb.QueryInput{
+	input := &dynamodb.QueryInput{
 		TableName:                 aws.String(ddb.tasksTableName),
+		IndexName:                 indexName,
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		KeyConditionExpression:    expr.KeyCondition(),
-	})
-	var tasks []Task
+		FilterExpression:          expr.Filter(),
+		ScanIndexForward:          aws.Bool(!req.Descending),
+		ExclusiveStartKey:         startKey,
+	}
+	if req.Limit > 0 {
+		input.Limit = aws.Int32(req.Limit)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, input)
+	var tasks []storage.Task
+	var lastEvaluatedKey map[string]types.AttributeValue
 	for queryPaginator.HasMorePages() {
 		response, err := queryPaginator.NextPage(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query ddb: %v", err)
 		} else {
-			var taskPage []Task
-			err = attributevalue.UnmarshalListOfMaps(response.Items, &taskPage)
+			taskPage, err := unmarshalTasks(response.Items)
 			if err != nil {
 				return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
 			} else {
-				tasks = append(tasks, taskPage...)
+				// evaluate the rest of the filter, which may leave fewer tasks than the limit in the page
+				for i := range taskPage {
+					if residual.Match(&taskPage[i]) {
+						tasks = append(tasks, taskPage[i])
+					}
+				}
 			}
 		}
+		// a limited query returns a single page
+		if req.Limit > 0 {
+			lastEvaluatedKey = response.LastEvaluatedKey
+			break
+		}
 	}
-	return &GetAllTasksResp{
-		Tasks: tasks,
+	nextPageToken, err := encodePageToken(lastEvaluatedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next page token: %v", err)
+	}
+	return &storage.GetAllTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
-type UpdateTaskReq struct {
-	UserID  string
-	TaskID  string
-	KVPairs map[string]interface{}
+// ScanTasks scans the tasks table. A limited scan returns a single page, which may hold fewer tasks than the limit.
+func (ddb *DynamoDBClient) ScanTasks(ctx context.Context, req *storage.ScanTasksReq) (*storage.ScanTasksResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	var startKey map[string]types.AttributeValue
+	if req.PageToken != "" {
+		key, err := decodeKey(req.PageToken)
+		if err == nil {
+			startKey, err = marshalStartKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+	}
+	input := &dynamodb.ScanInput{
+		TableName:         aws.String(ddb.tasksTableName),
+		ExclusiveStartKey: startKey,
+	}
+	if req.Limit > 0 {
+		input.Limit = aws.Int32(req.Limit)
+	}
+	scanPaginator := dynamodb.NewScanPaginator(ddb.client, input)
+	var tasks []storage.Task
+	var lastEvaluatedKey map[string]types.AttributeValue
+	for scanPaginator.HasMorePages() {
+		response, err := scanPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ddb: %v", err)
+		}
+		taskPage, err := unmarshalTasks(response.Items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scan response: %v", err)
+		}
+		tasks = append(tasks, taskPage...)
+		if req.Limit > 0 {
+			lastEvaluatedKey = response.LastEvaluatedKey
+			break
+		}
+	}
+	nextPageToken, err := encodePageToken(lastEvaluatedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next page token: %v", err)
+	}
+	return &storage.ScanTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
 }
-type UpdateTaskResp struct {
-	Task Task
+
+// GetAssignedTasks queries the assignee's tasks through the assignee index. Global secondary indexes are
+// eventually consistent, so a task assigned or unassigned just before may be missing or still returned.
+func (ddb *DynamoDBClient) GetAssignedTasks(ctx context.Context, req *storage.GetAssignedTasksReq) (*storage.GetAssignedTasksResp, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(expression.Key(storage.AssigneeIDKey).Equal(modelValue(req.AssigneeID))).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, &dynamodb.QueryInput{
+		TableName:                 &ddb.tasksTableName,
+		IndexName:                 aws.String(assigneeIndex),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	var tasks []storage.Task
+	for queryPaginator.HasMorePages() {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		taskPage, err := unmarshalTasks(response.Items)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		tasks = append(tasks, taskPage...)
+	}
+	return &storage.GetAssignedTasksResp{
+		Tasks: tasks,
+	}, nil
 }
 
+// buildUpdateExpression validates the given attributes and sets each of them along with updated_at.
+// Setting the status to COMPLETE sets completed_at unless the task was already complete,
+// and setting any other status removes it.
 func buildUpdateExpression(kvPairs map[string]interface{}) (*expression.UpdateBuilder, error) {
-	update := expression.Set(expression.Name("updated_at"), expression.Value(time.Now().Unix()))
+	now := time.Now().Unix()
+	update := expression.Set(expression.Name(storage.UpdatedAtKey), modelValue(now))
 	for name, value := range kvPairs {
 		switch name {
-		case TitleKey, DescriptionKey, StatusKey:
+		case storage.TitleKey, storage.DescriptionKey, storage.PriorityKey, storage.ProjectIDKey:
 			if _, ok := value.(string); !ok {
 				return nil, fmt.Errorf("the value type of %s should be a string", name)
 			}
-		case DueDateKey:
+		case storage.AssigneeIDKey:
+			assigneeID, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("the value type of %s should be a string", name)
+			}
+			// index keys cannot be empty, so unassigned tasks have no assignee id
+			if assigneeID == "" {
+				update = update.Remove(expression.Name(name))
+				continue
+			}
+		case storage.StatusKey:
+			status, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("the value type of %s should be a string", name)
+			}
+			if status == storage.CompleteStatus {
+				update = update.Set(expression.Name(storage.CompletedAtKey), expression.IfNotExists(expression.Name(storage.CompletedAtKey), modelValue(now)))
+			} else {
+				update = update.Remove(expression.Name(storage.CompletedAtKey))
+			}
+		case storage.DueDateKey:
 			if _, ok := value.(int64); !ok {
 				return nil, fmt.Errorf("the value type of %s should be int64", name)
 			}
-		case TagsKey, ParentsKey:
+		case storage.EffortMinutesKey:
+			if _, ok := value.(uint32); !ok {
+				return nil, fmt.Errorf("the value type of %s should be uint32", name)
+			}
+		case storage.RequireChecklistCompleteKey:
+			if _, ok := value.(bool); !ok {
+				return nil, fmt.Errorf("the value type of %s should be a bool", name)
+			}
+		case storage.TagsKey, storage.ParentsKey:
 			if _, ok := value.([]string); !ok {
 				return nil, fmt.Errorf("the value type of %s should be a list of strings", name)
 			}
-		case RecurringRuleKey:
+		case storage.RecurringRuleKey:
 			if value == nil {
 				continue
 			}
-			if _, ok := value.(*RecurringRule); !ok {
-				return nil, fmt.Errorf("the value type of %s should model the RecurringRule Type", name)
+			if _, ok := value.(*storage.RecurringRule); !ok {
+				return nil, fmt.Errorf("the value type of %s should model the storage.RecurringRule Type", name)
 			}
-		case UserIDKey, TaskIDKey:
+		case storage.UserIDKey, storage.TaskIDKey, storage.CreatedAtKey, storage.UpdatedAtKey, storage.CompletedAtKey, storage.StatusHistoryKey, storage.ChecklistKey:
 			return nil, fmt.Errorf("not allowed to update %s", name)
 		default:
 			return nil, fmt.Errorf("unknown task attribute: %s", name)
 		}
-		update.Set(expression.Name(name), expression.Value(value))
+		update.Set(expression.Name(name), modelValue(value))
 	}
 	return &update, nil
 }
 
-func (ddb *DynamoDBClient) UpdateTask(ctx context.Context, req *UpdateTaskReq) (*UpdateTaskResp, error) {
+// appendStatusChange appends a change to the given status to the task's status history.
+func appendStatusChange(update expression.UpdateBuilder, status string) expression.UpdateBuilder {
+	change := []storage.StatusChange{{Status: status, ChangedAt: time.Now().Unix()}}
+	history := expression.IfNotExists(expression.Name(storage.StatusHistoryKey), modelValue([]storage.StatusChange{}))
+	return update.Set(expression.Name(storage.StatusHistoryKey), expression.ListAppend(history, modelValue(change)))
+}
+
+// buildTaskUpdate builds the conditional update of an existing task requested by req.
+func buildTaskUpdate(req *storage.UpdateTaskReq) (expression.Expression, error) {
 	update, err := buildUpdateExpression(req.KVPairs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get update builder: %v", err)
+		return expression.Expression{}, fmt.Errorf("failed to get update builder: %v", err)
+	}
+	cond := expression.Equal(expression.Name(storage.TaskIDKey), modelValue(req.TaskID))
+	if req.ExpectedStatus != "" {
+		cond = cond.And(expression.Equal(expression.Name(storage.StatusKey), modelValue(req.ExpectedStatus)))
+		if status, ok := req.KVPairs[storage.StatusKey].(string); ok && status != req.ExpectedStatus {
+			*update = appendStatusChange(*update, status)
+		}
+	}
+	if req.ExpectChecklist {
+		cond = cond.And(checklistEquals(req.ExpectedChecklist))
 	}
-	cond := expression.Equal(expression.Name(TaskIDKey), expression.Value(req.TaskID))
 	expr, err := expression.NewBuilder().WithCondition(cond).WithUpdate(*update).Build()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build expression: %v", err)
+		return expression.Expression{}, fmt.Errorf("failed to build expression: %v", err)
+	}
+	return expr, nil
+}
+
+// UpdateTask sets the given attributes of an existing task and returns the updated task.
+// It returns storage.ErrNotFound if the task does not exist.
+func (ddb *DynamoDBClient) UpdateTask(ctx context.Context, req *storage.UpdateTaskReq) (*storage.UpdateTaskResp, error) {
+	expr, err := buildTaskUpdate(req)
+	if err != nil {
+		return nil, err
 	}
 	resp, err := ddb.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-		TableName: &ddb.tasksTableName,
-		Key: map[string]types.AttributeValue{
-			"user_id": &types.AttributeValueMemberS{Value: req.UserID},
-			"task_id": &types.AttributeValueMemberS{Value: req.TaskID},
-		},
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		ConditionExpression:       expr.Condition(),
-		UpdateExpression:          expr.Update(),
-		ReturnValues:              types.ReturnValueUpdatedNew,
+		TableName:                           &ddb.tasksTableName,
+		Key:                                 taskKey(req.UserID, req.TaskID),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ConditionExpression:                 expr.Condition(),
+		UpdateExpression:                    expr.Update(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update item: %v", err)
+		return nil, fmt.Errorf("failed to update item: %w", conditionCheckError(err))
 	}
-	updatedTask := Task{}
-	if err = attributevalue.UnmarshalMap(resp.Attributes, &updatedTask); err != nil {
+	updatedTask := storage.Task{}
+	if err = unmarshalTask(resp.Attributes, &updatedTask); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal attribute map: %v", err)
 	}
-	return &UpdateTaskResp{
+	return &storage.UpdateTaskResp{
 		Task: updatedTask,
 	}, nil
 }
 
-type DeleteTaskReq struct {
-	UserID string
-	TaskID string
+// conditionCheckError converts a failed condition check into storage.ErrNotFound when the item did not exist,
+// or storage.ErrConditionFailed when it did. Other errors are returned as is.
+func conditionCheckError(err error) error {
+	var condErr *types.ConditionalCheckFailedException
+	if !errors.As(err, &condErr) {
+		return err
+	}
+	if len(condErr.Item) == 0 {
+		return storage.ErrNotFound
+	}
+	return storage.ErrConditionFailed
 }
-type DeleteTaskResp struct{}
 
-func (ddb *DynamoDBClient) DeleteTask(ctx context.Context, req *DeleteTaskReq) (*DeleteTaskResp, error) {
+func (ddb *DynamoDBClient) DeleteTask(ctx context.Context, req *storage.DeleteTaskReq) (*storage.DeleteTaskResp, error) {
 	_, err := ddb.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: &ddb.tasksTableName,
-		Key: map[string]types.AttributeValue{
-			"user_id": &types.AttributeValueMemberS{Value: req.UserID},
-			"task_id": &types.AttributeValueMemberS{Value: req.TaskID},
-		},
+		Key:       taskKey(req.UserID, req.TaskID),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete item: %v", err)
 	}
-	return &DeleteTaskResp{}, nil
+	return &storage.DeleteTaskResp{}, nil
 }
@@ -1,8 +1,15 @@
 package dynamodb
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
 func Test_buildUpdateExpression(t *testing.T) {
@@ -18,13 +25,13 @@ func Test_buildUpdateExpression(t *testing.T) {
 			name: "happy path",
 			args: args{
 				kvPairs: map[string]interface{}{
-					TitleKey:         "new title",
-					DescriptionKey:   "new description",
-					StatusKey:        "COMPLETE",
-					TagsKey:          []string{"tag1", "tag2"},
-					ParentsKey:       []string{},
-					DueDateKey:       time.Now().Unix(),
-					RecurringRuleKey: nil,
+					storage.TitleKey:         "new title",
+					storage.DescriptionKey:   "new description",
+					storage.StatusKey:        "COMPLETE",
+					storage.TagsKey:          []string{"tag1", "tag2"},
+					storage.ParentsKey:       []string{},
+					storage.DueDateKey:       time.Now().Unix(),
+					storage.RecurringRuleKey: nil,
 				},
 			},
 			wantErr: false,
@@ -33,13 +40,13 @@ func Test_buildUpdateExpression(t *testing.T) {
 			name: "happy path - non nil recurring rule",
 			args: args{
 				kvPairs: map[string]interface{}{
-					TitleKey:       "new title",
-					DescriptionKey: "new description",
-					StatusKey:      "COMPLETE",
-					TagsKey:        []string{"tag1", "tag2"},
-					ParentsKey:     []string{},
-					DueDateKey:     time.Now().Unix(),
-					RecurringRuleKey: &RecurringRule{
+					storage.TitleKey:       "new title",
+					storage.DescriptionKey: "new description",
+					storage.StatusKey:      "COMPLETE",
+					storage.TagsKey:        []string{"tag1", "tag2"},
+					storage.ParentsKey:     []string{},
+					storage.DueDateKey:     time.Now().Unix(),
+					storage.RecurringRuleKey: &storage.RecurringRule{
 						CronExpression: "* * * 1 *", // whatever this means
 					},
 				},
@@ -50,14 +57,14 @@ func Test_buildUpdateExpression(t *testing.T) {
 			name: "not allowed to update user id",
 			args: args{
 				kvPairs: map[string]interface{}{
-					UserIDKey:        "user_id",
-					TitleKey:         "new title",
-					DescriptionKey:   "new description",
-					StatusKey:        "COMPLETE",
-					TagsKey:          []string{"tag1", "tag2"},
-					ParentsKey:       []string{},
-					DueDateKey:       time.Now().Unix(),
-					RecurringRuleKey: nil,
+					storage.UserIDKey:        "user_id",
+					storage.TitleKey:         "new title",
+					storage.DescriptionKey:   "new description",
+					storage.StatusKey:        "COMPLETE",
+					storage.TagsKey:          []string{"tag1", "tag2"},
+					storage.ParentsKey:       []string{},
+					storage.DueDateKey:       time.Now().Unix(),
+					storage.RecurringRuleKey: nil,
 				},
 			},
 			wantErr: true,
@@ -66,14 +73,81 @@ func Test_buildUpdateExpression(t *testing.T) {
 			name: "not allowed to update task id",
 			args: args{
 				kvPairs: map[string]interface{}{
-					TaskIDKey:        "task_id",
-					TitleKey:         "new title",
-					DescriptionKey:   "new description",
-					StatusKey:        "COMPLETE",
-					TagsKey:          []string{"tag1", "tag2"},
-					ParentsKey:       []string{},
-					DueDateKey:       time.Now().Unix(),
-					RecurringRuleKey: nil,
+					storage.TaskIDKey:        "task_id",
+					storage.TitleKey:         "new title",
+					storage.DescriptionKey:   "new description",
+					storage.StatusKey:        "COMPLETE",
+					storage.TagsKey:          []string{"tag1", "tag2"},
+					storage.ParentsKey:       []string{},
+					storage.DueDateKey:       time.Now().Unix(),
+					storage.RecurringRuleKey: nil,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "happy path - reopen task",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.TitleKey:  "new title",
+					storage.StatusKey: "INCOMPLETE",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "not allowed to update created at",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.TitleKey:     "new title",
+					storage.CreatedAtKey: time.Now().Unix(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not allowed to update completed at",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.StatusKey:      "COMPLETE",
+					storage.CompletedAtKey: time.Now().Unix(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "status is not a string",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.StatusKey: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "happy path - priority and effort",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.PriorityKey:      "P1",
+					storage.EffortMinutesKey: uint32(30),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "effort is not a uint32",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.EffortMinutesKey: 30,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not allowed to update status history",
+			args: args{
+				kvPairs: map[string]interface{}{
+					storage.StatusHistoryKey: []storage.StatusChange{},
 				},
 			},
 			wantErr: true,
@@ -82,14 +156,14 @@ func Test_buildUpdateExpression(t *testing.T) {
 			name: "unknown attribute",
 			args: args{
 				kvPairs: map[string]interface{}{
-					TitleKey:         "new title",
-					DescriptionKey:   "new description",
-					StatusKey:        "COMPLETE",
-					TagsKey:          []string{"tag1", "tag2"},
-					ParentsKey:       []string{},
-					DueDateKey:       time.Now().Unix(),
-					RecurringRuleKey: nil,
-					"unknown_attr":   "doesn't matter the value",
+					storage.TitleKey:         "new title",
+					storage.DescriptionKey:   "new description",
+					storage.StatusKey:        "COMPLETE",
+					storage.TagsKey:          []string{"tag1", "tag2"},
+					storage.ParentsKey:       []string{},
+					storage.DueDateKey:       time.Now().Unix(),
+					storage.RecurringRuleKey: nil,
+					"unknown_attr":           "doesn't matter the value",
 				},
 			},
 			wantErr: true,
@@ -105,3 +179,256 @@ func Test_buildUpdateExpression(t *testing.T) {
 		})
 	}
 }
+
+func Test_pageToken(t *testing.T) {
+	lastEvaluatedKey := map[string]types.AttributeValue{
+		storage.UserIDKey:  &types.AttributeValueMemberS{Value: "user"},
+		storage.TaskIDKey:  &types.AttributeValueMemberS{Value: "task"},
+		storage.DueDateKey: &types.AttributeValueMemberN{Value: "1700000000"},
+	}
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr bool
+	}{
+		{
+			name:    "happy path",
+			userID:  "user",
+			wantErr: false,
+		},
+		{
+			name:    "token belongs to another user",
+			userID:  "another user",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := encodePageToken(lastEvaluatedKey)
+			if err != nil {
+				t.Fatalf("encodePageToken() error = %v", err)
+			}
+			got, err := decodePageToken(token, tt.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodePageToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, lastEvaluatedKey) {
+				t.Errorf("decodePageToken() = %v, want %v", got, lastEvaluatedKey)
+			}
+		})
+	}
+}
+
+func Test_decodePageToken_malformed(t *testing.T) {
+	if _, err := decodePageToken("not a token!", "user"); err == nil {
+		t.Error("decodePageToken() expected error for malformed token")
+	}
+	if got, err := decodePageToken("", "user"); err != nil || got != nil {
+		t.Errorf("decodePageToken() = %v, %v, want nil start key for empty token", got, err)
+	}
+}
+
+func Test_unmarshalTasks(t *testing.T) {
+	items := []map[string]types.AttributeValue{
+		{"task_id": &types.AttributeValueMemberS{Value: "old"}},
+		{"task_id": &types.AttributeValueMemberS{Value: "new"}, "priority": &types.AttributeValueMemberS{Value: "P1"}},
+	}
+	got, err := unmarshalTasks(items)
+	if err != nil {
+		t.Fatalf("unmarshalTasks() error = %v", err)
+	}
+	want := []storage.Task{
+		{TaskID: "old", Priority: storage.UnspecifiedPriority},
+		{TaskID: "new", Priority: "P1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unmarshalTasks() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_buildFilterExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *storage.GetAllTasksReq
+		wantFilter   bool
+		wantResidual storage.And
+	}{
+		{
+			name:       "no filters",
+			req:        &storage.GetAllTasksReq{UserID: "user"},
+			wantFilter: false,
+		},
+		{
+			name:       "priorities",
+			req:        &storage.GetAllTasksReq{UserID: "user", Priorities: []string{"P0", "P1"}},
+			wantFilter: true,
+		},
+		{
+			name: "all filters",
+			req: &storage.GetAllTasksReq{
+				UserID:           "user",
+				Statuses:         []string{"INCOMPLETE"},
+				Priorities:       []string{"P0"},
+				MaxEffortMinutes: 60,
+			},
+			wantFilter: true,
+		},
+		{
+			name: "translated filter",
+			req: &storage.GetAllTasksReq{
+				UserID: "user",
+				Filter: storage.And{
+					storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "INCOMPLETE"},
+					storage.Not{Filter: storage.Contains{Key: storage.TagsKey, Value: "someday"}},
+				},
+			},
+			wantFilter: true,
+		},
+		{
+			name: "filter matching text",
+			req: &storage.GetAllTasksReq{
+				UserID: "user",
+				Filter: storage.And{
+					storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: int64(100)},
+					storage.Contains{Key: storage.TitleKey, Value: "notes"},
+				},
+			},
+			wantFilter:   true,
+			wantResidual: storage.And{storage.Contains{Key: storage.TitleKey, Value: "notes"}},
+		},
+		{
+			name: "filter matching only text",
+			req: &storage.GetAllTasksReq{
+				UserID: "user",
+				Filter: storage.Or{
+					storage.Contains{Key: storage.TitleKey, Value: "notes"},
+					storage.Contains{Key: storage.TagsKey, Value: "notes"},
+				},
+			},
+			wantFilter: false,
+			wantResidual: storage.And{storage.Or{
+				storage.Contains{Key: storage.TitleKey, Value: "notes"},
+				storage.Contains{Key: storage.TagsKey, Value: "notes"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, ok, residual := buildFilterExpression(tt.req)
+			if !reflect.DeepEqual(residual, tt.wantResidual) {
+				t.Errorf("buildFilterExpression() residual = %v, want %v", residual, tt.wantResidual)
+			}
+			if ok != tt.wantFilter {
+				t.Errorf("buildFilterExpression() ok = %v, want %v", ok, tt.wantFilter)
+				return
+			}
+			if !ok {
+				return
+			}
+			expr, err := expression.NewBuilder().WithFilter(filter).Build()
+			if err != nil {
+				t.Errorf("failed to build filter expression: %v", err)
+				return
+			}
+			if expr.Filter() == nil {
+				t.Error("buildFilterExpression() built an empty filter")
+			}
+		})
+	}
+}
+
+func Test_conditionCheckError(t *testing.T) {
+	otherErr := errors.New("test error")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "item does not exist",
+			err:  &types.ConditionalCheckFailedException{},
+			want: storage.ErrNotFound,
+		},
+		{
+			name: "item has changed",
+			err: &types.ConditionalCheckFailedException{
+				Item: map[string]types.AttributeValue{
+					storage.StatusKey: &types.AttributeValueMemberS{Value: "COMPLETE"},
+				},
+			},
+			want: storage.ErrConditionFailed,
+		},
+		{
+			name: "other error",
+			err:  otherErr,
+			want: otherErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionCheckError(tt.err); !errors.Is(got, tt.want) {
+				t.Errorf("conditionCheckError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_appendStatusChange(t *testing.T) {
+	update, err := buildUpdateExpression(map[string]interface{}{storage.StatusKey: "IN_PROGRESS"})
+	if err != nil {
+		t.Fatalf("buildUpdateExpression() error = %v", err)
+	}
+	expr, err := expression.NewBuilder().WithUpdate(appendStatusChange(*update, "IN_PROGRESS")).Build()
+	if err != nil {
+		t.Fatalf("failed to build update expression: %v", err)
+	}
+	if got := *expr.Update(); !strings.Contains(got, "list_append(if_not_exists(") {
+		t.Errorf("appendStatusChange() update expression = %s, want list_append", got)
+	}
+}
+
+func Test_buildTaskUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *storage.UpdateTaskReq
+		want string
+	}{
+		{
+			name: "unconditional",
+			req:  &storage.UpdateTaskReq{TaskID: "task", KVPairs: map[string]interface{}{storage.TitleKey: "title"}},
+			want: "#0 = :0",
+		},
+		{
+			name: "expected status",
+			req:  &storage.UpdateTaskReq{TaskID: "task", ExpectedStatus: "INCOMPLETE"},
+			want: "(#0 = :0) AND (#1 = :1)",
+		},
+		{
+			name: "expected checklist",
+			req: &storage.UpdateTaskReq{
+				TaskID:            "task",
+				ExpectedStatus:    "INCOMPLETE",
+				ExpectChecklist:   true,
+				ExpectedChecklist: []storage.ChecklistItem{{ID: "item", Done: true}},
+			},
+			want: "((#0 = :0) AND (#1 = :1)) AND (#2 = :2)",
+		},
+		{
+			name: "expected empty checklist",
+			req:  &storage.UpdateTaskReq{TaskID: "task", ExpectChecklist: true},
+			want: "(#0 = :0) AND ((attribute_not_exists (#1)) OR (#1 = :1))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := buildTaskUpdate(tt.req)
+			if err != nil {
+				t.Fatalf("buildTaskUpdate() error = %v", err)
+			}
+			if got := *expr.Condition(); got != tt.want {
+				t.Errorf("buildTaskUpdate() condition = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package dynamodb
+
+import (
+	"context"
+	"todo/tracing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceOperation wraps every DynamoDB operation, including its retries, in a child span of the request that made it.
+var traceOperation = middleware.InitializeMiddlewareFunc("TraceOperation", func(
+	ctx context.Context,
+	in middleware.InitializeInput,
+	next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	ctx, span := tracing.Tracer.Start(ctx, "DynamoDB."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemDynamoDB,
+			semconv.RPCSystemKey.String("aws-api"),
+			semconv.RPCService("DynamoDB"),
+			semconv.RPCMethod(operation),
+		),
+	)
+	defer span.End()
+	if tables := tableNames(in.Parameters); len(tables) > 0 {
+		span.SetAttributes(semconv.AWSDynamoDBTableNames(tables...))
+	}
+
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	tracing.RecordError(span, err)
+	return out, metadata, err
+})
+
+// addTraceOperation adds traceOperation to the stack of every operation.
+func addTraceOperation(stack *middleware.Stack) error {
+	return stack.Initialize.Add(traceOperation, middleware.After)
+}
+
+// tableNames returns the tables written or read by the input of an operation the client uses.
+func tableNames(input any) []string {
+	var table *string
+	switch input := input.(type) {
+	case *dynamodb.GetItemInput:
+		table = input.TableName
+	case *dynamodb.PutItemInput:
+		table = input.TableName
+	case *dynamodb.UpdateItemInput:
+		table = input.TableName
+	case *dynamodb.DeleteItemInput:
+		table = input.TableName
+	case *dynamodb.QueryInput:
+		table = input.TableName
+	case *dynamodb.ScanInput:
+		table = input.TableName
+	case *dynamodb.DescribeTableInput:
+		table = input.TableName
+	case *dynamodb.TransactWriteItemsInput:
+		var tables []string
+		seen := map[string]bool{}
+		for _, item := range input.TransactItems {
+			var name *string
+			switch {
+			case item.Put != nil:
+				name = item.Put.TableName
+			case item.Update != nil:
+				name = item.Update.TableName
+			case item.Delete != nil:
+				name = item.Delete.TableName
+			case item.ConditionCheck != nil:
+				name = item.ConditionCheck.TableName
+			}
+			if name != nil && !seen[*name] {
+				seen[*name] = true
+				tables = append(tables, *name)
+			}
+		}
+		return tables
+	}
+	if table == nil {
+		return nil
+	}
+	return []string{aws.ToString(table)}
+}
@@ -0,0 +1,37 @@
+package dynamodb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func Test_tableNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  []string
+	}{
+		{name: "get item", input: &dynamodb.GetItemInput{TableName: aws.String("tasks")}, want: []string{"tasks"}},
+		{
+			name: "transaction",
+			input: &dynamodb.TransactWriteItemsInput{TransactItems: []types.TransactWriteItem{
+				{Put: &types.Put{TableName: aws.String("tasks")}},
+				{Update: &types.Update{TableName: aws.String("users")}},
+				{Delete: &types.Delete{TableName: aws.String("tasks")}},
+			}},
+			want: []string{"tasks", "users"},
+		},
+		{name: "unsupported operation", input: &dynamodb.ListTablesInput{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tableNames(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tableNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
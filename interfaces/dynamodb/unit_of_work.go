@@ -0,0 +1,154 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// maxTransactItems is the most items DynamoDB allows in a single transaction.
+const maxTransactItems = 100
+
+// taskKey returns the primary key of a task in the tasks table.
+func taskKey(userID, taskID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id": &types.AttributeValueMemberS{Value: userID},
+		"task_id": &types.AttributeValueMemberS{Value: taskID},
+	}
+}
+
+// unitOfWork collects writes as the items of a single TransactWriteItems call.
+// The first error building an item is kept and returned by Commit.
+type unitOfWork struct {
+	ddb   *DynamoDBClient
+	items []types.TransactWriteItem
+	err   error
+}
+
+// NewUnitOfWork starts a unit of work committed as a DynamoDB transaction.
+func (ddb *DynamoDBClient) NewUnitOfWork() storage.UnitOfWork {
+	return &unitOfWork{ddb: ddb}
+}
+
+func (u *unitOfWork) AddUser(user storage.User) {
+	item, err := attributevalue.MarshalMapWithOptions(user, encoderOptions)
+	if err != nil {
+		u.fail(fmt.Errorf("failed to marshal user: %v", err))
+		return
+	}
+	u.items = append(u.items, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(u.ddb.usersTableName),
+			Item:      item,
+		},
+	})
+}
+
+func (u *unitOfWork) AddTask(task storage.Task) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewTask(task, time.Now().Unix()), encoderOptions)
+	if err != nil {
+		u.fail(fmt.Errorf("failed to marshal task: %v", err))
+		return
+	}
+	u.items = append(u.items, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(u.ddb.tasksTableName),
+			Item:      item,
+		},
+	})
+}
+
+func (u *unitOfWork) UpdateTask(req storage.UpdateTaskReq) {
+	expr, err := buildTaskUpdate(&req)
+	if err != nil {
+		u.fail(err)
+		return
+	}
+	u.items = append(u.items, types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                           aws.String(u.ddb.tasksTableName),
+			Key:                                 taskKey(req.UserID, req.TaskID),
+			ExpressionAttributeNames:            expr.Names(),
+			ExpressionAttributeValues:           expr.Values(),
+			ConditionExpression:                 expr.Condition(),
+			UpdateExpression:                    expr.Update(),
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		},
+	})
+}
+
+func (u *unitOfWork) DeleteTask(req storage.DeleteTaskReq) {
+	u.items = append(u.items, types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName: aws.String(u.ddb.tasksTableName),
+			Key:       taskKey(req.UserID, req.TaskID),
+		},
+	})
+}
+
+func (u *unitOfWork) AddEvent(event storage.Event) {
+	item, err := attributevalue.MarshalMapWithOptions(storage.NewEvent(event, time.Now().Unix()), encoderOptions)
+	if err != nil {
+		u.fail(fmt.Errorf("failed to marshal event: %v", err))
+		return
+	}
+	u.items = append(u.items, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(u.ddb.eventsTableName),
+			Item:      item,
+		},
+	})
+}
+
+func (u *unitOfWork) fail(err error) {
+	if u.err == nil {
+		u.err = err
+	}
+}
+
+// Commit writes every item in one transaction. A failed condition check is returned as
+// storage.ErrNotFound when the item did not exist, or storage.ErrConditionFailed when it did.
+func (u *unitOfWork) Commit(ctx context.Context) error {
+	if u.err != nil {
+		return u.err
+	}
+	if len(u.items) == 0 {
+		return nil
+	}
+	if len(u.items) > maxTransactItems {
+		return fmt.Errorf("unable to commit more than %d writes at once", maxTransactItems)
+	}
+	_, err := u.ddb.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: u.items,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", transactionError(err))
+	}
+	return nil
+}
+
+// transactionError converts a transaction cancelled by a failed condition check like conditionCheckError does.
+// Other errors are returned as is.
+func transactionError(err error) error {
+	var cancelErr *types.TransactionCanceledException
+	if !errors.As(err, &cancelErr) {
+		return err
+	}
+	for _, reason := range cancelErr.CancellationReasons {
+		if aws.ToString(reason.Code) != "ConditionalCheckFailed" {
+			continue
+		}
+		if len(reason.Item) == 0 {
+			return storage.ErrNotFound
+		}
+		return storage.ErrConditionFailed
+	}
+	return err
+}
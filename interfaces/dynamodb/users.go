@@ -4,28 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"todo/interfaces/storage"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
-type User struct {
-	ID             string `dynamodbav:"id"`
-	FirstName      string `dynamodbav:"first_name"`
-	LastName       string `dynamodbav:"last_name"`
-	Email          string `dynamodbav:"email"`
-	HashedPassword string `dynamodbav:"hashed_password"`
-}
-
-type AddUserReq struct {
-	User User
-}
-type AddUserResp struct{}
-
 // AddUser puts a user into the users table exactly as given.
-func (ddb *DynamoDBClient) AddUser(ctx context.Context, req *AddUserReq) (*AddUserResp, error) {
-	item, err := attributevalue.MarshalMap(req.User)
+func (ddb *DynamoDBClient) AddUser(ctx context.Context, req *storage.AddUserReq) (*storage.AddUserResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(req.User, encoderOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal user: %v", err)
 	}
@@ -36,19 +25,12 @@ func (ddb *DynamoDBClient) AddUser(ctx context.Context, req *AddUserReq) (*AddUs
 	if err != nil {
 		return nil, fmt.Errorf("failed to put user into users table: %v", err)
 	}
-	return &AddUserResp{}, nil
-}
-
-type GetUserReq struct {
-	ID string `dynamodbav:"id"`
-}
-type GetUserResp struct {
-	User *User
+	return &storage.AddUserResp{}, nil
 }
 
 // GetUser uses the given user id to find a user.
-// User will be nil if no user is found.
-func (ddb *DynamoDBClient) GetUser(ctx context.Context, req *GetUserReq) (*GetUserResp, error) {
+// storage.User will be nil if no user is found.
+func (ddb *DynamoDBClient) GetUser(ctx context.Context, req *storage.GetUserReq) (*storage.GetUserResp, error) {
 	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &ddb.usersTableName,
 		Key: map[string]types.AttributeValue{
@@ -58,29 +40,50 @@ func (ddb *DynamoDBClient) GetUser(ctx context.Context, req *GetUserReq) (*GetUs
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %v", err)
 	}
-	var user *User
+	var user *storage.User
 	if getItemResp.Item != nil {
-		user = &User{}
-		err = attributevalue.UnmarshalMap(getItemResp.Item, user)
+		user = &storage.User{}
+		err = attributevalue.UnmarshalMapWithOptions(getItemResp.Item, user, decoderOptions)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal user: %v", err)
 		}
 	}
-	return &GetUserResp{
+	return &storage.GetUserResp{
 		User: user,
 	}, nil
 }
 
-type UpdateUserReq struct{}
-type UpdateUserResp struct{}
-
-func (ddb *DynamoDBClient) UpdateUser(ctx context.Context, req *UpdateUserReq) (*UpdateUserResp, error) {
-	return nil, errors.New("not implemented yet")
+// UpdateUser replaces the fields of the user that are set, on the condition that the user exists.
+func (ddb *DynamoDBClient) UpdateUser(ctx context.Context, req *storage.UpdateUserReq) (*storage.UpdateUserResp, error) {
+	// an update expression cannot be empty
+	if req.FeedTokenHash == nil {
+		return &storage.UpdateUserResp{}, nil
+	}
+	update := expression.Set(expression.Name(storage.FeedTokenHashKey), expression.Value(*req.FeedTokenHash))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name("id"))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update expression: %v", err)
+	}
+	_, err = ddb.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: &ddb.usersTableName,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: req.ID},
+		},
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", conditionCheckError(err))
+	}
+	return &storage.UpdateUserResp{}, nil
 }
 
-type DeleteUserReq struct{}
-type DeleteUserResp struct{}
-
-func (ddb *DynamoDBClient) DeleteUser(ctx context.Context, req *DeleteUserReq) (*DeleteUserResp, error) {
+func (ddb *DynamoDBClient) DeleteUser(ctx context.Context, req *storage.DeleteUserReq) (*storage.DeleteUserResp, error) {
 	return nil, errors.New("not implemented yet")
 }
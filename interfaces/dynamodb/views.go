@@ -0,0 +1,100 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"todo/interfaces/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// viewKey returns the primary key of a view in the views table.
+func viewKey(userID, name string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"user_id": &types.AttributeValueMemberS{Value: userID},
+		"name":    &types.AttributeValueMemberS{Value: name},
+	}
+}
+
+// SaveView puts a view into the views table, replacing any view of the user with the same name.
+func (ddb *DynamoDBClient) SaveView(ctx context.Context, req *storage.SaveViewReq) (*storage.SaveViewResp, error) {
+	item, err := attributevalue.MarshalMapWithOptions(req.View, encoderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal view: %v", err)
+	}
+	_, err = ddb.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &ddb.viewsTableName,
+		Item:      item,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put view into views table: %v", err)
+	}
+	return &storage.SaveViewResp{}, nil
+}
+
+func (ddb *DynamoDBClient) GetView(ctx context.Context, req *storage.GetViewReq) (*storage.GetViewResp, error) {
+	getItemResp, err := ddb.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &ddb.viewsTableName,
+		Key:       viewKey(req.UserID, req.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get view: %v", err)
+	}
+	var view *storage.View
+	if getItemResp.Item != nil {
+		view = &storage.View{}
+		err = attributevalue.UnmarshalMapWithOptions(getItemResp.Item, view, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal view: %v", err)
+		}
+	}
+	return &storage.GetViewResp{
+		View: view,
+	}, nil
+}
+
+// GetAllViews queries the user's views, which the views table sorts by name.
+func (ddb *DynamoDBClient) GetAllViews(ctx context.Context, req *storage.GetAllViewsReq) (*storage.GetAllViewsResp, error) {
+	keyEx := expression.Key("user_id").Equal(modelValue(req.UserID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	queryPaginator := dynamodb.NewQueryPaginator(ddb.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(ddb.viewsTableName),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+	})
+	var views []storage.View
+	for queryPaginator.HasMorePages() {
+		response, err := queryPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ddb: %v", err)
+		}
+		var viewPage []storage.View
+		err = attributevalue.UnmarshalListOfMapsWithOptions(response.Items, &viewPage, decoderOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query response: %v", err)
+		}
+		views = append(views, viewPage...)
+	}
+	return &storage.GetAllViewsResp{
+		Views: views,
+	}, nil
+}
+
+func (ddb *DynamoDBClient) DeleteView(ctx context.Context, req *storage.DeleteViewReq) (*storage.DeleteViewResp, error) {
+	_, err := ddb.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &ddb.viewsTableName,
+		Key:       viewKey(req.UserID, req.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete item: %v", err)
+	}
+	return &storage.DeleteViewResp{}, nil
+}
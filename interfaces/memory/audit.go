@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// AddAuditEntry stores an audit entry, replacing any entry of the same id, and overwrites its creation time
+// with the current time.
+func (m *MemoryClient) AddAuditEntry(ctx context.Context, req *storage.AddAuditEntryReq) (*storage.AddAuditEntryResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := req.Entry
+	entry.CreatedAt = time.Now().Unix()
+	entries := slices.DeleteFunc(m.audit[entry.ProjectID], func(stored storage.AuditEntry) bool {
+		return stored.EntryID == entry.EntryID
+	})
+	m.audit[entry.ProjectID] = append(entries, entry)
+	return &storage.AddAuditEntryResp{}, nil
+}
+
+// GetAuditEntries returns the entries of the project in descending entry id order, up to the limit.
+func (m *MemoryClient) GetAuditEntries(ctx context.Context, req *storage.GetAuditEntriesReq) (*storage.GetAuditEntriesResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := slices.Clone(m.audit[req.ProjectID])
+	slices.SortFunc(entries, func(a, b storage.AuditEntry) int {
+		return strings.Compare(b.EntryID, a.EntryID)
+	})
+	if req.Limit > 0 && len(entries) > int(req.Limit) {
+		entries = entries[:req.Limit]
+	}
+	return &storage.GetAuditEntriesResp{
+		Entries: entries,
+	}, nil
+}
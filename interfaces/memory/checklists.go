@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"todo/interfaces/storage"
+)
+
+// checklistItemAt returns ErrConditionFailed unless the item with the given id is at the given index.
+func checklistItemAt(task *storage.Task, index int, itemID string) error {
+	if index < 0 || index >= len(task.Checklist) || task.Checklist[index].ID != itemID {
+		return storage.ErrConditionFailed
+	}
+	return nil
+}
+
+// AddChecklistItem appends an item to the end of a task's checklist.
+func (m *MemoryClient) AddChecklistItem(ctx context.Context, req *storage.AddChecklistItemReq) (*storage.AddChecklistItemResp, error) {
+	task, err := m.updateTask(req.UserID, req.TaskID, func(task *storage.Task) error {
+		task.Checklist = append(task.Checklist, req.Item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.AddChecklistItemResp{Task: *task}, nil
+}
+
+// SetChecklistItemDone marks a checklist item as done or not done. It fails with ErrConditionFailed
+// if the item is no longer at the given index or is already in the requested state.
+func (m *MemoryClient) SetChecklistItemDone(ctx context.Context, req *storage.SetChecklistItemDoneReq) (*storage.SetChecklistItemDoneResp, error) {
+	task, err := m.updateTask(req.UserID, req.TaskID, func(task *storage.Task) error {
+		if err := checklistItemAt(task, req.Index, req.ItemID); err != nil {
+			return err
+		}
+		if task.Checklist[req.Index].Done == req.Done {
+			return storage.ErrConditionFailed
+		}
+		task.Checklist[req.Index].Done = req.Done
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.SetChecklistItemDoneResp{Task: *task}, nil
+}
+
+// RemoveChecklistItem removes an item from a task's checklist. It fails with ErrConditionFailed
+// if the item is no longer at the given index.
+func (m *MemoryClient) RemoveChecklistItem(ctx context.Context, req *storage.RemoveChecklistItemReq) (*storage.RemoveChecklistItemResp, error) {
+	task, err := m.updateTask(req.UserID, req.TaskID, func(task *storage.Task) error {
+		if err := checklistItemAt(task, req.Index, req.ItemID); err != nil {
+			return err
+		}
+		task.Checklist = slices.Delete(task.Checklist, req.Index, req.Index+1)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.RemoveChecklistItemResp{Task: *task}, nil
+}
+
+// ReplaceChecklist replaces a task's checklist, such as to reorder it. It fails with ErrConditionFailed
+// if the stored checklist is not the expected checklist.
+func (m *MemoryClient) ReplaceChecklist(ctx context.Context, req *storage.ReplaceChecklistReq) (*storage.ReplaceChecklistResp, error) {
+	task, err := m.updateTask(req.UserID, req.TaskID, func(task *storage.Task) error {
+		if !slices.Equal(task.Checklist, req.ExpectedChecklist) {
+			return storage.ErrConditionFailed
+		}
+		task.Checklist = slices.Clone(req.Checklist)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.ReplaceChecklistResp{Task: *task}, nil
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// cloneEvent returns a deep copy of the event so that callers cannot modify stored events.
+func cloneEvent(event storage.Event) storage.Event {
+	if event.RecurringRule != nil {
+		rule := *event.RecurringRule
+		event.RecurringRule = &rule
+	}
+	return event
+}
+
+// AddEvent puts an event into the events table, overwriting any timestamps on the given event with the current time.
+func (m *MemoryClient) AddEvent(ctx context.Context, req *storage.AddEventReq) (*storage.AddEventResp, error) {
+	event := storage.NewEvent(cloneEvent(req.Event), time.Now().Unix())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.putEvent(event)
+	return &storage.AddEventResp{}, nil
+}
+
+// putEvent stores the event. The caller must hold the write lock.
+func (m *MemoryClient) putEvent(event storage.Event) {
+	if m.events[event.UserID] == nil {
+		m.events[event.UserID] = make(map[string]storage.Event)
+	}
+	m.events[event.UserID][event.EventID] = event
+}
+
+func (m *MemoryClient) GetEvent(ctx context.Context, req *storage.GetEventReq) (*storage.GetEventResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	event, ok := m.events[req.UserID][req.EventID]
+	if !ok {
+		return &storage.GetEventResp{}, nil
+	}
+	event = cloneEvent(event)
+	return &storage.GetEventResp{
+		Event: &event,
+	}, nil
+}
+
+func (m *MemoryClient) BatchGetEvent(ctx context.Context, req *storage.BatchGetEventReq) (*storage.BatchGetEventResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+// encodeEventPageToken converts the position after the given event into an opaque page token holding its key.
+func encodeEventPageToken(event *storage.Event) (string, error) {
+	data, err := json.Marshal(storage.Event{UserID: event.UserID, EventID: event.EventID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeEventPageToken converts a page token back into a position, asserting that it belongs to the given user.
+func decodeEventPageToken(pageToken, userID string) (*storage.Event, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page token: %v", err)
+	}
+	position := &storage.Event{}
+	if err := json.Unmarshal(data, position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page token: %v", err)
+	}
+	if position.UserID != userID {
+		return nil, errors.New("page token does not belong to user")
+	}
+	return position, nil
+}
+
+// GetAllEvents returns the user's events in event id order.
+func (m *MemoryClient) GetAllEvents(ctx context.Context, req *storage.GetAllEventsReq) (*storage.GetAllEventsResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	var after string
+	if req.PageToken != "" {
+		position, err := decodeEventPageToken(req.PageToken, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+		after = position.EventID
+	}
+
+	m.mu.RLock()
+	var events []storage.Event
+	for _, event := range m.events[req.UserID] {
+		if event.EventID > after {
+			events = append(events, cloneEvent(event))
+		}
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(events, func(a, b storage.Event) int {
+		return strings.Compare(a.EventID, b.EventID)
+	})
+	var nextPageToken string
+	if req.Limit > 0 && len(events) > int(req.Limit) {
+		events = events[:req.Limit]
+		var err error
+		nextPageToken, err = encodeEventPageToken(&events[len(events)-1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+	return &storage.GetAllEventsResp{
+		Events:        events,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (m *MemoryClient) UpdateEvent(ctx context.Context, req *storage.UpdateEventReq) (*storage.UpdateEventResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+func (m *MemoryClient) DeleteEvent(ctx context.Context, req *storage.DeleteEventReq) (*storage.DeleteEventResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.events[req.UserID], req.EventID)
+	return &storage.DeleteEventResp{}, nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// AddMember stores a member, overwriting its timestamps with the current time.
+func (m *MemoryClient) AddMember(ctx context.Context, req *storage.AddMemberReq) (*storage.AddMemberResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	member := storage.NewMember(req.Member, time.Now().Unix())
+	if m.members[member.UserID] == nil {
+		m.members[member.UserID] = make(map[string]storage.Member)
+	}
+	m.members[member.UserID][member.ProjectID] = member
+	return &storage.AddMemberResp{}, nil
+}
+
+func (m *MemoryClient) GetMember(ctx context.Context, req *storage.GetMemberReq) (*storage.GetMemberResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	member, ok := m.members[req.UserID][req.ProjectID]
+	if !ok {
+		return &storage.GetMemberResp{}, nil
+	}
+	return &storage.GetMemberResp{
+		Member: &member,
+	}, nil
+}
+
+// GetMemberships returns every membership of the user in project id order.
+func (m *MemoryClient) GetMemberships(ctx context.Context, req *storage.GetMembershipsReq) (*storage.GetMembershipsResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var members []storage.Member
+	for _, member := range m.members[req.UserID] {
+		members = append(members, member)
+	}
+	slices.SortFunc(members, func(a, b storage.Member) int {
+		return strings.Compare(a.ProjectID, b.ProjectID)
+	})
+	return &storage.GetMembershipsResp{
+		Members: members,
+	}, nil
+}
+
+// GetProjectMembers returns every member of the project in user id order.
+func (m *MemoryClient) GetProjectMembers(ctx context.Context, req *storage.GetProjectMembersReq) (*storage.GetProjectMembersResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var members []storage.Member
+	for _, memberships := range m.members {
+		if member, ok := memberships[req.ProjectID]; ok {
+			members = append(members, member)
+		}
+	}
+	slices.SortFunc(members, func(a, b storage.Member) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return &storage.GetProjectMembersResp{
+		Members: members,
+	}, nil
+}
+
+// UpdateMember replaces the role and accepted flag of an existing member.
+func (m *MemoryClient) UpdateMember(ctx context.Context, req *storage.UpdateMemberReq) (*storage.UpdateMemberResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.members[req.Member.UserID][req.Member.ProjectID]
+	if !ok {
+		return nil, fmt.Errorf("failed to update member: %w", storage.ErrNotFound)
+	}
+	if !req.Expects(stored) {
+		return nil, fmt.Errorf("failed to update member: %w", storage.ErrConditionFailed)
+	}
+	member := storage.UpdatedMember(stored, req.Member, time.Now().Unix())
+	m.members[member.UserID][member.ProjectID] = member
+	return &storage.UpdateMemberResp{
+		Member: member,
+	}, nil
+}
+
+func (m *MemoryClient) DeleteMember(ctx context.Context, req *storage.DeleteMemberReq) (*storage.DeleteMemberResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.members[req.UserID], req.ProjectID)
+	return &storage.DeleteMemberResp{}, nil
+}
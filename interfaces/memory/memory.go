@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"todo/interfaces/storage"
+)
+
+// MemoryClient stores users, tasks, events, views, projects, members and audit entries in memory, losing them when the server stops.
+// It implements the same behavior as the other storage backends and is safe for concurrent use.
+type MemoryClient struct {
+	mu sync.RWMutex
+	// users maps user ids to users
+	users map[string]storage.User
+	// tasks maps user ids to task ids to tasks
+	tasks map[string]map[string]storage.Task
+	// events maps user ids to event ids to events
+	events map[string]map[string]storage.Event
+	// views maps user ids to view names to views
+	views map[string]map[string]storage.View
+	// projects maps user ids to project ids to projects
+	projects map[string]map[string]storage.Project
+	// members maps user ids to project ids to the user's memberships
+	members map[string]map[string]storage.Member
+	// audit maps project ids to the project's audit entries
+	audit map[string][]storage.AuditEntry
+}
+
+// make client implement defined interface
+var _ storage.Backend = &MemoryClient{}
+
+// NewMemoryClient returns a client holding no data.
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		users:    make(map[string]storage.User),
+		tasks:    make(map[string]map[string]storage.Task),
+		events:   make(map[string]map[string]storage.Event),
+		views:    make(map[string]map[string]storage.View),
+		projects: make(map[string]map[string]storage.Project),
+		members:  make(map[string]map[string]storage.Member),
+		audit:    make(map[string][]storage.AuditEntry),
+	}
+}
+
+// Ping always succeeds, since memory is always reachable.
+func (m *MemoryClient) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close does nothing, since memory holds no connections.
+func (m *MemoryClient) Close() error {
+	return nil
+}
+
+// cloneTask returns a deep copy of the task so that callers cannot modify stored tasks.
+func cloneTask(task storage.Task) storage.Task {
+	task.Tags = slices.Clone(task.Tags)
+	task.Parents = slices.Clone(task.Parents)
+	if task.RecurringRule != nil {
+		rule := *task.RecurringRule
+		task.RecurringRule = &rule
+	}
+	task.StatusHistory = slices.Clone(task.StatusHistory)
+	task.Checklist = slices.Clone(task.Checklist)
+	return task
+}
+
+// AddUser puts a user into the users table exactly as given.
+func (m *MemoryClient) AddUser(ctx context.Context, req *storage.AddUserReq) (*storage.AddUserResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users[req.User.ID] = req.User
+	return &storage.AddUserResp{}, nil
+}
+
+// GetUser uses the given user id to find a user.
+// User will be nil if no user is found.
+func (m *MemoryClient) GetUser(ctx context.Context, req *storage.GetUserReq) (*storage.GetUserResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	user, ok := m.users[req.ID]
+	if !ok {
+		return &storage.GetUserResp{}, nil
+	}
+	return &storage.GetUserResp{
+		User: &user,
+	}, nil
+}
+
+// UpdateUser replaces the fields of the user that are set.
+func (m *MemoryClient) UpdateUser(ctx context.Context, req *storage.UpdateUserReq) (*storage.UpdateUserResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[req.ID]
+	if !ok {
+		return nil, fmt.Errorf("failed to update user: %w", storage.ErrNotFound)
+	}
+	if req.FeedTokenHash != nil {
+		user.FeedTokenHash = *req.FeedTokenHash
+	}
+	m.users[req.ID] = user
+	return &storage.UpdateUserResp{}, nil
+}
+
+func (m *MemoryClient) DeleteUser(ctx context.Context, req *storage.DeleteUserReq) (*storage.DeleteUserResp, error) {
+	return nil, errors.New("not implemented yet")
+}
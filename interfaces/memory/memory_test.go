@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"todo/interfaces/storage"
+	"todo/interfaces/storage/conformance"
+)
+
+func Test_MemoryClient_Conformance(t *testing.T) {
+	conformance.Run(t, NewMemoryClient())
+}
+
+func Test_MemoryClient_returnsCopies(t *testing.T) {
+	ctx := context.Background()
+	client := NewMemoryClient()
+	task := storage.Task{UserID: "user", TaskID: "task", Title: "title", Status: "INCOMPLETE", Tags: []string{"tag"}}
+	if _, err := client.AddTask(ctx, &storage.AddTaskReq{Task: task}); err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+	task.Tags[0] = "changed by caller"
+
+	resp, err := client.GetTask(ctx, &storage.GetTaskReq{UserID: "user", TaskID: "task"})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	resp.Task.Tags[0] = "changed by caller"
+
+	resp, err = client.GetTask(ctx, &storage.GetTaskReq{UserID: "user", TaskID: "task"})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if resp.Task.Tags[0] != "tag" {
+		t.Errorf("GetTask() tags = %v, want stored task unchanged", resp.Task.Tags)
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// AddProject stores a project, overwriting its timestamps with the current time.
+func (m *MemoryClient) AddProject(ctx context.Context, req *storage.AddProjectReq) (*storage.AddProjectResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	project := storage.NewProject(req.Project, time.Now().Unix())
+	if m.projects[project.UserID] == nil {
+		m.projects[project.UserID] = make(map[string]storage.Project)
+	}
+	m.projects[project.UserID][project.ProjectID] = project
+	return &storage.AddProjectResp{}, nil
+}
+
+func (m *MemoryClient) GetProject(ctx context.Context, req *storage.GetProjectReq) (*storage.GetProjectResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	project, ok := m.projects[req.UserID][req.ProjectID]
+	if !ok {
+		return &storage.GetProjectResp{}, nil
+	}
+	return &storage.GetProjectResp{
+		Project: &project,
+	}, nil
+}
+
+// GetAllProjects returns every project of the user in project id order.
+func (m *MemoryClient) GetAllProjects(ctx context.Context, req *storage.GetAllProjectsReq) (*storage.GetAllProjectsResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var projects []storage.Project
+	for _, project := range m.projects[req.UserID] {
+		projects = append(projects, project)
+	}
+	slices.SortFunc(projects, func(a, b storage.Project) int {
+		return strings.Compare(a.ProjectID, b.ProjectID)
+	})
+	return &storage.GetAllProjectsResp{
+		Projects: projects,
+	}, nil
+}
+
+// UpdateProject replaces the name, description, color and archived flag of an existing project.
+func (m *MemoryClient) UpdateProject(ctx context.Context, req *storage.UpdateProjectReq) (*storage.UpdateProjectResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.projects[req.Project.UserID][req.Project.ProjectID]
+	if !ok {
+		return nil, fmt.Errorf("failed to update project: %w", storage.ErrNotFound)
+	}
+	project := storage.UpdatedProject(stored, req.Project, time.Now().Unix())
+	m.projects[project.UserID][project.ProjectID] = project
+	return &storage.UpdateProjectResp{
+		Project: project,
+	}, nil
+}
+
+func (m *MemoryClient) DeleteProject(ctx context.Context, req *storage.DeleteProjectReq) (*storage.DeleteProjectResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.projects[req.UserID], req.ProjectID)
+	return &storage.DeleteProjectResp{}, nil
+}
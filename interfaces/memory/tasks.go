@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// compareTasks orders tasks by the given sort key, or only by task id when it is empty,
+// breaking ties by task id.
+func compareTasks(a, b *storage.Task, sortKey string) int {
+	var c int
+	switch sortKey {
+	case storage.DueDateKey:
+		c = cmp.Compare(a.DueDate, b.DueDate)
+	case storage.CreatedAtKey:
+		c = cmp.Compare(a.CreatedAt, b.CreatedAt)
+	case storage.UpdatedAtKey:
+		c = cmp.Compare(a.UpdatedAt, b.UpdatedAt)
+	case storage.TitleKey:
+		c = cmp.Compare(a.Title, b.Title)
+	case storage.PriorityKey:
+		c = cmp.Compare(a.Priority, b.Priority)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.TaskID, b.TaskID)
+}
+
+// sortKeys are the task attributes that tasks may be sorted by.
+var sortKeys = []string{
+	storage.DueDateKey,
+	storage.CreatedAtKey,
+	storage.UpdatedAtKey,
+	storage.TitleKey,
+	storage.PriorityKey,
+}
+
+// AddTask puts a task into the tasks table, overwriting any timestamps on the given task
+// with the current time and starting its status history with its status.
+func (m *MemoryClient) AddTask(ctx context.Context, req *storage.AddTaskReq) (*storage.AddTaskResp, error) {
+	task := storage.NewTask(cloneTask(req.Task), time.Now().Unix())
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.putTask(task)
+	return &storage.AddTaskResp{}, nil
+}
+
+// putTask stores the task. The caller must hold the write lock.
+func (m *MemoryClient) putTask(task storage.Task) {
+	if m.tasks[task.UserID] == nil {
+		m.tasks[task.UserID] = make(map[string]storage.Task)
+	}
+	m.tasks[task.UserID][task.TaskID] = task
+}
+
+func (m *MemoryClient) GetTask(ctx context.Context, req *storage.GetTaskReq) (*storage.GetTaskResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	task, ok := m.tasks[req.UserID][req.TaskID]
+	if !ok {
+		return &storage.GetTaskResp{}, nil
+	}
+	task = cloneTask(task)
+	return &storage.GetTaskResp{
+		Task: &task,
+	}, nil
+}
+
+func (m *MemoryClient) BatchGetTask(ctx context.Context, req *storage.BatchGetTaskReq) (*storage.BatchGetTaskResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+// encodePageToken converts the position after the given task into an opaque page token.
+// The token holds the task's key and its value of the sort key.
+func encodePageToken(task *storage.Task) (string, error) {
+	data, err := json.Marshal(storage.Task{
+		UserID:    task.UserID,
+		TaskID:    task.TaskID,
+		Title:     task.Title,
+		DueDate:   task.DueDate,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+		Priority:  task.Priority,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken converts a page token back into a position, asserting that it belongs to the given user.
+func decodePageToken(pageToken, userID string) (*storage.Task, error) {
+	position, err := decodePosition(pageToken)
+	if err != nil {
+		return nil, err
+	}
+	if position.UserID != userID {
+		return nil, errors.New("page token does not belong to user")
+	}
+	return position, nil
+}
+
+// decodePosition converts a page token back into a position of any user.
+func decodePosition(pageToken string) (*storage.Task, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page token: %v", err)
+	}
+	position := &storage.Task{}
+	if err := json.Unmarshal(data, position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page token: %v", err)
+	}
+	return position, nil
+}
+
+// matches reports whether the task satisfies every filter of the request.
+func matches(task *storage.Task, req *storage.GetAllTasksReq) bool {
+	if len(req.Statuses) > 0 && !slices.Contains(req.Statuses, task.Status) {
+		return false
+	}
+	if len(req.Priorities) > 0 && !slices.Contains(req.Priorities, task.Priority) {
+		return false
+	}
+	if req.MaxEffortMinutes > 0 && (task.EffortMinutes == 0 || task.EffortMinutes > req.MaxEffortMinutes) {
+		return false
+	}
+	if req.Filter != nil && !req.Filter.Match(task) {
+		return false
+	}
+	return true
+}
+
+// GetAllTasks returns the user's tasks matching the request's filters, ordered by its sort key.
+func (m *MemoryClient) GetAllTasks(ctx context.Context, req *storage.GetAllTasksReq) (*storage.GetAllTasksResp, error) {
+	if req.SortKey != "" && !slices.Contains(sortKeys, req.SortKey) {
+		return nil, fmt.Errorf("unable to sort by %s", req.SortKey)
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	var position *storage.Task
+	if req.PageToken != "" {
+		var err error
+		position, err = decodePageToken(req.PageToken, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+	}
+	compare := func(a, b *storage.Task) int {
+		if req.Descending {
+			return compareTasks(b, a, req.SortKey)
+		}
+		return compareTasks(a, b, req.SortKey)
+	}
+
+	// filter, skipping tasks up to the page token
+	m.mu.RLock()
+	var tasks []storage.Task
+	for _, task := range m.tasks[req.UserID] {
+		if matches(&task, req) && (position == nil || compare(&task, position) > 0) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	m.mu.RUnlock()
+
+	// sort and limit
+	slices.SortFunc(tasks, func(a, b storage.Task) int {
+		return compare(&a, &b)
+	})
+	var nextPageToken string
+	if req.Limit > 0 && len(tasks) > int(req.Limit) {
+		tasks = tasks[:req.Limit]
+		var err error
+		nextPageToken, err = encodePageToken(&tasks[len(tasks)-1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+	return &storage.GetAllTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ScanTasks returns the tasks of every user ordered by user id and then task id.
+func (m *MemoryClient) ScanTasks(ctx context.Context, req *storage.ScanTasksReq) (*storage.ScanTasksResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	var position *storage.Task
+	if req.PageToken != "" {
+		var err error
+		position, err = decodePosition(req.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+	}
+	compare := func(a, b *storage.Task) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), cmp.Compare(a.TaskID, b.TaskID))
+	}
+
+	// skip tasks up to the page token
+	m.mu.RLock()
+	var tasks []storage.Task
+	for _, userTasks := range m.tasks {
+		for _, task := range userTasks {
+			if position == nil || compare(&task, position) > 0 {
+				tasks = append(tasks, cloneTask(task))
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	// sort and limit
+	slices.SortFunc(tasks, func(a, b storage.Task) int {
+		return compare(&a, &b)
+	})
+	var nextPageToken string
+	if req.Limit > 0 && len(tasks) > int(req.Limit) {
+		tasks = tasks[:req.Limit]
+		var err error
+		nextPageToken, err = encodePageToken(&tasks[len(tasks)-1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+	return &storage.ScanTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetAssignedTasks returns the assignee's tasks in user id and task id order.
+func (m *MemoryClient) GetAssignedTasks(ctx context.Context, req *storage.GetAssignedTasksReq) (*storage.GetAssignedTasksResp, error) {
+	m.mu.RLock()
+	var tasks []storage.Task
+	for _, userTasks := range m.tasks {
+		for _, task := range userTasks {
+			if task.AssigneeID == req.AssigneeID {
+				tasks = append(tasks, cloneTask(task))
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(tasks, func(a, b storage.Task) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), cmp.Compare(a.TaskID, b.TaskID))
+	})
+	return &storage.GetAssignedTasksResp{
+		Tasks: tasks,
+	}, nil
+}
+
+// updateTask applies fn to a copy of a stored task and, if it succeeds, stores the copy with updated_at set.
+// It returns ErrNotFound if the task does not exist, along with any error returned by fn.
+func (m *MemoryClient) updateTask(userID, taskID string, fn func(task *storage.Task) error) (*storage.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.tasks[userID][taskID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	task := cloneTask(stored)
+	if err := fn(&task); err != nil {
+		return nil, err
+	}
+	task.UpdatedAt = time.Now().Unix()
+	m.tasks[userID][taskID] = task
+	task = cloneTask(task)
+	return &task, nil
+}
+
+// applyUpdate returns the change requested by req, failing with ErrConditionFailed
+// if the task's status or checklist is not the expected one.
+func applyUpdate(req *storage.UpdateTaskReq) func(task *storage.Task) error {
+	return func(task *storage.Task) error {
+		if req.ExpectedStatus != "" && task.Status != req.ExpectedStatus {
+			return storage.ErrConditionFailed
+		}
+		if req.ExpectChecklist && !slices.Equal(task.Checklist, req.ExpectedChecklist) {
+			return storage.ErrConditionFailed
+		}
+		now := time.Now().Unix()
+		if err := storage.ApplyKVPairs(task, req.KVPairs, now); err != nil {
+			return err
+		}
+		if req.ExpectedStatus != "" && task.Status != req.ExpectedStatus {
+			task.StatusHistory = append(task.StatusHistory, storage.StatusChange{Status: task.Status, ChangedAt: now})
+		}
+		return nil
+	}
+}
+
+// UpdateTask sets the given attributes of an existing task and returns the updated task.
+// It returns ErrNotFound if the task does not exist.
+func (m *MemoryClient) UpdateTask(ctx context.Context, req *storage.UpdateTaskReq) (*storage.UpdateTaskResp, error) {
+	task, err := m.updateTask(req.UserID, req.TaskID, applyUpdate(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return &storage.UpdateTaskResp{
+		Task: *task,
+	}, nil
+}
+
+func (m *MemoryClient) DeleteTask(ctx context.Context, req *storage.DeleteTaskReq) (*storage.DeleteTaskResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tasks[req.UserID], req.TaskID)
+	return &storage.DeleteTaskResp{}, nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// taskKey identifies a stored task.
+type taskKey struct {
+	userID, taskID string
+}
+
+// staged holds the writes of a unit of work being committed, so that the stored data
+// is only changed once every write has succeeded.
+type staged struct {
+	users map[string]storage.User
+	// tasks maps task keys to written tasks, or nil for deleted tasks
+	tasks  map[taskKey]*storage.Task
+	events []storage.Event
+}
+
+// unitOfWork collects writes to apply in order while holding the write lock.
+type unitOfWork struct {
+	m      *MemoryClient
+	writes []func(s *staged) error
+}
+
+// NewUnitOfWork starts a unit of work whose writes are applied together under the client's lock.
+func (m *MemoryClient) NewUnitOfWork() storage.UnitOfWork {
+	return &unitOfWork{m: m}
+}
+
+func (u *unitOfWork) AddUser(user storage.User) {
+	u.writes = append(u.writes, func(s *staged) error {
+		s.users[user.ID] = user
+		return nil
+	})
+}
+
+func (u *unitOfWork) AddTask(task storage.Task) {
+	u.writes = append(u.writes, func(s *staged) error {
+		task := storage.NewTask(cloneTask(task), time.Now().Unix())
+		s.tasks[taskKey{task.UserID, task.TaskID}] = &task
+		return nil
+	})
+}
+
+func (u *unitOfWork) UpdateTask(req storage.UpdateTaskReq) {
+	u.writes = append(u.writes, func(s *staged) error {
+		key := taskKey{req.UserID, req.TaskID}
+		stored, ok := s.tasks[key]
+		if !ok {
+			if task, ok := u.m.tasks[req.UserID][req.TaskID]; ok {
+				stored = &task
+			}
+		}
+		if stored == nil {
+			return storage.ErrNotFound
+		}
+		task := cloneTask(*stored)
+		if err := applyUpdate(&req)(&task); err != nil {
+			return err
+		}
+		task.UpdatedAt = time.Now().Unix()
+		s.tasks[key] = &task
+		return nil
+	})
+}
+
+func (u *unitOfWork) DeleteTask(req storage.DeleteTaskReq) {
+	u.writes = append(u.writes, func(s *staged) error {
+		s.tasks[taskKey{req.UserID, req.TaskID}] = nil
+		return nil
+	})
+}
+
+func (u *unitOfWork) AddEvent(event storage.Event) {
+	u.writes = append(u.writes, func(s *staged) error {
+		s.events = append(s.events, storage.NewEvent(cloneEvent(event), time.Now().Unix()))
+		return nil
+	})
+}
+
+// Commit stages every write and, if all of them succeed, applies them to the stored data.
+func (u *unitOfWork) Commit(ctx context.Context) error {
+	u.m.mu.Lock()
+	defer u.m.mu.Unlock()
+	s := &staged{
+		users: make(map[string]storage.User),
+		tasks: make(map[taskKey]*storage.Task),
+	}
+	for _, write := range u.writes {
+		if err := write(s); err != nil {
+			return err
+		}
+	}
+	for id, user := range s.users {
+		u.m.users[id] = user
+	}
+	for key, task := range s.tasks {
+		if task == nil {
+			delete(u.m.tasks[key.userID], key.taskID)
+			continue
+		}
+		u.m.putTask(*task)
+	}
+	for _, event := range s.events {
+		u.m.putEvent(event)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"todo/interfaces/storage"
+)
+
+// SaveView puts a view, replacing any view of the user with the same name.
+func (m *MemoryClient) SaveView(ctx context.Context, req *storage.SaveViewReq) (*storage.SaveViewResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.views[req.View.UserID] == nil {
+		m.views[req.View.UserID] = make(map[string]storage.View)
+	}
+	m.views[req.View.UserID][req.View.Name] = req.View
+	return &storage.SaveViewResp{}, nil
+}
+
+func (m *MemoryClient) GetView(ctx context.Context, req *storage.GetViewReq) (*storage.GetViewResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	view, ok := m.views[req.UserID][req.Name]
+	if !ok {
+		return &storage.GetViewResp{}, nil
+	}
+	return &storage.GetViewResp{
+		View: &view,
+	}, nil
+}
+
+// GetAllViews returns every view of the user in name order.
+func (m *MemoryClient) GetAllViews(ctx context.Context, req *storage.GetAllViewsReq) (*storage.GetAllViewsResp, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var views []storage.View
+	for _, view := range m.views[req.UserID] {
+		views = append(views, view)
+	}
+	slices.SortFunc(views, func(a, b storage.View) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return &storage.GetAllViewsResp{
+		Views: views,
+	}, nil
+}
+
+func (m *MemoryClient) DeleteView(ctx context.Context, req *storage.DeleteViewReq) (*storage.DeleteViewResp, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.views[req.UserID], req.Name)
+	return &storage.DeleteViewResp{}, nil
+}
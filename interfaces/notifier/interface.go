@@ -0,0 +1,27 @@
+package notifier
+
+import "context"
+
+// kinds of notifications
+const (
+	KindTaskAssigned   = "task.assigned"
+	KindTaskUnassigned = "task.unassigned"
+)
+
+// Notification tells a user about a change another user made.
+type Notification struct {
+	// UserID is the id of the user to notify
+	UserID string
+	// Kind is what changed, such as KindTaskAssigned
+	Kind string
+	// ActorID is the id of the user who made the change
+	ActorID   string
+	ProjectID string
+	TaskID    string
+	TaskTitle string
+}
+
+type NotifierInterface interface {
+	// Notify delivers the notification, returning an error if it could not be delivered.
+	Notify(ctx context.Context, notification Notification) error
+}
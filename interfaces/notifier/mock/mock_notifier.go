@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"context"
+	"todo/interfaces/notifier"
+)
+
+// MockNotifier mocks LogNotifier, recording the notifications it delivers
+type MockNotifier struct {
+	Notifications []notifier.Notification
+	NotifyErr     error
+}
+
+// assert that MockNotifier implements NotifierInterface
+var _ notifier.NotifierInterface = &MockNotifier{}
+
+// Notify records the notification unless NotifyErr is set.
+func (mn *MockNotifier) Notify(ctx context.Context, notification notifier.Notification) error {
+	if mn.NotifyErr != nil {
+		return mn.NotifyErr
+	}
+	mn.Notifications = append(mn.Notifications, notification)
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package notifier tells users about changes other users make to their work, such as tasks assigned to them.
+package notifier
+
+import (
+	"context"
+	"todo/logging"
+)
+
+// LogNotifier delivers notifications by writing them to the log of the request that caused them,
+// from which they can be forwarded to users.
+type LogNotifier struct{}
+
+// assert that LogNotifier implements NotifierInterface
+var _ NotifierInterface = &LogNotifier{}
+
+// NewLogNotifier returns a notifier writing to the request logs.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify logs the notification at the info level.
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	logging.FromContext(ctx).InfoContext(ctx, "notification",
+		"notify_user_id", notification.UserID,
+		"kind", notification.Kind,
+		"actor_id", notification.ActorID,
+		"project_id", notification.ProjectID,
+		"task_id", notification.TaskID,
+		"task_title", notification.TaskTitle,
+	)
+	return nil
+}
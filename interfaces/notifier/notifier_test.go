@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"todo/logging"
+)
+
+func Test_LogNotifier_Notify(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logging.NewContext(context.Background(), logging.New(&buf, "json", slog.LevelInfo))
+	err := NewLogNotifier().Notify(ctx, Notification{
+		UserID:    "user_2",
+		Kind:      KindTaskAssigned,
+		ActorID:   "user_1",
+		ProjectID: "project",
+		TaskID:    "task",
+		TaskTitle: "title",
+	})
+	if err != nil {
+		t.Fatalf("LogNotifier.Notify() error = %v", err)
+	}
+	record := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to unmarshal log record: %v", err)
+	}
+	for key, want := range map[string]string{
+		"msg":            "notification",
+		"notify_user_id": "user_2",
+		"kind":           KindTaskAssigned,
+		"actor_id":       "user_1",
+		"project_id":     "project",
+		"task_id":        "task",
+		"task_title":     "title",
+	} {
+		if got := record[key]; got != want {
+			t.Errorf("logged %s = %v, want %s", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package rate_limiter limits how often callers may call methods with token buckets.
+package rate_limiter
+
+import (
+	"sync"
+	"time"
+	"todo/config"
+
+	"golang.org/x/time/rate"
+)
+
+// sweepInterval is how often buckets that have refilled are forgotten.
+const sweepInterval = time.Minute
+
+// bucketKey identifies the bucket of a caller for a limit. The default limit has an empty method.
+type bucketKey struct {
+	caller string
+	method string
+}
+
+// RateLimiter holds a token bucket per caller for the default limit and for each method with its own limit.
+// Buckets are created full and forgotten once they have refilled, so that idle callers take no memory.
+type RateLimiter struct {
+	cfg config.RateLimitConfig
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*rate.Limiter
+	lastSweep time.Time
+}
+
+// NewRateLimiter returns a rate limiter with the given limits, which must be valid.
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:     cfg,
+		buckets: map[bucketKey]*rate.Limiter{},
+	}
+}
+
+// Allow takes the method's cost from the caller's bucket for the method, returning true if it could be taken at now.
+// Otherwise it takes nothing and returns how long the caller must wait until it can be taken.
+func (r *RateLimiter) Allow(caller, method string, now time.Time) (bool, time.Duration) {
+	// get the method's limit and cost
+	key := bucketKey{caller: caller}
+	limit, burst := rate.Limit(r.cfg.RequestsPerSecond), r.cfg.Burst
+	methodLimit := r.cfg.Methods[method]
+	if methodLimit.RequestsPerSecond > 0 {
+		key.method = method
+		limit, burst = rate.Limit(methodLimit.RequestsPerSecond), methodLimit.Burst
+	}
+	if limit == 0 {
+		return true, 0
+	}
+	cost := max(methodLimit.Cost, 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweep(now)
+
+	// take the cost from the caller's bucket, creating it full if it does not exist
+	limiter, ok := r.buckets[key]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		r.buckets[key] = limiter
+	}
+	reservation := limiter.ReserveN(now, cost)
+	if !reservation.OK() {
+		return false, rate.InfDuration
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep forgets the buckets that have refilled since they were last used, at most once per sweepInterval.
+func (r *RateLimiter) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < sweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for key, limiter := range r.buckets {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(r.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package rate_limiter
+
+import (
+	"testing"
+	"time"
+	"todo/config"
+)
+
+func Test_RateLimiter_Allow(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		RequestsPerSecond: 1,
+		Burst:             3,
+		Methods: map[string]config.MethodRateLimitConfig{
+			"expensive": {Cost: 2},
+			"own limit": {RequestsPerSecond: 0.5, Burst: 1},
+		},
+	}
+	start := time.Unix(1700000000, 0)
+	type call struct {
+		caller    string
+		method    string
+		after     time.Duration
+		wantAllow bool
+		wantWait  time.Duration
+	}
+	tests := []struct {
+		name  string
+		cfg   config.RateLimitConfig
+		calls []call
+	}{
+		{
+			name: "burst then refill",
+			cfg:  cfg,
+			calls: []call{
+				{caller: "user1", method: "cheap", wantAllow: true},
+				{caller: "user1", method: "cheap", wantAllow: true},
+				{caller: "user1", method: "cheap", wantAllow: true},
+				{caller: "user1", method: "cheap", wantAllow: false, wantWait: time.Second},
+				{caller: "user1", method: "cheap", after: time.Second, wantAllow: true},
+			},
+		},
+		{
+			name: "cost",
+			cfg:  cfg,
+			calls: []call{
+				{caller: "user1", method: "expensive", wantAllow: true},
+				{caller: "user1", method: "expensive", wantAllow: false, wantWait: time.Second},
+				{caller: "user1", method: "cheap", wantAllow: true},
+			},
+		},
+		{
+			name: "callers have separate buckets",
+			cfg:  cfg,
+			calls: []call{
+				{caller: "user1", method: "expensive", wantAllow: true},
+				{caller: "user1", method: "expensive", wantAllow: false, wantWait: time.Second},
+				{caller: "user2", method: "expensive", wantAllow: true},
+			},
+		},
+		{
+			name: "method with own limit",
+			cfg:  cfg,
+			calls: []call{
+				{caller: "user1", method: "own limit", wantAllow: true},
+				{caller: "user1", method: "own limit", wantAllow: false, wantWait: 2 * time.Second},
+				{caller: "user1", method: "cheap", wantAllow: true},
+			},
+		},
+		{
+			name: "no default limit",
+			cfg:  config.RateLimitConfig{Methods: cfg.Methods},
+			calls: []call{
+				{caller: "user1", method: "cheap", wantAllow: true},
+				{caller: "user1", method: "cheap", wantAllow: true},
+				{caller: "user1", method: "own limit", wantAllow: true},
+				{caller: "user1", method: "own limit", wantAllow: false, wantWait: 2 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRateLimiter(tt.cfg)
+			now := start
+			for i, c := range tt.calls {
+				now = now.Add(c.after)
+				allow, wait := r.Allow(c.caller, c.method, now)
+				if allow != c.wantAllow || wait != c.wantWait {
+					t.Errorf("call %d: Allow() = %v, %v, want %v, %v", i, allow, wait, c.wantAllow, c.wantWait)
+				}
+			}
+		})
+	}
+}
+
+func Test_RateLimiter_sweep(t *testing.T) {
+	r := NewRateLimiter(config.RateLimitConfig{RequestsPerSecond: 1, Burst: 2})
+	start := time.Unix(1700000000, 0)
+	r.Allow("user1", "method", start)
+	r.Allow("user2", "method", start.Add(sweepInterval-100*time.Millisecond))
+	r.Allow("user3", "method", start.Add(sweepInterval))
+	if _, ok := r.buckets[bucketKey{caller: "user1"}]; ok {
+		t.Errorf("bucket of user1 was not forgotten after refilling")
+	}
+	if _, ok := r.buckets[bucketKey{caller: "user2"}]; !ok {
+		t.Errorf("bucket of user2 was forgotten before refilling")
+	}
+}
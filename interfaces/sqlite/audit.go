@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// auditColumns are the columns of the audit table in the order GetAuditEntries reads them.
+const auditColumns = "project_id, entry_id, user_id, action, task_id, details, created_at"
+
+// AddAuditEntry puts an entry into the audit table, overwriting the creation time of the given entry
+// with the current time.
+func (s *SQLiteClient) AddAuditEntry(ctx context.Context, req *storage.AddAuditEntryReq) (*storage.AddAuditEntryResp, error) {
+	entry := req.Entry
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO audit ("+auditColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entry.ProjectID, entry.EntryID, entry.UserID, entry.Action, entry.TaskID, entry.Details, time.Now().Unix(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put entry into audit table: %v", err)
+	}
+	return &storage.AddAuditEntryResp{}, nil
+}
+
+// GetAuditEntries returns the entries of the project in descending entry id order, up to the limit.
+func (s *SQLiteClient) GetAuditEntries(ctx context.Context, req *storage.GetAuditEntriesReq) (*storage.GetAuditEntriesResp, error) {
+	// a negative limit has no upper bound
+	limit := int64(-1)
+	if req.Limit > 0 {
+		limit = int64(req.Limit)
+	}
+	rows, err := s.db.QueryContext(ctx, "SELECT "+auditColumns+" FROM audit WHERE project_id = ? ORDER BY entry_id DESC LIMIT ?", req.ProjectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit entries: %v", err)
+	}
+	defer rows.Close()
+	var entries []storage.AuditEntry
+	for rows.Next() {
+		var entry storage.AuditEntry
+		err := rows.Scan(&entry.ProjectID, &entry.EntryID, &entry.UserID, &entry.Action, &entry.TaskID, &entry.Details, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query audit entries: %v", err)
+	}
+	return &storage.GetAuditEntriesResp{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// eventColumns are the columns of the events table in the order scanEvent reads them.
+const eventColumns = `user_id, event_id, title, description, location, start_time, end_time, all_day, recurring_rule,
+	created_at, updated_at`
+
+// scanEvent reads an event from a row holding the event columns.
+func scanEvent(row scanner) (*storage.Event, error) {
+	event := &storage.Event{}
+	var recurringRule string
+	err := row.Scan(
+		&event.UserID, &event.EventID, &event.Title, &event.Description, &event.Location, &event.StartTime, &event.EndTime, &event.AllDay, &recurringRule,
+		&event.CreatedAt, &event.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(recurringRule), &event.RecurringRule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event column: %v", err)
+	}
+	return event, nil
+}
+
+// putEvent inserts the event, replacing any event with the same key.
+func putEvent(ctx context.Context, tx *sql.Tx, event *storage.Event) error {
+	recurringRule, err := json.Marshal(event.RecurringRule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event column: %v", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO events ("+eventColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		event.UserID, event.EventID, event.Title, event.Description, event.Location, event.StartTime, event.EndTime, event.AllDay, string(recurringRule),
+		event.CreatedAt, event.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put event into events table: %v", err)
+	}
+	return nil
+}
+
+// AddEvent puts an event into the events table, overwriting any timestamps on the given event with the current time.
+func (s *SQLiteClient) AddEvent(ctx context.Context, req *storage.AddEventReq) (*storage.AddEventResp, error) {
+	event := storage.NewEvent(req.Event, time.Now().Unix())
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return putEvent(ctx, tx, &event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.AddEventResp{}, nil
+}
+
+func (s *SQLiteClient) GetEvent(ctx context.Context, req *storage.GetEventReq) (*storage.GetEventResp, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+eventColumns+" FROM events WHERE user_id = ? AND event_id = ?", req.UserID, req.EventID)
+	event, err := scanEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetEventResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %v", err)
+	}
+	return &storage.GetEventResp{
+		Event: event,
+	}, nil
+}
+
+func (s *SQLiteClient) BatchGetEvent(ctx context.Context, req *storage.BatchGetEventReq) (*storage.BatchGetEventResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+// eventPageToken is the position after which the next page of events starts.
+type eventPageToken struct {
+	UserID  string `json:"user_id"`
+	EventID string `json:"event_id"`
+}
+
+// GetAllEvents queries the user's events in event id order.
+func (s *SQLiteClient) GetAllEvents(ctx context.Context, req *storage.GetAllEventsReq) (*storage.GetAllEventsResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	query := "SELECT " + eventColumns + " FROM events WHERE user_id = ?"
+	args := []any{req.UserID}
+
+	// start after the page token
+	if req.PageToken != "" {
+		position, err := decodeEventPageToken(req.PageToken, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+		query, args = query+" AND event_id > ?", append(args, position.EventID)
+	}
+
+	// fetch one more event than the limit to find out whether there is another page
+	query += " ORDER BY event_id"
+	if req.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, req.Limit+1)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %v", err)
+	}
+	defer rows.Close()
+	var events []storage.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %v", err)
+		}
+		events = append(events, *event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query events: %v", err)
+	}
+
+	var nextPageToken string
+	if req.Limit > 0 && len(events) > int(req.Limit) {
+		events = events[:req.Limit]
+		nextPageToken, err = encodeEventPageToken(&events[len(events)-1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+	return &storage.GetAllEventsResp{
+		Events:        events,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// encodeEventPageToken converts the position after the given event into an opaque page token.
+func encodeEventPageToken(event *storage.Event) (string, error) {
+	data, err := json.Marshal(eventPageToken{UserID: event.UserID, EventID: event.EventID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeEventPageToken converts a page token back into a position, asserting that it belongs to the given user.
+func decodeEventPageToken(token, userID string) (*eventPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page token: %v", err)
+	}
+	var position eventPageToken
+	if err := json.Unmarshal(data, &position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page token: %v", err)
+	}
+	if position.UserID != userID {
+		return nil, errors.New("page token does not belong to user")
+	}
+	return &position, nil
+}
+
+func (s *SQLiteClient) UpdateEvent(ctx context.Context, req *storage.UpdateEventReq) (*storage.UpdateEventResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+func (s *SQLiteClient) DeleteEvent(ctx context.Context, req *storage.DeleteEventReq) (*storage.DeleteEventResp, error) {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM events WHERE user_id = ? AND event_id = ?", req.UserID, req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete event: %v", err)
+	}
+	return &storage.DeleteEventResp{}, nil
+}
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"strings"
+	"todo/interfaces/storage"
+	"unicode"
+)
+
+// filterOps are the comparison operators of filters, which SQLite shares.
+var filterOps = map[storage.Op]bool{
+	storage.OpEq: true,
+	storage.OpLt: true,
+	storage.OpLe: true,
+	storage.OpGt: true,
+	storage.OpGe: true,
+}
+
+// translateFilter returns a condition matching the tasks the filter matches along with its arguments,
+// or false if the filter holds a condition SQLite does not evaluate as Match does.
+func translateFilter(f storage.Filter) (string, []any, bool) {
+	switch f := f.(type) {
+	case storage.And:
+		return translateFilters(f, " AND ", "1")
+	case storage.Or:
+		return translateFilters(f, " OR ", "0")
+	case storage.Not:
+		cond, args, ok := translateFilter(f.Filter)
+		return "NOT (" + cond + ")", args, ok
+	case storage.Compare:
+		if !filterOps[f.Op] {
+			return "", nil, false
+		}
+		switch f.Value.(type) {
+		case string:
+			switch f.Key {
+			case storage.StatusKey, storage.PriorityKey, storage.ProjectIDKey:
+			default:
+				return "", nil, false
+			}
+		case int64:
+			switch f.Key {
+			case storage.DueDateKey, storage.CreatedAtKey, storage.UpdatedAtKey, storage.CompletedAtKey, storage.EffortMinutesKey:
+			default:
+				return "", nil, false
+			}
+		default:
+			return "", nil, false
+		}
+		cond := f.Key + " " + string(f.Op) + " ?"
+		if storage.OptionalAttribute(f.Key) {
+			cond = "(" + f.Key + " <> 0 AND " + cond + ")"
+		}
+		return cond, []any{f.Value}, true
+	case storage.Contains:
+		switch f.Key {
+		case storage.TagsKey:
+			return "EXISTS (SELECT 1 FROM json_each(tags) WHERE value = ?)", []any{f.Value}, true
+		case storage.TitleKey, storage.DescriptionKey:
+			// lower only folds the case of ASCII letters
+			if strings.IndexFunc(f.Value, func(r rune) bool { return r > unicode.MaxASCII }) >= 0 {
+				return "", nil, false
+			}
+			return "instr(lower(" + f.Key + "), ?) > 0", []any{strings.ToLower(f.Value)}, true
+		}
+	}
+	return "", nil, false
+}
+
+// translateFilters joins the conditions of every filter with the operator, returning empty when there are none.
+func translateFilters(filters []storage.Filter, operator, empty string) (string, []any, bool) {
+	if len(filters) == 0 {
+		return empty, nil, true
+	}
+	conds := make([]string, 0, len(filters))
+	var args []any
+	for _, f := range filters {
+		cond, condArgs, ok := translateFilter(f)
+		if !ok {
+			return "", nil, false
+		}
+		conds, args = append(conds, cond), append(args, condArgs...)
+	}
+	return "(" + strings.Join(conds, operator) + ")", args, true
+}
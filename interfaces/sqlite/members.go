@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// memberColumns are the columns of the members table in the order scanMember reads them.
+const memberColumns = "user_id, project_id, owner_id, role, invited_by, accepted, created_at, updated_at"
+
+// scanMember reads a member from a row holding the member columns.
+func scanMember(row scanner) (*storage.Member, error) {
+	member := &storage.Member{}
+	err := row.Scan(
+		&member.UserID, &member.ProjectID, &member.OwnerID, &member.Role, &member.InvitedBy, &member.Accepted,
+		&member.CreatedAt, &member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// queryMembers returns the members of the rows of a query selecting the member columns.
+func (s *SQLiteClient) queryMembers(ctx context.Context, query string, args ...any) ([]storage.Member, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members: %v", err)
+	}
+	defer rows.Close()
+	var members []storage.Member
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %v", err)
+		}
+		members = append(members, *member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query members: %v", err)
+	}
+	return members, nil
+}
+
+// AddMember puts a member into the members table, overwriting any timestamps on the given member
+// with the current time.
+func (s *SQLiteClient) AddMember(ctx context.Context, req *storage.AddMemberReq) (*storage.AddMemberResp, error) {
+	member := storage.NewMember(req.Member, time.Now().Unix())
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO members ("+memberColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		member.UserID, member.ProjectID, member.OwnerID, member.Role, member.InvitedBy, member.Accepted,
+		member.CreatedAt, member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put member into members table: %v", err)
+	}
+	return &storage.AddMemberResp{}, nil
+}
+
+func (s *SQLiteClient) GetMember(ctx context.Context, req *storage.GetMemberReq) (*storage.GetMemberResp, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+memberColumns+" FROM members WHERE user_id = ? AND project_id = ?", req.UserID, req.ProjectID)
+	member, err := scanMember(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetMemberResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %v", err)
+	}
+	return &storage.GetMemberResp{
+		Member: member,
+	}, nil
+}
+
+// GetMemberships returns every membership of the user in project id order.
+func (s *SQLiteClient) GetMemberships(ctx context.Context, req *storage.GetMembershipsReq) (*storage.GetMembershipsResp, error) {
+	members, err := s.queryMembers(ctx, "SELECT "+memberColumns+" FROM members WHERE user_id = ? ORDER BY project_id", req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.GetMembershipsResp{
+		Members: members,
+	}, nil
+}
+
+// GetProjectMembers returns every member of the project in user id order.
+func (s *SQLiteClient) GetProjectMembers(ctx context.Context, req *storage.GetProjectMembersReq) (*storage.GetProjectMembersResp, error) {
+	members, err := s.queryMembers(ctx, "SELECT "+memberColumns+" FROM members WHERE project_id = ? ORDER BY user_id", req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.GetProjectMembersResp{
+		Members: members,
+	}, nil
+}
+
+// UpdateMember replaces the role and accepted flag of an existing member.
+func (s *SQLiteClient) UpdateMember(ctx context.Context, req *storage.UpdateMemberReq) (*storage.UpdateMemberResp, error) {
+	var member storage.Member
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, "SELECT "+memberColumns+" FROM members WHERE user_id = ? AND project_id = ?", req.Member.UserID, req.Member.ProjectID)
+		stored, err := scanMember(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get member: %v", err)
+		}
+		if !req.Expects(*stored) {
+			return storage.ErrConditionFailed
+		}
+		member = storage.UpdatedMember(*stored, req.Member, time.Now().Unix())
+		_, err = tx.ExecContext(ctx,
+			"UPDATE members SET role = ?, accepted = ?, updated_at = ? WHERE user_id = ? AND project_id = ?",
+			member.Role, member.Accepted, member.UpdatedAt, member.UserID, member.ProjectID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to set member: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+	return &storage.UpdateMemberResp{
+		Member: member,
+	}, nil
+}
+
+func (s *SQLiteClient) DeleteMember(ctx context.Context, req *storage.DeleteMemberReq) (*storage.DeleteMemberResp, error) {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM members WHERE user_id = ? AND project_id = ?", req.UserID, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete member: %v", err)
+	}
+	return &storage.DeleteMemberResp{}, nil
+}
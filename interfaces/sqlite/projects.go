@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// projectColumns are the columns of the projects table in the order scanProject reads them.
+const projectColumns = "user_id, project_id, name, description, color, archived, created_at, updated_at"
+
+// scanProject reads a project from a row holding the project columns.
+func scanProject(row scanner) (*storage.Project, error) {
+	project := &storage.Project{}
+	err := row.Scan(
+		&project.UserID, &project.ProjectID, &project.Name, &project.Description, &project.Color, &project.Archived,
+		&project.CreatedAt, &project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// AddProject puts a project into the projects table, overwriting any timestamps on the given project
+// with the current time.
+func (s *SQLiteClient) AddProject(ctx context.Context, req *storage.AddProjectReq) (*storage.AddProjectResp, error) {
+	project := storage.NewProject(req.Project, time.Now().Unix())
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO projects ("+projectColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		project.UserID, project.ProjectID, project.Name, project.Description, project.Color, project.Archived,
+		project.CreatedAt, project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put project into projects table: %v", err)
+	}
+	return &storage.AddProjectResp{}, nil
+}
+
+func (s *SQLiteClient) GetProject(ctx context.Context, req *storage.GetProjectReq) (*storage.GetProjectResp, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+projectColumns+" FROM projects WHERE user_id = ? AND project_id = ?", req.UserID, req.ProjectID)
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetProjectResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %v", err)
+	}
+	return &storage.GetProjectResp{
+		Project: project,
+	}, nil
+}
+
+// GetAllProjects returns every project of the user in project id order.
+func (s *SQLiteClient) GetAllProjects(ctx context.Context, req *storage.GetAllProjectsReq) (*storage.GetAllProjectsResp, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+projectColumns+" FROM projects WHERE user_id = ? ORDER BY project_id", req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query projects: %v", err)
+	}
+	defer rows.Close()
+	var projects []storage.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %v", err)
+		}
+		projects = append(projects, *project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query projects: %v", err)
+	}
+	return &storage.GetAllProjectsResp{
+		Projects: projects,
+	}, nil
+}
+
+// UpdateProject replaces the name, description, color and archived flag of an existing project.
+func (s *SQLiteClient) UpdateProject(ctx context.Context, req *storage.UpdateProjectReq) (*storage.UpdateProjectResp, error) {
+	var project storage.Project
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, "SELECT "+projectColumns+" FROM projects WHERE user_id = ? AND project_id = ?", req.Project.UserID, req.Project.ProjectID)
+		stored, err := scanProject(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get project: %v", err)
+		}
+		project = storage.UpdatedProject(*stored, req.Project, time.Now().Unix())
+		_, err = tx.ExecContext(ctx,
+			"UPDATE projects SET name = ?, description = ?, color = ?, archived = ?, updated_at = ? WHERE user_id = ? AND project_id = ?",
+			project.Name, project.Description, project.Color, project.Archived, project.UpdatedAt, project.UserID, project.ProjectID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to set project: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+	return &storage.UpdateProjectResp{
+		Project: project,
+	}, nil
+}
+
+func (s *SQLiteClient) DeleteProject(ctx context.Context, req *storage.DeleteProjectReq) (*storage.DeleteProjectResp, error) {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM projects WHERE user_id = ? AND project_id = ?", req.UserID, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %v", err)
+	}
+	return &storage.DeleteProjectResp{}, nil
+}
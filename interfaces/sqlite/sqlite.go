@@ -0,0 +1,196 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"todo/interfaces/storage"
+	"todo/logging"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteClient stores users, tasks, events, views, projects, members and audit entries in an embedded SQLite database file.
+// It implements the same interface and behavior as the DynamoDB client so the server can run
+// without AWS credentials.
+type SQLiteClient struct {
+	db *sql.DB
+}
+
+// make client implement defined interface
+var _ storage.Backend = &SQLiteClient{}
+
+// migrations are applied in order to bring a database up to date, the database's user_version
+// recording how many have been applied. Applied migrations must never be changed, only appended to.
+var migrations = []string{
+	`CREATE TABLE users (
+		id              TEXT PRIMARY KEY,
+		first_name      TEXT NOT NULL,
+		last_name       TEXT NOT NULL,
+		email           TEXT NOT NULL,
+		hashed_password TEXT NOT NULL
+	);
+	CREATE TABLE tasks (
+		user_id                    TEXT NOT NULL,
+		task_id                    TEXT NOT NULL,
+		title                      TEXT NOT NULL,
+		description                TEXT NOT NULL,
+		status                     TEXT NOT NULL,
+		tags                       TEXT NOT NULL,
+		parents                    TEXT NOT NULL,
+		due_date                   INTEGER NOT NULL,
+		recurring_rule             TEXT NOT NULL,
+		created_at                 INTEGER NOT NULL,
+		updated_at                 INTEGER NOT NULL,
+		completed_at               INTEGER NOT NULL,
+		priority                   TEXT NOT NULL,
+		effort_minutes             INTEGER NOT NULL,
+		status_history             TEXT NOT NULL,
+		checklist                  TEXT NOT NULL,
+		require_checklist_complete INTEGER NOT NULL,
+		PRIMARY KEY (user_id, task_id)
+	);
+	CREATE INDEX tasks_due_date ON tasks (user_id, due_date, task_id);
+	CREATE INDEX tasks_created_at ON tasks (user_id, created_at, task_id);
+	CREATE INDEX tasks_updated_at ON tasks (user_id, updated_at, task_id);
+	CREATE INDEX tasks_title ON tasks (user_id, title, task_id);
+	CREATE INDEX tasks_priority ON tasks (user_id, priority, task_id);`,
+	`CREATE TABLE events (
+		user_id        TEXT NOT NULL,
+		event_id       TEXT NOT NULL,
+		title          TEXT NOT NULL,
+		description    TEXT NOT NULL,
+		location       TEXT NOT NULL,
+		start_time     INTEGER NOT NULL,
+		end_time       INTEGER NOT NULL,
+		all_day        INTEGER NOT NULL,
+		recurring_rule TEXT NOT NULL,
+		created_at     INTEGER NOT NULL,
+		updated_at     INTEGER NOT NULL,
+		PRIMARY KEY (user_id, event_id)
+	);`,
+	`ALTER TABLE users ADD COLUMN feed_token_hash TEXT NOT NULL DEFAULT '';`,
+	`CREATE TABLE views (
+		user_id    TEXT NOT NULL,
+		name       TEXT NOT NULL,
+		query      TEXT NOT NULL,
+		sort_by    TEXT NOT NULL,
+		descending INTEGER NOT NULL,
+		PRIMARY KEY (user_id, name)
+	);`,
+	`ALTER TABLE tasks ADD COLUMN project_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX tasks_project_id ON tasks (user_id, project_id, task_id);
+	CREATE TABLE projects (
+		user_id     TEXT NOT NULL,
+		project_id  TEXT NOT NULL,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL,
+		color       TEXT NOT NULL,
+		archived    INTEGER NOT NULL,
+		created_at  INTEGER NOT NULL,
+		updated_at  INTEGER NOT NULL,
+		PRIMARY KEY (user_id, project_id)
+	);`,
+	`CREATE TABLE members (
+		user_id    TEXT NOT NULL,
+		project_id TEXT NOT NULL,
+		owner_id   TEXT NOT NULL,
+		role       TEXT NOT NULL,
+		invited_by TEXT NOT NULL,
+		accepted   INTEGER NOT NULL,
+		created_at INTEGER NOT NULL,
+		updated_at INTEGER NOT NULL,
+		PRIMARY KEY (user_id, project_id)
+	);
+	CREATE INDEX members_project_id ON members (project_id, user_id);
+	CREATE TABLE audit (
+		project_id TEXT NOT NULL,
+		entry_id   TEXT NOT NULL,
+		user_id    TEXT NOT NULL,
+		action     TEXT NOT NULL,
+		task_id    TEXT NOT NULL,
+		details    TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		PRIMARY KEY (project_id, entry_id)
+	);`,
+	`ALTER TABLE tasks ADD COLUMN assignee_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX tasks_assignee_id ON tasks (assignee_id, user_id, task_id);`,
+}
+
+// NewSQLiteClient opens the SQLite database at the given path, creating it if it does not exist,
+// and migrates it to the latest schema.
+func NewSQLiteClient(ctx context.Context, path string) (*SQLiteClient, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %v", err)
+	}
+	// a single connection serializes transactions, making each read-modify-write atomic
+	db.SetMaxOpenConns(1)
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate sqlite database: %v", err)
+	}
+	return &SQLiteClient{db: db}, nil
+}
+
+// Close closes the database.
+func (s *SQLiteClient) Close() error {
+	return s.db.Close()
+}
+
+// migrate applies every migration the database has not yet applied.
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to get schema version: %v", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %d is newer than this server supports", version)
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %v", i+1, err)
+		}
+		// pragmas cannot take parameters
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to set schema version: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Ping queries every table, returning an error if any cannot be queried.
+func (s *SQLiteClient) Ping(ctx context.Context) error {
+	for _, table := range []string{"users", "tasks", "events", "views", "projects", "members", "audit"} {
+		if _, err := s.db.ExecContext(ctx, "SELECT 1 FROM "+table+" LIMIT 1"); err != nil {
+			return fmt.Errorf("failed to query table %s: %v", table, err)
+		}
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction, committing it if fn succeeds and rolling it back otherwise.
+func (s *SQLiteClient) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	if err := fn(tx); err != nil {
+		logging.FromContext(ctx).DebugContext(ctx, "rolling back sqlite transaction", "error", err)
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
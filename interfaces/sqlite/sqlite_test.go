@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"todo/interfaces/storage/conformance"
+)
+
+// newTestClient returns a client of a new database in a temporary directory.
+func newTestClient(t *testing.T) (*SQLiteClient, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.db")
+	client, err := NewSQLiteClient(context.Background(), path)
+	if err != nil {
+		t.Fatalf("NewSQLiteClient() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client, path
+}
+
+func Test_SQLiteClient_Conformance(t *testing.T) {
+	client, _ := newTestClient(t)
+	conformance.Run(t, client)
+}
+
+func Test_SQLiteClient_Ping_closed(t *testing.T) {
+	client, _ := newTestClient(t)
+	client.Close()
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping() of closed client error = nil, want error")
+	}
+}
+
+func Test_NewSQLiteClient_migrations(t *testing.T) {
+	client, path := newTestClient(t)
+	var version int
+	if err := client.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("failed to get schema version: %v", err)
+	}
+	if version != len(migrations) {
+		t.Errorf("schema version = %d, want %d", version, len(migrations))
+	}
+	client.Close()
+
+	// reopening a migrated database applies no migrations
+	reopened, err := NewSQLiteClient(context.Background(), path)
+	if err != nil {
+		t.Fatalf("NewSQLiteClient() of migrated database error = %v", err)
+	}
+	defer reopened.Close()
+
+	// a database migrated by a newer server is rejected
+	if _, err := reopened.db.Exec("PRAGMA user_version = 1000"); err != nil {
+		t.Fatalf("failed to set schema version: %v", err)
+	}
+	if _, err := NewSQLiteClient(context.Background(), path); err == nil {
+		t.Error("NewSQLiteClient() of newer database error = nil, want error")
+	}
+}
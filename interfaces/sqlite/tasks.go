@@ -0,0 +1,462 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// taskColumns are the columns of the tasks table in the order scanTask reads them.
+const taskColumns = `user_id, task_id, title, description, status, tags, parents, due_date, recurring_rule,
+	created_at, updated_at, completed_at, priority, effort_minutes, status_history, checklist, require_checklist_complete, project_id, assignee_id`
+
+// sortColumns are the task attributes that tasks may be sorted by, each of which is an indexed column.
+var sortColumns = map[string]bool{
+	storage.DueDateKey:   true,
+	storage.CreatedAtKey: true,
+	storage.UpdatedAtKey: true,
+	storage.TitleKey:     true,
+	storage.PriorityKey:  true,
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads a task from a row holding the task columns.
+func scanTask(row scanner) (*storage.Task, error) {
+	task := &storage.Task{}
+	var tags, parents, recurringRule, statusHistory, checklist string
+	err := row.Scan(
+		&task.UserID, &task.TaskID, &task.Title, &task.Description, &task.Status, &tags, &parents, &task.DueDate, &recurringRule,
+		&task.CreatedAt, &task.UpdatedAt, &task.CompletedAt, &task.Priority, &task.EffortMinutes, &statusHistory, &checklist, &task.RequireChecklistComplete, &task.ProjectID, &task.AssigneeID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []struct {
+		data  string
+		value any
+	}{
+		{tags, &task.Tags},
+		{parents, &task.Parents},
+		{recurringRule, &task.RecurringRule},
+		{statusHistory, &task.StatusHistory},
+		{checklist, &task.Checklist},
+	} {
+		if err := json.Unmarshal([]byte(column.data), column.value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task column: %v", err)
+		}
+	}
+	return task, nil
+}
+
+// putTask inserts the task, replacing any task with the same key.
+func putTask(ctx context.Context, tx *sql.Tx, task *storage.Task) error {
+	var columns []any
+	for _, value := range []any{task.Tags, task.Parents, task.RecurringRule, task.StatusHistory, task.Checklist} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal task column: %v", err)
+		}
+		columns = append(columns, string(data))
+	}
+	_, err := tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO tasks ("+taskColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		task.UserID, task.TaskID, task.Title, task.Description, task.Status, columns[0], columns[1], task.DueDate, columns[2],
+		task.CreatedAt, task.UpdatedAt, task.CompletedAt, task.Priority, task.EffortMinutes, columns[3], columns[4], task.RequireChecklistComplete, task.ProjectID, task.AssigneeID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put task into tasks table: %v", err)
+	}
+	return nil
+}
+
+// AddTask puts a task into the tasks table, overwriting any timestamps on the given task
+// with the current time and starting its status history with its status.
+func (s *SQLiteClient) AddTask(ctx context.Context, req *storage.AddTaskReq) (*storage.AddTaskResp, error) {
+	task := storage.NewTask(req.Task, time.Now().Unix())
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return putTask(ctx, tx, &task)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.AddTaskResp{}, nil
+}
+
+func (s *SQLiteClient) GetTask(ctx context.Context, req *storage.GetTaskReq) (*storage.GetTaskResp, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND task_id = ?", req.UserID, req.TaskID)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetTaskResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %v", err)
+	}
+	return &storage.GetTaskResp{
+		Task: task,
+	}, nil
+}
+
+func (s *SQLiteClient) BatchGetTask(ctx context.Context, req *storage.BatchGetTaskReq) (*storage.BatchGetTaskResp, error) {
+	return nil, errors.New("not implemented yet")
+}
+
+// pageToken is the position after which the next page of a query starts.
+type pageToken struct {
+	UserID string `json:"user_id"`
+	TaskID string `json:"task_id"`
+	// SortValue is the value of the sort column of the last task returned, if tasks are sorted.
+	SortValue json.RawMessage `json:"sort_value,omitempty"`
+}
+
+// encodePageToken converts the position after the given task into an opaque page token.
+func encodePageToken(task *storage.Task, sortKey string) (string, error) {
+	token := pageToken{UserID: task.UserID, TaskID: task.TaskID}
+	if sortKey != "" {
+		sortValue, err := json.Marshal(sortValue(task, sortKey))
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal sort value: %v", err)
+		}
+		token.SortValue = sortValue
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken converts a page token back into a position, asserting that it belongs to the given user
+// and holds a sort value of the right type for the sort key.
+func decodePageToken(token, userID, sortKey string) (*pageToken, any, error) {
+	position, err := decodePosition(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if position.UserID != userID {
+		return nil, nil, errors.New("page token does not belong to user")
+	}
+	if sortKey == "" {
+		return position, nil, nil
+	}
+	switch sortValue(&storage.Task{}, sortKey).(type) {
+	case string:
+		var text string
+		if err := json.Unmarshal(position.SortValue, &text); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal sort value: %v", err)
+		}
+		return position, text, nil
+	default:
+		var number int64
+		if err := json.Unmarshal(position.SortValue, &number); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal sort value: %v", err)
+		}
+		return position, number, nil
+	}
+}
+
+// decodePosition converts a page token back into a position of any user.
+func decodePosition(token string) (*pageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page token: %v", err)
+	}
+	var position pageToken
+	if err := json.Unmarshal(data, &position); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page token: %v", err)
+	}
+	return &position, nil
+}
+
+// sortValue returns the task's value of the sort column.
+func sortValue(task *storage.Task, sortKey string) any {
+	switch sortKey {
+	case storage.DueDateKey:
+		return task.DueDate
+	case storage.CreatedAtKey:
+		return task.CreatedAt
+	case storage.UpdatedAtKey:
+		return task.UpdatedAt
+	case storage.TitleKey:
+		return task.Title
+	default:
+		return task.Priority
+	}
+}
+
+// inClause returns a condition matching rows whose column equals one of the given values, along with its arguments.
+func inClause(column string, values []string) (string, []any) {
+	args := make([]any, 0, len(values))
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return column + " IN (?" + strings.Repeat(", ?", len(values)-1) + ")", args
+}
+
+// GetAllTasks queries the user's tasks, using the index of the sort key when one is given.
+func (s *SQLiteClient) GetAllTasks(ctx context.Context, req *storage.GetAllTasksReq) (*storage.GetAllTasksResp, error) {
+	if req.SortKey != "" && !sortColumns[req.SortKey] {
+		return nil, fmt.Errorf("unable to sort by %s", req.SortKey)
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+
+	// filter
+	conds := []string{"user_id = ?"}
+	args := []any{req.UserID}
+	if len(req.Statuses) > 0 {
+		cond, condArgs := inClause(storage.StatusKey, req.Statuses)
+		conds, args = append(conds, cond), append(args, condArgs...)
+	}
+	if len(req.Priorities) > 0 {
+		cond, condArgs := inClause(storage.PriorityKey, req.Priorities)
+		conds, args = append(conds, cond), append(args, condArgs...)
+	}
+	if req.MaxEffortMinutes > 0 {
+		conds, args = append(conds, "effort_minutes BETWEEN 1 AND ?"), append(args, req.MaxEffortMinutes)
+	}
+	var residual storage.And
+	if req.Filter != nil {
+		for _, conjunct := range storage.Conjuncts(req.Filter) {
+			cond, condArgs, ok := translateFilter(conjunct)
+			if !ok {
+				residual = append(residual, conjunct)
+				continue
+			}
+			conds, args = append(conds, cond), append(args, condArgs...)
+		}
+	}
+
+	// sort, breaking ties by task id
+	order, after := "ASC", ">"
+	if req.Descending {
+		order, after = "DESC", "<"
+	}
+	orderBy := "task_id " + order
+	if req.SortKey != "" {
+		orderBy = req.SortKey + " " + order + ", " + orderBy
+	}
+
+	// start after the page token
+	if req.PageToken != "" {
+		position, value, err := decodePageToken(req.PageToken, req.UserID, req.SortKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+		if req.SortKey == "" {
+			conds, args = append(conds, "task_id "+after+" ?"), append(args, position.TaskID)
+		} else {
+			conds, args = append(conds, "("+req.SortKey+", task_id) "+after+" (?, ?)"), append(args, value, position.TaskID)
+		}
+	}
+
+	// fetch one more task than the limit to find out whether there is another page
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conds, " AND ") + " ORDER BY " + orderBy
+	if req.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, req.Limit+1)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+	defer rows.Close()
+	var tasks []storage.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %v", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+
+	var nextPageToken string
+	if req.Limit > 0 && len(tasks) > int(req.Limit) {
+		tasks = tasks[:req.Limit]
+		nextPageToken, err = encodePageToken(&tasks[len(tasks)-1], req.SortKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+
+	// evaluate the rest of the filter, which may leave fewer tasks than the limit in the page
+	if len(residual) > 0 {
+		tasks = slices.DeleteFunc(tasks, func(task storage.Task) bool {
+			return !residual.Match(&task)
+		})
+	}
+	return &storage.GetAllTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ScanTasks returns the tasks of every user ordered by user id and then task id.
+func (s *SQLiteClient) ScanTasks(ctx context.Context, req *storage.ScanTasksReq) (*storage.ScanTasksResp, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+
+	// start after the page token
+	query := "SELECT " + taskColumns + " FROM tasks"
+	var args []any
+	if req.PageToken != "" {
+		position, err := decodePosition(req.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		}
+		query += " WHERE (user_id, task_id) > (?, ?)"
+		args = append(args, position.UserID, position.TaskID)
+	}
+
+	// fetch one more task than the limit to find out whether there is another page
+	query += " ORDER BY user_id, task_id"
+	if req.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, req.Limit+1)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+	defer rows.Close()
+	var tasks []storage.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %v", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+
+	var nextPageToken string
+	if req.Limit > 0 && len(tasks) > int(req.Limit) {
+		tasks = tasks[:req.Limit]
+		nextPageToken, err = encodePageToken(&tasks[len(tasks)-1], "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next page token: %v", err)
+		}
+	}
+	return &storage.ScanTasksResp{
+		Tasks:         tasks,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetAssignedTasks returns the assignee's tasks ordered by user id and then task id.
+func (s *SQLiteClient) GetAssignedTasks(ctx context.Context, req *storage.GetAssignedTasksReq) (*storage.GetAssignedTasksResp, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE assignee_id = ? ORDER BY user_id, task_id", req.AssigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+	defer rows.Close()
+	var tasks []storage.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %v", err)
+		}
+		tasks = append(tasks, *task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %v", err)
+	}
+	return &storage.GetAssignedTasksResp{
+		Tasks: tasks,
+	}, nil
+}
+
+// updateTask reads a task, applies fn to it and writes it back with updated_at set, all in one transaction.
+// It returns ErrNotFound if the task does not exist, along with any error returned by fn.
+func (s *SQLiteClient) updateTask(ctx context.Context, userID, taskID string, fn func(task *storage.Task) error) (*storage.Task, error) {
+	var task *storage.Task
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		task, err = updateTaskTx(ctx, tx, userID, taskID, fn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// updateTaskTx is updateTask within the given transaction.
+func updateTaskTx(ctx context.Context, tx *sql.Tx, userID, taskID string, fn func(task *storage.Task) error) (*storage.Task, error) {
+	row := tx.QueryRowContext(ctx, "SELECT "+taskColumns+" FROM tasks WHERE user_id = ? AND task_id = ?", userID, taskID)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %v", err)
+	}
+	if err := fn(task); err != nil {
+		return nil, err
+	}
+	task.UpdatedAt = time.Now().Unix()
+	if err := putTask(ctx, tx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// applyUpdate returns the change requested by req, failing with ErrConditionFailed
+// if the task's status or checklist is not the expected one.
+func applyUpdate(req *storage.UpdateTaskReq) func(task *storage.Task) error {
+	return func(task *storage.Task) error {
+		if req.ExpectedStatus != "" && task.Status != req.ExpectedStatus {
+			return storage.ErrConditionFailed
+		}
+		if req.ExpectChecklist && !slices.Equal(task.Checklist, req.ExpectedChecklist) {
+			return storage.ErrConditionFailed
+		}
+		now := time.Now().Unix()
+		if err := storage.ApplyKVPairs(task, req.KVPairs, now); err != nil {
+			return err
+		}
+		if req.ExpectedStatus != "" && task.Status != req.ExpectedStatus {
+			task.StatusHistory = append(task.StatusHistory, storage.StatusChange{Status: task.Status, ChangedAt: now})
+		}
+		return nil
+	}
+}
+
+// UpdateTask sets the given attributes of an existing task and returns the updated task.
+// It returns ErrNotFound if the task does not exist.
+func (s *SQLiteClient) UpdateTask(ctx context.Context, req *storage.UpdateTaskReq) (*storage.UpdateTaskResp, error) {
+	task, err := s.updateTask(ctx, req.UserID, req.TaskID, applyUpdate(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+	return &storage.UpdateTaskResp{
+		Task: *task,
+	}, nil
+}
+
+const deleteTaskQuery = "DELETE FROM tasks WHERE user_id = ? AND task_id = ?"
+
+func (s *SQLiteClient) DeleteTask(ctx context.Context, req *storage.DeleteTaskReq) (*storage.DeleteTaskResp, error) {
+	_, err := s.db.ExecContext(ctx, deleteTaskQuery, req.UserID, req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete task: %v", err)
+	}
+	return &storage.DeleteTaskResp{}, nil
+}
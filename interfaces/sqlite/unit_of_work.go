@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// unitOfWork collects writes to run in order within a single transaction.
+type unitOfWork struct {
+	s      *SQLiteClient
+	writes []func(ctx context.Context, tx *sql.Tx) error
+}
+
+// NewUnitOfWork starts a unit of work committed as a SQLite transaction.
+func (s *SQLiteClient) NewUnitOfWork() storage.UnitOfWork {
+	return &unitOfWork{s: s}
+}
+
+func (u *unitOfWork) AddUser(user storage.User) {
+	u.writes = append(u.writes, func(ctx context.Context, tx *sql.Tx) error {
+		return putUser(ctx, tx, &user)
+	})
+}
+
+func (u *unitOfWork) AddTask(task storage.Task) {
+	u.writes = append(u.writes, func(ctx context.Context, tx *sql.Tx) error {
+		task := storage.NewTask(task, time.Now().Unix())
+		return putTask(ctx, tx, &task)
+	})
+}
+
+func (u *unitOfWork) UpdateTask(req storage.UpdateTaskReq) {
+	u.writes = append(u.writes, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := updateTaskTx(ctx, tx, req.UserID, req.TaskID, applyUpdate(&req))
+		return err
+	})
+}
+
+func (u *unitOfWork) DeleteTask(req storage.DeleteTaskReq) {
+	u.writes = append(u.writes, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, deleteTaskQuery, req.UserID, req.TaskID); err != nil {
+			return fmt.Errorf("failed to delete task: %v", err)
+		}
+		return nil
+	})
+}
+
+func (u *unitOfWork) AddEvent(event storage.Event) {
+	u.writes = append(u.writes, func(ctx context.Context, tx *sql.Tx) error {
+		event := storage.NewEvent(event, time.Now().Unix())
+		return putEvent(ctx, tx, &event)
+	})
+}
+
+// Commit runs every write in one transaction, rolling it back if any write fails.
+func (u *unitOfWork) Commit(ctx context.Context) error {
+	return u.s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, write := range u.writes {
+			if err := write(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"todo/interfaces/storage"
+)
+
+// AddUser puts a user into the users table exactly as given.
+func (s *SQLiteClient) AddUser(ctx context.Context, req *storage.AddUserReq) (*storage.AddUserResp, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		return putUser(ctx, tx, &req.User)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &storage.AddUserResp{}, nil
+}
+
+// putUser inserts or replaces a user within the given transaction.
+func putUser(ctx context.Context, tx *sql.Tx, user *storage.User) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT OR REPLACE INTO users (id, first_name, last_name, email, hashed_password, feed_token_hash) VALUES (?, ?, ?, ?, ?, ?)",
+		user.ID, user.FirstName, user.LastName, user.Email, user.HashedPassword, user.FeedTokenHash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put user into users table: %v", err)
+	}
+	return nil
+}
+
+// GetUser uses the given user id to find a user.
+// User will be nil if no user is found.
+func (s *SQLiteClient) GetUser(ctx context.Context, req *storage.GetUserReq) (*storage.GetUserResp, error) {
+	user := &storage.User{}
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, first_name, last_name, email, hashed_password, feed_token_hash FROM users WHERE id = ?",
+		req.ID,
+	).Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.HashedPassword, &user.FeedTokenHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetUserResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+	return &storage.GetUserResp{
+		User: user,
+	}, nil
+}
+
+// UpdateUser replaces the fields of the user that are set.
+func (s *SQLiteClient) UpdateUser(ctx context.Context, req *storage.UpdateUserReq) (*storage.UpdateUserResp, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE id = ?)", req.ID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to get user: %v", err)
+		}
+		if !exists {
+			return storage.ErrNotFound
+		}
+		if req.FeedTokenHash != nil {
+			if _, err := tx.ExecContext(ctx, "UPDATE users SET feed_token_hash = ? WHERE id = ?", *req.FeedTokenHash, req.ID); err != nil {
+				return fmt.Errorf("failed to set feed token hash: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return &storage.UpdateUserResp{}, nil
+}
+
+func (s *SQLiteClient) DeleteUser(ctx context.Context, req *storage.DeleteUserReq) (*storage.DeleteUserResp, error) {
+	return nil, errors.New("not implemented yet")
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"todo/interfaces/storage"
+)
+
+// viewColumns are the columns of the views table in the order scanView reads them.
+const viewColumns = "user_id, name, query, sort_by, descending"
+
+// scanView reads a view from a row holding the view columns.
+func scanView(row scanner) (*storage.View, error) {
+	view := &storage.View{}
+	if err := row.Scan(&view.UserID, &view.Name, &view.Query, &view.SortBy, &view.Descending); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// SaveView puts a view, replacing any view of the user with the same name.
+func (s *SQLiteClient) SaveView(ctx context.Context, req *storage.SaveViewReq) (*storage.SaveViewResp, error) {
+	view := req.View
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO views ("+viewColumns+") VALUES (?, ?, ?, ?, ?)",
+		view.UserID, view.Name, view.Query, view.SortBy, view.Descending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put view into views table: %v", err)
+	}
+	return &storage.SaveViewResp{}, nil
+}
+
+func (s *SQLiteClient) GetView(ctx context.Context, req *storage.GetViewReq) (*storage.GetViewResp, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+viewColumns+" FROM views WHERE user_id = ? AND name = ?", req.UserID, req.Name)
+	view, err := scanView(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &storage.GetViewResp{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get view: %v", err)
+	}
+	return &storage.GetViewResp{
+		View: view,
+	}, nil
+}
+
+// GetAllViews returns every view of the user in name order.
+func (s *SQLiteClient) GetAllViews(ctx context.Context, req *storage.GetAllViewsReq) (*storage.GetAllViewsResp, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+viewColumns+" FROM views WHERE user_id = ? ORDER BY name", req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query views: %v", err)
+	}
+	defer rows.Close()
+	var views []storage.View
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan view: %v", err)
+		}
+		views = append(views, *view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query views: %v", err)
+	}
+	return &storage.GetAllViewsResp{
+		Views: views,
+	}, nil
+}
+
+func (s *SQLiteClient) DeleteView(ctx context.Context, req *storage.DeleteViewReq) (*storage.DeleteViewResp, error) {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM views WHERE user_id = ? AND name = ?", req.UserID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete view: %v", err)
+	}
+	return &storage.DeleteViewResp{}, nil
+}
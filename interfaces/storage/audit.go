@@ -0,0 +1,25 @@
+package storage
+
+import "context"
+
+// AuditStore stores the audit entries of projects.
+type AuditStore interface {
+	// AddAuditEntry overwrites the creation time of the entry with the current time.
+	AddAuditEntry(context.Context, *AddAuditEntryReq) (*AddAuditEntryResp, error)
+	// GetAuditEntries returns the newest entries of the project first.
+	GetAuditEntries(context.Context, *GetAuditEntriesReq) (*GetAuditEntriesResp, error)
+}
+
+type AddAuditEntryReq struct {
+	Entry AuditEntry
+}
+type AddAuditEntryResp struct{}
+
+// GetAuditEntriesReq gets at most Limit entries of the project, or every entry if Limit is not positive.
+type GetAuditEntriesReq struct {
+	ProjectID string
+	Limit     int32
+}
+type GetAuditEntriesResp struct {
+	Entries []AuditEntry
+}
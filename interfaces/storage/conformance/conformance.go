@@ -0,0 +1,1504 @@
+// Package conformance holds the tests that every implementation of storage.Backend must pass,
+// so that the server behaves the same whichever storage backend it is configured with.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"todo/interfaces/storage"
+
+	"github.com/google/uuid"
+)
+
+// Run runs the conformance tests against the given backend.
+// Every test uses fresh user ids, so the backend may be shared with other tests and hold existing data.
+func Run(t *testing.T, db storage.Backend) {
+	t.Run("Ping", func(t *testing.T) {
+		if err := db.Ping(context.Background()); err != nil {
+			t.Errorf("Ping() error = %v", err)
+		}
+	})
+	t.Run("Users", func(t *testing.T) { testUsers(t, db) })
+	t.Run("AddTask and GetTask", func(t *testing.T) { testAddGetTask(t, db) })
+	t.Run("GetAllTasks", func(t *testing.T) { testGetAllTasks(t, db) })
+	t.Run("GetAllTasks pagination", func(t *testing.T) { testGetAllTasksPagination(t, db) })
+	t.Run("GetAllTasks filter", func(t *testing.T) { testGetAllTasksFilter(t, db) })
+	t.Run("ScanTasks", func(t *testing.T) { testScanTasks(t, db) })
+	t.Run("GetAssignedTasks", func(t *testing.T) { testGetAssignedTasks(t, db) })
+	t.Run("UpdateTask", func(t *testing.T) { testUpdateTask(t, db) })
+	t.Run("Checklists", func(t *testing.T) { testChecklists(t, db) })
+	t.Run("DeleteTask", func(t *testing.T) { testDeleteTask(t, db) })
+	t.Run("Concurrent updates", func(t *testing.T) { testConcurrentUpdates(t, db) })
+	t.Run("UnitOfWork", func(t *testing.T) { testUnitOfWork(t, db) })
+	t.Run("Events", func(t *testing.T) { testEvents(t, db) })
+	t.Run("Views", func(t *testing.T) { testViews(t, db) })
+	t.Run("Projects", func(t *testing.T) { testProjects(t, db) })
+	t.Run("Members", func(t *testing.T) { testMembers(t, db) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, db) })
+}
+
+// newTask returns a task of a fresh user with every attribute set.
+func newTask() storage.Task {
+	return storage.Task{
+		UserID:      uuid.New().String(),
+		TaskID:      uuid.New().String(),
+		Title:       "title",
+		Description: "description",
+		Status:      "INCOMPLETE",
+		Tags:        []string{"tag1", "tag2"},
+		Parents:     []string{"parent"},
+		DueDate:     1700000000,
+		RecurringRule: &storage.RecurringRule{
+			CronExpression: "0 9 * * 1",
+			StartDate:      1700000000,
+			EndDate:        1800000000,
+		},
+		Priority:      "P1",
+		EffortMinutes: 30,
+		Checklist: []storage.ChecklistItem{
+			{ID: "item-a", Text: "a"},
+			{ID: "item-b", Text: "b", Done: true},
+		},
+		RequireChecklistComplete: true,
+		ProjectID:                "project",
+	}
+}
+
+// addTask adds the task, failing the test on error, and returns it as stored.
+func addTask(t *testing.T, db storage.Backend, task storage.Task) *storage.Task {
+	t.Helper()
+	if _, err := db.AddTask(context.Background(), &storage.AddTaskReq{Task: task}); err != nil {
+		t.Fatalf("AddTask() error = %v", err)
+	}
+	return getTask(t, db, task.UserID, task.TaskID)
+}
+
+// getTask gets the task, failing the test on error.
+func getTask(t *testing.T, db storage.Backend, userID, taskID string) *storage.Task {
+	t.Helper()
+	resp, err := db.GetTask(context.Background(), &storage.GetTaskReq{UserID: userID, TaskID: taskID})
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	return resp.Task
+}
+
+// taskIDs returns the ids of the tasks in order.
+func taskIDs(tasks []storage.Task) []string {
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.TaskID)
+	}
+	return ids
+}
+
+func testUsers(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	user := storage.User{
+		ID:             uuid.New().String(),
+		FirstName:      "first",
+		LastName:       "last",
+		Email:          "user@fake_email.com",
+		HashedPassword: "hash",
+	}
+	if _, err := db.AddUser(ctx, &storage.AddUserReq{User: user}); err != nil {
+		t.Fatalf("AddUser() error = %v", err)
+	}
+	resp, err := db.GetUser(ctx, &storage.GetUserReq{ID: user.ID})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if resp.User == nil || *resp.User != user {
+		t.Errorf("GetUser() = %v, want %v", resp.User, user)
+	}
+
+	resp, err = db.GetUser(ctx, &storage.GetUserReq{ID: uuid.New().String()})
+	if err != nil {
+		t.Fatalf("GetUser() of missing user error = %v", err)
+	}
+	if resp.User != nil {
+		t.Errorf("GetUser() of missing user = %v, want nil", resp.User)
+	}
+
+	// update
+	hash := "feed-token-hash"
+	if _, err := db.UpdateUser(ctx, &storage.UpdateUserReq{ID: user.ID, FeedTokenHash: &hash}); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	resp, err = db.GetUser(ctx, &storage.GetUserReq{ID: user.ID})
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	user.FeedTokenHash = hash
+	if resp.User == nil || *resp.User != user {
+		t.Errorf("GetUser() after UpdateUser() = %v, want %v", resp.User, user)
+	}
+	_, err = db.UpdateUser(ctx, &storage.UpdateUserReq{ID: uuid.New().String(), FeedTokenHash: &hash})
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("UpdateUser() of missing user error = %v, want %v", err, storage.ErrNotFound)
+	}
+}
+
+func testAddGetTask(t *testing.T, db storage.Backend) {
+	want := newTask()
+	got := addTask(t, db, want)
+	if got == nil {
+		t.Fatal("GetTask() = nil, want task")
+	}
+	if got.CreatedAt == 0 || got.UpdatedAt != got.CreatedAt {
+		t.Errorf("GetTask() created at = %d, updated at = %d", got.CreatedAt, got.UpdatedAt)
+	}
+	if got.CompletedAt != 0 {
+		t.Errorf("GetTask() completed at = %d, want 0", got.CompletedAt)
+	}
+	wantHistory := []storage.StatusChange{{Status: want.Status, ChangedAt: got.CreatedAt}}
+	if !slices.Equal(got.StatusHistory, wantHistory) {
+		t.Errorf("GetTask() status history = %v, want %v", got.StatusHistory, wantHistory)
+	}
+	want.CreatedAt, want.UpdatedAt, want.StatusHistory = got.CreatedAt, got.UpdatedAt, got.StatusHistory
+	if !equalTasks(*got, want) {
+		t.Errorf("GetTask() = %+v, want %+v", *got, want)
+	}
+
+	complete := newTask()
+	complete.Status = storage.CompleteStatus
+	got = addTask(t, db, complete)
+	if got.CompletedAt != got.CreatedAt {
+		t.Errorf("GetTask() of complete task completed at = %d, want %d", got.CompletedAt, got.CreatedAt)
+	}
+
+	if missing := getTask(t, db, want.UserID, uuid.New().String()); missing != nil {
+		t.Errorf("GetTask() of missing task = %v, want nil", missing)
+	}
+}
+
+// equalTasks reports whether two tasks are equal, treating nil and empty lists as equal.
+func equalTasks(a, b storage.Task) bool {
+	equalRules := (a.RecurringRule == nil && b.RecurringRule == nil) ||
+		(a.RecurringRule != nil && b.RecurringRule != nil && *a.RecurringRule == *b.RecurringRule)
+	return a.UserID == b.UserID &&
+		a.TaskID == b.TaskID &&
+		a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.Status == b.Status &&
+		slices.Equal(a.Tags, b.Tags) &&
+		slices.Equal(a.Parents, b.Parents) &&
+		a.DueDate == b.DueDate &&
+		equalRules &&
+		a.CreatedAt == b.CreatedAt &&
+		a.UpdatedAt == b.UpdatedAt &&
+		a.CompletedAt == b.CompletedAt &&
+		a.Priority == b.Priority &&
+		a.EffortMinutes == b.EffortMinutes &&
+		slices.Equal(a.StatusHistory, b.StatusHistory) &&
+		slices.Equal(a.Checklist, b.Checklist) &&
+		a.RequireChecklistComplete == b.RequireChecklistComplete &&
+		a.ProjectID == b.ProjectID &&
+		a.AssigneeID == b.AssigneeID
+}
+
+func testGetAllTasks(t *testing.T, db storage.Backend) {
+	userID := uuid.New().String()
+	for i, task := range []storage.Task{
+		{TaskID: "task-a", Title: "c", Status: "INCOMPLETE", DueDate: 3, Priority: "P2", EffortMinutes: 30},
+		{TaskID: "task-b", Title: "a", Status: "COMPLETE", DueDate: 1, Priority: "P0", EffortMinutes: 90},
+		{TaskID: "task-c", Title: "b", Status: "IN_PROGRESS", DueDate: 2, Priority: "PRIORITY_UNSPECIFIED"},
+	} {
+		task.UserID = userID
+		addTask(t, db, task)
+		// tasks of other users are never returned
+		other := newTask()
+		other.TaskID = task.TaskID
+		other.Title = string(rune('a' + i))
+		addTask(t, db, other)
+	}
+
+	tests := []struct {
+		name string
+		req  storage.GetAllTasksReq
+		want []string
+	}{
+		{name: "task id order", req: storage.GetAllTasksReq{}, want: []string{"task-a", "task-b", "task-c"}},
+		{name: "sort by title", req: storage.GetAllTasksReq{SortKey: storage.TitleKey}, want: []string{"task-b", "task-c", "task-a"}},
+		{name: "sort by due date descending", req: storage.GetAllTasksReq{SortKey: storage.DueDateKey, Descending: true}, want: []string{"task-a", "task-c", "task-b"}},
+		{name: "sort by priority", req: storage.GetAllTasksReq{SortKey: storage.PriorityKey}, want: []string{"task-b", "task-a", "task-c"}},
+		{name: "filter by status", req: storage.GetAllTasksReq{Statuses: []string{"COMPLETE", "IN_PROGRESS"}}, want: []string{"task-b", "task-c"}},
+		{name: "filter by priority", req: storage.GetAllTasksReq{Priorities: []string{"P2"}}, want: []string{"task-a"}},
+		{name: "filter by max effort", req: storage.GetAllTasksReq{MaxEffortMinutes: 60}, want: []string{"task-a"}},
+		{name: "filter and sort", req: storage.GetAllTasksReq{SortKey: storage.TitleKey, Statuses: []string{"INCOMPLETE", "IN_PROGRESS"}}, want: []string{"task-c", "task-a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.UserID = userID
+			resp, err := db.GetAllTasks(context.Background(), &tt.req)
+			if err != nil {
+				t.Fatalf("GetAllTasks() error = %v", err)
+			}
+			if got := taskIDs(resp.Tasks); !slices.Equal(got, tt.want) {
+				t.Errorf("GetAllTasks() = %v, want %v", got, tt.want)
+			}
+			if resp.NextPageToken != "" {
+				t.Errorf("GetAllTasks() next page token = %q, want none", resp.NextPageToken)
+			}
+		})
+	}
+
+	t.Run("unknown sort key", func(t *testing.T) {
+		_, err := db.GetAllTasks(context.Background(), &storage.GetAllTasksReq{UserID: userID, SortKey: storage.DescriptionKey})
+		if err == nil {
+			t.Error("GetAllTasks() error = nil, want error")
+		}
+	})
+}
+
+func testGetAllTasksPagination(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	want := []string{"task-a", "task-b", "task-c", "task-d", "task-e"}
+	for i, taskID := range want {
+		addTask(t, db, storage.Task{UserID: userID, TaskID: taskID, Title: string(rune('e' - i)), Status: "INCOMPLETE"})
+	}
+
+	for _, descending := range []bool{false, true} {
+		req := &storage.GetAllTasksReq{UserID: userID, SortKey: storage.TitleKey, Descending: descending, Limit: 2}
+		var got []string
+		for pages := 0; ; pages++ {
+			if pages > len(want) {
+				t.Fatalf("GetAllTasks() returned more pages than tasks")
+			}
+			resp, err := db.GetAllTasks(ctx, req)
+			if err != nil {
+				t.Fatalf("GetAllTasks() error = %v", err)
+			}
+			if len(resp.Tasks) > int(req.Limit) {
+				t.Errorf("GetAllTasks() returned %d tasks, want at most %d", len(resp.Tasks), req.Limit)
+			}
+			got = append(got, taskIDs(resp.Tasks)...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		wantOrder := slices.Clone(want)
+		if !descending {
+			slices.Reverse(wantOrder)
+		}
+		if !slices.Equal(got, wantOrder) {
+			t.Errorf("GetAllTasks() descending = %v pages = %v, want %v", descending, got, wantOrder)
+		}
+	}
+
+	t.Run("page token of another user", func(t *testing.T) {
+		resp, err := db.GetAllTasks(ctx, &storage.GetAllTasksReq{UserID: userID, Limit: 1})
+		if err != nil {
+			t.Fatalf("GetAllTasks() error = %v", err)
+		}
+		_, err = db.GetAllTasks(ctx, &storage.GetAllTasksReq{UserID: uuid.New().String(), Limit: 1, PageToken: resp.NextPageToken})
+		if err == nil {
+			t.Error("GetAllTasks() error = nil, want error")
+		}
+	})
+
+	t.Run("malformed page token", func(t *testing.T) {
+		_, err := db.GetAllTasks(ctx, &storage.GetAllTasksReq{UserID: userID, Limit: 1, PageToken: "not a token"})
+		if err == nil {
+			t.Error("GetAllTasks() error = nil, want error")
+		}
+	})
+}
+
+func testGetAllTasksFilter(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	for _, task := range []storage.Task{
+		{TaskID: "task-a", Title: "Release notes", Status: "INCOMPLETE", Tags: []string{"work"}, DueDate: 100, Priority: "P1", EffortMinutes: 30},
+		{TaskID: "task-b", Title: "Write blog", Description: "draft of the release NOTES", Status: "COMPLETE", Tags: []string{"work", "someday"}, Priority: "PRIORITY_UNSPECIFIED"},
+		{TaskID: "task-c", Title: "Café visit", Status: "IN_PROGRESS", DueDate: 200, Priority: "P0", ProjectID: "project"},
+	} {
+		task.UserID = userID
+		addTask(t, db, task)
+	}
+	notes := storage.Or{
+		storage.Contains{Key: storage.TitleKey, Value: "notes"},
+		storage.Contains{Key: storage.DescriptionKey, Value: "notes"},
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.Filter
+		want   []string
+	}{
+		{
+			name:   "status",
+			filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "INCOMPLETE"},
+			want:   []string{"task-a"},
+		},
+		{
+			name: "tag and not tag",
+			filter: storage.And{
+				storage.Contains{Key: storage.TagsKey, Value: "work"},
+				storage.Not{Filter: storage.Contains{Key: storage.TagsKey, Value: "someday"}},
+			},
+			want: []string{"task-a"},
+		},
+		{
+			name:   "optional attribute excludes tasks without it",
+			filter: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: int64(150)},
+			want:   []string{"task-a"},
+		},
+		{
+			name:   "negated optional attribute includes tasks without it",
+			filter: storage.Not{Filter: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: int64(150)}},
+			want:   []string{"task-b", "task-c"},
+		},
+		{
+			name:   "attribute stored only when set",
+			filter: storage.Compare{Key: storage.CompletedAtKey, Op: storage.OpGt, Value: int64(0)},
+			want:   []string{"task-b"},
+		},
+		{
+			name:   "effort",
+			filter: storage.Compare{Key: storage.EffortMinutesKey, Op: storage.OpLe, Value: int64(30)},
+			want:   []string{"task-a"},
+		},
+		{
+			name:   "priority order",
+			filter: storage.Compare{Key: storage.PriorityKey, Op: storage.OpLe, Value: "P1"},
+			want:   []string{"task-a", "task-c"},
+		},
+		{
+			name:   "project",
+			filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: "project"},
+			want:   []string{"task-c"},
+		},
+		{
+			name:   "not project",
+			filter: storage.Not{Filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: "project"}},
+			want:   []string{"task-a", "task-b"},
+		},
+		{
+			name:   "text ignoring case",
+			filter: notes,
+			want:   []string{"task-a", "task-b"},
+		},
+		{
+			name:   "text outside ASCII ignoring case",
+			filter: storage.Contains{Key: storage.TitleKey, Value: "CAFÉ"},
+			want:   []string{"task-c"},
+		},
+		{
+			name: "text and other conditions",
+			filter: storage.And{
+				notes,
+				storage.Or{
+					storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "COMPLETE"},
+					storage.Compare{Key: storage.PriorityKey, Op: storage.OpEq, Value: "P0"},
+				},
+			},
+			want: []string{"task-b"},
+		},
+		{
+			name:   "empty and",
+			filter: storage.And{},
+			want:   []string{"task-a", "task-b", "task-c"},
+		},
+		{
+			name:   "empty or",
+			filter: storage.Or{},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := db.GetAllTasks(ctx, &storage.GetAllTasksReq{UserID: userID, Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("GetAllTasks() error = %v", err)
+			}
+			if got := taskIDs(resp.Tasks); !slices.Equal(got, tt.want) {
+				t.Errorf("GetAllTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		// pages may hold fewer tasks than the limit, but every matching task is returned once
+		req := &storage.GetAllTasksReq{UserID: userID, Filter: notes, Limit: 1}
+		var got []string
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatalf("GetAllTasks() returned more pages than tasks")
+			}
+			resp, err := db.GetAllTasks(ctx, req)
+			if err != nil {
+				t.Fatalf("GetAllTasks() error = %v", err)
+			}
+			got = append(got, taskIDs(resp.Tasks)...)
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		if want := []string{"task-a", "task-b"}; !slices.Equal(got, want) {
+			t.Errorf("GetAllTasks() pages = %v, want %v", got, want)
+		}
+	})
+}
+
+func testScanTasks(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	want := map[string]bool{}
+	for _, userID := range []string{uuid.New().String(), uuid.New().String()} {
+		for _, taskID := range []string{"task-a", "task-b", "task-c"} {
+			addTask(t, db, storage.Task{UserID: userID, TaskID: taskID, Title: taskID, Status: "INCOMPLETE"})
+			want[userID+"/"+taskID] = true
+		}
+	}
+
+	// other tests' tasks are scanned too, so only check that every added task is returned once
+	req := &storage.ScanTasksReq{Limit: 2}
+	seen := map[string]bool{}
+	for {
+		resp, err := db.ScanTasks(ctx, req)
+		if err != nil {
+			t.Fatalf("ScanTasks() error = %v", err)
+		}
+		if len(resp.Tasks) > int(req.Limit) {
+			t.Errorf("ScanTasks() returned %d tasks, want at most %d", len(resp.Tasks), req.Limit)
+		}
+		for _, task := range resp.Tasks {
+			key := task.UserID + "/" + task.TaskID
+			if seen[key] {
+				t.Errorf("ScanTasks() returned %s more than once", key)
+			}
+			seen[key] = true
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	for key := range want {
+		if !seen[key] {
+			t.Errorf("ScanTasks() did not return %s", key)
+		}
+	}
+
+	t.Run("malformed page token", func(t *testing.T) {
+		_, err := db.ScanTasks(ctx, &storage.ScanTasksReq{Limit: 1, PageToken: "not a token"})
+		if err == nil {
+			t.Error("ScanTasks() error = nil, want error")
+		}
+	})
+}
+
+func testGetAssignedTasks(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	assigneeID, ownerA, ownerB := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	addTask(t, db, storage.Task{UserID: ownerA, TaskID: "task-a", Title: "a", Status: "INCOMPLETE", AssigneeID: assigneeID})
+	addTask(t, db, storage.Task{UserID: ownerA, TaskID: "task-b", Title: "b", Status: "INCOMPLETE"})
+	addTask(t, db, storage.Task{UserID: ownerB, TaskID: "task-c", Title: "c", Status: "INCOMPLETE", AssigneeID: assigneeID})
+
+	// assignedKeys returns the sorted keys of the tasks assigned to the assignee.
+	assignedKeys := func(t *testing.T, assigneeID string) []string {
+		t.Helper()
+		resp, err := db.GetAssignedTasks(ctx, &storage.GetAssignedTasksReq{AssigneeID: assigneeID})
+		if err != nil {
+			t.Fatalf("GetAssignedTasks() error = %v", err)
+		}
+		keys := []string{}
+		for _, task := range resp.Tasks {
+			if task.AssigneeID != assigneeID {
+				t.Errorf("GetAssignedTasks() returned task assigned to %q, want %q", task.AssigneeID, assigneeID)
+			}
+			keys = append(keys, task.UserID+"/"+task.TaskID)
+		}
+		slices.Sort(keys)
+		return keys
+	}
+	want := []string{ownerA + "/task-a", ownerB + "/task-c"}
+	slices.Sort(want)
+	if got := assignedKeys(t, assigneeID); !slices.Equal(got, want) {
+		t.Errorf("GetAssignedTasks() = %v, want %v", got, want)
+	}
+
+	t.Run("assign and unassign", func(t *testing.T) {
+		for _, update := range []struct {
+			taskID     string
+			assigneeID string
+		}{{"task-b", assigneeID}, {"task-a", ""}} {
+			resp, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+				UserID:  ownerA,
+				TaskID:  update.taskID,
+				KVPairs: map[string]interface{}{storage.AssigneeIDKey: update.assigneeID},
+			})
+			if err != nil {
+				t.Fatalf("UpdateTask() error = %v", err)
+			}
+			if resp.Task.AssigneeID != update.assigneeID {
+				t.Errorf("UpdateTask() assignee = %q, want %q", resp.Task.AssigneeID, update.assigneeID)
+			}
+		}
+		want := []string{ownerA + "/task-b", ownerB + "/task-c"}
+		slices.Sort(want)
+		if got := assignedKeys(t, assigneeID); !slices.Equal(got, want) {
+			t.Errorf("GetAssignedTasks() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("no assigned tasks", func(t *testing.T) {
+		if got := assignedKeys(t, uuid.New().String()); len(got) != 0 {
+			t.Errorf("GetAssignedTasks() = %v, want none", got)
+		}
+	})
+}
+
+func testUpdateTask(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	task := addTask(t, db, newTask())
+
+	t.Run("set attributes", func(t *testing.T) {
+		resp, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+			UserID: task.UserID,
+			TaskID: task.TaskID,
+			KVPairs: map[string]interface{}{
+				storage.TitleKey:         "new title",
+				storage.TagsKey:          []string{"tag3"},
+				storage.DueDateKey:       int64(1800000000),
+				storage.EffortMinutesKey: uint32(45),
+				storage.RecurringRuleKey: nil,
+				storage.ProjectIDKey:     "other project",
+			},
+		})
+		if err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		got := getTask(t, db, task.UserID, task.TaskID)
+		if !equalTasks(resp.Task, *got) {
+			t.Errorf("UpdateTask() = %+v, want stored task %+v", resp.Task, *got)
+		}
+		if got.Title != "new title" || !slices.Equal(got.Tags, []string{"tag3"}) || got.DueDate != 1800000000 || got.EffortMinutes != 45 ||
+			got.ProjectID != "other project" {
+			t.Errorf("UpdateTask() stored %+v", *got)
+		}
+		if got.Description != task.Description || got.UpdatedAt < task.UpdatedAt {
+			t.Errorf("UpdateTask() stored %+v", *got)
+		}
+	})
+
+	t.Run("complete and reopen", func(t *testing.T) {
+		resp, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+			UserID:         task.UserID,
+			TaskID:         task.TaskID,
+			KVPairs:        map[string]interface{}{storage.StatusKey: storage.CompleteStatus},
+			ExpectedStatus: task.Status,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if resp.Task.CompletedAt == 0 {
+			t.Error("UpdateTask() completed at = 0, want timestamp")
+		}
+		if len(resp.Task.StatusHistory) != 2 || resp.Task.StatusHistory[1].Status != storage.CompleteStatus {
+			t.Errorf("UpdateTask() status history = %v", resp.Task.StatusHistory)
+		}
+
+		resp, err = db.UpdateTask(ctx, &storage.UpdateTaskReq{
+			UserID:         task.UserID,
+			TaskID:         task.TaskID,
+			KVPairs:        map[string]interface{}{storage.StatusKey: "INCOMPLETE"},
+			ExpectedStatus: storage.CompleteStatus,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if resp.Task.CompletedAt != 0 {
+			t.Errorf("UpdateTask() completed at = %d, want 0", resp.Task.CompletedAt)
+		}
+		if len(resp.Task.StatusHistory) != 3 {
+			t.Errorf("UpdateTask() status history = %v", resp.Task.StatusHistory)
+		}
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		_, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+			UserID:         task.UserID,
+			TaskID:         task.TaskID,
+			KVPairs:        map[string]interface{}{storage.StatusKey: "BLOCKED"},
+			ExpectedStatus: storage.CompleteStatus,
+		})
+		if !errors.Is(err, storage.ErrConditionFailed) {
+			t.Errorf("UpdateTask() error = %v, want %v", err, storage.ErrConditionFailed)
+		}
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		_, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+			UserID:  task.UserID,
+			TaskID:  uuid.New().String(),
+			KVPairs: map[string]interface{}{storage.TitleKey: "title"},
+		})
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("UpdateTask() error = %v, want %v", err, storage.ErrNotFound)
+		}
+	})
+
+	t.Run("invalid attributes", func(t *testing.T) {
+		for _, kvPairs := range []map[string]interface{}{
+			{storage.CreatedAtKey: int64(1)},
+			{storage.ChecklistKey: []storage.ChecklistItem{}},
+			{storage.TitleKey: 1},
+			{"unknown": "value"},
+		} {
+			_, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{UserID: task.UserID, TaskID: task.TaskID, KVPairs: kvPairs})
+			if err == nil {
+				t.Errorf("UpdateTask(%v) error = nil, want error", kvPairs)
+			}
+		}
+	})
+}
+
+func testChecklists(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	task := newTask()
+	task.Checklist = nil
+	addTask(t, db, task)
+	checklist := func() []storage.ChecklistItem {
+		return getTask(t, db, task.UserID, task.TaskID).Checklist
+	}
+
+	for _, item := range []storage.ChecklistItem{{ID: "item-a", Text: "a"}, {ID: "item-b", Text: "b"}} {
+		resp, err := db.AddChecklistItem(ctx, &storage.AddChecklistItemReq{UserID: task.UserID, TaskID: task.TaskID, Item: item})
+		if err != nil {
+			t.Fatalf("AddChecklistItem() error = %v", err)
+		}
+		if got := resp.Task.Checklist; len(got) == 0 || got[len(got)-1] != item {
+			t.Errorf("AddChecklistItem() checklist = %v, want %v last", got, item)
+		}
+	}
+	_, err := db.AddChecklistItem(ctx, &storage.AddChecklistItemReq{UserID: task.UserID, TaskID: uuid.New().String(), Item: storage.ChecklistItem{ID: "item-c"}})
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("AddChecklistItem() of missing task error = %v, want %v", err, storage.ErrNotFound)
+	}
+
+	// done
+	done := &storage.SetChecklistItemDoneReq{UserID: task.UserID, TaskID: task.TaskID, Index: 1, ItemID: "item-b", Done: true}
+	if _, err := db.SetChecklistItemDone(ctx, done); err != nil {
+		t.Fatalf("SetChecklistItemDone() error = %v", err)
+	}
+	if got := checklist(); len(got) != 2 || !got[1].Done || got[0].Done {
+		t.Errorf("SetChecklistItemDone() checklist = %v", got)
+	}
+	if _, err := db.SetChecklistItemDone(ctx, done); !errors.Is(err, storage.ErrConditionFailed) {
+		t.Errorf("SetChecklistItemDone() of done item error = %v, want %v", err, storage.ErrConditionFailed)
+	}
+	moved := &storage.SetChecklistItemDoneReq{UserID: task.UserID, TaskID: task.TaskID, Index: 0, ItemID: "item-b"}
+	if _, err := db.SetChecklistItemDone(ctx, moved); !errors.Is(err, storage.ErrConditionFailed) {
+		t.Errorf("SetChecklistItemDone() of moved item error = %v, want %v", err, storage.ErrConditionFailed)
+	}
+
+	// replace
+	reordered := []storage.ChecklistItem{{ID: "item-b", Text: "b", Done: true}, {ID: "item-a", Text: "a"}}
+	_, err = db.ReplaceChecklist(ctx, &storage.ReplaceChecklistReq{UserID: task.UserID, TaskID: task.TaskID, Checklist: reordered, ExpectedChecklist: reordered})
+	if !errors.Is(err, storage.ErrConditionFailed) {
+		t.Errorf("ReplaceChecklist() of unexpected checklist error = %v, want %v", err, storage.ErrConditionFailed)
+	}
+	_, err = db.ReplaceChecklist(ctx, &storage.ReplaceChecklistReq{UserID: task.UserID, TaskID: task.TaskID, Checklist: reordered, ExpectedChecklist: checklist()})
+	if err != nil {
+		t.Fatalf("ReplaceChecklist() error = %v", err)
+	}
+	if got := checklist(); !slices.Equal(got, reordered) {
+		t.Errorf("ReplaceChecklist() checklist = %v, want %v", got, reordered)
+	}
+
+	// remove
+	remove := &storage.RemoveChecklistItemReq{UserID: task.UserID, TaskID: task.TaskID, Index: 1, ItemID: "item-b"}
+	if _, err := db.RemoveChecklistItem(ctx, remove); !errors.Is(err, storage.ErrConditionFailed) {
+		t.Errorf("RemoveChecklistItem() of moved item error = %v, want %v", err, storage.ErrConditionFailed)
+	}
+	remove.Index = 0
+	if _, err := db.RemoveChecklistItem(ctx, remove); err != nil {
+		t.Fatalf("RemoveChecklistItem() error = %v", err)
+	}
+	if got := checklist(); !slices.Equal(got, reordered[1:]) {
+		t.Errorf("RemoveChecklistItem() checklist = %v, want %v", got, reordered[1:])
+	}
+
+	// update expecting a checklist
+	complete := &storage.UpdateTaskReq{
+		UserID:            task.UserID,
+		TaskID:            task.TaskID,
+		KVPairs:           map[string]interface{}{storage.StatusKey: storage.CompleteStatus},
+		ExpectChecklist:   true,
+		ExpectedChecklist: reordered,
+	}
+	if _, err := db.UpdateTask(ctx, complete); !errors.Is(err, storage.ErrConditionFailed) {
+		t.Errorf("UpdateTask() of unexpected checklist error = %v, want %v", err, storage.ErrConditionFailed)
+	}
+	if got := getTask(t, db, task.UserID, task.TaskID); got.Status == storage.CompleteStatus {
+		t.Errorf("UpdateTask() of unexpected checklist status = %v, want the status unchanged", got.Status)
+	}
+	complete.ExpectedChecklist = checklist()
+	if _, err := db.UpdateTask(ctx, complete); err != nil {
+		t.Errorf("UpdateTask() of expected checklist error = %v", err)
+	}
+	empty := newTask()
+	empty.Checklist = nil
+	addTask(t, db, empty)
+	complete.UserID, complete.TaskID, complete.ExpectedChecklist = empty.UserID, empty.TaskID, nil
+	if _, err := db.UpdateTask(ctx, complete); err != nil {
+		t.Errorf("UpdateTask() of expected empty checklist error = %v", err)
+	}
+}
+
+func testDeleteTask(t *testing.T, db storage.Backend) {
+	task := addTask(t, db, newTask())
+	_, err := db.DeleteTask(context.Background(), &storage.DeleteTaskReq{UserID: task.UserID, TaskID: task.TaskID})
+	if err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	if got := getTask(t, db, task.UserID, task.TaskID); got != nil {
+		t.Errorf("GetTask() of deleted task = %v, want nil", got)
+	}
+}
+
+func testConcurrentUpdates(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	task := newTask()
+	task.Checklist = nil
+	addTask(t, db, task)
+	const writers = 10
+
+	// every append is kept
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item := storage.ChecklistItem{ID: uuid.New().String(), Text: "item"}
+			if _, err := db.AddChecklistItem(ctx, &storage.AddChecklistItemReq{UserID: task.UserID, TaskID: task.TaskID, Item: item}); err != nil {
+				t.Errorf("AddChecklistItem() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := getTask(t, db, task.UserID, task.TaskID).Checklist; len(got) != writers {
+		t.Errorf("concurrent AddChecklistItem() checklist has %d items, want %d", len(got), writers)
+	}
+
+	// only one of several changes from the same status succeeds
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := db.UpdateTask(ctx, &storage.UpdateTaskReq{
+				UserID:         task.UserID,
+				TaskID:         task.TaskID,
+				KVPairs:        map[string]interface{}{storage.StatusKey: "IN_PROGRESS"},
+				ExpectedStatus: task.Status,
+			})
+			if err != nil && !errors.Is(err, storage.ErrConditionFailed) {
+				t.Errorf("UpdateTask() error = %v", err)
+			}
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if succeeded != 1 {
+		t.Errorf("concurrent UpdateTask() succeeded %d times, want 1", succeeded)
+	}
+	if got := getTask(t, db, task.UserID, task.TaskID).StatusHistory; len(got) != 2 {
+		t.Errorf("concurrent UpdateTask() status history = %v, want 2 changes", got)
+	}
+}
+
+func testUnitOfWork(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+
+	t.Run("commit", func(t *testing.T) {
+		user := storage.User{ID: uuid.New().String(), FirstName: "first", LastName: "last", Email: "email", HashedPassword: "hash"}
+		added := newTask()
+		added.UserID = user.ID
+		updated := addTask(t, db, storage.Task{UserID: user.ID, TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"})
+		deleted := addTask(t, db, storage.Task{UserID: user.ID, TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"})
+
+		uow := db.NewUnitOfWork()
+		uow.AddUser(user)
+		uow.AddTask(added)
+		uow.UpdateTask(storage.UpdateTaskReq{
+			UserID:         user.ID,
+			TaskID:         updated.TaskID,
+			KVPairs:        map[string]interface{}{storage.StatusKey: storage.CompleteStatus},
+			ExpectedStatus: "INCOMPLETE",
+		})
+		uow.DeleteTask(storage.DeleteTaskReq{UserID: user.ID, TaskID: deleted.TaskID})
+		event := newEvent()
+		event.UserID = user.ID
+		uow.AddEvent(event)
+		if err := uow.Commit(ctx); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		resp, err := db.GetUser(ctx, &storage.GetUserReq{ID: user.ID})
+		if err != nil {
+			t.Fatalf("GetUser() error = %v", err)
+		}
+		if resp.User == nil || *resp.User != user {
+			t.Errorf("GetUser() after commit = %v, want %v", resp.User, user)
+		}
+		got := getTask(t, db, user.ID, added.TaskID)
+		if got == nil || got.CreatedAt == 0 || !equalTasks(*got, storage.NewTask(added, got.CreatedAt)) {
+			t.Errorf("GetTask() of added task = %v, want %v with timestamps and status history", got, added)
+		}
+		got = getTask(t, db, user.ID, updated.TaskID)
+		if got.Status != storage.CompleteStatus || got.CompletedAt == 0 || len(got.StatusHistory) != 2 {
+			t.Errorf("GetTask() of updated task = %v, want it completed with 2 status changes", got)
+		}
+		if got := getTask(t, db, user.ID, deleted.TaskID); got != nil {
+			t.Errorf("GetTask() of deleted task = %v, want nil", got)
+		}
+		gotEvent := getEvent(t, db, user.ID, event.EventID)
+		if gotEvent == nil || gotEvent.CreatedAt == 0 || !equalEvents(*gotEvent, storage.NewEvent(event, gotEvent.CreatedAt)) {
+			t.Errorf("GetEvent() of added event = %v, want %v with timestamps", gotEvent, event)
+		}
+	})
+
+	t.Run("failed condition writes nothing", func(t *testing.T) {
+		userID := uuid.New().String()
+		deleted := addTask(t, db, storage.Task{UserID: userID, TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"})
+		updated := addTask(t, db, storage.Task{UserID: userID, TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"})
+		added := storage.Task{UserID: userID, TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"}
+
+		event := newEvent()
+		event.UserID = userID
+
+		uow := db.NewUnitOfWork()
+		uow.AddTask(added)
+		uow.AddEvent(event)
+		uow.DeleteTask(storage.DeleteTaskReq{UserID: userID, TaskID: deleted.TaskID})
+		uow.UpdateTask(storage.UpdateTaskReq{
+			UserID:         userID,
+			TaskID:         updated.TaskID,
+			KVPairs:        map[string]interface{}{storage.StatusKey: "IN_PROGRESS"},
+			ExpectedStatus: storage.CompleteStatus,
+		})
+		if err := uow.Commit(ctx); !errors.Is(err, storage.ErrConditionFailed) {
+			t.Fatalf("Commit() error = %v, want ErrConditionFailed", err)
+		}
+		if got := getTask(t, db, userID, added.TaskID); got != nil {
+			t.Errorf("GetTask() of task added by failed commit = %v, want nil", got)
+		}
+		if got := getEvent(t, db, userID, event.EventID); got != nil {
+			t.Errorf("GetEvent() of event added by failed commit = %v, want nil", got)
+		}
+		if got := getTask(t, db, userID, deleted.TaskID); got == nil {
+			t.Errorf("GetTask() of task deleted by failed commit = nil, want it kept")
+		}
+		if got := getTask(t, db, userID, updated.TaskID); got == nil || !equalTasks(*got, *updated) {
+			t.Errorf("GetTask() of task updated by failed commit = %v, want %v", got, updated)
+		}
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		uow := db.NewUnitOfWork()
+		uow.UpdateTask(storage.UpdateTaskReq{
+			UserID:  uuid.New().String(),
+			TaskID:  uuid.New().String(),
+			KVPairs: map[string]interface{}{storage.TitleKey: "title"},
+		})
+		if err := uow.Commit(ctx); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("Commit() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("invalid attributes", func(t *testing.T) {
+		task := addTask(t, db, storage.Task{UserID: uuid.New().String(), TaskID: uuid.New().String(), Title: "title", Status: "INCOMPLETE"})
+		uow := db.NewUnitOfWork()
+		uow.UpdateTask(storage.UpdateTaskReq{
+			UserID:  task.UserID,
+			TaskID:  task.TaskID,
+			KVPairs: map[string]interface{}{storage.CreatedAtKey: int64(1)},
+		})
+		if err := uow.Commit(ctx); err == nil {
+			t.Errorf("Commit() error = nil, want error")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if err := db.NewUnitOfWork().Commit(ctx); err != nil {
+			t.Errorf("Commit() error = %v", err)
+		}
+	})
+}
+
+// newEvent returns an event of a fresh user with every attribute set.
+func newEvent() storage.Event {
+	return storage.Event{
+		UserID:      uuid.New().String(),
+		EventID:     uuid.New().String(),
+		Title:       "title",
+		Description: "description",
+		Location:    "location",
+		StartTime:   1700000000,
+		EndTime:     1700003600,
+		AllDay:      true,
+		RecurringRule: &storage.RecurringRule{
+			CronExpression: "0 9 * * 1",
+			StartDate:      1700000000,
+			EndDate:        1800000000,
+		},
+	}
+}
+
+// addEvent adds the event, failing the test on error, and returns it as stored.
+func addEvent(t *testing.T, db storage.Backend, event storage.Event) *storage.Event {
+	t.Helper()
+	if _, err := db.AddEvent(context.Background(), &storage.AddEventReq{Event: event}); err != nil {
+		t.Fatalf("AddEvent() error = %v", err)
+	}
+	return getEvent(t, db, event.UserID, event.EventID)
+}
+
+// getEvent gets the event, failing the test on error.
+func getEvent(t *testing.T, db storage.Backend, userID, eventID string) *storage.Event {
+	t.Helper()
+	resp, err := db.GetEvent(context.Background(), &storage.GetEventReq{UserID: userID, EventID: eventID})
+	if err != nil {
+		t.Fatalf("GetEvent() error = %v", err)
+	}
+	return resp.Event
+}
+
+// equalEvents reports whether two events are equal, comparing their recurring rules by value.
+func equalEvents(a, b storage.Event) bool {
+	equalRules := (a.RecurringRule == nil && b.RecurringRule == nil) ||
+		(a.RecurringRule != nil && b.RecurringRule != nil && *a.RecurringRule == *b.RecurringRule)
+	a.RecurringRule, b.RecurringRule = nil, nil
+	return equalRules && a == b
+}
+
+func testEvents(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+
+	t.Run("AddEvent and GetEvent", func(t *testing.T) {
+		want := newEvent()
+		got := addEvent(t, db, want)
+		if got == nil {
+			t.Fatal("GetEvent() = nil, want event")
+		}
+		if got.CreatedAt == 0 || got.UpdatedAt != got.CreatedAt {
+			t.Errorf("GetEvent() created at = %d, updated at = %d", got.CreatedAt, got.UpdatedAt)
+		}
+		want.CreatedAt, want.UpdatedAt = got.CreatedAt, got.UpdatedAt
+		if !equalEvents(*got, want) {
+			t.Errorf("GetEvent() = %+v, want %+v", *got, want)
+		}
+
+		withoutRule := newEvent()
+		withoutRule.RecurringRule = nil
+		if got := addEvent(t, db, withoutRule); got.RecurringRule != nil {
+			t.Errorf("GetEvent() of event without rule recurring rule = %v, want nil", got.RecurringRule)
+		}
+
+		if missing := getEvent(t, db, want.UserID, uuid.New().String()); missing != nil {
+			t.Errorf("GetEvent() of missing event = %v, want nil", missing)
+		}
+	})
+
+	t.Run("GetAllEvents", func(t *testing.T) {
+		userID := uuid.New().String()
+		var wantIDs []string
+		for i := 0; i < 5; i++ {
+			event := newEvent()
+			event.UserID = userID
+			addEvent(t, db, event)
+			wantIDs = append(wantIDs, event.EventID)
+		}
+		slices.Sort(wantIDs)
+		addEvent(t, db, newEvent())
+
+		var gotIDs []string
+		req := &storage.GetAllEventsReq{UserID: userID, Limit: 2}
+		for pages := 0; ; pages++ {
+			if pages > len(wantIDs) {
+				t.Fatalf("GetAllEvents() returned more pages than events")
+			}
+			resp, err := db.GetAllEvents(ctx, req)
+			if err != nil {
+				t.Fatalf("GetAllEvents() error = %v", err)
+			}
+			if len(resp.Events) > int(req.Limit) {
+				t.Errorf("GetAllEvents() returned %d events, want at most %d", len(resp.Events), req.Limit)
+			}
+			for _, event := range resp.Events {
+				gotIDs = append(gotIDs, event.EventID)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		if !slices.Equal(gotIDs, wantIDs) {
+			t.Errorf("GetAllEvents() pages = %v, want %v", gotIDs, wantIDs)
+		}
+
+		resp, err := db.GetAllEvents(ctx, &storage.GetAllEventsReq{UserID: userID})
+		if err != nil {
+			t.Fatalf("GetAllEvents() without limit error = %v", err)
+		}
+		if len(resp.Events) != len(wantIDs) || resp.NextPageToken != "" {
+			t.Errorf("GetAllEvents() without limit = %d events and page token %q, want %d events", len(resp.Events), resp.NextPageToken, len(wantIDs))
+		}
+	})
+
+	t.Run("GetAllEvents page token of another user", func(t *testing.T) {
+		userID := uuid.New().String()
+		for i := 0; i < 2; i++ {
+			event := newEvent()
+			event.UserID = userID
+			addEvent(t, db, event)
+		}
+		resp, err := db.GetAllEvents(ctx, &storage.GetAllEventsReq{UserID: userID, Limit: 1})
+		if err != nil {
+			t.Fatalf("GetAllEvents() error = %v", err)
+		}
+		if resp.NextPageToken == "" {
+			t.Fatal("GetAllEvents() next page token is empty")
+		}
+		_, err = db.GetAllEvents(ctx, &storage.GetAllEventsReq{UserID: uuid.New().String(), PageToken: resp.NextPageToken})
+		if err == nil {
+			t.Error("GetAllEvents() with page token of another user error = nil, want error")
+		}
+	})
+
+	t.Run("DeleteEvent", func(t *testing.T) {
+		event := addEvent(t, db, newEvent())
+		_, err := db.DeleteEvent(ctx, &storage.DeleteEventReq{UserID: event.UserID, EventID: event.EventID})
+		if err != nil {
+			t.Fatalf("DeleteEvent() error = %v", err)
+		}
+		if got := getEvent(t, db, event.UserID, event.EventID); got != nil {
+			t.Errorf("GetEvent() of deleted event = %v, want nil", got)
+		}
+	})
+}
+
+// getView gets the view, failing the test on error.
+func getView(t *testing.T, db storage.Backend, userID, name string) *storage.View {
+	t.Helper()
+	resp, err := db.GetView(context.Background(), &storage.GetViewReq{UserID: userID, Name: name})
+	if err != nil {
+		t.Fatalf("GetView() error = %v", err)
+	}
+	return resp.View
+}
+
+func testViews(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	save := func(view storage.View) {
+		t.Helper()
+		if _, err := db.SaveView(ctx, &storage.SaveViewReq{View: view}); err != nil {
+			t.Fatalf("SaveView() error = %v", err)
+		}
+	}
+
+	t.Run("SaveView and GetView", func(t *testing.T) {
+		want := storage.View{UserID: userID, Name: "Work", Query: "tag:work", SortBy: "SORT_BY_DUE_DATE", Descending: true}
+		save(want)
+		if got := getView(t, db, userID, want.Name); got == nil || *got != want {
+			t.Errorf("GetView() = %v, want %+v", got, want)
+		}
+
+		// saving a view of the same name replaces it
+		want.Query = "tag:work status:incomplete"
+		want.Descending = false
+		save(want)
+		if got := getView(t, db, userID, want.Name); got == nil || *got != want {
+			t.Errorf("GetView() of replaced view = %v, want %+v", got, want)
+		}
+
+		if missing := getView(t, db, userID, "Missing"); missing != nil {
+			t.Errorf("GetView() of missing view = %v, want nil", missing)
+		}
+		if other := getView(t, db, uuid.New().String(), want.Name); other != nil {
+			t.Errorf("GetView() of view of another user = %v, want nil", other)
+		}
+	})
+
+	t.Run("GetAllViews", func(t *testing.T) {
+		userID := uuid.New().String()
+		for _, name := range []string{"b", "c", "a"} {
+			save(storage.View{UserID: userID, Name: name, Query: "tag:" + name})
+		}
+		save(storage.View{UserID: uuid.New().String(), Name: "d"})
+		resp, err := db.GetAllViews(ctx, &storage.GetAllViewsReq{UserID: userID})
+		if err != nil {
+			t.Fatalf("GetAllViews() error = %v", err)
+		}
+		var names []string
+		for _, view := range resp.Views {
+			names = append(names, view.Name)
+		}
+		if want := []string{"a", "b", "c"}; !slices.Equal(names, want) {
+			t.Errorf("GetAllViews() names = %v, want %v", names, want)
+		}
+	})
+
+	t.Run("DeleteView", func(t *testing.T) {
+		view := storage.View{UserID: userID, Name: "Deleted", Query: "tag:deleted"}
+		save(view)
+		for i := 0; i < 2; i++ {
+			if _, err := db.DeleteView(ctx, &storage.DeleteViewReq{UserID: userID, Name: view.Name}); err != nil {
+				t.Fatalf("DeleteView() error = %v", err)
+			}
+		}
+		if got := getView(t, db, userID, view.Name); got != nil {
+			t.Errorf("GetView() of deleted view = %v, want nil", got)
+		}
+	})
+}
+
+// getProject gets the project, failing the test on error.
+func getProject(t *testing.T, db storage.Backend, userID, projectID string) *storage.Project {
+	t.Helper()
+	resp, err := db.GetProject(context.Background(), &storage.GetProjectReq{UserID: userID, ProjectID: projectID})
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	return resp.Project
+}
+
+func testProjects(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	userID := uuid.New().String()
+	add := func(project storage.Project) *storage.Project {
+		t.Helper()
+		if _, err := db.AddProject(ctx, &storage.AddProjectReq{Project: project}); err != nil {
+			t.Fatalf("AddProject() error = %v", err)
+		}
+		return getProject(t, db, project.UserID, project.ProjectID)
+	}
+
+	t.Run("AddProject and GetProject", func(t *testing.T) {
+		want := storage.Project{UserID: userID, ProjectID: uuid.New().String(), Name: "Work", Description: "day job", Color: "#1e90ff", CreatedAt: 1, UpdatedAt: 1}
+		got := add(want)
+		if got == nil || got.CreatedAt <= 1 || got.UpdatedAt != got.CreatedAt {
+			t.Fatalf("GetProject() = %+v, want timestamps set by the store", got)
+		}
+		want.CreatedAt, want.UpdatedAt = got.CreatedAt, got.UpdatedAt
+		if *got != want {
+			t.Errorf("GetProject() = %+v, want %+v", *got, want)
+		}
+
+		if missing := getProject(t, db, userID, uuid.New().String()); missing != nil {
+			t.Errorf("GetProject() of missing project = %v, want nil", missing)
+		}
+		if other := getProject(t, db, uuid.New().String(), want.ProjectID); other != nil {
+			t.Errorf("GetProject() of project of another user = %v, want nil", other)
+		}
+	})
+
+	t.Run("GetAllProjects", func(t *testing.T) {
+		userID := uuid.New().String()
+		for _, projectID := range []string{"b", "c", "a"} {
+			add(storage.Project{UserID: userID, ProjectID: projectID, Name: "project " + projectID})
+		}
+		add(storage.Project{UserID: uuid.New().String(), ProjectID: "d"})
+		resp, err := db.GetAllProjects(ctx, &storage.GetAllProjectsReq{UserID: userID})
+		if err != nil {
+			t.Fatalf("GetAllProjects() error = %v", err)
+		}
+		var projectIDs []string
+		for _, project := range resp.Projects {
+			projectIDs = append(projectIDs, project.ProjectID)
+		}
+		if want := []string{"a", "b", "c"}; !slices.Equal(projectIDs, want) {
+			t.Errorf("GetAllProjects() project ids = %v, want %v", projectIDs, want)
+		}
+	})
+
+	t.Run("UpdateProject", func(t *testing.T) {
+		project := add(storage.Project{UserID: userID, ProjectID: uuid.New().String(), Name: "Home", Color: "#ffffff"})
+		resp, err := db.UpdateProject(ctx, &storage.UpdateProjectReq{Project: storage.Project{
+			UserID:      userID,
+			ProjectID:   project.ProjectID,
+			Name:        "House",
+			Description: "chores",
+			Archived:    true,
+			CreatedAt:   1,
+		}})
+		if err != nil {
+			t.Fatalf("UpdateProject() error = %v", err)
+		}
+		got := getProject(t, db, userID, project.ProjectID)
+		if got == nil || resp.Project != *got {
+			t.Fatalf("UpdateProject() = %+v, want stored project %+v", resp.Project, got)
+		}
+		want := *project
+		want.Name, want.Description, want.Color, want.Archived, want.UpdatedAt = "House", "chores", "", true, got.UpdatedAt
+		if *got != want || got.UpdatedAt < project.UpdatedAt {
+			t.Errorf("UpdateProject() stored %+v, want %+v", *got, want)
+		}
+
+		missingID := uuid.New().String()
+		_, err = db.UpdateProject(ctx, &storage.UpdateProjectReq{Project: storage.Project{UserID: userID, ProjectID: missingID, Name: "Missing"}})
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("UpdateProject() of missing project error = %v, want %v", err, storage.ErrNotFound)
+		}
+		if missing := getProject(t, db, userID, missingID); missing != nil {
+			t.Errorf("UpdateProject() of missing project created %+v", missing)
+		}
+	})
+
+	t.Run("DeleteProject", func(t *testing.T) {
+		project := add(storage.Project{UserID: userID, ProjectID: uuid.New().String(), Name: "Deleted"})
+		for i := 0; i < 2; i++ {
+			if _, err := db.DeleteProject(ctx, &storage.DeleteProjectReq{UserID: userID, ProjectID: project.ProjectID}); err != nil {
+				t.Fatalf("DeleteProject() error = %v", err)
+			}
+		}
+		if got := getProject(t, db, userID, project.ProjectID); got != nil {
+			t.Errorf("GetProject() of deleted project = %v, want nil", got)
+		}
+	})
+}
+
+func getMember(t *testing.T, db storage.Backend, userID, projectID string) *storage.Member {
+	t.Helper()
+	resp, err := db.GetMember(context.Background(), &storage.GetMemberReq{UserID: userID, ProjectID: projectID})
+	if err != nil {
+		t.Fatalf("GetMember() error = %v", err)
+	}
+	return resp.Member
+}
+
+// memberKeys returns the user id and project id of each member, joined by a slash.
+func memberKeys(members []storage.Member) []string {
+	var keys []string
+	for _, member := range members {
+		keys = append(keys, member.UserID+"/"+member.ProjectID)
+	}
+	return keys
+}
+
+func testMembers(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	add := func(member storage.Member) *storage.Member {
+		t.Helper()
+		if _, err := db.AddMember(ctx, &storage.AddMemberReq{Member: member}); err != nil {
+			t.Fatalf("AddMember() error = %v", err)
+		}
+		return getMember(t, db, member.UserID, member.ProjectID)
+	}
+
+	t.Run("AddMember and GetMember", func(t *testing.T) {
+		want := storage.Member{
+			UserID:    uuid.New().String(),
+			ProjectID: uuid.New().String(),
+			OwnerID:   uuid.New().String(),
+			Role:      "EDITOR",
+			InvitedBy: uuid.New().String(),
+			CreatedAt: 1,
+			UpdatedAt: 1,
+		}
+		got := add(want)
+		if got == nil || got.CreatedAt <= 1 || got.UpdatedAt != got.CreatedAt {
+			t.Fatalf("GetMember() = %+v, want timestamps set by the store", got)
+		}
+		want.CreatedAt, want.UpdatedAt = got.CreatedAt, got.UpdatedAt
+		if *got != want {
+			t.Errorf("GetMember() = %+v, want %+v", *got, want)
+		}
+
+		if missing := getMember(t, db, want.UserID, uuid.New().String()); missing != nil {
+			t.Errorf("GetMember() of missing member = %v, want nil", missing)
+		}
+		if other := getMember(t, db, uuid.New().String(), want.ProjectID); other != nil {
+			t.Errorf("GetMember() of another user = %v, want nil", other)
+		}
+	})
+
+	t.Run("GetMemberships and GetProjectMembers", func(t *testing.T) {
+		userID, otherUserID := uuid.New().String(), uuid.New().String()
+		projectA, projectB := "a"+uuid.New().String(), "b"+uuid.New().String()
+		for _, member := range []storage.Member{
+			{UserID: userID, ProjectID: projectB, Role: "VIEWER"},
+			{UserID: otherUserID, ProjectID: projectA, Role: "VIEWER"},
+			{UserID: userID, ProjectID: projectA, Role: "OWNER", Accepted: true},
+			{UserID: otherUserID, ProjectID: projectB, Role: "EDITOR"},
+		} {
+			add(member)
+		}
+
+		memberships, err := db.GetMemberships(ctx, &storage.GetMembershipsReq{UserID: userID})
+		if err != nil {
+			t.Fatalf("GetMemberships() error = %v", err)
+		}
+		if want := []string{userID + "/" + projectA, userID + "/" + projectB}; !slices.Equal(memberKeys(memberships.Members), want) {
+			t.Errorf("GetMemberships() = %v, want %v", memberKeys(memberships.Members), want)
+		}
+
+		members, err := db.GetProjectMembers(ctx, &storage.GetProjectMembersReq{ProjectID: projectA})
+		if err != nil {
+			t.Fatalf("GetProjectMembers() error = %v", err)
+		}
+		want := []string{userID + "/" + projectA, otherUserID + "/" + projectA}
+		if userID > otherUserID {
+			want[0], want[1] = want[1], want[0]
+		}
+		if !slices.Equal(memberKeys(members.Members), want) {
+			t.Errorf("GetProjectMembers() = %v, want %v", memberKeys(members.Members), want)
+		}
+	})
+
+	t.Run("UpdateMember", func(t *testing.T) {
+		member := add(storage.Member{UserID: uuid.New().String(), ProjectID: uuid.New().String(), OwnerID: "owner", Role: "VIEWER", InvitedBy: "owner"})
+		resp, err := db.UpdateMember(ctx, &storage.UpdateMemberReq{Member: storage.Member{
+			UserID:    member.UserID,
+			ProjectID: member.ProjectID,
+			OwnerID:   "other owner",
+			Role:      "EDITOR",
+			Accepted:  true,
+			CreatedAt: 1,
+		}})
+		if err != nil {
+			t.Fatalf("UpdateMember() error = %v", err)
+		}
+		got := getMember(t, db, member.UserID, member.ProjectID)
+		if got == nil || resp.Member != *got {
+			t.Fatalf("UpdateMember() = %+v, want stored member %+v", resp.Member, got)
+		}
+		want := *member
+		want.Role, want.Accepted, want.UpdatedAt = "EDITOR", true, got.UpdatedAt
+		if *got != want || got.UpdatedAt < member.UpdatedAt {
+			t.Errorf("UpdateMember() stored %+v, want %+v", *got, want)
+		}
+
+		missingID := uuid.New().String()
+		_, err = db.UpdateMember(ctx, &storage.UpdateMemberReq{Member: storage.Member{UserID: member.UserID, ProjectID: missingID, Role: "EDITOR"}})
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("UpdateMember() of missing member error = %v, want %v", err, storage.ErrNotFound)
+		}
+		if missing := getMember(t, db, member.UserID, missingID); missing != nil {
+			t.Errorf("UpdateMember() of missing member created %+v", missing)
+		}
+	})
+
+	t.Run("UpdateMember with expected role", func(t *testing.T) {
+		member := add(storage.Member{UserID: uuid.New().String(), ProjectID: uuid.New().String(), OwnerID: "owner", Role: "EDITOR"})
+		update := func(role string, accepted bool, expectedRole string, expectedAccepted bool) error {
+			t.Helper()
+			_, err := db.UpdateMember(ctx, &storage.UpdateMemberReq{
+				Member:           storage.Member{UserID: member.UserID, ProjectID: member.ProjectID, Role: role, Accepted: accepted},
+				ExpectedRole:     expectedRole,
+				ExpectedAccepted: expectedAccepted,
+			})
+			return err
+		}
+		check := func(wantRole string, wantAccepted bool) {
+			t.Helper()
+			got := getMember(t, db, member.UserID, member.ProjectID)
+			if got == nil || got.Role != wantRole || got.Accepted != wantAccepted {
+				t.Errorf("GetMember() = %+v, want role %s and accepted %v", got, wantRole, wantAccepted)
+			}
+		}
+
+		// the invitee is demoted, then accepts the invitation as read before the demotion
+		if err := update("VIEWER", false, "EDITOR", false); err != nil {
+			t.Fatalf("UpdateMember() error = %v", err)
+		}
+		if err := update("EDITOR", true, "EDITOR", false); !errors.Is(err, storage.ErrConditionFailed) {
+			t.Errorf("UpdateMember() with stale role error = %v, want %v", err, storage.ErrConditionFailed)
+		}
+		check("VIEWER", false)
+
+		// the invitee accepts, then is promoted as read before accepting
+		if err := update("VIEWER", true, "VIEWER", false); err != nil {
+			t.Fatalf("UpdateMember() error = %v", err)
+		}
+		if err := update("EDITOR", false, "VIEWER", false); !errors.Is(err, storage.ErrConditionFailed) {
+			t.Errorf("UpdateMember() with stale accepted flag error = %v, want %v", err, storage.ErrConditionFailed)
+		}
+		check("VIEWER", true)
+
+		_, err := db.UpdateMember(ctx, &storage.UpdateMemberReq{
+			Member:       storage.Member{UserID: member.UserID, ProjectID: uuid.New().String(), Role: "EDITOR"},
+			ExpectedRole: "VIEWER",
+		})
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("UpdateMember() of missing member error = %v, want %v", err, storage.ErrNotFound)
+		}
+	})
+
+	t.Run("DeleteMember", func(t *testing.T) {
+		member := add(storage.Member{UserID: uuid.New().String(), ProjectID: uuid.New().String(), Role: "VIEWER"})
+		for i := 0; i < 2; i++ {
+			if _, err := db.DeleteMember(ctx, &storage.DeleteMemberReq{UserID: member.UserID, ProjectID: member.ProjectID}); err != nil {
+				t.Fatalf("DeleteMember() error = %v", err)
+			}
+		}
+		if got := getMember(t, db, member.UserID, member.ProjectID); got != nil {
+			t.Errorf("GetMember() of deleted member = %v, want nil", got)
+		}
+	})
+}
+
+func testAudit(t *testing.T, db storage.Backend) {
+	ctx := context.Background()
+	projectID := uuid.New().String()
+	for _, entryID := range []string{"b", "c", "a"} {
+		_, err := db.AddAuditEntry(ctx, &storage.AddAuditEntryReq{Entry: storage.AuditEntry{
+			ProjectID: projectID,
+			EntryID:   entryID,
+			UserID:    "user",
+			Action:    "task.updated",
+			TaskID:    "task " + entryID,
+			Details:   "details",
+			CreatedAt: 1,
+		}})
+		if err != nil {
+			t.Fatalf("AddAuditEntry() error = %v", err)
+		}
+	}
+	_, err := db.AddAuditEntry(ctx, &storage.AddAuditEntryReq{Entry: storage.AuditEntry{ProjectID: uuid.New().String(), EntryID: "d"}})
+	if err != nil {
+		t.Fatalf("AddAuditEntry() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		limit int32
+		want  []string
+	}{
+		{name: "every entry newest first", want: []string{"c", "b", "a"}},
+		{name: "limit", limit: 2, want: []string{"c", "b"}},
+		{name: "limit above count", limit: 5, want: []string{"c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := db.GetAuditEntries(ctx, &storage.GetAuditEntriesReq{ProjectID: projectID, Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("GetAuditEntries() error = %v", err)
+			}
+			var entryIDs []string
+			for _, entry := range resp.Entries {
+				entryIDs = append(entryIDs, entry.EntryID)
+				if entry.CreatedAt <= 1 || entry.UserID != "user" || entry.TaskID != "task "+entry.EntryID {
+					t.Errorf("GetAuditEntries() entry = %+v, want stored entry with its creation time set by the store", entry)
+				}
+			}
+			if !slices.Equal(entryIDs, tt.want) {
+				t.Errorf("GetAuditEntries() entry ids = %v, want %v", entryIDs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package storage
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an item that must exist does not.
+	ErrNotFound = errors.New("item not found")
+	// ErrConditionFailed is returned when a conditional write fails because the stored item has changed.
+	ErrConditionFailed = errors.New("condition failed")
+)
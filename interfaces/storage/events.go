@@ -0,0 +1,61 @@
+package storage
+
+import "context"
+
+// EventStore stores the events of each user.
+type EventStore interface {
+	// AddEvent overwrites the timestamps of the event with the current time.
+	AddEvent(context.Context, *AddEventReq) (*AddEventResp, error)
+	// GetEvent returns a nil Event, not an error, when the event does not exist.
+	GetEvent(context.Context, *GetEventReq) (*GetEventResp, error)
+	BatchGetEvent(context.Context, *BatchGetEventReq) (*BatchGetEventResp, error)
+	// GetAllEvents returns the user's events in event id order.
+	GetAllEvents(context.Context, *GetAllEventsReq) (*GetAllEventsResp, error)
+	UpdateEvent(context.Context, *UpdateEventReq) (*UpdateEventResp, error)
+	DeleteEvent(context.Context, *DeleteEventReq) (*DeleteEventResp, error)
+}
+
+type AddEventReq struct {
+	Event Event
+}
+type AddEventResp struct{}
+
+type GetEventReq struct {
+	UserID  string
+	EventID string
+}
+type GetEventResp struct {
+	Event *Event
+}
+
+type BatchGetEventReq struct{}
+type BatchGetEventResp struct{}
+
+type GetAllEventsReq struct {
+	UserID string
+	// Limit is the maximum number of events to return. All events are returned when it is 0.
+	Limit int32
+	// PageToken is the NextPageToken of a previous call.
+	PageToken string
+}
+type GetAllEventsResp struct {
+	Events []Event
+	// NextPageToken is empty when there are no more events to return.
+	NextPageToken string
+}
+
+type UpdateEventReq struct{}
+type UpdateEventResp struct{}
+
+type DeleteEventReq struct {
+	UserID  string
+	EventID string
+}
+type DeleteEventResp struct{}
+
+// NewEvent returns the event as it is stored when added at the given time, with its timestamps set to that time.
+func NewEvent(event Event, now int64) Event {
+	event.CreatedAt = now
+	event.UpdatedAt = now
+	return event
+}
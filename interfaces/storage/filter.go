@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Filter is a condition on tasks, built from comparisons of task attributes combined with And, Or and Not.
+// Backends evaluate as much of a filter as they can while reading tasks, and the rest with Match.
+type Filter interface {
+	// Match reports whether the task satisfies the filter.
+	Match(task *Task) bool
+}
+
+// Op is a comparison operator.
+type Op string
+
+const (
+	OpEq Op = "="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// And matches tasks matching every one of its filters; an empty And matches every task.
+type And []Filter
+
+// Or matches tasks matching any of its filters; an empty Or matches no task.
+type Or []Filter
+
+// Not matches tasks not matching its filter.
+type Not struct {
+	Filter Filter
+}
+
+// Compare matches tasks whose attribute compares to the value by the operator. The attribute is StatusKey,
+// PriorityKey or ProjectIDKey, compared with a string, or DueDateKey, CreatedAtKey, UpdatedAtKey, CompletedAtKey or
+// EffortMinutesKey, compared with an int64. Tasks never match by an optional attribute they have no value of.
+type Compare struct {
+	Key   string
+	Op    Op
+	Value any
+}
+
+// Contains matches tasks whose TagsKey attribute holds the value as a tag, or whose TitleKey or DescriptionKey
+// attribute holds the value, ignoring case.
+type Contains struct {
+	Key   string
+	Value string
+}
+
+// OptionalAttribute reports whether the numeric attribute is optional, in which case a zero value
+// means the task has no value of it.
+func OptionalAttribute(key string) bool {
+	return key == DueDateKey || key == CompletedAtKey || key == EffortMinutesKey
+}
+
+// Conjuncts returns the filters a task must match every one of to match f, flattening nested Ands,
+// so that backends can evaluate those they are able to and the rest with Match.
+func Conjuncts(f Filter) []Filter {
+	and, ok := f.(And)
+	if !ok {
+		return []Filter{f}
+	}
+	var conjuncts []Filter
+	for _, child := range and {
+		conjuncts = append(conjuncts, Conjuncts(child)...)
+	}
+	return conjuncts
+}
+
+func (f And) Match(task *Task) bool {
+	for _, child := range f {
+		if !child.Match(task) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Or) Match(task *Task) bool {
+	for _, child := range f {
+		if child.Match(task) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Not) Match(task *Task) bool {
+	return !f.Filter.Match(task)
+}
+
+func (f Compare) Match(task *Task) bool {
+	var c int
+	switch value := f.Value.(type) {
+	case string:
+		var attribute string
+		switch f.Key {
+		case StatusKey:
+			attribute = task.Status
+		case PriorityKey:
+			attribute = task.Priority
+			if attribute == "" {
+				attribute = UnspecifiedPriority
+			}
+		case ProjectIDKey:
+			attribute = task.ProjectID
+		default:
+			return false
+		}
+		c = cmp.Compare(attribute, value)
+	case int64:
+		var attribute int64
+		switch f.Key {
+		case DueDateKey:
+			attribute = task.DueDate
+		case CreatedAtKey:
+			attribute = task.CreatedAt
+		case UpdatedAtKey:
+			attribute = task.UpdatedAt
+		case CompletedAtKey:
+			attribute = task.CompletedAt
+		case EffortMinutesKey:
+			attribute = int64(task.EffortMinutes)
+		default:
+			return false
+		}
+		if attribute == 0 && OptionalAttribute(f.Key) {
+			return false
+		}
+		c = cmp.Compare(attribute, value)
+	default:
+		return false
+	}
+	switch f.Op {
+	case OpEq:
+		return c == 0
+	case OpLt:
+		return c < 0
+	case OpLe:
+		return c <= 0
+	case OpGt:
+		return c > 0
+	case OpGe:
+		return c >= 0
+	default:
+		return false
+	}
+}
+
+func (f Contains) Match(task *Task) bool {
+	switch f.Key {
+	case TagsKey:
+		return slices.Contains(task.Tags, f.Value)
+	case TitleKey:
+		return strings.Contains(strings.ToLower(task.Title), strings.ToLower(f.Value))
+	case DescriptionKey:
+		return strings.Contains(strings.ToLower(task.Description), strings.ToLower(f.Value))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Filter_Match(t *testing.T) {
+	task := &Task{
+		Title:         "Release notes",
+		Description:   "For the Café",
+		Status:        "INCOMPLETE",
+		Tags:          []string{"work"},
+		DueDate:       100,
+		Priority:      "P1",
+		EffortMinutes: 30,
+		ProjectID:     "project",
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{name: "equal", filter: Compare{Key: StatusKey, Op: OpEq, Value: "INCOMPLETE"}, want: true},
+		{name: "not equal", filter: Compare{Key: StatusKey, Op: OpEq, Value: "COMPLETE"}, want: false},
+		{name: "less than", filter: Compare{Key: DueDateKey, Op: OpLt, Value: int64(101)}, want: true},
+		{name: "greater than or equal", filter: Compare{Key: EffortMinutesKey, Op: OpGe, Value: int64(30)}, want: true},
+		{name: "priority order", filter: Compare{Key: PriorityKey, Op: OpGt, Value: "P0"}, want: true},
+		{name: "project", filter: Compare{Key: ProjectIDKey, Op: OpEq, Value: "project"}, want: true},
+		{name: "optional attribute without a value", filter: Compare{Key: CompletedAtKey, Op: OpLt, Value: int64(100)}, want: false},
+		{name: "attribute of the wrong type", filter: Compare{Key: StatusKey, Op: OpEq, Value: int64(0)}, want: false},
+		{name: "unknown attribute", filter: Compare{Key: "unknown", Op: OpEq, Value: "INCOMPLETE"}, want: false},
+		{name: "tag", filter: Contains{Key: TagsKey, Value: "work"}, want: true},
+		{name: "tags match exactly", filter: Contains{Key: TagsKey, Value: "Work"}, want: false},
+		{name: "text ignoring case", filter: Contains{Key: DescriptionKey, Value: "café"}, want: true},
+		{name: "and", filter: And{Contains{Key: TagsKey, Value: "work"}, Contains{Key: TitleKey, Value: "blog"}}, want: false},
+		{name: "or", filter: Or{Contains{Key: TagsKey, Value: "home"}, Contains{Key: TitleKey, Value: "NOTES"}}, want: true},
+		{name: "not", filter: Not{Filter: Contains{Key: TagsKey, Value: "someday"}}, want: true},
+		{name: "empty and", filter: And{}, want: true},
+		{name: "empty or", filter: Or{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(task); got != tt.want {
+				t.Errorf("Filter.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Compare_Match_noPriority(t *testing.T) {
+	// tasks stored before priorities existed have no priority, which matches as PRIORITY_UNSPECIFIED does
+	task := &Task{Status: "INCOMPLETE"}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{name: "unspecified", filter: Compare{Key: PriorityKey, Op: OpEq, Value: UnspecifiedPriority}, want: true},
+		{name: "before a priority", filter: Compare{Key: PriorityKey, Op: OpLt, Value: "P2"}, want: false},
+		{name: "after every priority", filter: Compare{Key: PriorityKey, Op: OpGt, Value: "P3"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(task); got != tt.want {
+				t.Errorf("Filter.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Conjuncts(t *testing.T) {
+	work := Contains{Key: TagsKey, Value: "work"}
+	notes := Contains{Key: TitleKey, Value: "notes"}
+	either := Or{work, notes}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []Filter
+	}{
+		{name: "single filter", filter: work, want: []Filter{work}},
+		{name: "nested ands are flattened", filter: And{work, And{notes, And{either}}}, want: []Filter{work, notes, either}},
+		{name: "empty and", filter: And{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Conjuncts(tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conjuncts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package storage
+
+import "context"
+
+// MemberStore stores the members of projects shared between users.
+type MemberStore interface {
+	// AddMember overwrites the timestamps of the member with the current time.
+	AddMember(context.Context, *AddMemberReq) (*AddMemberResp, error)
+	// GetMember returns a nil Member, not an error, when the user is not a member of the project.
+	GetMember(context.Context, *GetMemberReq) (*GetMemberResp, error)
+	// GetMemberships returns every membership of the user, accepted or not, in project id order.
+	GetMemberships(context.Context, *GetMembershipsReq) (*GetMembershipsResp, error)
+	// GetProjectMembers returns every member of the project, accepted or not, in user id order.
+	GetProjectMembers(context.Context, *GetProjectMembersReq) (*GetProjectMembersResp, error)
+	// UpdateMember returns ErrNotFound when the user is not a member of the project, and ErrConditionFailed
+	// when the member's stored role or accepted flag is not the expected one.
+	UpdateMember(context.Context, *UpdateMemberReq) (*UpdateMemberResp, error)
+	// DeleteMember deletes a member if it exists.
+	DeleteMember(context.Context, *DeleteMemberReq) (*DeleteMemberResp, error)
+}
+
+type AddMemberReq struct {
+	Member Member
+}
+type AddMemberResp struct{}
+
+type GetMemberReq struct {
+	UserID    string
+	ProjectID string
+}
+type GetMemberResp struct {
+	Member *Member
+}
+
+type GetMembershipsReq struct {
+	UserID string
+}
+type GetMembershipsResp struct {
+	Members []Member
+}
+
+type GetProjectMembersReq struct {
+	ProjectID string
+}
+type GetProjectMembersResp struct {
+	Members []Member
+}
+
+// UpdateMemberReq replaces the role and accepted flag of the member with those of the given member,
+// identified by its user id and project id, and sets its update time to the current time.
+type UpdateMemberReq struct {
+	Member Member
+	// ExpectedRole, when set, makes the update fail with ErrConditionFailed unless the member's stored role
+	// matches it and its stored accepted flag matches ExpectedAccepted, so that concurrent updates of
+	// the member cannot write back what the other changed.
+	ExpectedRole     string
+	ExpectedAccepted bool
+}
+type UpdateMemberResp struct {
+	Member Member
+}
+
+// Expects reports whether the stored member has the role and accepted flag the update expects.
+func (req *UpdateMemberReq) Expects(stored Member) bool {
+	return req.ExpectedRole == "" || (stored.Role == req.ExpectedRole && stored.Accepted == req.ExpectedAccepted)
+}
+
+type DeleteMemberReq struct {
+	UserID    string
+	ProjectID string
+}
+type DeleteMemberResp struct{}
+
+// NewMember returns the member as it is stored when added at the given time, with its timestamps set to that time.
+func NewMember(member Member, now int64) Member {
+	member.CreatedAt = now
+	member.UpdatedAt = now
+	return member
+}
+
+// UpdatedMember returns the stored member with the role and accepted flag of the update,
+// as it is stored when updated at the given time.
+func UpdatedMember(stored, update Member, now int64) Member {
+	stored.Role = update.Role
+	stored.Accepted = update.Accepted
+	stored.UpdatedAt = now
+	return stored
+}
@@ -0,0 +1,51 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+type MockAuditStore struct {
+	// Tables
+	AuditTable []storage.AuditEntry
+
+	// Audit entries
+	AddAuditEntryErr   error
+	GetAuditEntriesErr error
+}
+
+// assert that MockAuditStore implements AuditStore
+var _ storage.AuditStore = &MockAuditStore{}
+
+func (m *MockAuditStore) AddAuditEntry(ctx context.Context, req *storage.AddAuditEntryReq) (*storage.AddAuditEntryResp, error) {
+	if m.AddAuditEntryErr != nil {
+		return nil, m.AddAuditEntryErr
+	}
+	entry := req.Entry
+	entry.CreatedAt = time.Now().Unix()
+	m.AuditTable = append(m.AuditTable, entry)
+	return &storage.AddAuditEntryResp{}, nil
+}
+
+// GetAuditEntries returns the project's entries in descending entry id order, up to the limit.
+func (m *MockAuditStore) GetAuditEntries(ctx context.Context, req *storage.GetAuditEntriesReq) (*storage.GetAuditEntriesResp, error) {
+	if m.GetAuditEntriesErr != nil {
+		return nil, m.GetAuditEntriesErr
+	}
+	var entries []storage.AuditEntry
+	for _, entry := range m.AuditTable {
+		if entry.ProjectID == req.ProjectID {
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b storage.AuditEntry) int {
+		return strings.Compare(b.EntryID, a.EntryID)
+	})
+	if req.Limit > 0 && len(entries) > int(req.Limit) {
+		entries = entries[:req.Limit]
+	}
+	return &storage.GetAuditEntriesResp{Entries: entries}, nil
+}
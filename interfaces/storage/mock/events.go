@@ -0,0 +1,88 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"todo/interfaces/storage"
+)
+
+type MockEventStore struct {
+	// Tables
+	EventsTable map[string][]storage.Event
+
+	// Events
+	AddEventErr      error
+	GetEventErr      error
+	BatchGetEventErr error
+	GetAllEventsErr  error
+	UpdateEventErr   error
+	DeleteEventErr   error
+}
+
+// assert that MockEventStore implements EventStore
+var _ storage.EventStore = &MockEventStore{}
+
+func (m *MockEventStore) AddEvent(ctx context.Context, req *storage.AddEventReq) (*storage.AddEventResp, error) {
+	if m.AddEventErr != nil {
+		return nil, m.AddEventErr
+	}
+	if m.EventsTable == nil {
+		m.EventsTable = make(map[string][]storage.Event)
+	}
+	event := storage.NewEvent(req.Event, time.Now().Unix())
+	m.EventsTable[req.Event.UserID] = append(m.EventsTable[req.Event.UserID], event)
+	return &storage.AddEventResp{}, nil
+}
+
+func (m *MockEventStore) GetEvent(ctx context.Context, req *storage.GetEventReq) (*storage.GetEventResp, error) {
+	if m.GetEventErr != nil {
+		return nil, m.GetEventErr
+	}
+	for _, event := range m.EventsTable[req.UserID] {
+		if event.EventID == req.EventID {
+			return &storage.GetEventResp{Event: &event}, nil
+		}
+	}
+	return &storage.GetEventResp{}, nil
+}
+
+func (m *MockEventStore) BatchGetEvent(ctx context.Context, req *storage.BatchGetEventReq) (*storage.BatchGetEventResp, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetAllEvents returns the user's events in the order they were added. Page tokens are offsets into the events.
+func (m *MockEventStore) GetAllEvents(ctx context.Context, req *storage.GetAllEventsReq) (*storage.GetAllEventsResp, error) {
+	if m.GetAllEventsErr != nil {
+		return nil, m.GetAllEventsErr
+	}
+	events := m.EventsTable[req.UserID]
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 || offset > len(events) {
+			return nil, fmt.Errorf("invalid page token: %s", req.PageToken)
+		}
+	}
+	events = events[offset:]
+	nextPageToken := ""
+	if req.Limit > 0 && int(req.Limit) < len(events) {
+		events = events[:req.Limit]
+		nextPageToken = strconv.Itoa(offset + int(req.Limit))
+	}
+	return &storage.GetAllEventsResp{Events: events, NextPageToken: nextPageToken}, nil
+}
+
+func (m *MockEventStore) UpdateEvent(ctx context.Context, req *storage.UpdateEventReq) (*storage.UpdateEventResp, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockEventStore) DeleteEvent(ctx context.Context, req *storage.DeleteEventReq) (*storage.DeleteEventResp, error) {
+	if m.DeleteEventErr != nil {
+		return nil, m.DeleteEventErr
+	}
+	return &storage.DeleteEventResp{}, nil
+}
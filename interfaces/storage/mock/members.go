@@ -0,0 +1,115 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+type MockMemberStore struct {
+	// Tables
+	MembersTable []storage.Member
+
+	// Members
+	AddMemberErr         error
+	GetMemberErr         error
+	GetMembershipsErr    error
+	GetProjectMembersErr error
+	UpdateMemberErr      error
+	DeleteMemberErr      error
+}
+
+// assert that MockMemberStore implements MemberStore
+var _ storage.MemberStore = &MockMemberStore{}
+
+// indexOf returns the index of the user's membership of the project, or -1 if there is none.
+func (m *MockMemberStore) indexOf(userID, projectID string) int {
+	return slices.IndexFunc(m.MembersTable, func(member storage.Member) bool {
+		return member.UserID == userID && member.ProjectID == projectID
+	})
+}
+
+func (m *MockMemberStore) AddMember(ctx context.Context, req *storage.AddMemberReq) (*storage.AddMemberResp, error) {
+	if m.AddMemberErr != nil {
+		return nil, m.AddMemberErr
+	}
+	member := storage.NewMember(req.Member, time.Now().Unix())
+	if i := m.indexOf(member.UserID, member.ProjectID); i >= 0 {
+		m.MembersTable[i] = member
+	} else {
+		m.MembersTable = append(m.MembersTable, member)
+	}
+	return &storage.AddMemberResp{}, nil
+}
+
+func (m *MockMemberStore) GetMember(ctx context.Context, req *storage.GetMemberReq) (*storage.GetMemberResp, error) {
+	if m.GetMemberErr != nil {
+		return nil, m.GetMemberErr
+	}
+	if i := m.indexOf(req.UserID, req.ProjectID); i >= 0 {
+		member := m.MembersTable[i]
+		return &storage.GetMemberResp{Member: &member}, nil
+	}
+	return &storage.GetMemberResp{}, nil
+}
+
+// GetMemberships returns the user's memberships in project id order.
+func (m *MockMemberStore) GetMemberships(ctx context.Context, req *storage.GetMembershipsReq) (*storage.GetMembershipsResp, error) {
+	if m.GetMembershipsErr != nil {
+		return nil, m.GetMembershipsErr
+	}
+	var members []storage.Member
+	for _, member := range m.MembersTable {
+		if member.UserID == req.UserID {
+			members = append(members, member)
+		}
+	}
+	slices.SortFunc(members, func(a, b storage.Member) int {
+		return strings.Compare(a.ProjectID, b.ProjectID)
+	})
+	return &storage.GetMembershipsResp{Members: members}, nil
+}
+
+// GetProjectMembers returns the members of the project in user id order.
+func (m *MockMemberStore) GetProjectMembers(ctx context.Context, req *storage.GetProjectMembersReq) (*storage.GetProjectMembersResp, error) {
+	if m.GetProjectMembersErr != nil {
+		return nil, m.GetProjectMembersErr
+	}
+	var members []storage.Member
+	for _, member := range m.MembersTable {
+		if member.ProjectID == req.ProjectID {
+			members = append(members, member)
+		}
+	}
+	slices.SortFunc(members, func(a, b storage.Member) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return &storage.GetProjectMembersResp{Members: members}, nil
+}
+
+func (m *MockMemberStore) UpdateMember(ctx context.Context, req *storage.UpdateMemberReq) (*storage.UpdateMemberResp, error) {
+	if m.UpdateMemberErr != nil {
+		return nil, m.UpdateMemberErr
+	}
+	i := m.indexOf(req.Member.UserID, req.Member.ProjectID)
+	if i < 0 {
+		return nil, storage.ErrNotFound
+	}
+	if !req.Expects(m.MembersTable[i]) {
+		return nil, storage.ErrConditionFailed
+	}
+	m.MembersTable[i] = storage.UpdatedMember(m.MembersTable[i], req.Member, time.Now().Unix())
+	return &storage.UpdateMemberResp{Member: m.MembersTable[i]}, nil
+}
+
+func (m *MockMemberStore) DeleteMember(ctx context.Context, req *storage.DeleteMemberReq) (*storage.DeleteMemberResp, error) {
+	if m.DeleteMemberErr != nil {
+		return nil, m.DeleteMemberErr
+	}
+	if i := m.indexOf(req.UserID, req.ProjectID); i >= 0 {
+		m.MembersTable = slices.Delete(m.MembersTable, i, i+1)
+	}
+	return &storage.DeleteMemberResp{}, nil
+}
@@ -0,0 +1,84 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+type MockProjectStore struct {
+	// Tables
+	ProjectsTable map[string][]storage.Project
+
+	// Projects
+	AddProjectErr     error
+	GetProjectErr     error
+	GetAllProjectsErr error
+	UpdateProjectErr  error
+	DeleteProjectErr  error
+}
+
+// assert that MockProjectStore implements ProjectStore
+var _ storage.ProjectStore = &MockProjectStore{}
+
+func (m *MockProjectStore) AddProject(ctx context.Context, req *storage.AddProjectReq) (*storage.AddProjectResp, error) {
+	if m.AddProjectErr != nil {
+		return nil, m.AddProjectErr
+	}
+	if m.ProjectsTable == nil {
+		m.ProjectsTable = make(map[string][]storage.Project)
+	}
+	project := storage.NewProject(req.Project, time.Now().Unix())
+	m.ProjectsTable[project.UserID] = append(m.ProjectsTable[project.UserID], project)
+	return &storage.AddProjectResp{}, nil
+}
+
+func (m *MockProjectStore) GetProject(ctx context.Context, req *storage.GetProjectReq) (*storage.GetProjectResp, error) {
+	if m.GetProjectErr != nil {
+		return nil, m.GetProjectErr
+	}
+	for _, project := range m.ProjectsTable[req.UserID] {
+		if project.ProjectID == req.ProjectID {
+			return &storage.GetProjectResp{Project: &project}, nil
+		}
+	}
+	return &storage.GetProjectResp{}, nil
+}
+
+// GetAllProjects returns the user's projects in project id order.
+func (m *MockProjectStore) GetAllProjects(ctx context.Context, req *storage.GetAllProjectsReq) (*storage.GetAllProjectsResp, error) {
+	if m.GetAllProjectsErr != nil {
+		return nil, m.GetAllProjectsErr
+	}
+	projects := slices.Clone(m.ProjectsTable[req.UserID])
+	slices.SortFunc(projects, func(a, b storage.Project) int {
+		return strings.Compare(a.ProjectID, b.ProjectID)
+	})
+	return &storage.GetAllProjectsResp{Projects: projects}, nil
+}
+
+func (m *MockProjectStore) UpdateProject(ctx context.Context, req *storage.UpdateProjectReq) (*storage.UpdateProjectResp, error) {
+	if m.UpdateProjectErr != nil {
+		return nil, m.UpdateProjectErr
+	}
+	projects := m.ProjectsTable[req.Project.UserID]
+	for i := range projects {
+		if projects[i].ProjectID == req.Project.ProjectID {
+			projects[i] = storage.UpdatedProject(projects[i], req.Project, time.Now().Unix())
+			return &storage.UpdateProjectResp{Project: projects[i]}, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (m *MockProjectStore) DeleteProject(ctx context.Context, req *storage.DeleteProjectReq) (*storage.DeleteProjectResp, error) {
+	if m.DeleteProjectErr != nil {
+		return nil, m.DeleteProjectErr
+	}
+	m.ProjectsTable[req.UserID] = slices.DeleteFunc(m.ProjectsTable[req.UserID], func(project storage.Project) bool {
+		return project.ProjectID == req.ProjectID
+	})
+	return &storage.DeleteProjectResp{}, nil
+}
@@ -0,0 +1,285 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+	"todo/interfaces/storage"
+)
+
+type MockTaskStore struct {
+	// Tables
+	TasksTable map[string][]storage.Task
+
+	// Tasks
+	AddTaskErr          error
+	GetTaskErr          error
+	BatchGetTaskErr     error
+	GetAllTasksErr      error
+	ScanTasksErr        error
+	GetAssignedTasksErr error
+	UpdateTaskErr       error
+	DeleteTaskErr       error
+
+	// Checklists
+	AddChecklistItemErr     error
+	SetChecklistItemDoneErr error
+	RemoveChecklistItemErr  error
+	ReplaceChecklistErr     error
+}
+
+// assert that MockTaskStore implements TaskStore
+var _ storage.TaskStore = &MockTaskStore{}
+
+func (m *MockTaskStore) AddTask(ctx context.Context, req *storage.AddTaskReq) (*storage.AddTaskResp, error) {
+	if m.AddTaskErr != nil {
+		return nil, m.AddTaskErr
+	}
+	if m.TasksTable == nil {
+		m.TasksTable = make(map[string][]storage.Task)
+	}
+	task := storage.NewTask(req.Task, time.Now().Unix())
+	m.TasksTable[req.Task.UserID] = append(m.TasksTable[req.Task.UserID], task)
+	return &storage.AddTaskResp{}, nil
+}
+
+func (m *MockTaskStore) GetTask(ctx context.Context, req *storage.GetTaskReq) (*storage.GetTaskResp, error) {
+	if m.GetTaskErr != nil {
+		return nil, m.GetTaskErr
+	}
+	if m.TasksTable == nil {
+		return nil, errors.New("tasksTable does not exist")
+	}
+	tasks, ok := m.TasksTable[req.UserID]
+	if !ok {
+		return nil, fmt.Errorf("user id %s does not exist", req.UserID)
+	}
+	for _, task := range tasks {
+		if task.TaskID == req.TaskID {
+			return &storage.GetTaskResp{Task: &task}, nil
+		}
+	}
+	return &storage.GetTaskResp{}, nil
+}
+
+func (m *MockTaskStore) BatchGetTask(ctx context.Context, req *storage.BatchGetTaskReq) (*storage.BatchGetTaskResp, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTaskStore) GetAllTasks(ctx context.Context, req *storage.GetAllTasksReq) (*storage.GetAllTasksResp, error) {
+	if m.GetAllTasksErr != nil {
+		return nil, m.GetAllTasksErr
+	}
+	if m.TasksTable == nil {
+		return nil, errors.New("tasksTable does not exist")
+	}
+	tasks := []storage.Task{}
+	for _, task := range m.TasksTable[req.UserID] {
+		if len(req.Statuses) > 0 && !slices.Contains(req.Statuses, task.Status) {
+			continue
+		}
+		if len(req.Priorities) > 0 && !slices.Contains(req.Priorities, task.Priority) {
+			continue
+		}
+		if req.MaxEffortMinutes > 0 && (task.EffortMinutes == 0 || task.EffortMinutes > req.MaxEffortMinutes) {
+			continue
+		}
+		if req.Filter != nil && !req.Filter.Match(&task) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	// sort
+	var less func(a, b *storage.Task) bool
+	switch req.SortKey {
+	case "":
+	case storage.DueDateKey:
+		less = func(a, b *storage.Task) bool { return a.DueDate < b.DueDate }
+	case storage.CreatedAtKey:
+		less = func(a, b *storage.Task) bool { return a.CreatedAt < b.CreatedAt }
+	case storage.UpdatedAtKey:
+		less = func(a, b *storage.Task) bool { return a.UpdatedAt < b.UpdatedAt }
+	case storage.TitleKey:
+		less = func(a, b *storage.Task) bool { return a.Title < b.Title }
+	case storage.PriorityKey:
+		less = func(a, b *storage.Task) bool { return a.Priority < b.Priority }
+	default:
+		return nil, fmt.Errorf("unable to sort by %s", req.SortKey)
+	}
+	if less != nil {
+		sort.SliceStable(tasks, func(i, j int) bool { return less(&tasks[i], &tasks[j]) })
+	}
+	if req.Descending {
+		slices.Reverse(tasks)
+	}
+
+	// paginate; page tokens are offsets into the sorted tasks
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 || offset > len(tasks) {
+			return nil, fmt.Errorf("invalid page token: %s", req.PageToken)
+		}
+	}
+	tasks = tasks[offset:]
+	nextPageToken := ""
+	if req.Limit > 0 && int(req.Limit) < len(tasks) {
+		tasks = tasks[:req.Limit]
+		nextPageToken = strconv.Itoa(offset + int(req.Limit))
+	}
+	return &storage.GetAllTasksResp{Tasks: tasks, NextPageToken: nextPageToken}, nil
+}
+
+// ScanTasks returns every task at once, ignoring the limit.
+func (m *MockTaskStore) ScanTasks(ctx context.Context, req *storage.ScanTasksReq) (*storage.ScanTasksResp, error) {
+	if m.ScanTasksErr != nil {
+		return nil, m.ScanTasksErr
+	}
+	var tasks []storage.Task
+	for _, userTasks := range m.TasksTable {
+		tasks = append(tasks, userTasks...)
+	}
+	return &storage.ScanTasksResp{Tasks: tasks}, nil
+}
+
+func (m *MockTaskStore) GetAssignedTasks(ctx context.Context, req *storage.GetAssignedTasksReq) (*storage.GetAssignedTasksResp, error) {
+	if m.GetAssignedTasksErr != nil {
+		return nil, m.GetAssignedTasksErr
+	}
+	var tasks []storage.Task
+	for _, userTasks := range m.TasksTable {
+		for _, task := range userTasks {
+			if task.AssigneeID == req.AssigneeID {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return &storage.GetAssignedTasksResp{Tasks: tasks}, nil
+}
+
+func (m *MockTaskStore) UpdateTask(ctx context.Context, req *storage.UpdateTaskReq) (*storage.UpdateTaskResp, error) {
+	if m.UpdateTaskErr != nil {
+		return nil, m.UpdateTaskErr
+	}
+	tasks := m.TasksTable[req.UserID]
+	for i := range tasks {
+		task := &tasks[i]
+		if task.TaskID != req.TaskID {
+			continue
+		}
+		if req.ExpectedStatus != "" && task.Status != req.ExpectedStatus {
+			return nil, storage.ErrConditionFailed
+		}
+		if req.ExpectChecklist && !slices.Equal(task.Checklist, req.ExpectedChecklist) {
+			return nil, storage.ErrConditionFailed
+		}
+		now := time.Now().Unix()
+		updated := *task
+		if err := storage.ApplyKVPairs(&updated, req.KVPairs, now); err != nil {
+			return nil, err
+		}
+		updated.UpdatedAt = now
+		if req.ExpectedStatus != "" && updated.Status != task.Status {
+			updated.StatusHistory = append(slices.Clone(task.StatusHistory), storage.StatusChange{Status: updated.Status, ChangedAt: now})
+		}
+		*task = updated
+		return &storage.UpdateTaskResp{Task: *task}, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (m *MockTaskStore) DeleteTask(ctx context.Context, req *storage.DeleteTaskReq) (*storage.DeleteTaskResp, error) {
+	if m.DeleteTaskErr != nil {
+		return nil, m.DeleteTaskErr
+	}
+	return &storage.DeleteTaskResp{}, nil
+}
+
+// findTask returns a pointer to the stored task so it can be modified in place.
+func (m *MockTaskStore) findTask(userID, taskID string) (*storage.Task, error) {
+	tasks := m.TasksTable[userID]
+	for i := range tasks {
+		if tasks[i].TaskID == taskID {
+			return &tasks[i], nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+// checklistItemAt returns an error unless the item with the given id is at the given index.
+func checklistItemAt(task *storage.Task, index int, itemID string) error {
+	if index < 0 || index >= len(task.Checklist) || task.Checklist[index].ID != itemID {
+		return storage.ErrConditionFailed
+	}
+	return nil
+}
+
+func (m *MockTaskStore) AddChecklistItem(ctx context.Context, req *storage.AddChecklistItemReq) (*storage.AddChecklistItemResp, error) {
+	if m.AddChecklistItemErr != nil {
+		return nil, m.AddChecklistItemErr
+	}
+	task, err := m.findTask(req.UserID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	task.Checklist = append(task.Checklist, req.Item)
+	task.UpdatedAt = time.Now().Unix()
+	return &storage.AddChecklistItemResp{Task: *task}, nil
+}
+
+func (m *MockTaskStore) SetChecklistItemDone(ctx context.Context, req *storage.SetChecklistItemDoneReq) (*storage.SetChecklistItemDoneResp, error) {
+	if m.SetChecklistItemDoneErr != nil {
+		return nil, m.SetChecklistItemDoneErr
+	}
+	task, err := m.findTask(req.UserID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checklistItemAt(task, req.Index, req.ItemID); err != nil {
+		return nil, err
+	}
+	if task.Checklist[req.Index].Done == req.Done {
+		return nil, storage.ErrConditionFailed
+	}
+	task.Checklist[req.Index].Done = req.Done
+	task.UpdatedAt = time.Now().Unix()
+	return &storage.SetChecklistItemDoneResp{Task: *task}, nil
+}
+
+func (m *MockTaskStore) RemoveChecklistItem(ctx context.Context, req *storage.RemoveChecklistItemReq) (*storage.RemoveChecklistItemResp, error) {
+	if m.RemoveChecklistItemErr != nil {
+		return nil, m.RemoveChecklistItemErr
+	}
+	task, err := m.findTask(req.UserID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checklistItemAt(task, req.Index, req.ItemID); err != nil {
+		return nil, err
+	}
+	task.Checklist = slices.Delete(slices.Clone(task.Checklist), req.Index, req.Index+1)
+	task.UpdatedAt = time.Now().Unix()
+	return &storage.RemoveChecklistItemResp{Task: *task}, nil
+}
+
+func (m *MockTaskStore) ReplaceChecklist(ctx context.Context, req *storage.ReplaceChecklistReq) (*storage.ReplaceChecklistResp, error) {
+	if m.ReplaceChecklistErr != nil {
+		return nil, m.ReplaceChecklistErr
+	}
+	task, err := m.findTask(req.UserID, req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(task.Checklist, req.ExpectedChecklist) {
+		return nil, storage.ErrConditionFailed
+	}
+	task.Checklist = req.Checklist
+	task.UpdatedAt = time.Now().Unix()
+	return &storage.ReplaceChecklistResp{Task: *task}, nil
+}
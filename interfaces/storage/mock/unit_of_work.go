@@ -0,0 +1,49 @@
+package mock
+
+import (
+	"context"
+	"todo/interfaces/storage"
+)
+
+// MockUnitOfWork applies the tasks and events added to it to Tasks and Events when committed.
+// Only AddTask, UpdateTask and AddEvent are supported; the other writes are ignored.
+type MockUnitOfWork struct {
+	Tasks     *MockTaskStore
+	Events    *MockEventStore
+	CommitErr error
+
+	tasks   []storage.Task
+	updates []storage.UpdateTaskReq
+	events  []storage.Event
+}
+
+// assert that MockUnitOfWork implements UnitOfWork
+var _ storage.UnitOfWork = &MockUnitOfWork{}
+
+func (m *MockUnitOfWork) AddUser(user storage.User)            {}
+func (m *MockUnitOfWork) AddTask(task storage.Task)            { m.tasks = append(m.tasks, task) }
+func (m *MockUnitOfWork) UpdateTask(req storage.UpdateTaskReq) { m.updates = append(m.updates, req) }
+func (m *MockUnitOfWork) DeleteTask(req storage.DeleteTaskReq) {}
+func (m *MockUnitOfWork) AddEvent(event storage.Event)         { m.events = append(m.events, event) }
+
+func (m *MockUnitOfWork) Commit(ctx context.Context) error {
+	if m.CommitErr != nil {
+		return m.CommitErr
+	}
+	for _, task := range m.tasks {
+		if _, err := m.Tasks.AddTask(ctx, &storage.AddTaskReq{Task: task}); err != nil {
+			return err
+		}
+	}
+	for _, update := range m.updates {
+		if _, err := m.Tasks.UpdateTask(ctx, &update); err != nil {
+			return err
+		}
+	}
+	for _, event := range m.events {
+		if _, err := m.Events.AddEvent(ctx, &storage.AddEventReq{Event: event}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"todo/interfaces/storage"
+)
+
+type MockUserStore struct {
+	// Tables
+	UsersTable map[string]storage.User
+
+	// Users
+	AddUserErr    error
+	GetUserErr    error
+	UpdateUserErr error
+	DeleteUserErr error
+}
+
+// assert that MockUserStore implements UserStore
+var _ storage.UserStore = &MockUserStore{}
+
+func (m *MockUserStore) AddUser(ctx context.Context, req *storage.AddUserReq) (*storage.AddUserResp, error) {
+	if m.AddUserErr != nil {
+		return nil, m.AddUserErr
+	}
+	if m.UsersTable == nil {
+		m.UsersTable = make(map[string]storage.User)
+	}
+	m.UsersTable[req.User.ID] = req.User
+	return &storage.AddUserResp{}, nil
+}
+
+func (m *MockUserStore) GetUser(ctx context.Context, req *storage.GetUserReq) (*storage.GetUserResp, error) {
+	if m.GetUserErr != nil {
+		return nil, m.GetUserErr
+	}
+	if m.UsersTable == nil {
+		return nil, errors.New("UsersTable does not exist")
+	}
+	// like the real clients, a missing user is nil rather than an error
+	user, ok := m.UsersTable[req.ID]
+	if !ok {
+		return &storage.GetUserResp{}, nil
+	}
+	return &storage.GetUserResp{
+		User: &user,
+	}, nil
+}
+
+func (m *MockUserStore) UpdateUser(ctx context.Context, req *storage.UpdateUserReq) (*storage.UpdateUserResp, error) {
+	if m.UpdateUserErr != nil {
+		return nil, m.UpdateUserErr
+	}
+	user, ok := m.UsersTable[req.ID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	if req.FeedTokenHash != nil {
+		user.FeedTokenHash = *req.FeedTokenHash
+	}
+	m.UsersTable[req.ID] = user
+	return &storage.UpdateUserResp{}, nil
+}
+
+func (m *MockUserStore) DeleteUser(ctx context.Context, req *storage.DeleteUserReq) (*storage.DeleteUserResp, error) {
+	return nil, errors.New("not implemented")
+}
@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"todo/interfaces/storage"
+)
+
+type MockViewStore struct {
+	// Tables
+	ViewsTable map[string][]storage.View
+
+	// Views
+	SaveViewErr    error
+	GetViewErr     error
+	GetAllViewsErr error
+	DeleteViewErr  error
+}
+
+// assert that MockViewStore implements ViewStore
+var _ storage.ViewStore = &MockViewStore{}
+
+func (m *MockViewStore) SaveView(ctx context.Context, req *storage.SaveViewReq) (*storage.SaveViewResp, error) {
+	if m.SaveViewErr != nil {
+		return nil, m.SaveViewErr
+	}
+	if m.ViewsTable == nil {
+		m.ViewsTable = make(map[string][]storage.View)
+	}
+	views := slices.DeleteFunc(m.ViewsTable[req.View.UserID], func(view storage.View) bool {
+		return view.Name == req.View.Name
+	})
+	m.ViewsTable[req.View.UserID] = append(views, req.View)
+	return &storage.SaveViewResp{}, nil
+}
+
+func (m *MockViewStore) GetView(ctx context.Context, req *storage.GetViewReq) (*storage.GetViewResp, error) {
+	if m.GetViewErr != nil {
+		return nil, m.GetViewErr
+	}
+	for _, view := range m.ViewsTable[req.UserID] {
+		if view.Name == req.Name {
+			return &storage.GetViewResp{View: &view}, nil
+		}
+	}
+	return &storage.GetViewResp{}, nil
+}
+
+// GetAllViews returns the user's views in name order.
+func (m *MockViewStore) GetAllViews(ctx context.Context, req *storage.GetAllViewsReq) (*storage.GetAllViewsResp, error) {
+	if m.GetAllViewsErr != nil {
+		return nil, m.GetAllViewsErr
+	}
+	views := slices.Clone(m.ViewsTable[req.UserID])
+	slices.SortFunc(views, func(a, b storage.View) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return &storage.GetAllViewsResp{Views: views}, nil
+}
+
+func (m *MockViewStore) DeleteView(ctx context.Context, req *storage.DeleteViewReq) (*storage.DeleteViewResp, error) {
+	if m.DeleteViewErr != nil {
+		return nil, m.DeleteViewErr
+	}
+	m.ViewsTable[req.UserID] = slices.DeleteFunc(m.ViewsTable[req.UserID], func(view storage.View) bool {
+		return view.Name == req.Name
+	})
+	return &storage.DeleteViewResp{}, nil
+}
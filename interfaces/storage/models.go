@@ -0,0 +1,208 @@
+package storage
+
+// attribute names of stored tasks
+const (
+	UserIDKey        = "user_id"
+	TaskIDKey        = "task_id"
+	TitleKey         = "title"
+	DescriptionKey   = "description"
+	StatusKey        = "status"
+	TagsKey          = "tags"
+	ParentsKey       = "parents"
+	DueDateKey       = "due_date"
+	RecurringRuleKey = "recurring_rule"
+	CreatedAtKey     = "created_at"
+	UpdatedAtKey     = "updated_at"
+	CompletedAtKey   = "completed_at"
+	PriorityKey      = "priority"
+	EffortMinutesKey = "effort_minutes"
+	StatusHistoryKey = "status_history"
+	ChecklistKey     = "checklist"
+	ProjectIDKey     = "project_id"
+	AssigneeIDKey    = "assignee_id"
+
+	RequireChecklistCompleteKey = "require_checklist_complete"
+)
+
+// attribute names of stored events that are not shared with tasks
+const (
+	EventIDKey   = "event_id"
+	LocationKey  = "location"
+	StartTimeKey = "start_time"
+	EndTimeKey   = "end_time"
+	AllDayKey    = "all_day"
+)
+
+// attribute names of stored views that are not shared with tasks
+const (
+	NameKey       = "name"
+	QueryKey      = "query"
+	SortByKey     = "sort_by"
+	DescendingKey = "descending"
+)
+
+// attribute names of stored projects that are not shared with tasks or views
+const (
+	ColorKey    = "color"
+	ArchivedKey = "archived"
+)
+
+// attribute names of stored members that are not shared with tasks or projects
+const (
+	RoleKey     = "role"
+	AcceptedKey = "accepted"
+)
+
+// attribute names of stored users that are updated on their own
+const (
+	FeedTokenHashKey = "feed_token_hash"
+)
+
+// CompleteStatus is the stored status that marks a task as complete.
+const CompleteStatus = "COMPLETE"
+
+// UnspecifiedPriority is the stored priority of tasks without one, which sorts after every other priority.
+// Tasks stored without a priority attribute have it too.
+const UnspecifiedPriority = "PRIORITY_UNSPECIFIED"
+
+// The json tags of the models name their stored attributes in every backend.
+
+type User struct {
+	ID             string `json:"id"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Email          string `json:"email"`
+	HashedPassword string `json:"hashed_password"`
+	// FeedTokenHash is the SHA-256 hash of the secret of the user's calendar feed token,
+	// or empty if the user has no feed.
+	FeedTokenHash string `json:"feed_token_hash"`
+}
+
+type RecurringRule struct {
+	CronExpression string `json:"cron_expression"`
+	StartDate      int64  `json:"start_date"`
+	EndDate        int64  `json:"end_date"`
+}
+
+// StatusChange records a task entering a status.
+type StatusChange struct {
+	Status    string `json:"status"`
+	ChangedAt int64  `json:"changed_at"`
+}
+
+// ChecklistItem is a small step of a task.
+type ChecklistItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+type Task struct {
+	UserID        string         `json:"user_id"`
+	TaskID        string         `json:"task_id"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	Status        string         `json:"status"`
+	Tags          []string       `json:"tags"`
+	Parents       []string       `json:"parents"`
+	DueDate       int64          `json:"due_date"`
+	RecurringRule *RecurringRule `json:"recurring_rule"`
+	// CreatedAt, UpdatedAt and CompletedAt are unix timestamps managed by the store.
+	// CompletedAt is only present while the task's status is COMPLETE.
+	CreatedAt   int64 `json:"created_at"`
+	UpdatedAt   int64 `json:"updated_at"`
+	CompletedAt int64 `json:"completed_at,omitempty"`
+	// Priority is the name of the task's priority, which sorts from P0 to P3 followed by PRIORITY_UNSPECIFIED.
+	Priority      string `json:"priority"`
+	EffortMinutes uint32 `json:"effort_minutes"`
+	// StatusHistory is managed by the store and holds every status the task has entered, oldest first.
+	StatusHistory []StatusChange `json:"status_history"`
+	// Checklist is omitted when empty rather than stored as an empty list.
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+	// RequireChecklistComplete prevents the task from being completed until every checklist item is done.
+	RequireChecklistComplete bool `json:"require_checklist_complete"`
+	// ProjectID is the id of the project of the user the task belongs to, or empty if it belongs to none.
+	// Tasks of a project shared with other users are kept with the tasks of the user owning the project.
+	ProjectID string `json:"project_id"`
+	// AssigneeID is the id of the user the task is assigned to, or empty if it is assigned to no one. It is
+	// omitted when empty so that only assigned tasks are kept in the index of tasks by assignee.
+	AssigneeID string `json:"assignee_id,omitempty"`
+}
+
+// Event is something happening at a time, such as a meeting, kept alongside the tasks of a user.
+type Event struct {
+	UserID      string `json:"user_id"`
+	EventID     string `json:"event_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	// StartTime and EndTime are unix timestamps. EndTime is never before StartTime.
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+	// AllDay events span the UTC dates from StartTime up to, but not including, EndTime.
+	AllDay        bool           `json:"all_day"`
+	RecurringRule *RecurringRule `json:"recurring_rule"`
+	// CreatedAt and UpdatedAt are unix timestamps managed by the store.
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// View is a named query of a user's tasks, saved so that it can be run again.
+type View struct {
+	UserID string `json:"user_id"`
+	// Name identifies the view among the views of the user.
+	Name string `json:"name"`
+	// Query is written in the query language of the query package.
+	Query string `json:"query"`
+	// SortBy is the name of the proto sort option the tasks of the view are sorted by.
+	SortBy     string `json:"sort_by"`
+	Descending bool   `json:"descending"`
+}
+
+// Project groups tasks of a user, such as to keep work apart from personal tasks.
+type Project struct {
+	UserID      string `json:"user_id"`
+	ProjectID   string `json:"project_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Color is a hex color such as #1e90ff, or empty for no color.
+	Color string `json:"color"`
+	// Archived projects are hidden from listings and cannot receive tasks.
+	Archived bool `json:"archived"`
+	// CreatedAt and UpdatedAt are unix timestamps managed by the store.
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// Member gives a user a role in a project another user shares with them. The member has no access to the
+// project until they accept the invitation.
+type Member struct {
+	UserID    string `json:"user_id"`
+	ProjectID string `json:"project_id"`
+	// OwnerID is the id of the user the project and its tasks are stored with.
+	OwnerID string `json:"owner_id"`
+	// Role is the name of the proto role of the member, such as EDITOR.
+	Role string `json:"role"`
+	// InvitedBy is the id of the user who invited the member.
+	InvitedBy string `json:"invited_by"`
+	Accepted  bool   `json:"accepted"`
+	// CreatedAt and UpdatedAt are unix timestamps managed by the store.
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// AuditEntry records a change a user made to a project or to one of its tasks.
+type AuditEntry struct {
+	ProjectID string `json:"project_id"`
+	// EntryID identifies the entry among the entries of the project, sorting entries from oldest to newest.
+	EntryID string `json:"entry_id"`
+	// UserID is the id of the user who made the change.
+	UserID string `json:"user_id"`
+	// Action names the change, such as task.updated.
+	Action string `json:"action"`
+	// TaskID is the id of the changed task, or empty if the change was not to a task.
+	TaskID  string `json:"task_id"`
+	Details string `json:"details"`
+	// CreatedAt is a unix timestamp managed by the store.
+	CreatedAt int64 `json:"created_at"`
+}
@@ -0,0 +1,70 @@
+package storage
+
+import "context"
+
+// ProjectStore stores the projects of each user.
+type ProjectStore interface {
+	// AddProject overwrites the timestamps of the project with the current time.
+	AddProject(context.Context, *AddProjectReq) (*AddProjectResp, error)
+	// GetProject returns a nil Project, not an error, when the project does not exist.
+	GetProject(context.Context, *GetProjectReq) (*GetProjectResp, error)
+	// GetAllProjects returns every project of the user in project id order.
+	GetAllProjects(context.Context, *GetAllProjectsReq) (*GetAllProjectsResp, error)
+	// UpdateProject returns ErrNotFound when the project does not exist.
+	UpdateProject(context.Context, *UpdateProjectReq) (*UpdateProjectResp, error)
+	// DeleteProject deletes a project if it exists, leaving its tasks as they are.
+	DeleteProject(context.Context, *DeleteProjectReq) (*DeleteProjectResp, error)
+}
+
+type AddProjectReq struct {
+	Project Project
+}
+type AddProjectResp struct{}
+
+type GetProjectReq struct {
+	UserID    string
+	ProjectID string
+}
+type GetProjectResp struct {
+	Project *Project
+}
+
+type GetAllProjectsReq struct {
+	UserID string
+}
+type GetAllProjectsResp struct {
+	Projects []Project
+}
+
+// UpdateProjectReq replaces the name, description, color and archived flag of the project with those of
+// the given project, identified by its user id and project id, and sets its update time to the current time.
+type UpdateProjectReq struct {
+	Project Project
+}
+type UpdateProjectResp struct {
+	Project Project
+}
+
+type DeleteProjectReq struct {
+	UserID    string
+	ProjectID string
+}
+type DeleteProjectResp struct{}
+
+// NewProject returns the project as it is stored when added at the given time, with its timestamps set to that time.
+func NewProject(project Project, now int64) Project {
+	project.CreatedAt = now
+	project.UpdatedAt = now
+	return project
+}
+
+// UpdatedProject returns the stored project with the name, description, color and archived flag of the update,
+// as it is stored when updated at the given time.
+func UpdatedProject(stored, update Project, now int64) Project {
+	stored.Name = update.Name
+	stored.Description = update.Description
+	stored.Color = update.Color
+	stored.Archived = update.Archived
+	stored.UpdatedAt = now
+	return stored
+}
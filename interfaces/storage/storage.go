@@ -0,0 +1,48 @@
+// Package storage defines the stores the server keeps its data in, independently of any database.
+// Each backend, such as DynamoDB or SQLite, implements every store along with units of work spanning them.
+package storage
+
+import "context"
+
+// Backend is a storage backend providing every store.
+type Backend interface {
+	UserStore
+	TaskStore
+	EventStore
+	ViewStore
+	ProjectStore
+	MemberStore
+	AuditStore
+	Pinger
+
+	// NewUnitOfWork starts a unit of work whose writes are committed together.
+	NewUnitOfWork() UnitOfWork
+	// Close releases the connections of the backend, which must not be used afterwards.
+	Close() error
+}
+
+// Pinger checks that a backend is ready to serve requests.
+type Pinger interface {
+	// Ping returns an error unless the backend can be reached and every table it stores data in exists.
+	Ping(ctx context.Context) error
+}
+
+// UnitOfWork collects writes to one or more stores and commits them atomically:
+// either every write is applied or none are. Nothing is written until Commit is called,
+// and a unit of work must not be used after it is committed. Each user, task or event may be
+// written at most once per unit of work, since DynamoDB transactions cannot write an item twice.
+type UnitOfWork interface {
+	// AddUser puts a user exactly as given, as UserStore.AddUser does.
+	AddUser(user User)
+	// AddTask puts a task, setting its timestamps and status history as TaskStore.AddTask does.
+	AddTask(task Task)
+	// UpdateTask updates a task as TaskStore.UpdateTask does, failing the commit with ErrNotFound
+	// if the task does not exist or ErrConditionFailed if its status is not the expected status.
+	UpdateTask(req UpdateTaskReq)
+	// DeleteTask deletes a task if it exists.
+	DeleteTask(req DeleteTaskReq)
+	// AddEvent puts an event, setting its timestamps as EventStore.AddEvent does.
+	AddEvent(event Event)
+	// Commit applies every write, returning an error and applying none of them if any write fails.
+	Commit(ctx context.Context) error
+}
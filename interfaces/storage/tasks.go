@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TaskStore stores the tasks of each user.
+type TaskStore interface {
+	// AddTask overwrites the timestamps of the task with the current time and starts its status history.
+	AddTask(context.Context, *AddTaskReq) (*AddTaskResp, error)
+	// GetTask returns a nil Task, not an error, when the task does not exist.
+	GetTask(context.Context, *GetTaskReq) (*GetTaskResp, error)
+	BatchGetTask(context.Context, *BatchGetTaskReq) (*BatchGetTaskResp, error)
+	GetAllTasks(context.Context, *GetAllTasksReq) (*GetAllTasksResp, error)
+	// ScanTasks returns the tasks of every user, a page at a time, in no particular order.
+	ScanTasks(context.Context, *ScanTasksReq) (*ScanTasksResp, error)
+	// GetAssignedTasks returns the tasks of every user that are assigned to the assignee, in no particular order.
+	GetAssignedTasks(context.Context, *GetAssignedTasksReq) (*GetAssignedTasksResp, error)
+	// UpdateTask returns ErrNotFound when the task does not exist.
+	UpdateTask(context.Context, *UpdateTaskReq) (*UpdateTaskResp, error)
+	DeleteTask(context.Context, *DeleteTaskReq) (*DeleteTaskResp, error)
+
+	// Checklists
+	AddChecklistItem(context.Context, *AddChecklistItemReq) (*AddChecklistItemResp, error)
+	SetChecklistItemDone(context.Context, *SetChecklistItemDoneReq) (*SetChecklistItemDoneResp, error)
+	RemoveChecklistItem(context.Context, *RemoveChecklistItemReq) (*RemoveChecklistItemResp, error)
+	ReplaceChecklist(context.Context, *ReplaceChecklistReq) (*ReplaceChecklistResp, error)
+}
+
+type AddTaskReq struct {
+	Task Task
+}
+type AddTaskResp struct{}
+
+type GetTaskReq struct {
+	UserID string
+	TaskID string
+}
+type GetTaskResp struct {
+	Task *Task
+}
+
+type BatchGetTaskReq struct{}
+type BatchGetTaskResp struct{}
+
+type GetAllTasksReq struct {
+	UserID   string
+	Statuses []string
+	// SortKey is the task attribute to sort by. Tasks are returned in task id order when it is empty.
+	// Backends may leave out tasks missing the attribute.
+	SortKey    string
+	Descending bool
+	// Limit is the maximum number of tasks to return. All tasks are returned when it is 0.
+	Limit int32
+	// PageToken is the NextPageToken of a previous call with the same sort options and filters.
+	PageToken string
+	// Priorities limits the tasks returned to those with one of the given priorities.
+	Priorities []string
+	// MaxEffortMinutes limits the tasks returned to those with a non-zero effort estimate no greater than it.
+	// No limit is applied when it is 0.
+	MaxEffortMinutes uint32
+	// Filter, when set, limits the tasks returned to those matching it.
+	Filter Filter
+}
+type GetAllTasksResp struct {
+	Tasks []Task
+	// NextPageToken is empty when there are no more tasks to return.
+	NextPageToken string
+}
+
+type ScanTasksReq struct {
+	// Limit is the maximum number of tasks to return. All tasks are returned when it is 0.
+	// Pages may hold fewer tasks than the limit even when there are more to return.
+	Limit int32
+	// PageToken is the NextPageToken of a previous call.
+	PageToken string
+}
+type ScanTasksResp struct {
+	Tasks []Task
+	// NextPageToken is empty when there are no more tasks to return.
+	NextPageToken string
+}
+
+type GetAssignedTasksReq struct {
+	AssigneeID string
+}
+type GetAssignedTasksResp struct {
+	Tasks []Task
+}
+
+type UpdateTaskReq struct {
+	UserID  string
+	TaskID  string
+	KVPairs map[string]interface{}
+	// ExpectedStatus, when set, makes the update fail with ErrConditionFailed unless the task's
+	// stored status matches it. A change of status is only recorded in the task's status history
+	// when ExpectedStatus is set.
+	ExpectedStatus string
+	// ExpectChecklist makes the update fail with ErrConditionFailed unless the task's stored checklist
+	// equals ExpectedChecklist, such as when completing a task that requires its checklist to be complete.
+	ExpectChecklist   bool
+	ExpectedChecklist []ChecklistItem
+}
+type UpdateTaskResp struct {
+	Task Task
+}
+
+type DeleteTaskReq struct {
+	UserID string
+	TaskID string
+}
+type DeleteTaskResp struct{}
+
+type AddChecklistItemReq struct {
+	UserID string
+	TaskID string
+	Item   ChecklistItem
+}
+type AddChecklistItemResp struct {
+	Task Task
+}
+
+type SetChecklistItemDoneReq struct {
+	UserID string
+	TaskID string
+	// Index and ItemID identify the item, which must still be at Index for the update to succeed.
+	Index  int
+	ItemID string
+	Done   bool
+}
+type SetChecklistItemDoneResp struct {
+	Task Task
+}
+
+type RemoveChecklistItemReq struct {
+	UserID string
+	TaskID string
+	// Index and ItemID identify the item, which must still be at Index for it to be removed.
+	Index  int
+	ItemID string
+}
+type RemoveChecklistItemResp struct {
+	Task Task
+}
+
+type ReplaceChecklistReq struct {
+	UserID    string
+	TaskID    string
+	Checklist []ChecklistItem
+	// ExpectedChecklist must equal the stored checklist for it to be replaced.
+	ExpectedChecklist []ChecklistItem
+}
+type ReplaceChecklistResp struct {
+	Task Task
+}
+
+// NewTask returns the task as it is stored when added at the given time: with its timestamps set
+// to that time and its status history started with its status.
+func NewTask(task Task, now int64) Task {
+	task.CreatedAt = now
+	task.UpdatedAt = now
+	task.CompletedAt = 0
+	if task.Status == CompleteStatus {
+		task.CompletedAt = now
+	}
+	task.StatusHistory = []StatusChange{{Status: task.Status, ChangedAt: now}}
+	return task
+}
+
+// NormalizeTag returns the form tags are written in: lower case, with runs of white space collapsed into
+// single spaces and none at either end, so that variants such as "Work" and "work " are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// NormalizeTags normalizes each tag, leaving out tags that are blank or repeat an earlier tag.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// ApplyKVPairs validates the given attributes and sets each of them on the task, following the
+// rules of UpdateTask that every backend shares. Setting the status to COMPLETE sets completed_at
+// unless the task was already complete, and setting any other status clears it. The task may be
+// partly updated when an error is returned.
+func ApplyKVPairs(task *Task, kvPairs map[string]interface{}, now int64) error {
+	for name, value := range kvPairs {
+		var ok bool
+		switch name {
+		case TitleKey:
+			task.Title, ok = value.(string)
+		case DescriptionKey:
+			task.Description, ok = value.(string)
+		case PriorityKey:
+			task.Priority, ok = value.(string)
+		case ProjectIDKey:
+			task.ProjectID, ok = value.(string)
+		case AssigneeIDKey:
+			task.AssigneeID, ok = value.(string)
+		case StatusKey:
+			if task.Status, ok = value.(string); ok {
+				if task.Status != CompleteStatus {
+					task.CompletedAt = 0
+				} else if task.CompletedAt == 0 {
+					task.CompletedAt = now
+				}
+			}
+		case DueDateKey:
+			task.DueDate, ok = value.(int64)
+		case EffortMinutesKey:
+			task.EffortMinutes, ok = value.(uint32)
+		case RequireChecklistCompleteKey:
+			task.RequireChecklistComplete, ok = value.(bool)
+		case TagsKey:
+			task.Tags, ok = value.([]string)
+		case ParentsKey:
+			task.Parents, ok = value.([]string)
+		case RecurringRuleKey:
+			if value == nil {
+				continue
+			}
+			task.RecurringRule, ok = value.(*RecurringRule)
+		case UserIDKey, TaskIDKey, CreatedAtKey, UpdatedAtKey,
+			CompletedAtKey, StatusHistoryKey, ChecklistKey:
+			return fmt.Errorf("not allowed to update %s", name)
+		default:
+			return fmt.Errorf("unknown task attribute: %s", name)
+		}
+		if !ok {
+			return fmt.Errorf("the value type of %s is invalid: %T", name, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_ApplyKVPairs(t *testing.T) {
+	tests := []struct {
+		name            string
+		task            Task
+		kvPairs         map[string]interface{}
+		wantCompletedAt int64
+		wantErr         bool
+	}{
+		{
+			name:    "happy path",
+			task:    Task{Status: "INCOMPLETE"},
+			kvPairs: map[string]interface{}{TitleKey: "title", EffortMinutesKey: uint32(30), RecurringRuleKey: nil},
+			wantErr: false,
+		},
+		{
+			name:            "complete task",
+			task:            Task{Status: "INCOMPLETE"},
+			kvPairs:         map[string]interface{}{StatusKey: CompleteStatus},
+			wantCompletedAt: 100,
+			wantErr:         false,
+		},
+		{
+			name:            "already complete task keeps completed at",
+			task:            Task{Status: CompleteStatus, CompletedAt: 50},
+			kvPairs:         map[string]interface{}{StatusKey: CompleteStatus},
+			wantCompletedAt: 50,
+			wantErr:         false,
+		},
+		{
+			name:    "reopen task",
+			task:    Task{Status: CompleteStatus, CompletedAt: 50},
+			kvPairs: map[string]interface{}{StatusKey: "INCOMPLETE"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid value type",
+			kvPairs: map[string]interface{}{DueDateKey: 1},
+			wantErr: true,
+		},
+		{
+			name:    "managed attribute",
+			kvPairs: map[string]interface{}{StatusHistoryKey: []StatusChange{}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown attribute",
+			kvPairs: map[string]interface{}{"unknown": "value"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyKVPairs(&tt.task, tt.kvPairs, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApplyKVPairs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.task.CompletedAt != tt.wantCompletedAt {
+				t.Errorf("ApplyKVPairs() completed at = %d, want %d", tt.task.CompletedAt, tt.wantCompletedAt)
+			}
+		})
+	}
+}
+
+func Test_NormalizeTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{name: "case and white space", tags: []string{"Work", " home  office\t"}, want: []string{"work", "home office"}},
+		{name: "variants are left out", tags: []string{"work", "Work", "work "}, want: []string{"work"}},
+		{name: "blank tags are left out", tags: []string{" ", "", "later"}, want: []string{"later"}},
+		{name: "no tags", tags: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTags(tt.tags); !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
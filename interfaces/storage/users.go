@@ -0,0 +1,36 @@
+package storage
+
+import "context"
+
+// UserStore stores the users of the service.
+type UserStore interface {
+	AddUser(context.Context, *AddUserReq) (*AddUserResp, error)
+	// GetUser returns a nil User, not an error, when the user does not exist.
+	GetUser(context.Context, *GetUserReq) (*GetUserResp, error)
+	// UpdateUser returns ErrNotFound when the user does not exist.
+	UpdateUser(context.Context, *UpdateUserReq) (*UpdateUserResp, error)
+	DeleteUser(context.Context, *DeleteUserReq) (*DeleteUserResp, error)
+}
+
+type AddUserReq struct {
+	User User
+}
+type AddUserResp struct{}
+
+type GetUserReq struct {
+	ID string
+}
+type GetUserResp struct {
+	User *User
+}
+
+// UpdateUserReq replaces the fields of the user that are not nil.
+type UpdateUserReq struct {
+	ID string
+	// FeedTokenHash replaces the hash of the user's feed token; an empty hash disables the feed.
+	FeedTokenHash *string
+}
+type UpdateUserResp struct{}
+
+type DeleteUserReq struct{}
+type DeleteUserResp struct{}
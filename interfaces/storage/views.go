@@ -0,0 +1,41 @@
+package storage
+
+import "context"
+
+// ViewStore stores the saved views of each user.
+type ViewStore interface {
+	// SaveView puts a view, replacing any view of the user with the same name.
+	SaveView(context.Context, *SaveViewReq) (*SaveViewResp, error)
+	// GetView returns a nil View, not an error, when the view does not exist.
+	GetView(context.Context, *GetViewReq) (*GetViewResp, error)
+	// GetAllViews returns every view of the user in name order.
+	GetAllViews(context.Context, *GetAllViewsReq) (*GetAllViewsResp, error)
+	// DeleteView deletes a view if it exists.
+	DeleteView(context.Context, *DeleteViewReq) (*DeleteViewResp, error)
+}
+
+type SaveViewReq struct {
+	View View
+}
+type SaveViewResp struct{}
+
+type GetViewReq struct {
+	UserID string
+	Name   string
+}
+type GetViewResp struct {
+	View *View
+}
+
+type GetAllViewsReq struct {
+	UserID string
+}
+type GetAllViewsResp struct {
+	Views []View
+}
+
+type DeleteViewReq struct {
+	UserID string
+	Name   string
+}
+type DeleteViewResp struct{}
@@ -9,8 +9,7 @@ import (
 )
 
 var (
-	JWT_SIGNING_METHOD  = jwt.SigningMethodHS256
-	JWT_EXPIRATION_TIME = time.Duration(time.Minute * 5)
+	JWT_SIGNING_METHOD = jwt.SigningMethodHS256
 )
 
 // assert that JWT_SIGNING_METHOD is of type SigningMethodHMAC
@@ -19,20 +18,25 @@ var _ jwt.SigningMethodHMAC = *JWT_SIGNING_METHOD
 // TokenManager handles issuing and verifying JWTs.
 type TokenManager struct {
 	Secret []byte
+	// Lifetime is how long issued tokens are valid for.
+	Lifetime time.Duration
 }
 
 // assert that TokenManager implements TokenManagerInterface
 var _ TokenManagerInterface = &TokenManager{}
 
-// NewTokenManager accepts a non-empty secret and returns a new instance of TokenManager.
-func NewTokenManager(secret string) (*TokenManager, error) {
+// NewTokenManager accepts a non-empty secret and a positive token lifetime and returns a new instance of TokenManager.
+func NewTokenManager(secret string, lifetime time.Duration) (*TokenManager, error) {
 	if secret == "" {
 		return nil, errors.New("secret cannot be empty")
 	}
-	return &TokenManager{Secret: []byte(secret)}, nil
+	if lifetime <= 0 {
+		return nil, errors.New("lifetime must be positive")
+	}
+	return &TokenManager{Secret: []byte(secret), Lifetime: lifetime}, nil
 }
 
-// IssueToken creates a new jwt with the user ID as the subject that expires in 5 minutes.
+// IssueToken creates a new jwt with the user ID as the subject that expires after the token manager lifetime.
 // It signs the token with the token manager secret and returns it.
 func (tm *TokenManager) IssueToken(userID string) (string, error) {
 	if userID == "" {
@@ -41,7 +45,7 @@ func (tm *TokenManager) IssueToken(userID string) (string, error) {
 
 	token := jwt.NewWithClaims(JWT_SIGNING_METHOD, jwt.MapClaims{
 		"sub": userID,
-		"exp": time.Now().Add(JWT_EXPIRATION_TIME).Unix(),
+		"exp": time.Now().Add(tm.Lifetime).Unix(),
 	})
 
 	signed, err := token.SignedString(tm.Secret)
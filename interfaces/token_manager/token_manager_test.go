@@ -3,10 +3,32 @@ package token_manager
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+func Test_NewTokenManager(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		lifetime time.Duration
+		wantErr  bool
+	}{
+		{name: "happy path", secret: "secret", lifetime: time.Minute, wantErr: false},
+		{name: "empty secret", secret: "", lifetime: time.Minute, wantErr: true},
+		{name: "non-positive lifetime", secret: "secret", lifetime: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokenManager(tt.secret, tt.lifetime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTokenManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_TokenManager_IssueToken(t *testing.T) {
 	type fields struct {
 		secret []byte
@@ -44,7 +66,8 @@ func Test_TokenManager_IssueToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tm := &TokenManager{
-				Secret: tt.fields.secret,
+				Secret:   tt.fields.secret,
+				Lifetime: time.Minute,
 			}
 			_, err := tm.IssueToken(tt.args.userID)
 			if (err != nil) != tt.wantErr {
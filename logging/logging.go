@@ -0,0 +1,82 @@
+// Package logging provides the server's structured logger and the request-scoped loggers carried by contexts.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// redactedKeys are the attribute keys whose values are never written, compared case-insensitively.
+var redactedKeys = map[string]bool{
+	"password":        true,
+	"hashed_password": true,
+	"token":           true,
+	"jwt":             true,
+	"access_jwt":      true,
+	"authorization":   true,
+	"jwt_secret":      true,
+}
+
+// redactedValue replaces the values of redacted attributes.
+const redactedValue = "[REDACTED]"
+
+// redact replaces the values of attributes with sensitive keys, such as passwords and tokens.
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if redactedKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redactedValue)
+	}
+	return attr
+}
+
+// New returns a logger writing records of at least the given level to w as JSON, or as text
+// if format is "text", with sensitive attributes redacted.
+func New(w io.Writer, format string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+	if format == "text" {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+type contextKey struct{}
+
+// requestLogger holds the logger of a request, which gains attributes as the request is handled.
+type requestLogger struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+// NewContext returns a context carrying the given logger for the rest of a request.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestLogger{logger: logger})
+}
+
+// FromContext returns the logger of the request the context belongs to, or the default logger if it has none.
+func FromContext(ctx context.Context) *slog.Logger {
+	rl, ok := ctx.Value(contextKey{}).(*requestLogger)
+	if !ok {
+		return slog.Default()
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.logger
+}
+
+// With adds attributes to the logger of the request the context belongs to, so that they are included
+// in every later log of the request, including those of callers up the stack. It does nothing
+// if the context has no logger.
+func With(ctx context.Context, args ...any) {
+	rl, ok := ctx.Value(contextKey{}).(*requestLogger)
+	if !ok {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.logger = rl.logger.With(args...)
+}
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func Test_New_redacts(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []any
+		key   string
+		want  any
+		group string
+	}{
+		{name: "password", args: []any{"password", "hunter2"}, key: "password", want: redactedValue},
+		{name: "case insensitive", args: []any{"Authorization", "Bearer token"}, key: "Authorization", want: redactedValue},
+		{name: "grouped", args: []any{slog.Group("req", "access_jwt", "token")}, group: "req", key: "access_jwt", want: redactedValue},
+		{name: "other attributes", args: []any{"user_id", "user1234"}, key: "user_id", want: "user1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			New(&buf, "json", slog.LevelInfo).Info("message", tt.args...)
+			record := map[string]any{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("failed to unmarshal log record: %v", err)
+			}
+			if tt.group != "" {
+				record, _ = record[tt.group].(map[string]any)
+			}
+			if got := record[tt.key]; got != tt.want {
+				t.Errorf("logged %s = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_With(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := NewContext(context.Background(), New(&buf, "json", slog.LevelInfo))
+
+	// attributes added further down the stack are seen by the request's later logs
+	func(ctx context.Context) { With(ctx, "user_id", "user1234") }(ctx)
+	FromContext(ctx).Info("message")
+	record := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to unmarshal log record: %v", err)
+	}
+	if record["user_id"] != "user1234" {
+		t.Errorf("logged user_id = %v, want user1234", record["user_id"])
+	}
+
+	// contexts without a logger use the default logger
+	With(context.Background(), "user_id", "user1234")
+	if got := FromContext(context.Background()); got != slog.Default() {
+		t.Errorf("FromContext() = %v, want default logger", got)
+	}
+}
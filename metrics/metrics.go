@@ -0,0 +1,103 @@
+// Package metrics defines the server's Prometheus metrics and serves them over HTTP.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric of the server, along with the Go runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// RPCRequests counts handled RPCs by method and status code.
+	RPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_rpc_requests_total",
+		Help: "Number of handled RPCs by method and status code.",
+	}, []string{"method", "code"})
+	// RPCDuration observes how long RPCs take to handle by method.
+	RPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "todo_rpc_duration_seconds",
+		Help:    "Time taken to handle RPCs by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// RateLimitedRequests counts requests rejected because their caller exceeded its rate limit by method.
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_rate_limited_requests_total",
+		Help: "Number of requests rejected because their caller exceeded its rate limit by method.",
+	}, []string{"method"})
+
+	// DynamoDBOperations counts DynamoDB operations by operation and result: "success" or "error".
+	DynamoDBOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_dynamodb_operations_total",
+		Help: "Number of DynamoDB operations by operation and result.",
+	}, []string{"operation", "result"})
+	// DynamoDBDuration observes how long DynamoDB operations take, including retries, by operation.
+	DynamoDBDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "todo_dynamodb_operation_duration_seconds",
+		Help:    "Time taken by DynamoDB operations, including retries, by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+	// DynamoDBConsumedCapacity counts the capacity units consumed by DynamoDB operations by table and operation.
+	DynamoDBConsumedCapacity = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_dynamodb_consumed_capacity_units_total",
+		Help: "Capacity units consumed by DynamoDB operations by table and operation.",
+	}, []string{"table", "operation"})
+	// DynamoDBThrottles counts DynamoDB attempts that were throttled by operation.
+	DynamoDBThrottles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_dynamodb_throttles_total",
+		Help: "Number of throttled DynamoDB attempts by operation.",
+	}, []string{"operation"})
+	// DynamoDBRetries counts DynamoDB attempts made after the first by operation.
+	DynamoDBRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_dynamodb_retries_total",
+		Help: "Number of retried DynamoDB attempts by operation.",
+	}, []string{"operation"})
+
+	// Signins counts sign in attempts by result: "success" or "failure".
+	Signins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_auth_signins_total",
+		Help: "Number of sign in attempts by result.",
+	}, []string{"result"})
+	// TokenVerificationFailures counts requests rejected because their jwt could not be verified.
+	TokenVerificationFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "todo_auth_token_verification_failures_total",
+		Help: "Number of requests rejected because their jwt could not be verified.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RPCRequests,
+		RPCDuration,
+		RateLimitedRequests,
+		DynamoDBOperations,
+		DynamoDBDuration,
+		DynamoDBConsumedCapacity,
+		DynamoDBThrottles,
+		DynamoDBRetries,
+		Signins,
+		TokenVerificationFailures,
+	)
+}
+
+// Handler returns the HTTP handler serving every metric of the registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// NewServer returns an HTTP server serving the metrics at /metrics on the given address.
+func NewServer(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{
+		Addr:    address,
+		Handler: mux,
+	}
+}
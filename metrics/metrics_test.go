@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_NewServer(t *testing.T) {
+	RPCRequests.WithLabelValues("/api.Todo/GetTask", "OK").Inc()
+	server := httptest.NewServer(NewServer("").Handler)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "metrics", path: "/metrics", wantStatus: 200, wantBody: `todo_rpc_requests_total{code="OK",method="/api.Todo/GetTask"} 1`},
+		{name: "runtime metrics", path: "/metrics", wantStatus: 200, wantBody: "go_goroutines"},
+		{name: "unknown path", path: "/", wantStatus: 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.Client().Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("failed to get %s: %v", tt.path, err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body does not contain %q", tt.wantBody)
+			}
+		})
+	}
+}
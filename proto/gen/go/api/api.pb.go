@@ -24,49 +24,240 @@ var File_api_proto protoreflect.FileDescriptor
 var file_api_proto_rawDesc = []byte{
 	0x0a, 0x09, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x70, 0x69,
 	0x1a, 0x0b, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a, 0x73,
-	0x75, 0x73, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xee, 0x02, 0x0a, 0x04, 0x54, 0x6f,
-	0x64, 0x6f, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x12, 0x0e, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x12, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07,
-	0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x64,
-	0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41,
-	0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07,
-	0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65,
-	0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47,
-	0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
-	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71,
-	0x1a, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73,
-	0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61,
+	0x75, 0x73, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x63, 0x61, 0x6c, 0x65, 0x6e,
+	0x64, 0x61, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0a, 0x74, 0x61, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x0e, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x32, 0xd3, 0x12, 0x0a, 0x04, 0x54, 0x6f, 0x64, 0x6f, 0x12, 0x2b, 0x0a, 0x06, 0x53,
+	0x69, 0x67, 0x6e, 0x75, 0x70, 0x12, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x75, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e,
+	0x69, 0x6e, 0x12, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x52,
+	0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54,
+	0x61, 0x73, 0x6b, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x73, 0x6b, 0x73,
+	0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x49, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x6f, 0x4d,
+	0x65, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x54, 0x6f, 0x4d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x6f,
+	0x4d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61,
 	0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64,
 	0x61, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69,
 	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22,
 	0x00, 0x12, 0x37, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x12,
 	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b,
 	0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x2f,
-	0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x41, 0x64,
+	0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41,
+	0x64, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69,
+	0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x54, 0x6f, 0x67, 0x67, 0x6c, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d,
+	0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c,
+	0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x11, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74,
+	0x65, 0x6d, 0x12, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6c, 0x69, 0x73,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0b, 0x49, 0x6d, 0x70,
+	0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x28, 0x01, 0x12, 0x45, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x12, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61,
+	0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x30, 0x01, 0x12, 0x45,
+	0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72,
+	0x12, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x6c,
+	0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x28, 0x01, 0x12, 0x52, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65,
+	0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x64, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x53, 0x61, 0x76,
+	0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x61, 0x76, 0x65,
+	0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x09,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x12, 0x2e, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x12, 0x0f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x75, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a, 0x10,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x75, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77,
+	0x12, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34,
+	0x0a, 0x09, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x67, 0x12, 0x11, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x12,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x09, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x41, 0x64,
+	0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41,
+	0x64, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x12, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x1a, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x15,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x34, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x11, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x76, 0x69,
+	0x74, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x69,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10,
+	0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76,
+	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3d,
+	0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x49, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_api_proto_goTypes = []any{
-	(*SignupReq)(nil),       // 0: api.SignupReq
-	(*SigninReq)(nil),       // 1: api.SigninReq
-	(*AddTaskReq)(nil),      // 2: api.AddTaskReq
-	(*GetTaskReq)(nil),      // 3: api.GetTaskReq
-	(*GetAllTasksReq)(nil),  // 4: api.GetAllTasksReq
-	(*UpdateTaskReq)(nil),   // 5: api.UpdateTaskReq
-	(*DeleteTaskReq)(nil),   // 6: api.DeleteTaskReq
-	(*SignupResp)(nil),      // 7: api.SignupResp
-	(*SigninResp)(nil),      // 8: api.SigninResp
-	(*AddTaskResp)(nil),     // 9: api.AddTaskResp
-	(*GetTaskResp)(nil),     // 10: api.GetTaskResp
-	(*GetAllTasksResp)(nil), // 11: api.GetAllTasksResp
-	(*UpdateTaskResp)(nil),  // 12: api.UpdateTaskResp
-	(*DeleteTaskResp)(nil),  // 13: api.DeleteTaskResp
+	(*SignupReq)(nil),               // 0: api.SignupReq
+	(*SigninReq)(nil),               // 1: api.SigninReq
+	(*AddTaskReq)(nil),              // 2: api.AddTaskReq
+	(*GetTaskReq)(nil),              // 3: api.GetTaskReq
+	(*GetAllTasksReq)(nil),          // 4: api.GetAllTasksReq
+	(*SearchTasksReq)(nil),          // 5: api.SearchTasksReq
+	(*ListAssignedToMeReq)(nil),     // 6: api.ListAssignedToMeReq
+	(*UpdateTaskReq)(nil),           // 7: api.UpdateTaskReq
+	(*DeleteTaskReq)(nil),           // 8: api.DeleteTaskReq
+	(*AddChecklistItemReq)(nil),     // 9: api.AddChecklistItemReq
+	(*ToggleChecklistItemReq)(nil),  // 10: api.ToggleChecklistItemReq
+	(*RemoveChecklistItemReq)(nil),  // 11: api.RemoveChecklistItemReq
+	(*MoveChecklistItemReq)(nil),    // 12: api.MoveChecklistItemReq
+	(*ExportTasksReq)(nil),          // 13: api.ExportTasksReq
+	(*ImportTasksReq)(nil),          // 14: api.ImportTasksReq
+	(*ExportCalendarReq)(nil),       // 15: api.ExportCalendarReq
+	(*ImportCalendarReq)(nil),       // 16: api.ImportCalendarReq
+	(*RegenerateFeedTokenReq)(nil),  // 17: api.RegenerateFeedTokenReq
+	(*SaveViewReq)(nil),             // 18: api.SaveViewReq
+	(*ListViewsReq)(nil),            // 19: api.ListViewsReq
+	(*RunViewReq)(nil),              // 20: api.RunViewReq
+	(*DeleteViewReq)(nil),           // 21: api.DeleteViewReq
+	(*ListTagsReq)(nil),             // 22: api.ListTagsReq
+	(*RenameTagReq)(nil),            // 23: api.RenameTagReq
+	(*MergeTagsReq)(nil),            // 24: api.MergeTagsReq
+	(*AddProjectReq)(nil),           // 25: api.AddProjectReq
+	(*GetProjectReq)(nil),           // 26: api.GetProjectReq
+	(*ListProjectsReq)(nil),         // 27: api.ListProjectsReq
+	(*UpdateProjectReq)(nil),        // 28: api.UpdateProjectReq
+	(*DeleteProjectReq)(nil),        // 29: api.DeleteProjectReq
+	(*MoveTasksReq)(nil),            // 30: api.MoveTasksReq
+	(*InviteMemberReq)(nil),         // 31: api.InviteMemberReq
+	(*ListInvitationsReq)(nil),      // 32: api.ListInvitationsReq
+	(*AcceptInvitationReq)(nil),     // 33: api.AcceptInvitationReq
+	(*ListMembersReq)(nil),          // 34: api.ListMembersReq
+	(*UpdateMemberRoleReq)(nil),     // 35: api.UpdateMemberRoleReq
+	(*RemoveMemberReq)(nil),         // 36: api.RemoveMemberReq
+	(*ListAuditEntriesReq)(nil),     // 37: api.ListAuditEntriesReq
+	(*SignupResp)(nil),              // 38: api.SignupResp
+	(*SigninResp)(nil),              // 39: api.SigninResp
+	(*AddTaskResp)(nil),             // 40: api.AddTaskResp
+	(*GetTaskResp)(nil),             // 41: api.GetTaskResp
+	(*GetAllTasksResp)(nil),         // 42: api.GetAllTasksResp
+	(*SearchTasksResp)(nil),         // 43: api.SearchTasksResp
+	(*ListAssignedToMeResp)(nil),    // 44: api.ListAssignedToMeResp
+	(*UpdateTaskResp)(nil),          // 45: api.UpdateTaskResp
+	(*DeleteTaskResp)(nil),          // 46: api.DeleteTaskResp
+	(*AddChecklistItemResp)(nil),    // 47: api.AddChecklistItemResp
+	(*ToggleChecklistItemResp)(nil), // 48: api.ToggleChecklistItemResp
+	(*RemoveChecklistItemResp)(nil), // 49: api.RemoveChecklistItemResp
+	(*MoveChecklistItemResp)(nil),   // 50: api.MoveChecklistItemResp
+	(*ExportTasksResp)(nil),         // 51: api.ExportTasksResp
+	(*ImportTasksResp)(nil),         // 52: api.ImportTasksResp
+	(*ExportCalendarResp)(nil),      // 53: api.ExportCalendarResp
+	(*ImportCalendarResp)(nil),      // 54: api.ImportCalendarResp
+	(*RegenerateFeedTokenResp)(nil), // 55: api.RegenerateFeedTokenResp
+	(*SaveViewResp)(nil),            // 56: api.SaveViewResp
+	(*ListViewsResp)(nil),           // 57: api.ListViewsResp
+	(*RunViewResp)(nil),             // 58: api.RunViewResp
+	(*DeleteViewResp)(nil),          // 59: api.DeleteViewResp
+	(*ListTagsResp)(nil),            // 60: api.ListTagsResp
+	(*RenameTagResp)(nil),           // 61: api.RenameTagResp
+	(*MergeTagsResp)(nil),           // 62: api.MergeTagsResp
+	(*AddProjectResp)(nil),          // 63: api.AddProjectResp
+	(*GetProjectResp)(nil),          // 64: api.GetProjectResp
+	(*ListProjectsResp)(nil),        // 65: api.ListProjectsResp
+	(*UpdateProjectResp)(nil),       // 66: api.UpdateProjectResp
+	(*DeleteProjectResp)(nil),       // 67: api.DeleteProjectResp
+	(*MoveTasksResp)(nil),           // 68: api.MoveTasksResp
+	(*InviteMemberResp)(nil),        // 69: api.InviteMemberResp
+	(*ListInvitationsResp)(nil),     // 70: api.ListInvitationsResp
+	(*AcceptInvitationResp)(nil),    // 71: api.AcceptInvitationResp
+	(*ListMembersResp)(nil),         // 72: api.ListMembersResp
+	(*UpdateMemberRoleResp)(nil),    // 73: api.UpdateMemberRoleResp
+	(*RemoveMemberResp)(nil),        // 74: api.RemoveMemberResp
+	(*ListAuditEntriesResp)(nil),    // 75: api.ListAuditEntriesResp
 }
 var file_api_proto_depIdxs = []int32{
 	0,  // 0: api.Todo.Signup:input_type -> api.SignupReq
@@ -74,17 +265,79 @@ var file_api_proto_depIdxs = []int32{
 	2,  // 2: api.Todo.AddTask:input_type -> api.AddTaskReq
 	3,  // 3: api.Todo.GetTask:input_type -> api.GetTaskReq
 	4,  // 4: api.Todo.GetAllTasks:input_type -> api.GetAllTasksReq
-	5,  // 5: api.Todo.UpdateTask:input_type -> api.UpdateTaskReq
-	6,  // 6: api.Todo.DeleteTask:input_type -> api.DeleteTaskReq
-	7,  // 7: api.Todo.Signup:output_type -> api.SignupResp
-	8,  // 8: api.Todo.Signin:output_type -> api.SigninResp
-	9,  // 9: api.Todo.AddTask:output_type -> api.AddTaskResp
-	10, // 10: api.Todo.GetTask:output_type -> api.GetTaskResp
-	11, // 11: api.Todo.GetAllTasks:output_type -> api.GetAllTasksResp
-	12, // 12: api.Todo.UpdateTask:output_type -> api.UpdateTaskResp
-	13, // 13: api.Todo.DeleteTask:output_type -> api.DeleteTaskResp
-	7,  // [7:14] is the sub-list for method output_type
-	0,  // [0:7] is the sub-list for method input_type
+	5,  // 5: api.Todo.SearchTasks:input_type -> api.SearchTasksReq
+	6,  // 6: api.Todo.ListAssignedToMe:input_type -> api.ListAssignedToMeReq
+	7,  // 7: api.Todo.UpdateTask:input_type -> api.UpdateTaskReq
+	8,  // 8: api.Todo.DeleteTask:input_type -> api.DeleteTaskReq
+	9,  // 9: api.Todo.AddChecklistItem:input_type -> api.AddChecklistItemReq
+	10, // 10: api.Todo.ToggleChecklistItem:input_type -> api.ToggleChecklistItemReq
+	11, // 11: api.Todo.RemoveChecklistItem:input_type -> api.RemoveChecklistItemReq
+	12, // 12: api.Todo.MoveChecklistItem:input_type -> api.MoveChecklistItemReq
+	13, // 13: api.Todo.ExportTasks:input_type -> api.ExportTasksReq
+	14, // 14: api.Todo.ImportTasks:input_type -> api.ImportTasksReq
+	15, // 15: api.Todo.ExportCalendar:input_type -> api.ExportCalendarReq
+	16, // 16: api.Todo.ImportCalendar:input_type -> api.ImportCalendarReq
+	17, // 17: api.Todo.RegenerateFeedToken:input_type -> api.RegenerateFeedTokenReq
+	18, // 18: api.Todo.SaveView:input_type -> api.SaveViewReq
+	19, // 19: api.Todo.ListViews:input_type -> api.ListViewsReq
+	20, // 20: api.Todo.RunView:input_type -> api.RunViewReq
+	21, // 21: api.Todo.DeleteView:input_type -> api.DeleteViewReq
+	22, // 22: api.Todo.ListTags:input_type -> api.ListTagsReq
+	23, // 23: api.Todo.RenameTag:input_type -> api.RenameTagReq
+	24, // 24: api.Todo.MergeTags:input_type -> api.MergeTagsReq
+	25, // 25: api.Todo.AddProject:input_type -> api.AddProjectReq
+	26, // 26: api.Todo.GetProject:input_type -> api.GetProjectReq
+	27, // 27: api.Todo.ListProjects:input_type -> api.ListProjectsReq
+	28, // 28: api.Todo.UpdateProject:input_type -> api.UpdateProjectReq
+	29, // 29: api.Todo.DeleteProject:input_type -> api.DeleteProjectReq
+	30, // 30: api.Todo.MoveTasks:input_type -> api.MoveTasksReq
+	31, // 31: api.Todo.InviteMember:input_type -> api.InviteMemberReq
+	32, // 32: api.Todo.ListInvitations:input_type -> api.ListInvitationsReq
+	33, // 33: api.Todo.AcceptInvitation:input_type -> api.AcceptInvitationReq
+	34, // 34: api.Todo.ListMembers:input_type -> api.ListMembersReq
+	35, // 35: api.Todo.UpdateMemberRole:input_type -> api.UpdateMemberRoleReq
+	36, // 36: api.Todo.RemoveMember:input_type -> api.RemoveMemberReq
+	37, // 37: api.Todo.ListAuditEntries:input_type -> api.ListAuditEntriesReq
+	38, // 38: api.Todo.Signup:output_type -> api.SignupResp
+	39, // 39: api.Todo.Signin:output_type -> api.SigninResp
+	40, // 40: api.Todo.AddTask:output_type -> api.AddTaskResp
+	41, // 41: api.Todo.GetTask:output_type -> api.GetTaskResp
+	42, // 42: api.Todo.GetAllTasks:output_type -> api.GetAllTasksResp
+	43, // 43: api.Todo.SearchTasks:output_type -> api.SearchTasksResp
+	44, // 44: api.Todo.ListAssignedToMe:output_type -> api.ListAssignedToMeResp
+	45, // 45: api.Todo.UpdateTask:output_type -> api.UpdateTaskResp
+	46, // 46: api.Todo.DeleteTask:output_type -> api.DeleteTaskResp
+	47, // 47: api.Todo.AddChecklistItem:output_type -> api.AddChecklistItemResp
+	48, // 48: api.Todo.ToggleChecklistItem:output_type -> api.ToggleChecklistItemResp
+	49, // 49: api.Todo.RemoveChecklistItem:output_type -> api.RemoveChecklistItemResp
+	50, // 50: api.Todo.MoveChecklistItem:output_type -> api.MoveChecklistItemResp
+	51, // 51: api.Todo.ExportTasks:output_type -> api.ExportTasksResp
+	52, // 52: api.Todo.ImportTasks:output_type -> api.ImportTasksResp
+	53, // 53: api.Todo.ExportCalendar:output_type -> api.ExportCalendarResp
+	54, // 54: api.Todo.ImportCalendar:output_type -> api.ImportCalendarResp
+	55, // 55: api.Todo.RegenerateFeedToken:output_type -> api.RegenerateFeedTokenResp
+	56, // 56: api.Todo.SaveView:output_type -> api.SaveViewResp
+	57, // 57: api.Todo.ListViews:output_type -> api.ListViewsResp
+	58, // 58: api.Todo.RunView:output_type -> api.RunViewResp
+	59, // 59: api.Todo.DeleteView:output_type -> api.DeleteViewResp
+	60, // 60: api.Todo.ListTags:output_type -> api.ListTagsResp
+	61, // 61: api.Todo.RenameTag:output_type -> api.RenameTagResp
+	62, // 62: api.Todo.MergeTags:output_type -> api.MergeTagsResp
+	63, // 63: api.Todo.AddProject:output_type -> api.AddProjectResp
+	64, // 64: api.Todo.GetProject:output_type -> api.GetProjectResp
+	65, // 65: api.Todo.ListProjects:output_type -> api.ListProjectsResp
+	66, // 66: api.Todo.UpdateProject:output_type -> api.UpdateProjectResp
+	67, // 67: api.Todo.DeleteProject:output_type -> api.DeleteProjectResp
+	68, // 68: api.Todo.MoveTasks:output_type -> api.MoveTasksResp
+	69, // 69: api.Todo.InviteMember:output_type -> api.InviteMemberResp
+	70, // 70: api.Todo.ListInvitations:output_type -> api.ListInvitationsResp
+	71, // 71: api.Todo.AcceptInvitation:output_type -> api.AcceptInvitationResp
+	72, // 72: api.Todo.ListMembers:output_type -> api.ListMembersResp
+	73, // 73: api.Todo.UpdateMemberRole:output_type -> api.UpdateMemberRoleResp
+	74, // 74: api.Todo.RemoveMember:output_type -> api.RemoveMemberResp
+	75, // 75: api.Todo.ListAuditEntries:output_type -> api.ListAuditEntriesResp
+	38, // [38:76] is the sub-list for method output_type
+	0,  // [0:38] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -97,6 +350,10 @@ func file_api_proto_init() {
 	}
 	file_tasks_proto_init()
 	file_susi_proto_init()
+	file_calendar_proto_init()
+	file_views_proto_init()
+	file_tags_proto_init()
+	file_projects_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
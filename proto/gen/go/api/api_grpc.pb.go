@@ -19,13 +19,44 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Todo_Signup_FullMethodName      = "/api.Todo/Signup"
-	Todo_Signin_FullMethodName      = "/api.Todo/Signin"
-	Todo_AddTask_FullMethodName     = "/api.Todo/AddTask"
-	Todo_GetTask_FullMethodName     = "/api.Todo/GetTask"
-	Todo_GetAllTasks_FullMethodName = "/api.Todo/GetAllTasks"
-	Todo_UpdateTask_FullMethodName  = "/api.Todo/UpdateTask"
-	Todo_DeleteTask_FullMethodName  = "/api.Todo/DeleteTask"
+	Todo_Signup_FullMethodName              = "/api.Todo/Signup"
+	Todo_Signin_FullMethodName              = "/api.Todo/Signin"
+	Todo_AddTask_FullMethodName             = "/api.Todo/AddTask"
+	Todo_GetTask_FullMethodName             = "/api.Todo/GetTask"
+	Todo_GetAllTasks_FullMethodName         = "/api.Todo/GetAllTasks"
+	Todo_SearchTasks_FullMethodName         = "/api.Todo/SearchTasks"
+	Todo_ListAssignedToMe_FullMethodName    = "/api.Todo/ListAssignedToMe"
+	Todo_UpdateTask_FullMethodName          = "/api.Todo/UpdateTask"
+	Todo_DeleteTask_FullMethodName          = "/api.Todo/DeleteTask"
+	Todo_AddChecklistItem_FullMethodName    = "/api.Todo/AddChecklistItem"
+	Todo_ToggleChecklistItem_FullMethodName = "/api.Todo/ToggleChecklistItem"
+	Todo_RemoveChecklistItem_FullMethodName = "/api.Todo/RemoveChecklistItem"
+	Todo_MoveChecklistItem_FullMethodName   = "/api.Todo/MoveChecklistItem"
+	Todo_ExportTasks_FullMethodName         = "/api.Todo/ExportTasks"
+	Todo_ImportTasks_FullMethodName         = "/api.Todo/ImportTasks"
+	Todo_ExportCalendar_FullMethodName      = "/api.Todo/ExportCalendar"
+	Todo_ImportCalendar_FullMethodName      = "/api.Todo/ImportCalendar"
+	Todo_RegenerateFeedToken_FullMethodName = "/api.Todo/RegenerateFeedToken"
+	Todo_SaveView_FullMethodName            = "/api.Todo/SaveView"
+	Todo_ListViews_FullMethodName           = "/api.Todo/ListViews"
+	Todo_RunView_FullMethodName             = "/api.Todo/RunView"
+	Todo_DeleteView_FullMethodName          = "/api.Todo/DeleteView"
+	Todo_ListTags_FullMethodName            = "/api.Todo/ListTags"
+	Todo_RenameTag_FullMethodName           = "/api.Todo/RenameTag"
+	Todo_MergeTags_FullMethodName           = "/api.Todo/MergeTags"
+	Todo_AddProject_FullMethodName          = "/api.Todo/AddProject"
+	Todo_GetProject_FullMethodName          = "/api.Todo/GetProject"
+	Todo_ListProjects_FullMethodName        = "/api.Todo/ListProjects"
+	Todo_UpdateProject_FullMethodName       = "/api.Todo/UpdateProject"
+	Todo_DeleteProject_FullMethodName       = "/api.Todo/DeleteProject"
+	Todo_MoveTasks_FullMethodName           = "/api.Todo/MoveTasks"
+	Todo_InviteMember_FullMethodName        = "/api.Todo/InviteMember"
+	Todo_ListInvitations_FullMethodName     = "/api.Todo/ListInvitations"
+	Todo_AcceptInvitation_FullMethodName    = "/api.Todo/AcceptInvitation"
+	Todo_ListMembers_FullMethodName         = "/api.Todo/ListMembers"
+	Todo_UpdateMemberRole_FullMethodName    = "/api.Todo/UpdateMemberRole"
+	Todo_RemoveMember_FullMethodName        = "/api.Todo/RemoveMember"
+	Todo_ListAuditEntries_FullMethodName    = "/api.Todo/ListAuditEntries"
 )
 
 // TodoClient is the client API for Todo service.
@@ -37,8 +68,39 @@ type TodoClient interface {
 	AddTask(ctx context.Context, in *AddTaskReq, opts ...grpc.CallOption) (*AddTaskResp, error)
 	GetTask(ctx context.Context, in *GetTaskReq, opts ...grpc.CallOption) (*GetTaskResp, error)
 	GetAllTasks(ctx context.Context, in *GetAllTasksReq, opts ...grpc.CallOption) (*GetAllTasksResp, error)
+	SearchTasks(ctx context.Context, in *SearchTasksReq, opts ...grpc.CallOption) (*SearchTasksResp, error)
+	ListAssignedToMe(ctx context.Context, in *ListAssignedToMeReq, opts ...grpc.CallOption) (*ListAssignedToMeResp, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskReq, opts ...grpc.CallOption) (*UpdateTaskResp, error)
 	DeleteTask(ctx context.Context, in *DeleteTaskReq, opts ...grpc.CallOption) (*DeleteTaskResp, error)
+	AddChecklistItem(ctx context.Context, in *AddChecklistItemReq, opts ...grpc.CallOption) (*AddChecklistItemResp, error)
+	ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemReq, opts ...grpc.CallOption) (*ToggleChecklistItemResp, error)
+	RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemReq, opts ...grpc.CallOption) (*RemoveChecklistItemResp, error)
+	MoveChecklistItem(ctx context.Context, in *MoveChecklistItemReq, opts ...grpc.CallOption) (*MoveChecklistItemResp, error)
+	ExportTasks(ctx context.Context, in *ExportTasksReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTasksResp], error)
+	ImportTasks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTasksReq, ImportTasksResp], error)
+	ExportCalendar(ctx context.Context, in *ExportCalendarReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCalendarResp], error)
+	ImportCalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCalendarReq, ImportCalendarResp], error)
+	RegenerateFeedToken(ctx context.Context, in *RegenerateFeedTokenReq, opts ...grpc.CallOption) (*RegenerateFeedTokenResp, error)
+	SaveView(ctx context.Context, in *SaveViewReq, opts ...grpc.CallOption) (*SaveViewResp, error)
+	ListViews(ctx context.Context, in *ListViewsReq, opts ...grpc.CallOption) (*ListViewsResp, error)
+	RunView(ctx context.Context, in *RunViewReq, opts ...grpc.CallOption) (*RunViewResp, error)
+	DeleteView(ctx context.Context, in *DeleteViewReq, opts ...grpc.CallOption) (*DeleteViewResp, error)
+	ListTags(ctx context.Context, in *ListTagsReq, opts ...grpc.CallOption) (*ListTagsResp, error)
+	RenameTag(ctx context.Context, in *RenameTagReq, opts ...grpc.CallOption) (*RenameTagResp, error)
+	MergeTags(ctx context.Context, in *MergeTagsReq, opts ...grpc.CallOption) (*MergeTagsResp, error)
+	AddProject(ctx context.Context, in *AddProjectReq, opts ...grpc.CallOption) (*AddProjectResp, error)
+	GetProject(ctx context.Context, in *GetProjectReq, opts ...grpc.CallOption) (*GetProjectResp, error)
+	ListProjects(ctx context.Context, in *ListProjectsReq, opts ...grpc.CallOption) (*ListProjectsResp, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectReq, opts ...grpc.CallOption) (*UpdateProjectResp, error)
+	DeleteProject(ctx context.Context, in *DeleteProjectReq, opts ...grpc.CallOption) (*DeleteProjectResp, error)
+	MoveTasks(ctx context.Context, in *MoveTasksReq, opts ...grpc.CallOption) (*MoveTasksResp, error)
+	InviteMember(ctx context.Context, in *InviteMemberReq, opts ...grpc.CallOption) (*InviteMemberResp, error)
+	ListInvitations(ctx context.Context, in *ListInvitationsReq, opts ...grpc.CallOption) (*ListInvitationsResp, error)
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationReq, opts ...grpc.CallOption) (*AcceptInvitationResp, error)
+	ListMembers(ctx context.Context, in *ListMembersReq, opts ...grpc.CallOption) (*ListMembersResp, error)
+	UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleReq, opts ...grpc.CallOption) (*UpdateMemberRoleResp, error)
+	RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error)
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesReq, opts ...grpc.CallOption) (*ListAuditEntriesResp, error)
 }
 
 type todoClient struct {
@@ -99,6 +161,26 @@ func (c *todoClient) GetAllTasks(ctx context.Context, in *GetAllTasksReq, opts .
 	return out, nil
 }
 
+func (c *todoClient) SearchTasks(ctx context.Context, in *SearchTasksReq, opts ...grpc.CallOption) (*SearchTasksResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTasksResp)
+	err := c.cc.Invoke(ctx, Todo_SearchTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListAssignedToMe(ctx context.Context, in *ListAssignedToMeReq, opts ...grpc.CallOption) (*ListAssignedToMeResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssignedToMeResp)
+	err := c.cc.Invoke(ctx, Todo_ListAssignedToMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *todoClient) UpdateTask(ctx context.Context, in *UpdateTaskReq, opts ...grpc.CallOption) (*UpdateTaskResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateTaskResp)
@@ -119,6 +201,320 @@ func (c *todoClient) DeleteTask(ctx context.Context, in *DeleteTaskReq, opts ...
 	return out, nil
 }
 
+func (c *todoClient) AddChecklistItem(ctx context.Context, in *AddChecklistItemReq, opts ...grpc.CallOption) (*AddChecklistItemResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddChecklistItemResp)
+	err := c.cc.Invoke(ctx, Todo_AddChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ToggleChecklistItem(ctx context.Context, in *ToggleChecklistItemReq, opts ...grpc.CallOption) (*ToggleChecklistItemResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ToggleChecklistItemResp)
+	err := c.cc.Invoke(ctx, Todo_ToggleChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) RemoveChecklistItem(ctx context.Context, in *RemoveChecklistItemReq, opts ...grpc.CallOption) (*RemoveChecklistItemResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveChecklistItemResp)
+	err := c.cc.Invoke(ctx, Todo_RemoveChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) MoveChecklistItem(ctx context.Context, in *MoveChecklistItemReq, opts ...grpc.CallOption) (*MoveChecklistItemResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveChecklistItemResp)
+	err := c.cc.Invoke(ctx, Todo_MoveChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ExportTasks(ctx context.Context, in *ExportTasksReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTasksResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[0], Todo_ExportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTasksReq, ExportTasksResp]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ExportTasksClient = grpc.ServerStreamingClient[ExportTasksResp]
+
+func (c *todoClient) ImportTasks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTasksReq, ImportTasksResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[1], Todo_ImportTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTasksReq, ImportTasksResp]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ImportTasksClient = grpc.ClientStreamingClient[ImportTasksReq, ImportTasksResp]
+
+func (c *todoClient) ExportCalendar(ctx context.Context, in *ExportCalendarReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCalendarResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[2], Todo_ExportCalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCalendarReq, ExportCalendarResp]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ExportCalendarClient = grpc.ServerStreamingClient[ExportCalendarResp]
+
+func (c *todoClient) ImportCalendar(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportCalendarReq, ImportCalendarResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Todo_ServiceDesc.Streams[3], Todo_ImportCalendar_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportCalendarReq, ImportCalendarResp]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ImportCalendarClient = grpc.ClientStreamingClient[ImportCalendarReq, ImportCalendarResp]
+
+func (c *todoClient) RegenerateFeedToken(ctx context.Context, in *RegenerateFeedTokenReq, opts ...grpc.CallOption) (*RegenerateFeedTokenResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateFeedTokenResp)
+	err := c.cc.Invoke(ctx, Todo_RegenerateFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) SaveView(ctx context.Context, in *SaveViewReq, opts ...grpc.CallOption) (*SaveViewResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveViewResp)
+	err := c.cc.Invoke(ctx, Todo_SaveView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListViews(ctx context.Context, in *ListViewsReq, opts ...grpc.CallOption) (*ListViewsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListViewsResp)
+	err := c.cc.Invoke(ctx, Todo_ListViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) RunView(ctx context.Context, in *RunViewReq, opts ...grpc.CallOption) (*RunViewResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunViewResp)
+	err := c.cc.Invoke(ctx, Todo_RunView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) DeleteView(ctx context.Context, in *DeleteViewReq, opts ...grpc.CallOption) (*DeleteViewResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteViewResp)
+	err := c.cc.Invoke(ctx, Todo_DeleteView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListTags(ctx context.Context, in *ListTagsReq, opts ...grpc.CallOption) (*ListTagsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResp)
+	err := c.cc.Invoke(ctx, Todo_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) RenameTag(ctx context.Context, in *RenameTagReq, opts ...grpc.CallOption) (*RenameTagResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameTagResp)
+	err := c.cc.Invoke(ctx, Todo_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) MergeTags(ctx context.Context, in *MergeTagsReq, opts ...grpc.CallOption) (*MergeTagsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeTagsResp)
+	err := c.cc.Invoke(ctx, Todo_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) AddProject(ctx context.Context, in *AddProjectReq, opts ...grpc.CallOption) (*AddProjectResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddProjectResp)
+	err := c.cc.Invoke(ctx, Todo_AddProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) GetProject(ctx context.Context, in *GetProjectReq, opts ...grpc.CallOption) (*GetProjectResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProjectResp)
+	err := c.cc.Invoke(ctx, Todo_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListProjects(ctx context.Context, in *ListProjectsReq, opts ...grpc.CallOption) (*ListProjectsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResp)
+	err := c.cc.Invoke(ctx, Todo_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) UpdateProject(ctx context.Context, in *UpdateProjectReq, opts ...grpc.CallOption) (*UpdateProjectResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProjectResp)
+	err := c.cc.Invoke(ctx, Todo_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) DeleteProject(ctx context.Context, in *DeleteProjectReq, opts ...grpc.CallOption) (*DeleteProjectResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProjectResp)
+	err := c.cc.Invoke(ctx, Todo_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) MoveTasks(ctx context.Context, in *MoveTasksReq, opts ...grpc.CallOption) (*MoveTasksResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveTasksResp)
+	err := c.cc.Invoke(ctx, Todo_MoveTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) InviteMember(ctx context.Context, in *InviteMemberReq, opts ...grpc.CallOption) (*InviteMemberResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteMemberResp)
+	err := c.cc.Invoke(ctx, Todo_InviteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListInvitations(ctx context.Context, in *ListInvitationsReq, opts ...grpc.CallOption) (*ListInvitationsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitationsResp)
+	err := c.cc.Invoke(ctx, Todo_ListInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationReq, opts ...grpc.CallOption) (*AcceptInvitationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptInvitationResp)
+	err := c.cc.Invoke(ctx, Todo_AcceptInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListMembers(ctx context.Context, in *ListMembersReq, opts ...grpc.CallOption) (*ListMembersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResp)
+	err := c.cc.Invoke(ctx, Todo_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleReq, opts ...grpc.CallOption) (*UpdateMemberRoleResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMemberRoleResp)
+	err := c.cc.Invoke(ctx, Todo_UpdateMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) RemoveMember(ctx context.Context, in *RemoveMemberReq, opts ...grpc.CallOption) (*RemoveMemberResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResp)
+	err := c.cc.Invoke(ctx, Todo_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesReq, opts ...grpc.CallOption) (*ListAuditEntriesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEntriesResp)
+	err := c.cc.Invoke(ctx, Todo_ListAuditEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TodoServer is the server API for Todo service.
 // All implementations must embed UnimplementedTodoServer
 // for forward compatibility.
@@ -128,8 +524,39 @@ type TodoServer interface {
 	AddTask(context.Context, *AddTaskReq) (*AddTaskResp, error)
 	GetTask(context.Context, *GetTaskReq) (*GetTaskResp, error)
 	GetAllTasks(context.Context, *GetAllTasksReq) (*GetAllTasksResp, error)
+	SearchTasks(context.Context, *SearchTasksReq) (*SearchTasksResp, error)
+	ListAssignedToMe(context.Context, *ListAssignedToMeReq) (*ListAssignedToMeResp, error)
 	UpdateTask(context.Context, *UpdateTaskReq) (*UpdateTaskResp, error)
 	DeleteTask(context.Context, *DeleteTaskReq) (*DeleteTaskResp, error)
+	AddChecklistItem(context.Context, *AddChecklistItemReq) (*AddChecklistItemResp, error)
+	ToggleChecklistItem(context.Context, *ToggleChecklistItemReq) (*ToggleChecklistItemResp, error)
+	RemoveChecklistItem(context.Context, *RemoveChecklistItemReq) (*RemoveChecklistItemResp, error)
+	MoveChecklistItem(context.Context, *MoveChecklistItemReq) (*MoveChecklistItemResp, error)
+	ExportTasks(*ExportTasksReq, grpc.ServerStreamingServer[ExportTasksResp]) error
+	ImportTasks(grpc.ClientStreamingServer[ImportTasksReq, ImportTasksResp]) error
+	ExportCalendar(*ExportCalendarReq, grpc.ServerStreamingServer[ExportCalendarResp]) error
+	ImportCalendar(grpc.ClientStreamingServer[ImportCalendarReq, ImportCalendarResp]) error
+	RegenerateFeedToken(context.Context, *RegenerateFeedTokenReq) (*RegenerateFeedTokenResp, error)
+	SaveView(context.Context, *SaveViewReq) (*SaveViewResp, error)
+	ListViews(context.Context, *ListViewsReq) (*ListViewsResp, error)
+	RunView(context.Context, *RunViewReq) (*RunViewResp, error)
+	DeleteView(context.Context, *DeleteViewReq) (*DeleteViewResp, error)
+	ListTags(context.Context, *ListTagsReq) (*ListTagsResp, error)
+	RenameTag(context.Context, *RenameTagReq) (*RenameTagResp, error)
+	MergeTags(context.Context, *MergeTagsReq) (*MergeTagsResp, error)
+	AddProject(context.Context, *AddProjectReq) (*AddProjectResp, error)
+	GetProject(context.Context, *GetProjectReq) (*GetProjectResp, error)
+	ListProjects(context.Context, *ListProjectsReq) (*ListProjectsResp, error)
+	UpdateProject(context.Context, *UpdateProjectReq) (*UpdateProjectResp, error)
+	DeleteProject(context.Context, *DeleteProjectReq) (*DeleteProjectResp, error)
+	MoveTasks(context.Context, *MoveTasksReq) (*MoveTasksResp, error)
+	InviteMember(context.Context, *InviteMemberReq) (*InviteMemberResp, error)
+	ListInvitations(context.Context, *ListInvitationsReq) (*ListInvitationsResp, error)
+	AcceptInvitation(context.Context, *AcceptInvitationReq) (*AcceptInvitationResp, error)
+	ListMembers(context.Context, *ListMembersReq) (*ListMembersResp, error)
+	UpdateMemberRole(context.Context, *UpdateMemberRoleReq) (*UpdateMemberRoleResp, error)
+	RemoveMember(context.Context, *RemoveMemberReq) (*RemoveMemberResp, error)
+	ListAuditEntries(context.Context, *ListAuditEntriesReq) (*ListAuditEntriesResp, error)
 	mustEmbedUnimplementedTodoServer()
 }
 
@@ -155,12 +582,105 @@ func (UnimplementedTodoServer) GetTask(context.Context, *GetTaskReq) (*GetTaskRe
 func (UnimplementedTodoServer) GetAllTasks(context.Context, *GetAllTasksReq) (*GetAllTasksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllTasks not implemented")
 }
+func (UnimplementedTodoServer) SearchTasks(context.Context, *SearchTasksReq) (*SearchTasksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTasks not implemented")
+}
+func (UnimplementedTodoServer) ListAssignedToMe(context.Context, *ListAssignedToMeReq) (*ListAssignedToMeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssignedToMe not implemented")
+}
 func (UnimplementedTodoServer) UpdateTask(context.Context, *UpdateTaskReq) (*UpdateTaskResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
 }
 func (UnimplementedTodoServer) DeleteTask(context.Context, *DeleteTaskReq) (*DeleteTaskResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTodoServer) AddChecklistItem(context.Context, *AddChecklistItemReq) (*AddChecklistItemResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChecklistItem not implemented")
+}
+func (UnimplementedTodoServer) ToggleChecklistItem(context.Context, *ToggleChecklistItemReq) (*ToggleChecklistItemResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
+func (UnimplementedTodoServer) RemoveChecklistItem(context.Context, *RemoveChecklistItemReq) (*RemoveChecklistItemResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChecklistItem not implemented")
+}
+func (UnimplementedTodoServer) MoveChecklistItem(context.Context, *MoveChecklistItemReq) (*MoveChecklistItemResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveChecklistItem not implemented")
+}
+func (UnimplementedTodoServer) ExportTasks(*ExportTasksReq, grpc.ServerStreamingServer[ExportTasksResp]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTasks not implemented")
+}
+func (UnimplementedTodoServer) ImportTasks(grpc.ClientStreamingServer[ImportTasksReq, ImportTasksResp]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTasks not implemented")
+}
+func (UnimplementedTodoServer) ExportCalendar(*ExportCalendarReq, grpc.ServerStreamingServer[ExportCalendarResp]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCalendar not implemented")
+}
+func (UnimplementedTodoServer) ImportCalendar(grpc.ClientStreamingServer[ImportCalendarReq, ImportCalendarResp]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportCalendar not implemented")
+}
+func (UnimplementedTodoServer) RegenerateFeedToken(context.Context, *RegenerateFeedTokenReq) (*RegenerateFeedTokenResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateFeedToken not implemented")
+}
+func (UnimplementedTodoServer) SaveView(context.Context, *SaveViewReq) (*SaveViewResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveView not implemented")
+}
+func (UnimplementedTodoServer) ListViews(context.Context, *ListViewsReq) (*ListViewsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViews not implemented")
+}
+func (UnimplementedTodoServer) RunView(context.Context, *RunViewReq) (*RunViewResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunView not implemented")
+}
+func (UnimplementedTodoServer) DeleteView(context.Context, *DeleteViewReq) (*DeleteViewResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteView not implemented")
+}
+func (UnimplementedTodoServer) ListTags(context.Context, *ListTagsReq) (*ListTagsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedTodoServer) RenameTag(context.Context, *RenameTagReq) (*RenameTagResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedTodoServer) MergeTags(context.Context, *MergeTagsReq) (*MergeTagsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedTodoServer) AddProject(context.Context, *AddProjectReq) (*AddProjectResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProject not implemented")
+}
+func (UnimplementedTodoServer) GetProject(context.Context, *GetProjectReq) (*GetProjectResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedTodoServer) ListProjects(context.Context, *ListProjectsReq) (*ListProjectsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedTodoServer) UpdateProject(context.Context, *UpdateProjectReq) (*UpdateProjectResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedTodoServer) DeleteProject(context.Context, *DeleteProjectReq) (*DeleteProjectResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedTodoServer) MoveTasks(context.Context, *MoveTasksReq) (*MoveTasksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTasks not implemented")
+}
+func (UnimplementedTodoServer) InviteMember(context.Context, *InviteMemberReq) (*InviteMemberResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (UnimplementedTodoServer) ListInvitations(context.Context, *ListInvitationsReq) (*ListInvitationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedTodoServer) AcceptInvitation(context.Context, *AcceptInvitationReq) (*AcceptInvitationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (UnimplementedTodoServer) ListMembers(context.Context, *ListMembersReq) (*ListMembersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedTodoServer) UpdateMemberRole(context.Context, *UpdateMemberRoleReq) (*UpdateMemberRoleResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMemberRole not implemented")
+}
+func (UnimplementedTodoServer) RemoveMember(context.Context, *RemoveMemberReq) (*RemoveMemberResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedTodoServer) ListAuditEntries(context.Context, *ListAuditEntriesReq) (*ListAuditEntriesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEntries not implemented")
+}
 func (UnimplementedTodoServer) mustEmbedUnimplementedTodoServer() {}
 func (UnimplementedTodoServer) testEmbeddedByValue()              {}
 
@@ -272,6 +792,42 @@ func _Todo_GetAllTasks_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Todo_SearchTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTasksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).SearchTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_SearchTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).SearchTasks(ctx, req.(*SearchTasksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListAssignedToMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignedToMeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListAssignedToMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListAssignedToMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListAssignedToMe(ctx, req.(*ListAssignedToMeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Todo_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateTaskReq)
 	if err := dec(in); err != nil {
@@ -308,6 +864,492 @@ func _Todo_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Todo_AddChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChecklistItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).AddChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_AddChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).AddChecklistItem(ctx, req.(*AddChecklistItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleChecklistItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ToggleChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ToggleChecklistItem(ctx, req.(*ToggleChecklistItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_RemoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChecklistItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RemoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RemoveChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RemoveChecklistItem(ctx, req.(*RemoveChecklistItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_MoveChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveChecklistItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).MoveChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_MoveChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).MoveChecklistItem(ctx, req.(*MoveChecklistItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ExportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTasksReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServer).ExportTasks(m, &grpc.GenericServerStream[ExportTasksReq, ExportTasksResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ExportTasksServer = grpc.ServerStreamingServer[ExportTasksResp]
+
+func _Todo_ImportTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServer).ImportTasks(&grpc.GenericServerStream[ImportTasksReq, ImportTasksResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ImportTasksServer = grpc.ClientStreamingServer[ImportTasksReq, ImportTasksResp]
+
+func _Todo_ExportCalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCalendarReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TodoServer).ExportCalendar(m, &grpc.GenericServerStream[ExportCalendarReq, ExportCalendarResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ExportCalendarServer = grpc.ServerStreamingServer[ExportCalendarResp]
+
+func _Todo_ImportCalendar_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TodoServer).ImportCalendar(&grpc.GenericServerStream[ImportCalendarReq, ImportCalendarResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Todo_ImportCalendarServer = grpc.ClientStreamingServer[ImportCalendarReq, ImportCalendarResp]
+
+func _Todo_RegenerateFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateFeedTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RegenerateFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RegenerateFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RegenerateFeedToken(ctx, req.(*RegenerateFeedTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_SaveView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).SaveView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_SaveView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).SaveView(ctx, req.(*SaveViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViewsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListViews(ctx, req.(*ListViewsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_RunView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RunView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RunView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RunView(ctx, req.(*RunViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_DeleteView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteViewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).DeleteView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_DeleteView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).DeleteView(ctx, req.(*DeleteViewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListTags(ctx, req.(*ListTagsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RenameTag(ctx, req.(*RenameTagReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).MergeTags(ctx, req.(*MergeTagsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_AddProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).AddProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_AddProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).AddProject(ctx, req.(*AddProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).GetProject(ctx, req.(*GetProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListProjects(ctx, req.(*ListProjectsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).UpdateProject(ctx, req.(*UpdateProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProjectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).DeleteProject(ctx, req.(*DeleteProjectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_MoveTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTasksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).MoveTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_MoveTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).MoveTasks(ctx, req.(*MoveTasksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).InviteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_InviteMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).InviteMember(ctx, req.(*InviteMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListInvitations(ctx, req.(*ListInvitationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_AcceptInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).AcceptInvitation(ctx, req.(*AcceptInvitationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListMembers(ctx, req.(*ListMembersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_UpdateMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemberRoleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).UpdateMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_UpdateMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).UpdateMemberRole(ctx, req.(*UpdateMemberRoleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).RemoveMember(ctx, req.(*RemoveMemberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Todo_ListAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEntriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoServer).ListAuditEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Todo_ListAuditEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoServer).ListAuditEntries(ctx, req.(*ListAuditEntriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Todo_ServiceDesc is the grpc.ServiceDesc for Todo service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -335,6 +1377,14 @@ var Todo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllTasks",
 			Handler:    _Todo_GetAllTasks_Handler,
 		},
+		{
+			MethodName: "SearchTasks",
+			Handler:    _Todo_SearchTasks_Handler,
+		},
+		{
+			MethodName: "ListAssignedToMe",
+			Handler:    _Todo_ListAssignedToMe_Handler,
+		},
 		{
 			MethodName: "UpdateTask",
 			Handler:    _Todo_UpdateTask_Handler,
@@ -343,7 +1393,128 @@ var Todo_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTask",
 			Handler:    _Todo_DeleteTask_Handler,
 		},
+		{
+			MethodName: "AddChecklistItem",
+			Handler:    _Todo_AddChecklistItem_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _Todo_ToggleChecklistItem_Handler,
+		},
+		{
+			MethodName: "RemoveChecklistItem",
+			Handler:    _Todo_RemoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "MoveChecklistItem",
+			Handler:    _Todo_MoveChecklistItem_Handler,
+		},
+		{
+			MethodName: "RegenerateFeedToken",
+			Handler:    _Todo_RegenerateFeedToken_Handler,
+		},
+		{
+			MethodName: "SaveView",
+			Handler:    _Todo_SaveView_Handler,
+		},
+		{
+			MethodName: "ListViews",
+			Handler:    _Todo_ListViews_Handler,
+		},
+		{
+			MethodName: "RunView",
+			Handler:    _Todo_RunView_Handler,
+		},
+		{
+			MethodName: "DeleteView",
+			Handler:    _Todo_DeleteView_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _Todo_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _Todo_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _Todo_MergeTags_Handler,
+		},
+		{
+			MethodName: "AddProject",
+			Handler:    _Todo_AddProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _Todo_GetProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _Todo_ListProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _Todo_UpdateProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _Todo_DeleteProject_Handler,
+		},
+		{
+			MethodName: "MoveTasks",
+			Handler:    _Todo_MoveTasks_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _Todo_InviteMember_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _Todo_ListInvitations_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _Todo_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _Todo_ListMembers_Handler,
+		},
+		{
+			MethodName: "UpdateMemberRole",
+			Handler:    _Todo_UpdateMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _Todo_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ListAuditEntries",
+			Handler:    _Todo_ListAuditEntries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTasks",
+			Handler:       _Todo_ExportTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTasks",
+			Handler:       _Todo_ImportTasks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCalendar",
+			Handler:       _Todo_ExportCalendar_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCalendar",
+			Handler:       _Todo_ImportCalendar_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
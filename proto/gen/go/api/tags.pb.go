@@ -0,0 +1,442 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.1
+// 	protoc        v5.29.2
+// source: tags.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TagCount is a tag along with the number of tasks holding it.
+type TagCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	TaskCount     int32                  `protobuf:"varint,2,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_tags_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TagCount) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagCount) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+type ListTagsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsReq) Reset() {
+	*x = ListTagsReq{}
+	mi := &file_tags_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsReq) ProtoMessage() {}
+
+func (x *ListTagsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsReq.ProtoReflect.Descriptor instead.
+func (*ListTagsReq) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{1}
+}
+
+type ListTagsResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tags holds every tag of the user's tasks as it is stored, in tag order
+	Tags          []*TagCount `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResp) Reset() {
+	*x = ListTagsResp{}
+	mi := &file_tags_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResp) ProtoMessage() {}
+
+func (x *ListTagsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResp.ProtoReflect.Descriptor instead.
+func (*ListTagsResp) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTagsResp) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// RenameTagReq replaces a tag with a new tag on every task of the user holding it.
+// Tasks are updated in batches; when a batch fails, the batches before it stay updated,
+// and retrying the request updates the remaining tasks.
+type RenameTagReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tag is matched exactly as it is stored, so that variants such as "Work " can be renamed
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// new_tag is normalized to lower case without surrounding or repeated white space
+	NewTag        string `protobuf:"bytes,2,opt,name=new_tag,json=newTag,proto3" json:"new_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagReq) Reset() {
+	*x = RenameTagReq{}
+	mi := &file_tags_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagReq) ProtoMessage() {}
+
+func (x *RenameTagReq) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagReq.ProtoReflect.Descriptor instead.
+func (*RenameTagReq) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RenameTagReq) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RenameTagReq) GetNewTag() string {
+	if x != nil {
+		return x.NewTag
+	}
+	return ""
+}
+
+type RenameTagResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedTasks  int32                  `protobuf:"varint,1,opt,name=updated_tasks,json=updatedTasks,proto3" json:"updated_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagResp) Reset() {
+	*x = RenameTagResp{}
+	mi := &file_tags_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagResp) ProtoMessage() {}
+
+func (x *RenameTagResp) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagResp.ProtoReflect.Descriptor instead.
+func (*RenameTagResp) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenameTagResp) GetUpdatedTasks() int32 {
+	if x != nil {
+		return x.UpdatedTasks
+	}
+	return 0
+}
+
+// MergeTagsReq replaces each of the tags with the target tag on every task of the user holding any of them,
+// updating tasks in batches as RenameTagReq does.
+type MergeTagsReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tags are matched exactly as they are stored
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// target is normalized as RenameTagReq.new_tag is
+	Target        string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsReq) Reset() {
+	*x = MergeTagsReq{}
+	mi := &file_tags_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsReq) ProtoMessage() {}
+
+func (x *MergeTagsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsReq.ProtoReflect.Descriptor instead.
+func (*MergeTagsReq) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MergeTagsReq) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MergeTagsReq) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type MergeTagsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedTasks  int32                  `protobuf:"varint,1,opt,name=updated_tasks,json=updatedTasks,proto3" json:"updated_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsResp) Reset() {
+	*x = MergeTagsResp{}
+	mi := &file_tags_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsResp) ProtoMessage() {}
+
+func (x *MergeTagsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_tags_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsResp.ProtoReflect.Descriptor instead.
+func (*MergeTagsResp) Descriptor() ([]byte, []int) {
+	return file_tags_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MergeTagsResp) GetUpdatedTasks() int32 {
+	if x != nil {
+		return x.UpdatedTasks
+	}
+	return 0
+}
+
+var File_tags_proto protoreflect.FileDescriptor
+
+var file_tags_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x74, 0x61, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x70,
+	0x69, 0x22, 0x3b, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12,
+	0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x0d,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x22, 0x31, 0x0a,
+	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x21, 0x0a,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x54, 0x61, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x22, 0x39, 0x0a, 0x0c, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74,
+	0x61, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x65, 0x77, 0x54, 0x61, 0x67, 0x22, 0x34, 0x0a, 0x0d, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x23, 0x0a, 0x0d,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b,
+	0x73, 0x22, 0x3a, 0x0a, 0x0c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x34, 0x0a,
+	0x0d, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x23,
+	0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61,
+	0x73, 0x6b, 0x73, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f,
+	0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tags_proto_rawDescOnce sync.Once
+	file_tags_proto_rawDescData = file_tags_proto_rawDesc
+)
+
+func file_tags_proto_rawDescGZIP() []byte {
+	file_tags_proto_rawDescOnce.Do(func() {
+		file_tags_proto_rawDescData = protoimpl.X.CompressGZIP(file_tags_proto_rawDescData)
+	})
+	return file_tags_proto_rawDescData
+}
+
+var file_tags_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_tags_proto_goTypes = []any{
+	(*TagCount)(nil),      // 0: api.TagCount
+	(*ListTagsReq)(nil),   // 1: api.ListTagsReq
+	(*ListTagsResp)(nil),  // 2: api.ListTagsResp
+	(*RenameTagReq)(nil),  // 3: api.RenameTagReq
+	(*RenameTagResp)(nil), // 4: api.RenameTagResp
+	(*MergeTagsReq)(nil),  // 5: api.MergeTagsReq
+	(*MergeTagsResp)(nil), // 6: api.MergeTagsResp
+}
+var file_tags_proto_depIdxs = []int32{
+	0, // 0: api.ListTagsResp.tags:type_name -> api.TagCount
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_tags_proto_init() }
+func file_tags_proto_init() {
+	if File_tags_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tags_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tags_proto_goTypes,
+		DependencyIndexes: file_tags_proto_depIdxs,
+		MessageInfos:      file_tags_proto_msgTypes,
+	}.Build()
+	File_tags_proto = out.File
+	file_tags_proto_rawDesc = nil
+	file_tags_proto_goTypes = nil
+	file_tags_proto_depIdxs = nil
+}
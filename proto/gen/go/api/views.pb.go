@@ -0,0 +1,572 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.1
+// 	protoc        v5.29.2
+// source: views.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// View is a named query of the user's tasks along with the order they are listed in.
+// Every user has the built-in views Today, Overdue, Upcoming 7 days and Blocked, the last listing
+// the open tasks with a parent that is neither complete nor cancelled.
+type View struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name identifies the view; saved views are found by their exact name and built-in views ignoring case
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// query is written in the query language of GetAllTasksReq.query; an empty query lists every task
+	Query         string        `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	SortBy        SortBy        `protobuf:"varint,3,opt,name=sort_by,json=sortBy,proto3,enum=api.SortBy" json:"sort_by,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,4,opt,name=sort_direction,json=sortDirection,proto3,enum=api.SortDirection" json:"sort_direction,omitempty"`
+	// built_in views are provided by the server and can neither be saved over nor deleted
+	BuiltIn       bool `protobuf:"varint,5,opt,name=built_in,json=builtIn,proto3" json:"built_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	mi := &file_views_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *View) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *View) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *View) GetSortBy() SortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return SortBy_SORT_BY_UNSPECIFIED
+}
+
+func (x *View) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_ASCENDING
+}
+
+func (x *View) GetBuiltIn() bool {
+	if x != nil {
+		return x.BuiltIn
+	}
+	return false
+}
+
+// SaveViewReq saves a view, replacing the user's view of the same name.
+type SaveViewReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// view.built_in is ignored
+	View          *View `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveViewReq) Reset() {
+	*x = SaveViewReq{}
+	mi := &file_views_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveViewReq) ProtoMessage() {}
+
+func (x *SaveViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveViewReq.ProtoReflect.Descriptor instead.
+func (*SaveViewReq) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SaveViewReq) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type SaveViewResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveViewResp) Reset() {
+	*x = SaveViewResp{}
+	mi := &file_views_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveViewResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveViewResp) ProtoMessage() {}
+
+func (x *SaveViewResp) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveViewResp.ProtoReflect.Descriptor instead.
+func (*SaveViewResp) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{2}
+}
+
+type ListViewsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsReq) Reset() {
+	*x = ListViewsReq{}
+	mi := &file_views_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsReq) ProtoMessage() {}
+
+func (x *ListViewsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsReq.ProtoReflect.Descriptor instead.
+func (*ListViewsReq) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{3}
+}
+
+type ListViewsResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// views holds the built-in views followed by the user's saved views in name order
+	Views         []*View `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsResp) Reset() {
+	*x = ListViewsResp{}
+	mi := &file_views_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsResp) ProtoMessage() {}
+
+func (x *ListViewsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsResp.ProtoReflect.Descriptor instead.
+func (*ListViewsResp) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListViewsResp) GetViews() []*View {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+// RunViewReq lists the tasks of a view as GetAllTasks does.
+type RunViewReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// page_size is the maximum number of tasks to return; all tasks are returned when it is 0.
+	// Pages may hold fewer tasks than page_size.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of a previous response of the same view
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunViewReq) Reset() {
+	*x = RunViewReq{}
+	mi := &file_views_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunViewReq) ProtoMessage() {}
+
+func (x *RunViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunViewReq.ProtoReflect.Descriptor instead.
+func (*RunViewReq) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunViewReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunViewReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *RunViewReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type RunViewResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// next_page_token is empty when there are no more tasks to return
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunViewResp) Reset() {
+	*x = RunViewResp{}
+	mi := &file_views_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunViewResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunViewResp) ProtoMessage() {}
+
+func (x *RunViewResp) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunViewResp.ProtoReflect.Descriptor instead.
+func (*RunViewResp) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunViewResp) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *RunViewResp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteViewReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewReq) Reset() {
+	*x = DeleteViewReq{}
+	mi := &file_views_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewReq) ProtoMessage() {}
+
+func (x *DeleteViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewReq.ProtoReflect.Descriptor instead.
+func (*DeleteViewReq) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteViewReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteViewResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewResp) Reset() {
+	*x = DeleteViewResp{}
+	mi := &file_views_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewResp) ProtoMessage() {}
+
+func (x *DeleteViewResp) ProtoReflect() protoreflect.Message {
+	mi := &file_views_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewResp.ProtoReflect.Descriptor instead.
+func (*DeleteViewResp) Descriptor() ([]byte, []int) {
+	return file_views_proto_rawDescGZIP(), []int{8}
+}
+
+var File_views_proto protoreflect.FileDescriptor
+
+var file_views_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x76, 0x69, 0x65, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61,
+	0x70, 0x69, 0x1a, 0x0b, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xac, 0x01, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x24, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x42, 0x79,
+	0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x39, 0x0a, 0x0e, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x5f, 0x69, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x49, 0x6e, 0x22, 0x2c,
+	0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x1d, 0x0a,
+	0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x22, 0x0e, 0x0a, 0x0c,
+	0x53, 0x61, 0x76, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x22, 0x0e, 0x0a, 0x0c,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x22, 0x30, 0x0a, 0x0d,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a,
+	0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x22, 0x5c,
+	0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x56, 0x0a, 0x0b,
+	0x52, 0x75, 0x6e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x05, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x23, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x42, 0x0e, 0x5a, 0x0c, 0x2e,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_views_proto_rawDescOnce sync.Once
+	file_views_proto_rawDescData = file_views_proto_rawDesc
+)
+
+func file_views_proto_rawDescGZIP() []byte {
+	file_views_proto_rawDescOnce.Do(func() {
+		file_views_proto_rawDescData = protoimpl.X.CompressGZIP(file_views_proto_rawDescData)
+	})
+	return file_views_proto_rawDescData
+}
+
+var file_views_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_views_proto_goTypes = []any{
+	(*View)(nil),           // 0: api.View
+	(*SaveViewReq)(nil),    // 1: api.SaveViewReq
+	(*SaveViewResp)(nil),   // 2: api.SaveViewResp
+	(*ListViewsReq)(nil),   // 3: api.ListViewsReq
+	(*ListViewsResp)(nil),  // 4: api.ListViewsResp
+	(*RunViewReq)(nil),     // 5: api.RunViewReq
+	(*RunViewResp)(nil),    // 6: api.RunViewResp
+	(*DeleteViewReq)(nil),  // 7: api.DeleteViewReq
+	(*DeleteViewResp)(nil), // 8: api.DeleteViewResp
+	(SortBy)(0),            // 9: api.SortBy
+	(SortDirection)(0),     // 10: api.SortDirection
+	(*Task)(nil),           // 11: api.Task
+}
+var file_views_proto_depIdxs = []int32{
+	9,  // 0: api.View.sort_by:type_name -> api.SortBy
+	10, // 1: api.View.sort_direction:type_name -> api.SortDirection
+	0,  // 2: api.SaveViewReq.view:type_name -> api.View
+	0,  // 3: api.ListViewsResp.views:type_name -> api.View
+	11, // 4: api.RunViewResp.tasks:type_name -> api.Task
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_views_proto_init() }
+func file_views_proto_init() {
+	if File_views_proto != nil {
+		return
+	}
+	file_tasks_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_views_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_views_proto_goTypes,
+		DependencyIndexes: file_views_proto_depIdxs,
+		MessageInfos:      file_views_proto_msgTypes,
+	}.Build()
+	File_views_proto = out.File
+	file_views_proto_rawDesc = nil
+	file_views_proto_goTypes = nil
+	file_views_proto_depIdxs = nil
+}
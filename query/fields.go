@@ -0,0 +1,202 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+)
+
+// statuses maps the status values of queries to the statuses tasks are stored with.
+var statuses = map[string]string{
+	"incomplete":  "INCOMPLETE",
+	"complete":    "COMPLETE",
+	"in_progress": "IN_PROGRESS",
+	"in-progress": "IN_PROGRESS",
+	"blocked":     "BLOCKED",
+	"cancelled":   "CANCELLED",
+	"canceled":    "CANCELLED",
+	"deferred":    "DEFERRED",
+}
+
+// priorities maps the priority values of queries to the priorities tasks are stored with, which are ordered
+// from P0 to P3, followed by no priority.
+var priorities = map[string]string{
+	"p0":   "P0",
+	"p1":   "P1",
+	"p2":   "P2",
+	"p3":   "P3",
+	"none": "PRIORITY_UNSPECIFIED",
+}
+
+// dateKeys maps the date fields of queries to the attributes they compare.
+var dateKeys = map[string]string{
+	"due":       storage.DueDateKey,
+	"created":   storage.CreatedAtKey,
+	"updated":   storage.UpdatedAtKey,
+	"completed": storage.CompletedAtKey,
+}
+
+const day = 24 * time.Hour
+
+// relativeTime matches times relative to now, such as 7d, -2w or 3h.
+var relativeTime = regexp.MustCompile(`^([+-]?)(\d+)([hdw])$`)
+
+// relativeUnits are the units of relative times.
+var relativeUnits = map[string]time.Duration{
+	"h": time.Hour,
+	"d": day,
+	"w": 7 * day,
+}
+
+// errOnlyEqual is returned when a field that can only be compared for equality is compared otherwise.
+var errOnlyEqual = errors.New("can only be compared with : or =")
+
+// fieldFilter returns the filter of a field compared with a value.
+func (p *parser) fieldFilter(field, op, value string) (storage.Filter, error) {
+	equal := op == ":" || op == "="
+	switch field {
+	case "status":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		if strings.EqualFold(value, "open") {
+			// open tasks are those still to be done
+			return storage.And{
+				storage.Not{Filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "COMPLETE"}},
+				storage.Not{Filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "CANCELLED"}},
+			}, nil
+		}
+		status, ok := statuses[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", value)
+		}
+		return storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: status}, nil
+	case "priority":
+		priority, ok := priorities[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown priority %q", value)
+		}
+		return storage.Compare{Key: storage.PriorityKey, Op: compareOp(op), Value: priority}, nil
+	case "tag":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		tag := storage.NormalizeTag(value)
+		if tag == "" {
+			return nil, errors.New("tag cannot be blank")
+		}
+		return storage.Contains{Key: storage.TagsKey, Value: tag}, nil
+	case "project":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		// tasks without a project are found with -has:project
+		return storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: value}, nil
+	case "title":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		return storage.Contains{Key: storage.TitleKey, Value: value}, nil
+	case "description":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		return storage.Contains{Key: storage.DescriptionKey, Value: value}, nil
+	case "has":
+		if !equal {
+			return nil, errOnlyEqual
+		}
+		// comparisons never match tasks without a value of an optional attribute
+		switch strings.ToLower(value) {
+		case "due":
+			return storage.Compare{Key: storage.DueDateKey, Op: storage.OpGt, Value: int64(0)}, nil
+		case "effort":
+			return storage.Compare{Key: storage.EffortMinutesKey, Op: storage.OpGt, Value: int64(0)}, nil
+		case "project":
+			return storage.Not{Filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: ""}}, nil
+		default:
+			return nil, fmt.Errorf("unknown attribute %q", value)
+		}
+	case "effort":
+		effort, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		if effort < time.Minute {
+			return nil, errors.New("effort must be at least a minute")
+		}
+		return storage.Compare{Key: storage.EffortMinutesKey, Op: compareOp(op), Value: int64(effort / time.Minute)}, nil
+	}
+	key, ok := dateKeys[field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	t, wholeDay, err := p.parseTime(value)
+	if err != nil {
+		return nil, err
+	}
+	if equal {
+		// a time matches the day it falls on
+		start := t.Truncate(day)
+		return storage.And{
+			storage.Compare{Key: key, Op: storage.OpGe, Value: start.Unix()},
+			storage.Compare{Key: key, Op: storage.OpLt, Value: start.Add(day).Unix()},
+		}, nil
+	}
+	if !wholeDay {
+		return storage.Compare{Key: key, Op: compareOp(op), Value: t.Unix()}, nil
+	}
+	// a date is compared as the whole day, so that due<=2024-04-13 includes the 13th
+	switch op {
+	case "<", ">=":
+		return storage.Compare{Key: key, Op: compareOp(op), Value: t.Unix()}, nil
+	case "<=":
+		return storage.Compare{Key: key, Op: storage.OpLt, Value: t.Add(day).Unix()}, nil
+	default:
+		return storage.Compare{Key: key, Op: storage.OpGe, Value: t.Add(day).Unix()}, nil
+	}
+}
+
+// compareOp returns the filter operator of a query operator.
+func compareOp(op string) storage.Op {
+	if op == ":" {
+		return storage.OpEq
+	}
+	return storage.Op(op)
+}
+
+// parseTime parses a date, such as 2024-04-13 or today, returning the start of the day in UTC, or a time
+// relative to now, such as 7d, returning false.
+func (p *parser) parseTime(value string) (time.Time, bool, error) {
+	today := p.now.Truncate(day)
+	switch strings.ToLower(value) {
+	case "now":
+		return p.now, false, nil
+	case "today":
+		return today, true, nil
+	case "tomorrow":
+		return today.Add(day), true, nil
+	case "yesterday":
+		return today.Add(-day), true, nil
+	}
+	if m := relativeTime.FindStringSubmatch(value); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		offset := time.Duration(n) * relativeUnits[m[3]]
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return p.now.Add(offset), false, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q, expected a date such as 2024-04-13, today, now or a time relative to now such as 7d", value)
+	}
+	return date, true, nil
+}
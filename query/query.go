@@ -0,0 +1,314 @@
+// Package query parses the query language tasks are filtered with into storage filters.
+//
+// A query is a list of terms that tasks must all match, such as
+//
+//	status:incomplete tag:work due<7d -tag:someday "release notes"
+//
+// A term is a word or a quoted phrase, matched against titles and descriptions ignoring case, or a field
+// compared with a value, as in tag:work or due<7d. Fields are compared with ":" or "=", and fields holding
+// dates, efforts or priorities also with "<", "<=", ">" and ">=". A term prefixed with "-" matches the tasks
+// the term does not, terms joined by OR match the tasks any of them do, and parentheses group terms, as in
+//
+//	(tag:work OR tag:home) -status:complete
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"todo/interfaces/storage"
+	"unicode"
+)
+
+// tokenKind is the kind of a token of a query.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenPhrase
+	tokenField
+	tokenNot
+	tokenOr
+	tokenAnd
+	tokenLParen
+	tokenRParen
+)
+
+// token is a token of a query. The field, op and value of a field term are set for tokenField,
+// and text holds the word or phrase of tokenWord and tokenPhrase.
+type token struct {
+	kind  tokenKind
+	pos   int
+	text  string
+	field string
+	op    string
+	value string
+}
+
+// ops are the comparison operators of field terms, longest first.
+var ops = []string{"<=", ">=", ":", "=", "<", ">"}
+
+// isTermEnd reports whether r ends an unquoted word or value.
+func isTermEnd(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')'
+}
+
+// lex splits a query into tokens, ending with tokenEOF.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for pos := 0; ; {
+		for pos < len(runes) && unicode.IsSpace(runes[pos]) {
+			pos++
+		}
+		if pos == len(runes) {
+			return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+		}
+		start := pos
+		switch r := runes[pos]; {
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, pos: start})
+			pos++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, pos: start})
+			pos++
+		case r == '-' && pos+1 < len(runes) && !unicode.IsSpace(runes[pos+1]) && runes[pos+1] != ')':
+			tokens = append(tokens, token{kind: tokenNot, pos: start})
+			pos++
+		case r == '"':
+			text, end, err := lexQuoted(runes, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, pos: start, text: text})
+			pos = end
+		default:
+			tok, end, err := lexTerm(runes, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos = end
+		}
+	}
+}
+
+// lexQuoted reads the quoted string starting at pos, in which \" and \\ escape a quote and a backslash,
+// returning its text and the position after it.
+func lexQuoted(runes []rune, pos int) (string, int, error) {
+	var text strings.Builder
+	for i := pos + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\') {
+				i++
+			}
+			text.WriteRune(runes[i])
+		case '"':
+			return text.String(), i + 1, nil
+		default:
+			text.WriteRune(runes[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quote at %d", pos)
+}
+
+// lexTerm reads the word or field term starting at pos, returning it and the position after it.
+func lexTerm(runes []rune, pos int) (token, int, error) {
+	start := pos
+	for pos < len(runes) && (unicode.IsLetter(runes[pos]) || runes[pos] == '_') {
+		pos++
+	}
+	if pos > start {
+		rest := string(runes[pos:])
+		for _, op := range ops {
+			if !strings.HasPrefix(rest, op) {
+				continue
+			}
+			tok := token{kind: tokenField, pos: start, field: strings.ToLower(string(runes[start:pos])), op: op}
+			pos += len(op)
+			if pos < len(runes) && runes[pos] == '"' {
+				value, end, err := lexQuoted(runes, pos)
+				if err != nil {
+					return token{}, 0, err
+				}
+				tok.value = value
+				return tok, end, nil
+			}
+			valueStart := pos
+			for pos < len(runes) && !isTermEnd(runes[pos]) {
+				pos++
+			}
+			if pos == valueStart {
+				return token{}, 0, fmt.Errorf("missing value of %s at %d", tok.field, start)
+			}
+			tok.value = string(runes[valueStart:pos])
+			return tok, pos, nil
+		}
+	}
+	for pos < len(runes) && !isTermEnd(runes[pos]) {
+		pos++
+	}
+	text := string(runes[start:pos])
+	switch text {
+	case "OR":
+		return token{kind: tokenOr, pos: start}, pos, nil
+	case "AND":
+		return token{kind: tokenAnd, pos: start}, pos, nil
+	}
+	return token{kind: tokenWord, pos: start, text: text}, pos, nil
+}
+
+// parser parses the tokens of a query, evaluating relative dates against now.
+type parser struct {
+	tokens []token
+	next   int
+	now    time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// Parse parses a query into a filter matching the tasks the query does, with relative dates such as 7d
+// evaluated against now. An empty query matches every task.
+func Parse(query string, now time.Time) (storage.Filter, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, now: now.UTC()}
+	if p.peek().kind == tokenEOF {
+		return storage.And{}, nil
+	}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at %d", describe(tok), tok.pos)
+	}
+	return filter, nil
+}
+
+// parseOr parses terms joined by OR.
+func (p *parser) parseOr() (storage.Filter, error) {
+	filter, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := storage.Or{filter}
+	for p.peek().kind == tokenOr {
+		p.advance()
+		filter, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, filter)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+// parseAnd parses one or more terms, optionally joined by AND.
+func (p *parser) parseAnd() (storage.Filter, error) {
+	var and storage.And
+	for {
+		switch p.peek().kind {
+		case tokenEOF, tokenRParen, tokenOr:
+			if len(and) == 0 {
+				tok := p.peek()
+				return nil, fmt.Errorf("expected a term before %s at %d", describe(tok), tok.pos)
+			}
+			if len(and) == 1 {
+				return and[0], nil
+			}
+			return and, nil
+		case tokenAnd:
+			if len(and) == 0 {
+				return nil, fmt.Errorf("expected a term before AND at %d", p.peek().pos)
+			}
+			p.advance()
+			if kind := p.peek().kind; kind == tokenEOF || kind == tokenRParen || kind == tokenOr || kind == tokenAnd {
+				tok := p.peek()
+				return nil, fmt.Errorf("expected a term after AND, not %s at %d", describe(tok), tok.pos)
+			}
+		}
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, filter)
+	}
+}
+
+// parseUnary parses a term, which may be negated.
+func (p *parser) parseUnary() (storage.Filter, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenNot:
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return storage.Not{Filter: filter}, nil
+	case tokenLParen:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next := p.advance(); next.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) to close ( at %d, not %s", tok.pos, describe(next))
+		}
+		return filter, nil
+	case tokenWord, tokenPhrase:
+		return textFilter(tok.text), nil
+	case tokenField:
+		filter, err := p.fieldFilter(tok.field, tok.op, tok.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid term %s%s%s at %d: %v", tok.field, tok.op, tok.value, tok.pos, err)
+		}
+		return filter, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s at %d", describe(tok), tok.pos)
+	}
+}
+
+// describe names a token in errors.
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenOr:
+		return "OR"
+	case tokenAnd:
+		return "AND"
+	case tokenLParen:
+		return "("
+	case tokenRParen:
+		return ")"
+	case tokenNot:
+		return "-"
+	default:
+		return fmt.Sprintf("%q", tok.text)
+	}
+}
+
+// textFilter matches tasks whose title or description holds the text, ignoring case.
+func textFilter(text string) storage.Filter {
+	return storage.Or{
+		storage.Contains{Key: storage.TitleKey, Value: text},
+		storage.Contains{Key: storage.DescriptionKey, Value: text},
+	}
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"todo/interfaces/storage"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 4, 13, 15, 30, 0, 0, time.UTC)
+	day13 := time.Date(2024, 4, 13, 0, 0, 0, 0, time.UTC).Unix()
+	day14 := time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC).Unix()
+	work := storage.Contains{Key: storage.TagsKey, Value: "work"}
+	home := storage.Contains{Key: storage.TagsKey, Value: "home"}
+	incomplete := storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "INCOMPLETE"}
+	text := func(s string) storage.Filter {
+		return storage.Or{
+			storage.Contains{Key: storage.TitleKey, Value: s},
+			storage.Contains{Key: storage.DescriptionKey, Value: s},
+		}
+	}
+	tests := []struct {
+		name    string
+		query   string
+		want    storage.Filter
+		wantErr bool
+	}{
+		{
+			name:  "example",
+			query: `status:incomplete tag:work due<7d -tag:someday "release notes"`,
+			want: storage.And{
+				incomplete,
+				work,
+				storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: now.Add(7 * day).Unix()},
+				storage.Not{Filter: storage.Contains{Key: storage.TagsKey, Value: "someday"}},
+				text("release notes"),
+			},
+		},
+		{name: "empty", query: "  ", want: storage.And{}},
+		{name: "single term", query: "tag:work", want: work},
+		{name: "word", query: "budget", want: text("budget")},
+		{name: "quoted value", query: `tag:"home office"`, want: storage.Contains{Key: storage.TagsKey, Value: "home office"}},
+		{name: "escaped quote", query: `"say \"hi\""`, want: text(`say "hi"`)},
+		{name: "tag is normalized", query: `tag:" Home  Office"`, want: storage.Contains{Key: storage.TagsKey, Value: "home office"}},
+		{name: "or", query: "tag:work OR tag:home", want: storage.Or{work, home}},
+		{name: "and binds tighter than or", query: "status:incomplete tag:work OR tag:home", want: storage.Or{storage.And{incomplete, work}, home}},
+		{name: "and keyword", query: "status:incomplete AND tag:work", want: storage.And{incomplete, work}},
+		{name: "parentheses", query: "status:incomplete (tag:work OR tag:home)", want: storage.And{incomplete, storage.Or{work, home}}},
+		{name: "negated group", query: "-(tag:work OR tag:home)", want: storage.Not{Filter: storage.Or{work, home}}},
+		{name: "lone dash is a word", query: "a - b", want: storage.And{text("a"), text("-"), text("b")}},
+		{name: "status ignores case", query: "Status:In-Progress", want: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "IN_PROGRESS"}},
+		{
+			name:  "open status",
+			query: "status:open",
+			want: storage.And{
+				storage.Not{Filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "COMPLETE"}},
+				storage.Not{Filter: storage.Compare{Key: storage.StatusKey, Op: storage.OpEq, Value: "CANCELLED"}},
+			},
+		},
+		{name: "priority", query: "priority<=p1", want: storage.Compare{Key: storage.PriorityKey, Op: storage.OpLe, Value: "P1"}},
+		{name: "no priority", query: "priority:none", want: storage.Compare{Key: storage.PriorityKey, Op: storage.OpEq, Value: "PRIORITY_UNSPECIFIED"}},
+		{
+			name:  "on a date",
+			query: "due:2024-04-13",
+			want: storage.And{
+				storage.Compare{Key: storage.DueDateKey, Op: storage.OpGe, Value: day13},
+				storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: day14},
+			},
+		},
+		{
+			name:  "on today",
+			query: "completed:today",
+			want: storage.And{
+				storage.Compare{Key: storage.CompletedAtKey, Op: storage.OpGe, Value: day13},
+				storage.Compare{Key: storage.CompletedAtKey, Op: storage.OpLt, Value: day14},
+			},
+		},
+		{name: "before a date", query: "due<2024-04-13", want: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: day13}},
+		{name: "up to a date includes the day", query: "due<=2024-04-13", want: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: day14}},
+		{name: "after a date excludes the day", query: "due>today", want: storage.Compare{Key: storage.DueDateKey, Op: storage.OpGe, Value: day14}},
+		{name: "from a date", query: "created>=2024-04-13", want: storage.Compare{Key: storage.CreatedAtKey, Op: storage.OpGe, Value: day13}},
+		{name: "past relative time", query: "updated>-2w", want: storage.Compare{Key: storage.UpdatedAtKey, Op: storage.OpGt, Value: now.Add(-14 * day).Unix()}},
+		{name: "now", query: "due<now", want: storage.Compare{Key: storage.DueDateKey, Op: storage.OpLt, Value: now.Unix()}},
+		{name: "effort", query: "effort<1h30m", want: storage.Compare{Key: storage.EffortMinutesKey, Op: storage.OpLt, Value: int64(90)}},
+		{name: "has due date", query: "has:due", want: storage.Compare{Key: storage.DueDateKey, Op: storage.OpGt, Value: int64(0)}},
+		{name: "without effort", query: "-has:effort", want: storage.Not{Filter: storage.Compare{Key: storage.EffortMinutesKey, Op: storage.OpGt, Value: int64(0)}}},
+		{name: "project", query: "project:1b2c", want: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: "1b2c"}},
+		{name: "without project", query: "-has:project", want: storage.Not{Filter: storage.Not{Filter: storage.Compare{Key: storage.ProjectIDKey, Op: storage.OpEq, Value: ""}}}},
+		{name: "title", query: "title:notes", want: storage.Contains{Key: storage.TitleKey, Value: "notes"}},
+		{name: "unknown field", query: "color:red", wantErr: true},
+		{name: "unknown status", query: "status:done", wantErr: true},
+		{name: "tag compared by order", query: "tag<work", wantErr: true},
+		{name: "project compared by order", query: "project>1b2c", wantErr: true},
+		{name: "invalid date", query: "due<2024-13-01", wantErr: true},
+		{name: "effort under a minute", query: "effort:30s", wantErr: true},
+		{name: "blank tag", query: `tag:" "`, wantErr: true},
+		{name: "missing value", query: "tag: work", wantErr: true},
+		{name: "unterminated quote", query: `"release notes`, wantErr: true},
+		{name: "unclosed parenthesis", query: "(tag:work", wantErr: true},
+		{name: "unopened parenthesis", query: "tag:work)", wantErr: true},
+		{name: "dangling or", query: "tag:work OR", wantErr: true},
+		{name: "leading and", query: "AND tag:work", wantErr: true},
+		{name: "empty group", query: "()", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Package search keeps an in-memory inverted index of the titles and descriptions of tasks and ranks
+// the tasks matching a query by relevance and recency.
+//
+// Words are indexed by their stems, so a query word matches every form of it, and a query word also
+// matches the words it is a prefix of, so that partly typed words find tasks. Each user's tasks are
+// indexed and searched separately.
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ranking parameters
+const (
+	// titleWeight is how many times more a word in the title counts than a word in the description.
+	titleWeight = 2
+	// prefixWeight scales the score of a word matched only by a query word being its prefix.
+	prefixWeight = 0.5
+	// bm25K1 and bm25B are the term frequency saturation and length normalization of BM25.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// recencyWeight is the share by which the score of a task updated just now is raised,
+	// and recencyHalfLife is the age at which the raise is halved.
+	recencyWeight   = 0.5
+	recencyHalfLife = 30 * 24 * time.Hour
+)
+
+// Document is the searchable text of a task.
+type Document struct {
+	UserID      string
+	TaskID      string
+	Title       string
+	Description string
+	// UpdatedAt is when the task was last updated, as a Unix timestamp.
+	UpdatedAt int64
+}
+
+// Result is a task matching a query.
+type Result struct {
+	TaskID string
+	Score  float64
+}
+
+// Index is an inverted index of the tasks of every user. It is safe for concurrent use.
+type Index struct {
+	mu    sync.RWMutex
+	users map[string]*userIndex
+}
+
+// userIndex indexes the tasks of one user.
+type userIndex struct {
+	docs map[string]*document
+	// postings maps each stem to the tasks containing it
+	postings map[string]map[string]*posting
+	// words counts the tasks containing each word, for prefix matching
+	words map[string]int
+	// totalLength is the sum of the lengths of docs
+	totalLength int
+}
+
+// document is an indexed task.
+type document struct {
+	// stems and words are the distinct stems and words of the task
+	stems     []string
+	words     []string
+	length    int
+	updatedAt int64
+}
+
+// posting counts the occurrences of a stem in a task.
+type posting struct {
+	title       int
+	description int
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{users: make(map[string]*userIndex)}
+}
+
+// Put indexes a task, replacing the task if it is already indexed. A task is not replaced by
+// a document last updated before the indexed one, so that racing writes keep the latest text.
+func (i *Index) Put(doc Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	user := i.users[doc.UserID]
+	if user == nil {
+		user = &userIndex{
+			docs:     make(map[string]*document),
+			postings: make(map[string]map[string]*posting),
+			words:    make(map[string]int),
+		}
+		i.users[doc.UserID] = user
+	}
+	if indexed, ok := user.docs[doc.TaskID]; ok {
+		if indexed.updatedAt > doc.UpdatedAt {
+			return
+		}
+		user.remove(doc.TaskID)
+	}
+	user.add(doc)
+}
+
+// Delete removes a task from the index, if it is indexed.
+func (i *Index) Delete(userID, taskID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	user := i.users[userID]
+	if user == nil {
+		return
+	}
+	user.remove(taskID)
+	if len(user.docs) == 0 {
+		delete(i.users, userID)
+	}
+}
+
+// add indexes a task that is not indexed.
+func (u *userIndex) add(doc Document) {
+	indexed := &document{updatedAt: doc.UpdatedAt}
+	postings := map[string]*posting{}
+	seenWords := map[string]bool{}
+	for field, text := range []string{doc.Title, doc.Description} {
+		for _, word := range Tokenize(text) {
+			indexed.length++
+			if !seenWords[word] {
+				seenWords[word] = true
+				indexed.words = append(indexed.words, word)
+				u.words[word]++
+			}
+			stem := Stem(word)
+			p := postings[stem]
+			if p == nil {
+				p = &posting{}
+				postings[stem] = p
+				indexed.stems = append(indexed.stems, stem)
+			}
+			if field == 0 {
+				p.title++
+			} else {
+				p.description++
+			}
+		}
+	}
+	for stem, p := range postings {
+		if u.postings[stem] == nil {
+			u.postings[stem] = make(map[string]*posting)
+		}
+		u.postings[stem][doc.TaskID] = p
+	}
+	u.docs[doc.TaskID] = indexed
+	u.totalLength += indexed.length
+}
+
+// remove removes a task from the index, if it is indexed.
+func (u *userIndex) remove(taskID string) {
+	indexed, ok := u.docs[taskID]
+	if !ok {
+		return
+	}
+	for _, stem := range indexed.stems {
+		delete(u.postings[stem], taskID)
+		if len(u.postings[stem]) == 0 {
+			delete(u.postings, stem)
+		}
+	}
+	for _, word := range indexed.words {
+		if u.words[word]--; u.words[word] == 0 {
+			delete(u.words, word)
+		}
+	}
+	delete(u.docs, taskID)
+	u.totalLength -= indexed.length
+}
+
+// Search returns the user's tasks containing every word of the query, or a word it is a prefix of, ordered
+// by their scores, highest first. Scores rank tasks by relevance, raised for tasks updated recently before now.
+// At most limit results are returned, or every result if limit is 0. Queries without a word that can be
+// searched for, such as those holding only stop words, match no tasks.
+func (i *Index) Search(userID, query string, limit int, now time.Time) []Result {
+	words := Tokenize(query)
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	user := i.users[userID]
+	if user == nil || len(words) == 0 {
+		return nil
+	}
+
+	// score the tasks matching every word, taking the best matching stem of each word
+	var scores map[string]float64
+	for _, word := range words {
+		stems := user.matchingStems(word)
+		wordScores := map[string]float64{}
+		for stem, weight := range stems {
+			idf := user.idf(stem)
+			for taskID, p := range user.postings[stem] {
+				if scores != nil {
+					if _, ok := scores[taskID]; !ok {
+						continue
+					}
+				}
+				score := weight * idf * user.termScore(p, user.docs[taskID].length)
+				wordScores[taskID] = max(wordScores[taskID], score)
+			}
+		}
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+		for taskID, score := range scores {
+			if wordScore, ok := wordScores[taskID]; ok {
+				scores[taskID] = score + wordScore
+			} else {
+				delete(scores, taskID)
+			}
+		}
+	}
+
+	// raise the scores of recently updated tasks
+	results := make([]Result, 0, len(scores))
+	for taskID, score := range scores {
+		age := max(now.Sub(time.Unix(user.docs[taskID].updatedAt, 0)), 0)
+		score *= 1 + recencyWeight*math.Exp2(-float64(age)/float64(recencyHalfLife))
+		results = append(results, Result{TaskID: taskID, Score: score})
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(user.docs[b.TaskID].updatedAt, user.docs[a.TaskID].updatedAt),
+			cmp.Compare(a.TaskID, b.TaskID),
+		)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchingStems returns the stems matched by a query word along with their weights: the word's own stem,
+// and the stems of the indexed words it is a prefix of.
+func (u *userIndex) matchingStems(word string) map[string]float64 {
+	stems := map[string]float64{}
+	for indexed := range u.words {
+		if strings.HasPrefix(indexed, word) {
+			stems[Stem(indexed)] = prefixWeight
+		}
+	}
+	if _, ok := u.postings[Stem(word)]; ok {
+		stems[Stem(word)] = 1
+	}
+	return stems
+}
+
+// idf is the inverse document frequency of a stem, which is higher the fewer tasks contain it.
+func (u *userIndex) idf(stem string) float64 {
+	n, df := float64(len(u.docs)), float64(len(u.postings[stem]))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// termScore is the BM25 term frequency component of a stem occurring as counted by p in a task of the given length.
+func (u *userIndex) termScore(p *posting, length int) float64 {
+	tf := float64(titleWeight*p.title + p.description)
+	avgLength := float64(u.totalLength) / float64(len(u.docs))
+	return tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(length)/avgLength))
+}
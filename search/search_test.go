@@ -0,0 +1,174 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 4, 13, 12, 0, 0, 0, time.UTC)
+
+// daysAgo returns the Unix timestamp of the given number of days before now.
+func daysAgo(days int) int64 {
+	return now.AddDate(0, 0, -days).Unix()
+}
+
+// resultIDs returns the task ids of results in order.
+func resultIDs(results []Result) []string {
+	var ids []string
+	for _, result := range results {
+		ids = append(ids, result.TaskID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := NewIndex()
+	for _, doc := range []Document{
+		{UserID: "u1", TaskID: "taxes", Title: "File taxes", Description: "Find the receipts from the dentist", UpdatedAt: daysAgo(300)},
+		{UserID: "u1", TaskID: "dentist", Title: "Book dentist appointment", UpdatedAt: daysAgo(10)},
+		{UserID: "u1", TaskID: "standup", Title: "Prepare for standup meeting", Description: "Planning notes", UpdatedAt: daysAgo(1)},
+		{UserID: "u1", TaskID: "planning", Title: "Quarterly planning", Description: "Meet with the team", UpdatedAt: daysAgo(2)},
+		{UserID: "u1", TaskID: "old-planning", Title: "Quarterly planning", Description: "Meet with the team", UpdatedAt: daysAgo(200)},
+		{UserID: "u2", TaskID: "other-user", Title: "File taxes", UpdatedAt: daysAgo(1)},
+	} {
+		index.Put(doc)
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		query  string
+		limit  int
+		want   []string
+	}{
+		{
+			name:   "word in the description",
+			userID: "u1",
+			query:  "receipts",
+			want:   []string{"taxes"},
+		},
+		{
+			name:   "other forms of a word",
+			userID: "u1",
+			query:  "filing tax",
+			want:   []string{"taxes"},
+		},
+		{
+			name:   "title matches rank above description matches",
+			userID: "u1",
+			query:  "dentist",
+			want:   []string{"dentist", "taxes"},
+		},
+		{
+			name:   "recently updated tasks rank above equally relevant ones",
+			userID: "u1",
+			query:  "quarterly planning",
+			want:   []string{"planning", "old-planning"},
+		},
+		{
+			name:   "every word must match",
+			userID: "u1",
+			query:  "planning standup",
+			want:   []string{"standup"},
+		},
+		{
+			name:   "prefixes match the words they start",
+			userID: "u1",
+			query:  "appoi",
+			want:   []string{"dentist"},
+		},
+		{
+			name:   "case and punctuation are ignored",
+			userID: "u1",
+			query:  "DENTIST, appointment!",
+			want:   []string{"dentist"},
+		},
+		{
+			name:   "limit",
+			userID: "u1",
+			query:  "meet",
+			limit:  2,
+			want:   []string{"standup", "planning"},
+		},
+		{
+			name:   "no matches",
+			userID: "u1",
+			query:  "groceries",
+			want:   nil,
+		},
+		{
+			name:   "only stop words",
+			userID: "u1",
+			query:  "the of",
+			want:   nil,
+		},
+		{
+			name:   "unknown user",
+			userID: "u3",
+			query:  "taxes",
+			want:   nil,
+		},
+		{
+			name:   "users are searched separately",
+			userID: "u2",
+			query:  "taxes",
+			want:   []string{"other-user"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := index.Search(tt.userID, tt.query, tt.limit, now)
+			if ids := resultIDs(got); !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Index.Search() = %v, want %v", ids, tt.want)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].Score > got[i-1].Score {
+					t.Errorf("Index.Search() results are not ordered by score: %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestIndex_Put(t *testing.T) {
+	t.Run("replaces the indexed task", func(t *testing.T) {
+		index := NewIndex()
+		index.Put(Document{UserID: "u1", TaskID: "t1", Title: "Buy milk", UpdatedAt: daysAgo(2)})
+		index.Put(Document{UserID: "u1", TaskID: "t1", Title: "Buy bread", UpdatedAt: daysAgo(1)})
+		if got := index.Search("u1", "milk", 0, now); len(got) != 0 {
+			t.Errorf("Index.Search() of replaced word = %v, want no results", got)
+		}
+		if got := resultIDs(index.Search("u1", "bread", 0, now)); !reflect.DeepEqual(got, []string{"t1"}) {
+			t.Errorf("Index.Search() of new word = %v, want [t1]", got)
+		}
+	})
+
+	t.Run("keeps a task updated after the document", func(t *testing.T) {
+		index := NewIndex()
+		index.Put(Document{UserID: "u1", TaskID: "t1", Title: "Buy bread", UpdatedAt: daysAgo(1)})
+		index.Put(Document{UserID: "u1", TaskID: "t1", Title: "Buy milk", UpdatedAt: daysAgo(2)})
+		if got := index.Search("u1", "milk", 0, now); len(got) != 0 {
+			t.Errorf("Index.Search() of stale word = %v, want no results", got)
+		}
+	})
+}
+
+func TestIndex_Delete(t *testing.T) {
+	index := NewIndex()
+	index.Put(Document{UserID: "u1", TaskID: "t1", Title: "Buy milk", UpdatedAt: daysAgo(1)})
+	index.Put(Document{UserID: "u1", TaskID: "t2", Title: "Buy bread", UpdatedAt: daysAgo(1)})
+	index.Delete("u1", "t1")
+	index.Delete("u1", "missing")
+	index.Delete("u2", "t1")
+
+	if got := index.Search("u1", "milk", 0, now); len(got) != 0 {
+		t.Errorf("Index.Search() of deleted task = %v, want no results", got)
+	}
+	if got := index.Search("u1", "mi", 0, now); len(got) != 0 {
+		t.Errorf("Index.Search() of prefix of deleted task = %v, want no results", got)
+	}
+	if got := resultIDs(index.Search("u1", "buy", 0, now)); !reflect.DeepEqual(got, []string{"t2"}) {
+		t.Errorf("Index.Search() = %v, want [t2]", got)
+	}
+}
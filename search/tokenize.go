@@ -0,0 +1,74 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are common English words that are left out of the index and of queries, since nearly every
+// task contains them.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "this": true, "to": true, "was": true, "with": true,
+}
+
+// Tokenize splits text into lower case words, leaving out stop words and words of a single letter.
+// Words are runs of letters and digits.
+func Tokenize(text string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 2 || stopWords[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// Stem reduces a lower case word to its stem by stripping common English suffixes, so that forms of a word
+// such as "file", "files", "filed" and "filing" share a stem. Stems are not always words themselves.
+// Short words and words with letters outside ASCII are left as they are.
+func Stem(word string) string {
+	if len(word) <= 3 || strings.IndexFunc(word, func(r rune) bool { return r > unicode.MaxASCII }) >= 0 {
+		return word
+	}
+
+	// plurals
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = word[:len(word)-1]
+	}
+
+	// past tenses and gerunds, when what remains holds a vowel
+	for _, suffix := range []string{"ing", "ed"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || strings.HasSuffix(word, "eed") || len(stem) < 3 || !strings.ContainsAny(stem, "aeiouy") {
+			continue
+		}
+		word = stem
+		// undouble the final consonant, as in "planned"
+		if n := len(word); word[n-1] == word[n-2] && !strings.ContainsRune("aeioulsz", rune(word[n-1])) {
+			word = word[:n-1]
+		}
+		break
+	}
+
+	// a final y after a consonant, as in "party" and "parties"
+	if n := len(word); n > 2 && word[n-1] == 'y' && !strings.ContainsRune("aeiou", rune(word[n-2])) {
+		word = word[:n-1] + "i"
+	}
+
+	// a final e, as in "file" and "filing"
+	if n := len(word); n > 3 && word[n-1] == 'e' && word[n-2] != 'e' {
+		word = word[:n-1]
+	}
+	return word
+}
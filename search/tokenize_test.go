@@ -0,0 +1,70 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "words are lower cased and split on punctuation",
+			text: "Call Bob re: Q3-budget, ASAP!",
+			want: []string{"call", "bob", "re", "q3", "budget", "asap"},
+		},
+		{
+			name: "stop words and single letters are left out",
+			text: "File the taxes for a friend's 2 kids",
+			want: []string{"file", "taxes", "friend", "kids"},
+		},
+		{
+			name: "letters outside ASCII",
+			text: "Réserver le café",
+			want: []string{"réserver", "le", "café"},
+		},
+		{
+			name: "empty",
+			text: "  -- ",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{words: []string{"file", "files", "filed", "filing"}, want: "fil"},
+		{words: []string{"task", "tasks"}, want: "task"},
+		{words: []string{"party", "parties"}, want: "parti"},
+		{words: []string{"plan", "plans", "planned", "planning"}, want: "plan"},
+		{words: []string{"meet", "meets", "meeting", "meetings"}, want: "meet"},
+		{words: []string{"class", "classes"}, want: "class"},
+		{words: []string{"tax", "taxes"}, want: "tax"},
+		{words: []string{"call", "calls", "called", "calling"}, want: "call"},
+		{words: []string{"status"}, want: "status"},
+		{words: []string{"day", "days"}, want: "day"},
+		{words: []string{"string"}, want: "string"},
+		// words outside ASCII are not stemmed
+		{words: []string{"cafés"}, want: "cafés"},
+	}
+	for _, tt := range tests {
+		for _, word := range tt.words {
+			if got := Stem(word); got != tt.want {
+				t.Errorf("Stem(%q) = %q, want %q", word, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package tracing sets up OpenTelemetry tracing and provides the tracer the server's own spans are started with.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters are the names of the supported span exporters. With "none" spans are still propagated
+// to and from other services, but never exported. With "stderr" they are written to standard error,
+// keeping them apart from the output of CLI commands.
+var Exporters = []string{"none", "stderr", "otlp"}
+
+// Tracer starts the spans of the server's own work, such as hashing passwords.
+var Tracer trace.Tracer = otel.Tracer("todo")
+
+// Setup sets the global tracer provider to export spans of the named service with the given exporter,
+// sampling the given fraction of new traces, and the global propagator to the W3C trace context and baggage.
+// Spans are exported over gRPC to endpoint with "otlp", or to the OTEL_EXPORTER_OTLP_ENDPOINT
+// environment variable if it is empty. The returned func flushes and stops exporting spans.
+func Setup(ctx context.Context, serviceName, exporter, endpoint string, sampleRatio float64) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	// get exporter
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stderr":
+		e, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stderr exporter: %v", err)
+		}
+		spanExporter = e
+	case "otlp":
+		var opts []otlptracegrpc.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+		}
+		e, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %v", err)
+		}
+		spanExporter = e
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q: expected one of %s", exporter, strings.Join(Exporters, ", "))
+	}
+
+	// set tracer provider
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %v", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// RecordError records err on the span and sets the span's status to error, if err is not nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Setup(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "none", exporter: "none", wantErr: false},
+		{name: "stderr", exporter: "stderr", wantErr: false},
+		{name: "otlp", exporter: "otlp", wantErr: false},
+		{name: "unknown exporter", exporter: "jaeger", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), "todo-test", tt.exporter, "http://localhost:4317", 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				// nothing was exported, so shutting down never reaches the collector
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("shutdown() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_RecordError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+		wantEvents int
+	}{
+		{name: "error", err: errors.New("test error"), wantStatus: codes.Error, wantEvents: 1},
+		{name: "nil error", err: nil, wantStatus: codes.Unset, wantEvents: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			_, span := provider.Tracer("test").Start(context.Background(), "span")
+			RecordError(span, tt.err)
+			span.End()
+
+			ended := recorder.Ended()[0]
+			if ended.Status().Code != tt.wantStatus || len(ended.Events()) != tt.wantEvents {
+				t.Errorf("span status = %v with %d events, want %v with %d", ended.Status().Code, len(ended.Events()), tt.wantStatus, tt.wantEvents)
+			}
+		})
+	}
+}